@@ -0,0 +1,236 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"strings"
+
+	"github.com/golang-tire/auth/internal/apps"
+	"github.com/golang-tire/auth/internal/domains"
+	"github.com/golang-tire/auth/internal/groups"
+	"github.com/golang-tire/auth/internal/pkg/db"
+	"github.com/golang-tire/auth/internal/pkg/pubsub"
+	auth "github.com/golang-tire/auth/internal/proto/v1"
+	"github.com/golang-tire/auth/internal/roles"
+	"github.com/golang-tire/auth/internal/rules"
+	"github.com/golang-tire/auth/internal/users"
+	"github.com/golang-tire/pkg/config"
+)
+
+// runCommand runs the cli command named by args[0], it returns the process exit code.
+func runCommand(ctx context.Context, args []string) (int, error) {
+	switch args[0] {
+	case "lint":
+		return lintPolicy(ctx, args[1:])
+	case "policy":
+		return policyCommand(ctx, args[1:])
+	case "app":
+		return appCommand(ctx, args[1:])
+	default:
+		return 2, fmt.Errorf("unknown command `%s`", args[0])
+	}
+}
+
+func rulesService(ctx context.Context) (rules.Service, error) {
+	dbInstance, err := loadDB(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return newRulesService(dbInstance), nil
+}
+
+func appsService(ctx context.Context) (apps.Service, apps.Repository, error) {
+	dbInstance, err := loadDB(ctx)
+	if err != nil {
+		return nil, nil, err
+	}
+	appsRepo := apps.NewRepository(dbInstance)
+	return apps.NewService(appsRepo, newRulesService(dbInstance)), appsRepo, nil
+}
+
+// loadDB connects the database, and the pubsub so the running servers reload the changes
+// the command makes.
+func loadDB(ctx context.Context) (*db.DB, error) {
+	err := config.Load()
+	if err != nil {
+		return nil, err
+	}
+	if _, err := pubsub.Init(ctx, redisClient()); err != nil {
+		return nil, err
+	}
+	return db.Init(ctx)
+}
+
+func newRulesService(dbInstance *db.DB) rules.Service {
+	domainsRepo := domains.NewRepository(dbInstance)
+	rolesRepo := roles.NewRepository(dbInstance)
+	return rules.NewService(
+		rules.NewRepository(dbInstance),
+		domainsRepo,
+		domains.NewService(domainsRepo, rolesRepo),
+		rolesRepo,
+		users.NewRepository(dbInstance),
+		apps.NewRepository(dbInstance),
+		groups.NewRepository(dbInstance),
+	)
+}
+
+// lintPolicy runs the rules linter and prints the findings, it returns
+// the process exit code which is non zero when any error is found.
+func lintPolicy(ctx context.Context, args []string) (int, error) {
+	fs := flag.NewFlagSet("lint", flag.ExitOnError)
+	severity := fs.String("severity", "info", "minimum severity to report (info, warning, error)")
+	if err := fs.Parse(args); err != nil {
+		return 1, err
+	}
+
+	minSeverity, ok := auth.LintSeverity_value[strings.ToUpper(*severity)]
+	if !ok {
+		return 1, fmt.Errorf("invalid severity `%s`", *severity)
+	}
+
+	rulesSrv, err := rulesService(ctx)
+	if err != nil {
+		return 1, err
+	}
+
+	res, err := rulesSrv.Lint(ctx, auth.LintSeverity(minSeverity))
+	if err != nil {
+		return 1, err
+	}
+
+	code := 0
+	for _, f := range res.Findings {
+		fmt.Fprintf(os.Stdout, "%-7s %-16s %s [%s]\n", f.Severity, f.Code, f.Message, strings.Join(f.Rules, ", "))
+		if f.Severity == auth.LintSeverity_ERROR {
+			code = 1
+		}
+	}
+	return code, nil
+}
+
+// policyCommand exports the policy to stdout or imports it from a file.
+func policyCommand(ctx context.Context, args []string) (int, error) {
+	if len(args) == 0 {
+		return 2, fmt.Errorf("usage: policy export|import [flags]")
+	}
+
+	fs := flag.NewFlagSet("policy "+args[0], flag.ExitOnError)
+	format := fs.String("format", "csv", "policy file format (csv, yaml)")
+	file := fs.String("file", "", "policy file to import")
+	dryRun := fs.Bool("dry-run", false, "only print the difference")
+	prune := fs.Bool("prune", false, "remove rules and user roles which are not in the file")
+	if err := fs.Parse(args[1:]); err != nil {
+		return 1, err
+	}
+
+	policyFormat, ok := auth.PolicyFormat_value[strings.ToUpper(*format)]
+	if !ok {
+		return 1, fmt.Errorf("invalid format `%s`", *format)
+	}
+
+	rulesSrv, err := rulesService(ctx)
+	if err != nil {
+		return 1, err
+	}
+
+	switch args[0] {
+	case "export":
+		res, err := rulesSrv.ExportPolicy(ctx, auth.PolicyFormat(policyFormat))
+		if err != nil {
+			return 1, err
+		}
+		fmt.Fprint(os.Stdout, res.Content)
+	case "import":
+		content, err := ioutil.ReadFile(*file)
+		if err != nil {
+			return 1, err
+		}
+		res, err := rulesSrv.ImportPolicy(ctx, &auth.ImportPolicyRequest{
+			Format:  auth.PolicyFormat(policyFormat),
+			Content: string(content),
+			DryRun:  *dryRun,
+			Prune:   *prune,
+		})
+		if err != nil {
+			return 1, err
+		}
+		for _, role := range res.CreatedRoles {
+			fmt.Fprintf(os.Stdout, "+ role %s\n", role)
+		}
+		for _, domain := range res.CreatedDomains {
+			fmt.Fprintf(os.Stdout, "+ domain %s\n", domain)
+		}
+		for _, line := range res.Added {
+			fmt.Fprintf(os.Stdout, "+ %s\n", line)
+		}
+		for _, line := range res.Removed {
+			fmt.Fprintf(os.Stdout, "- %s\n", line)
+		}
+		if !res.Applied {
+			fmt.Fprintln(os.Stdout, "dry run, nothing applied")
+		}
+	default:
+		return 2, fmt.Errorf("unknown policy command `%s`", args[0])
+	}
+	return 0, nil
+}
+
+// appCommand imports the resources and routes of an app from its api schema.
+func appCommand(ctx context.Context, args []string) (int, error) {
+	if len(args) == 0 || args[0] != "import-schema" {
+		return 2, fmt.Errorf("usage: app import-schema [flags]")
+	}
+
+	fs := flag.NewFlagSet("app "+args[0], flag.ExitOnError)
+	name := fs.String("app", "", "name of the app")
+	format := fs.String("format", "openapi", "schema file format (openapi, descriptor_set)")
+	file := fs.String("file", "", "schema file to import")
+	dryRun := fs.Bool("dry-run", false, "only print the difference")
+	prune := fs.Bool("prune", false, "remove resources and routes which are not in the schema")
+	if err := fs.Parse(args[1:]); err != nil {
+		return 1, err
+	}
+
+	schemaFormat, ok := auth.SchemaFormat_value[strings.ToUpper(*format)]
+	if !ok {
+		return 1, fmt.Errorf("invalid format `%s`", *format)
+	}
+	content, err := ioutil.ReadFile(*file)
+	if err != nil {
+		return 1, err
+	}
+
+	appsSrv, appsRepo, err := appsService(ctx)
+	if err != nil {
+		return 1, err
+	}
+	app, err := appsRepo.GetAppByName(ctx, *name)
+	if err != nil {
+		return 1, fmt.Errorf("app `%s` not found: %v", *name, err)
+	}
+
+	res, err := appsSrv.ImportSchema(ctx, &auth.ImportAppSchemaRequest{
+		Uuid:    app.UUID,
+		Format:  auth.SchemaFormat(schemaFormat),
+		Content: content,
+		DryRun:  *dryRun,
+		Prune:   *prune,
+	})
+	if err != nil {
+		return 1, err
+	}
+	for _, line := range res.Added {
+		fmt.Fprintf(os.Stdout, "+ %s\n", line)
+	}
+	for _, line := range res.Removed {
+		fmt.Fprintf(os.Stdout, "- %s\n", line)
+	}
+	if !res.Applied {
+		fmt.Fprintln(os.Stdout, "dry run, nothing applied")
+	}
+	return 0, nil
+}
@@ -9,12 +9,19 @@ import (
 	"strings"
 	"syscall"
 
+	"github.com/golang-tire/auth/internal/access_requests"
 	"github.com/golang-tire/auth/internal/apps"
 	"github.com/golang-tire/auth/internal/audit_logs"
 	"github.com/golang-tire/auth/internal/auth"
+	"github.com/golang-tire/auth/internal/bundles"
 	"github.com/golang-tire/auth/internal/domains"
+	"github.com/golang-tire/auth/internal/groups"
+	"github.com/golang-tire/auth/internal/organizations"
+	"github.com/golang-tire/auth/internal/relations"
+	"github.com/golang-tire/auth/internal/role_templates"
 	"github.com/golang-tire/auth/internal/roles"
 	"github.com/golang-tire/auth/internal/rules"
+	"github.com/golang-tire/auth/internal/shadow_decisions"
 	"github.com/golang-tire/auth/internal/users"
 	"github.com/golang-tire/pkg/grpcgw"
 	"github.com/grpc-ecosystem/grpc-gateway/v2/runtime"
@@ -78,12 +85,29 @@ func main() {
 		panic(err)
 	}
 
+	if flag.NArg() > 0 {
+		code, err := runCommand(ctx, flag.Args())
+		if err != nil {
+			panic(err)
+		}
+		os.Exit(code)
+	}
+
 	err = setUp(ctx)
 	if err != nil {
 		panic(err)
 	}
 }
 
+// redisClient returns a client of the configured redis server.
+func redisClient() *redis.Client {
+	return redis.NewClient(&redis.Options{
+		Addr:     fmt.Sprintf("%s:%d", redisHost.String(), redisPort.Int()),
+		Password: redisPassword.String(),
+		DB:       redisDb.Int(),
+	})
+}
+
 func setUp(ctx context.Context) error {
 
 	// reload configs
@@ -92,12 +116,7 @@ func setUp(ctx context.Context) error {
 		return err
 	}
 
-	rdb := redis.NewClient(&redis.Options{
-		Addr:     fmt.Sprintf("%s:%d", redisHost.String(), redisPort.Int()),
-		Password: redisPassword.String(),
-		DB:       redisDb.Int(),
-	})
-
+	rdb := redisClient()
 	pubSub, err := pubsub.Init(ctx, rdb)
 	if err != nil {
 		return err
@@ -113,8 +132,15 @@ func setUp(ctx context.Context) error {
 	}
 
 	models := []interface{}{
+		&entity.Organization{},
 		&entity.Domain{},
 		&entity.Role{},
+		&entity.DomainSettings{},
+		&entity.PermissionBundle{},
+		&entity.BundlePermission{},
+		&entity.RoleBundle{},
+		&entity.RoleTemplate{},
+		&entity.RoleTemplateBundle{},
 		&entity.Rule{},
 		&entity.User{},
 		&entity.UserRole{},
@@ -122,6 +148,18 @@ func setUp(ctx context.Context) error {
 		&entity.App{},
 		&entity.Resource{},
 		&entity.Object{},
+		&entity.Route{},
+		&entity.PolicyRevision{},
+		&entity.ShadowDecision{},
+		&entity.AccessRequest{},
+		&entity.AccessApproval{},
+		&entity.ApproverRule{},
+		&entity.Group{},
+		&entity.GroupMember{},
+		&entity.GroupRole{},
+		&entity.RelationTuple{},
+		&entity.RelationChange{},
+		&entity.RelationDefinition{},
 	}
 
 	err = db.CreateSchema(dbInstance.DB(), models)
@@ -129,37 +167,80 @@ func setUp(ctx context.Context) error {
 		return err
 	}
 
+	organizationsRepo := organizations.NewRepository(dbInstance)
+	organizationsSrv := organizations.NewService(organizationsRepo)
+	organizations.New(organizationsSrv)
+
+	rolesRepo := roles.NewRepository(dbInstance)
+
 	domainsRepo := domains.NewRepository(dbInstance)
-	domainsSrv := domains.NewService(domainsRepo)
+	domainsSrv := domains.NewService(domainsRepo, rolesRepo)
 	domains.New(domainsSrv)
+	if err = pubSub.AddHandler(pubsub.DomainChange, domainsSrv.OnDomainChange); err != nil {
+		return err
+	}
 
-	rolesRepo := roles.NewRepository(dbInstance)
 	rolesSrv := roles.NewService(rolesRepo)
 	roles.New(rolesSrv)
 
-	rulesRepo := rules.NewRepository(dbInstance)
-	rulesSrv := rules.NewService(rulesRepo, domainsRepo, rolesRepo)
-	rules.New(rulesSrv)
-
 	usersRepo := users.NewRepository(dbInstance)
-	usersSrv := users.NewService(usersRepo, domainsRepo, rolesRepo)
-	users.New(usersSrv)
 
 	auditLogRepo := audit_logs.NewRepository(dbInstance)
 	auditLogSrv := audit_logs.NewService(auditLogRepo, usersRepo)
 	audit_logs.New(auditLogSrv)
 
 	appsRepo := apps.NewRepository(dbInstance)
-	appsSrv := apps.NewService(appsRepo)
+
+	groupsRepo := groups.NewRepository(dbInstance)
+
+	rulesRepo := rules.NewRepository(dbInstance)
+	rulesSrv := rules.NewService(rulesRepo, domainsRepo, domainsSrv, rolesRepo, usersRepo, appsRepo, groupsRepo)
+	rules.New(rulesSrv)
+
+	bundlesRepo := bundles.NewRepository(dbInstance)
+	bundlesSrv := bundles.NewService(bundlesRepo, rolesRepo, domainsRepo, rulesSrv)
+	bundles.New(bundlesSrv)
+
+	roleTemplatesRepo := role_templates.NewRepository(dbInstance)
+	roleTemplatesSrv := role_templates.NewService(roleTemplatesRepo, rolesRepo, domainsRepo, bundlesRepo, bundlesSrv)
+	role_templates.New(roleTemplatesSrv)
+
+	appsSrv := apps.NewService(appsRepo, rulesSrv)
 	apps.New(appsSrv)
+	if err = pubSub.AddHandler(pubsub.AppChange, appsSrv.OnAppChange); err != nil {
+		return err
+	}
+
+	usersSrv := users.NewService(usersRepo, domainsRepo, rolesRepo, rulesSrv)
+	users.New(usersSrv)
 
-	rbacSrv, err := auth.InitRbac(ctx, rulesSrv, usersSrv, pubSub)
+	expiryScheduler, err := users.NewExpiryScheduler(usersSrv, auditLogSrv)
 	if err != nil {
 		return err
 	}
 
-	authService := auth.NewService(usersSrv, rbacSrv)
-	_, err = auth.New(ctx, authService, rulesSrv, usersSrv)
+	accessRequestsRepo := access_requests.NewRepository(dbInstance)
+	accessRequestsSrv := access_requests.NewService(accessRequestsRepo, usersRepo, rolesRepo, domainsRepo, rulesSrv, auditLogSrv)
+	access_requests.New(accessRequestsSrv)
+
+	groupsSrv := groups.NewService(groupsRepo, usersRepo, rolesRepo, domainsRepo)
+	groups.New(groupsSrv)
+
+	relationsRepo := relations.NewRepository(dbInstance)
+	relationsSrv := relations.NewService(relationsRepo, appsRepo)
+	relations.New(relationsSrv)
+
+	rbacSrv, err := auth.InitRbac(ctx, rulesSrv, usersSrv, groupsSrv, domainsSrv, organizationsSrv, pubSub)
+	if err != nil {
+		return err
+	}
+
+	shadowDecisionsRepo := shadow_decisions.NewRepository(dbInstance)
+	shadowDecisionsSrv := shadow_decisions.NewService(shadowDecisionsRepo)
+	shadow_decisions.New(shadowDecisionsSrv)
+
+	authService := auth.NewService(usersSrv, appsSrv, domainsSrv, shadowDecisionsSrv, relationsSrv, rbacSrv)
+	_, err = auth.New(ctx, authService, rulesSrv, usersSrv, domainsSrv, organizationsSrv, rbacSrv)
 	if err != nil {
 		return err
 	}
@@ -175,6 +256,7 @@ func setUp(ctx context.Context) error {
 	}
 
 	pubSub.Run(ctx)
+	expiryScheduler.Run(ctx)
 
 	err = grpcgw.Serve(ctx,
 		grpcgw.GrpcPort(grpcPort.Int()),
@@ -194,7 +276,14 @@ func grpcHeaderMatcher(key string) (string, bool) {
 	switch strings.ToLower(key) {
 	case "x-forwarded-uri", "x-forwarded-method", "x-auth-user-email", "x-auth-user-uuid", "x-auth-user-name":
 		return key, true
+	case "x-auth-decision-resource", "x-auth-decision-object", "x-auth-decision-route", "x-auth-decision-roles", "x-auth-decision-rule":
+		return key, true
+	case "x-auth-domain", "x-auth-organization":
+		return key, true
 	default:
+		if strings.HasPrefix(strings.ToLower(key), "x-auth-attr-") {
+			return key, true
+		}
 		return runtime.DefaultHeaderMatcher(key)
 	}
 }
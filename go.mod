@@ -3,6 +3,7 @@ module github.com/golang-tire/auth
 go 1.15
 
 require (
+	github.com/Knetic/govaluate v3.0.1-0.20171022003610-9aa49832a739+incompatible
 	github.com/ThreeDotsLabs/watermill v1.1.1
 	github.com/alicebob/miniredis/v2 v2.14.1
 	github.com/casbin/casbin/v2 v2.17.0
@@ -31,6 +32,7 @@ require (
 	google.golang.org/grpc/examples v0.0.0-20201112215255-90f1b3ee835b // indirect
 	google.golang.org/protobuf v1.25.0
 	gopkg.in/check.v1 v1.0.0-20200227125254-8fa46927fb4f // indirect
+	gopkg.in/yaml.v2 v2.3.0
 	gorm.io/driver/postgres v1.0.5
 	gorm.io/gorm v1.20.6
 )
@@ -0,0 +1,143 @@
+package access_requests
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/golang-tire/pkg/grpcgw"
+	"github.com/golang/protobuf/ptypes/empty"
+	"github.com/grpc-ecosystem/grpc-gateway/v2/runtime"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	authn "github.com/golang-tire/auth/internal/auth"
+	"github.com/golang-tire/auth/internal/pkg/helpers"
+	auth "github.com/golang-tire/auth/internal/proto/v1"
+)
+
+type API interface {
+	grpcgw.Controller
+}
+
+type api struct {
+	service Service
+	auth.AccessRequestServiceServer
+}
+
+func (a api) InitRest(ctx context.Context, conn *grpc.ClientConn, mux *runtime.ServeMux, httpMux *http.ServeMux) {
+	cl := auth.NewAccessRequestServiceClient(conn)
+	_ = auth.RegisterAccessRequestServiceHandlerClient(ctx, mux, cl)
+}
+
+func (a api) InitGrpc(ctx context.Context, server *grpc.Server) {
+	auth.RegisterAccessRequestServiceServer(server, a)
+}
+
+func (a api) ListAccessRequests(ctx context.Context, request *auth.ListAccessRequestsRequest) (*auth.ListAccessRequestsResponse, error) {
+	user, err := authn.ExtractUser(ctx)
+	if err != nil {
+		return nil, status.Errorf(codes.Unauthenticated, "user not found")
+	}
+	offset, limit := helpers.GetOffsetAndLimit(request.Offset, request.Limit)
+	var requestStatus string
+	if request.FilterStatus {
+		requestStatus = request.Status.String()
+	}
+	res, err := a.service.Query(ctx, user.Username, request.Requester, requestStatus, offset, limit)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+	return res, nil
+}
+
+func (a api) GetAccessRequest(ctx context.Context, request *auth.GetAccessRequestRequest) (*auth.AccessRequest, error) {
+	user, err := authn.ExtractUser(ctx)
+	if err != nil {
+		return nil, status.Errorf(codes.Unauthenticated, "user not found")
+	}
+	res, err := a.service.Get(ctx, user.Username, request.Uuid)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+	return res, nil
+}
+
+func (a api) CreateAccessRequest(ctx context.Context, request *auth.CreateAccessRequestRequest) (*auth.AccessRequest, error) {
+	user, err := authn.ExtractUser(ctx)
+	if err != nil {
+		return nil, status.Errorf(codes.Unauthenticated, "user not found")
+	}
+	res, err := a.service.Create(ctx, user.Username, request)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+	return res, nil
+}
+
+func (a api) ApproveAccessRequest(ctx context.Context, request *auth.DecideAccessRequestRequest) (*auth.AccessRequest, error) {
+	user, err := authn.ExtractUser(ctx)
+	if err != nil {
+		return nil, status.Errorf(codes.Unauthenticated, "user not found")
+	}
+	res, err := a.service.Approve(ctx, user.Username, request)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+	return res, nil
+}
+
+func (a api) DenyAccessRequest(ctx context.Context, request *auth.DecideAccessRequestRequest) (*auth.AccessRequest, error) {
+	user, err := authn.ExtractUser(ctx)
+	if err != nil {
+		return nil, status.Errorf(codes.Unauthenticated, "user not found")
+	}
+	res, err := a.service.Deny(ctx, user.Username, request)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+	return res, nil
+}
+
+func (a api) CancelAccessRequest(ctx context.Context, request *auth.CancelAccessRequestRequest) (*auth.AccessRequest, error) {
+	user, err := authn.ExtractUser(ctx)
+	if err != nil {
+		return nil, status.Errorf(codes.Unauthenticated, "user not found")
+	}
+	res, err := a.service.Cancel(ctx, user.Username, request.Uuid)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+	return res, nil
+}
+
+func (a api) ListApproverRules(ctx context.Context, request *auth.ListApproverRulesRequest) (*auth.ListApproverRulesResponse, error) {
+	offset, limit := helpers.GetOffsetAndLimit(request.Offset, request.Limit)
+	res, err := a.service.QueryApproverRules(ctx, offset, limit)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+	return res, nil
+}
+
+func (a api) CreateApproverRule(ctx context.Context, request *auth.CreateApproverRuleRequest) (*auth.ApproverRule, error) {
+	res, err := a.service.CreateApproverRule(ctx, request)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+	return res, nil
+}
+
+func (a api) DeleteApproverRule(ctx context.Context, request *auth.DeleteApproverRuleRequest) (*empty.Empty, error) {
+	_, err := a.service.DeleteApproverRule(ctx, request.Uuid)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+	return &empty.Empty{}, nil
+}
+
+func New(srv Service) API {
+	s := api{service: srv}
+	grpcgw.RegisterController(s)
+	return s
+}
@@ -0,0 +1,200 @@
+package access_requests
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+
+	"github.com/golang-tire/auth/internal/pkg/db"
+
+	"github.com/golang-tire/auth/internal/entity"
+)
+
+// Visibility limits the access requests to the ones of the user and of the named domains,
+// unless all is set.
+type Visibility struct {
+	All     bool
+	UserID  uint
+	Domains []string
+}
+
+// Repository encapsulates the logic to access access requests from the data source.
+type Repository interface {
+	// Get returns the access request with the specified UUID.
+	Get(ctx context.Context, uuid string) (entity.AccessRequest, error)
+	// GetForUpdate returns the access request with the specified UUID and locks it until the
+	// transaction of the context ends.
+	GetForUpdate(ctx context.Context, uuid string) (entity.AccessRequest, error)
+	// Query returns the visible access requests of the given requester and status, empty filters match all.
+	Query(ctx context.Context, visibility Visibility, requester, status string, offset, limit int64) ([]entity.AccessRequest, int, error)
+	// Create saves a new access request in the storage.
+	Create(ctx context.Context, req entity.AccessRequest) (string, error)
+	// Update updates the access request with given UUID in the storage.
+	Update(ctx context.Context, req entity.AccessRequest) error
+	// AddApproval saves a decision of an approver on an access request.
+	AddApproval(ctx context.Context, approval entity.AccessApproval) error
+
+	// GetApproverRule returns the approver rule with the specified UUID.
+	GetApproverRule(ctx context.Context, uuid string) (entity.ApproverRule, error)
+	// QueryApproverRules returns the list of approver rules with the given offset and limit.
+	QueryApproverRules(ctx context.Context, offset, limit int64) ([]entity.ApproverRule, int, error)
+	// AllApproverRules returns all approver rules.
+	AllApproverRules(ctx context.Context) ([]entity.ApproverRule, error)
+	// CreateApproverRule saves a new approver rule in the storage.
+	CreateApproverRule(ctx context.Context, rule entity.ApproverRule) (string, error)
+	// DeleteApproverRule removes the approver rule from the storage.
+	DeleteApproverRule(ctx context.Context, rule entity.ApproverRule) error
+
+	// Transactional runs f in a transaction, repository calls using the given context join it.
+	Transactional(ctx context.Context, f func(ctx context.Context) error) error
+}
+
+// repository persists access requests in database
+type repository struct {
+	db *db.DB
+}
+
+// NewRepository creates a new access request repository
+func NewRepository(db *db.DB) Repository {
+	return repository{db}
+}
+
+// Get reads the access request with the specified UUID from the database.
+func (r repository) Get(ctx context.Context, uuid string) (entity.AccessRequest, error) {
+	return r.get(r.db.With(ctx), uuid)
+}
+
+// GetForUpdate reads the access request with the specified UUID with a row lock.
+func (r repository) GetForUpdate(ctx context.Context, uuid string) (entity.AccessRequest, error) {
+	return r.get(r.db.With(ctx).Clauses(clause.Locking{Strength: "UPDATE"}), uuid)
+}
+
+func (r repository) get(tx *gorm.DB, uuid string) (entity.AccessRequest, error) {
+	var req entity.AccessRequest
+	res := tx.
+		Preload("Requester").
+		Preload("Role").
+		Preload("Domain").
+		Preload("Approvals.Approver").
+		Where("uuid = ?", uuid).First(&req)
+	if res.Error != nil && res.Error == gorm.ErrRecordNotFound {
+		return entity.AccessRequest{}, fmt.Errorf("access request with uuid `%s` not found", uuid)
+	}
+	return req, res.Error
+}
+
+// Query retrieves the access requests with the specified filters, offset and limit from the database.
+func (r repository) Query(ctx context.Context, visibility Visibility, requester, status string, offset, limit int64) ([]entity.AccessRequest, int, error) {
+	var _requests []entity.AccessRequest
+	var count int64
+
+	query := r.db.With(ctx).Model(&entity.AccessRequest{})
+	if !visibility.All {
+		domains := r.db.With(ctx).Select("id").Where("name IN ?", visibility.Domains).Table("domains")
+		query = query.Where("requester_id = ? OR domain_id IN (?)", visibility.UserID, domains)
+	}
+	if requester != "" {
+		subQuery := r.db.With(ctx).Select("id").Where("username = ?", requester).Table("users")
+		query = query.Where("requester_id IN (?)", subQuery)
+	}
+	if status != "" {
+		query = query.Where("status = ?", status)
+	}
+	if res := query.Count(&count); res.Error != nil {
+		return nil, 0, res.Error
+	}
+
+	res := query.
+		Limit(int(limit)).
+		Offset(int(offset)).
+		Order("id desc").
+		Preload("Requester").
+		Preload("Role").
+		Preload("Domain").
+		Preload("Approvals.Approver").
+		Find(&_requests)
+	return _requests, int(count), res.Error
+}
+
+// Create saves a new access request record in the database.
+// It returns the UUID of the newly inserted access request record.
+func (r repository) Create(ctx context.Context, req entity.AccessRequest) (string, error) {
+	now := time.Now()
+	req.UUID = uuid.New().String()
+	req.CreatedAt = now
+	req.UpdatedAt = now
+	res := r.db.With(ctx).Create(&req)
+	return req.UUID, res.Error
+}
+
+// Update saves the changes to an access request in the database.
+func (r repository) Update(ctx context.Context, req entity.AccessRequest) error {
+	res := r.db.With(ctx).Omit("Approvals").Save(&req)
+	return res.Error
+}
+
+// AddApproval saves a decision of an approver in the database.
+func (r repository) AddApproval(ctx context.Context, approval entity.AccessApproval) error {
+	now := time.Now()
+	approval.CreatedAt = now
+	approval.UpdatedAt = now
+	res := r.db.With(ctx).Create(&approval)
+	return res.Error
+}
+
+// GetApproverRule reads the approver rule with the specified UUID from the database.
+func (r repository) GetApproverRule(ctx context.Context, uuid string) (entity.ApproverRule, error) {
+	var rule entity.ApproverRule
+	res := r.db.With(ctx).Where("uuid = ?", uuid).First(&rule)
+	if res.Error != nil && res.Error == gorm.ErrRecordNotFound {
+		return entity.ApproverRule{}, fmt.Errorf("approver rule with uuid `%s` not found", uuid)
+	}
+	return rule, res.Error
+}
+
+// QueryApproverRules retrieves the approver rules with the specified offset and limit from the database.
+func (r repository) QueryApproverRules(ctx context.Context, offset, limit int64) ([]entity.ApproverRule, int, error) {
+	var _rules []entity.ApproverRule
+	var count int64
+	if res := r.db.With(ctx).Model(&entity.ApproverRule{}).Count(&count); res.Error != nil {
+		return nil, 0, res.Error
+	}
+	res := r.db.With(ctx).
+		Limit(int(limit)).
+		Offset(int(offset)).
+		Order("id asc").
+		Find(&_rules)
+	return _rules, int(count), res.Error
+}
+
+// AllApproverRules returns all approver rules in the database.
+func (r repository) AllApproverRules(ctx context.Context) ([]entity.ApproverRule, error) {
+	var _rules []entity.ApproverRule
+	res := r.db.With(ctx).Order("id asc").Find(&_rules)
+	return _rules, res.Error
+}
+
+// CreateApproverRule saves a new approver rule record in the database.
+func (r repository) CreateApproverRule(ctx context.Context, rule entity.ApproverRule) (string, error) {
+	now := time.Now()
+	rule.UUID = uuid.New().String()
+	rule.CreatedAt = now
+	rule.UpdatedAt = now
+	res := r.db.With(ctx).Create(&rule)
+	return rule.UUID, res.Error
+}
+
+// DeleteApproverRule deletes an approver rule from the database.
+func (r repository) DeleteApproverRule(ctx context.Context, rule entity.ApproverRule) error {
+	res := r.db.With(ctx).Delete(&rule)
+	return res.Error
+}
+
+// Transactional runs f in a database transaction.
+func (r repository) Transactional(ctx context.Context, f func(ctx context.Context) error) error {
+	return r.db.Transactional(ctx, f)
+}
@@ -0,0 +1,112 @@
+package access_requests
+
+import (
+	"context"
+	"errors"
+
+	"github.com/google/uuid"
+
+	"github.com/golang-tire/auth/internal/entity"
+)
+
+var errNotFound = errors.New("not found")
+
+func NewMockRepository() *mockRepository {
+	return &mockRepository{}
+}
+
+type mockRepository struct {
+	items []entity.AccessRequest
+	rules []entity.ApproverRule
+}
+
+func (m mockRepository) Get(ctx context.Context, uuid string) (entity.AccessRequest, error) {
+	for _, item := range m.items {
+		if item.UUID == uuid {
+			return item, nil
+		}
+	}
+	return entity.AccessRequest{}, errNotFound
+}
+
+func (m mockRepository) GetForUpdate(ctx context.Context, uuid string) (entity.AccessRequest, error) {
+	return m.Get(ctx, uuid)
+}
+
+func (m mockRepository) Query(ctx context.Context, visibility Visibility, requester, status string, offset, limit int64) ([]entity.AccessRequest, int, error) {
+	var items []entity.AccessRequest
+	for _, item := range m.items {
+		if !visibility.allows(item) {
+			continue
+		}
+		if (requester == "" || item.Requester.Username == requester) && (status == "" || item.Status == status) {
+			items = append(items, item)
+		}
+	}
+	return items, len(items), nil
+}
+
+func (m *mockRepository) Create(ctx context.Context, req entity.AccessRequest) (string, error) {
+	req.UUID = uuid.New().String()
+	req.ID = uint(len(m.items) + 1)
+	m.items = append(m.items, req)
+	return req.UUID, nil
+}
+
+func (m *mockRepository) Update(ctx context.Context, req entity.AccessRequest) error {
+	for i, item := range m.items {
+		if item.UUID == req.UUID {
+			req.Approvals = item.Approvals
+			m.items[i] = req
+			break
+		}
+	}
+	return nil
+}
+
+func (m *mockRepository) AddApproval(ctx context.Context, approval entity.AccessApproval) error {
+	for i, item := range m.items {
+		if item.ID == approval.AccessRequestID {
+			m.items[i].Approvals = append(m.items[i].Approvals, approval)
+			return nil
+		}
+	}
+	return errNotFound
+}
+
+func (m mockRepository) GetApproverRule(ctx context.Context, uuid string) (entity.ApproverRule, error) {
+	for _, rule := range m.rules {
+		if rule.UUID == uuid {
+			return rule, nil
+		}
+	}
+	return entity.ApproverRule{}, errNotFound
+}
+
+func (m mockRepository) QueryApproverRules(ctx context.Context, offset, limit int64) ([]entity.ApproverRule, int, error) {
+	return m.rules, len(m.rules), nil
+}
+
+func (m mockRepository) AllApproverRules(ctx context.Context) ([]entity.ApproverRule, error) {
+	return m.rules, nil
+}
+
+func (m *mockRepository) CreateApproverRule(ctx context.Context, rule entity.ApproverRule) (string, error) {
+	rule.UUID = uuid.New().String()
+	m.rules = append(m.rules, rule)
+	return rule.UUID, nil
+}
+
+func (m *mockRepository) DeleteApproverRule(ctx context.Context, rule entity.ApproverRule) error {
+	for i, item := range m.rules {
+		if item.UUID == rule.UUID {
+			m.rules = append(m.rules[:i], m.rules[i+1:]...)
+			break
+		}
+	}
+	return nil
+}
+
+func (m mockRepository) Transactional(ctx context.Context, f func(ctx context.Context) error) error {
+	return f(ctx)
+}
@@ -0,0 +1,542 @@
+package access_requests
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	validation "github.com/go-ozzo/ozzo-validation/v4"
+	"github.com/go-ozzo/ozzo-validation/v4/is"
+	"github.com/golang-tire/pkg/config"
+	"github.com/golang-tire/pkg/log"
+
+	"github.com/golang-tire/auth/internal/domains"
+	"github.com/golang-tire/auth/internal/entity"
+	"github.com/golang-tire/auth/internal/pkg/pubsub"
+	"github.com/golang-tire/auth/internal/pkg/scope"
+	auth "github.com/golang-tire/auth/internal/proto/v1"
+	"github.com/golang-tire/auth/internal/roles"
+	"github.com/golang-tire/auth/internal/users"
+)
+
+// anyName matches every role or domain in approver rules
+const anyName = "*"
+
+// maxDuration is the longest period a role can be requested for
+var maxDuration = config.RegisterString("accessRequests.maxDuration", "720h")
+
+// Service encapsulates use case logic for access requests.
+type Service interface {
+	Get(ctx context.Context, viewer, uuid string) (*auth.AccessRequest, error)
+	Query(ctx context.Context, viewer, requester, status string, offset, limit int64) (*auth.ListAccessRequestsResponse, error)
+	Create(ctx context.Context, requester string, req *auth.CreateAccessRequestRequest) (*auth.AccessRequest, error)
+	Approve(ctx context.Context, approver string, req *auth.DecideAccessRequestRequest) (*auth.AccessRequest, error)
+	Deny(ctx context.Context, approver string, req *auth.DecideAccessRequestRequest) (*auth.AccessRequest, error)
+	Cancel(ctx context.Context, requester string, uuid string) (*auth.AccessRequest, error)
+
+	QueryApproverRules(ctx context.Context, offset, limit int64) (*auth.ListApproverRulesResponse, error)
+	CreateApproverRule(ctx context.Context, req *auth.CreateApproverRuleRequest) (*auth.ApproverRule, error)
+	DeleteApproverRule(ctx context.Context, uuid string) (*auth.ApproverRule, error)
+}
+
+// ValidateCreateRequest validates the CreateAccessRequestRequest fields.
+func ValidateCreateRequest(c *auth.CreateAccessRequestRequest) error {
+	return validation.ValidateStruct(c,
+		validation.Field(&c.Role, validation.Required, validation.Length(1, 128)),
+		validation.Field(&c.Domain, validation.Required, validation.Length(1, 128)),
+		validation.Field(&c.Duration, validation.Required, validation.By(validateDuration)),
+		validation.Field(&c.Justification, validation.Required, validation.Length(1, 1024)),
+	)
+}
+
+// ValidateDecideRequest validates the DecideAccessRequestRequest fields.
+func ValidateDecideRequest(c *auth.DecideAccessRequestRequest) error {
+	return validation.ValidateStruct(c,
+		validation.Field(&c.Uuid, validation.Required, is.UUID),
+		validation.Field(&c.Comment, validation.Length(0, 1024)),
+	)
+}
+
+// ValidateCreateApproverRuleRequest validates the CreateApproverRuleRequest fields.
+func ValidateCreateApproverRuleRequest(c *auth.CreateApproverRuleRequest) error {
+	return validation.ValidateStruct(c,
+		validation.Field(&c.Role, validation.Required, validation.Length(1, 128)),
+		validation.Field(&c.Domain, validation.Required, validation.Length(1, 128)),
+		validation.Field(&c.ApproverRole, validation.Required, validation.Length(1, 128)),
+	)
+}
+
+func validateDuration(value interface{}) error {
+	s, _ := value.(string)
+	d, err := time.ParseDuration(s)
+	if err != nil {
+		return errors.New("must be a duration like 8h")
+	}
+	max, err := time.ParseDuration(maxDuration.String())
+	if err != nil {
+		return err
+	}
+	if d <= 0 || d > max {
+		return fmt.Errorf("must be between 0 and %s", max)
+	}
+	return nil
+}
+
+type service struct {
+	repo        Repository
+	usersRepo   users.Repository
+	rolesRepo   roles.Repository
+	domainsRepo domains.Repository
+	recorder    users.PolicyRecorder
+	auditLogs   users.AuditLogger
+}
+
+// NewService creates a new access request service, recorder and auditLogs may be nil.
+func NewService(repo Repository, usersRepo users.Repository, rolesRepo roles.Repository, domainsRepo domains.Repository,
+	recorder users.PolicyRecorder, auditLogs users.AuditLogger) Service {
+	return service{repo, usersRepo, rolesRepo, domainsRepo, recorder, auditLogs}
+}
+
+// Get returns the access request with the specified UUID if the viewer can see it.
+func (s service) Get(ctx context.Context, viewer, uuid string) (*auth.AccessRequest, error) {
+	visibility, err := s.visibility(ctx, viewer)
+	if err != nil {
+		return nil, err
+	}
+	req, err := s.repo.Get(ctx, uuid)
+	if err != nil {
+		return nil, err
+	}
+	if !visibility.allows(req) {
+		return nil, fmt.Errorf("access request with uuid `%s` not found", uuid)
+	}
+	return req.ToProto(), nil
+}
+
+// get returns the access request with the specified UUID.
+func (s service) get(ctx context.Context, uuid string) (*auth.AccessRequest, error) {
+	req, err := s.repo.Get(ctx, uuid)
+	if err != nil {
+		return nil, err
+	}
+	return req.ToProto(), nil
+}
+
+// visibility returns the access requests the viewer can see, their own requests and the
+// requests in the domains they administer or hold an approver role in.
+func (s service) visibility(ctx context.Context, viewer string) (Visibility, error) {
+	sc := scope.FromContext(ctx)
+	if sc.IsGlobal() {
+		return Visibility{All: true}, nil
+	}
+	user, err := s.usersRepo.FindOne(ctx, "users.username = ?", viewer)
+	if err != nil {
+		return Visibility{}, fmt.Errorf("user `%s` not found", viewer)
+	}
+	rules, err := s.repo.AllApproverRules(ctx)
+	if err != nil {
+		return Visibility{}, err
+	}
+	approverRoles := map[string]bool{}
+	for _, r := range rules {
+		approverRoles[r.ApproverRole] = true
+	}
+
+	v := Visibility{UserID: user.ID, Domains: sc.DomainList()}
+	now := time.Now()
+	for _, ur := range user.UserRoles {
+		if !approverRoles[ur.Role.Title] || !ur.Active(now) {
+			continue
+		}
+		if ur.Domain.Name == "" || ur.Domain.Name == anyName {
+			return Visibility{All: true}, nil
+		}
+		v.Domains = append(v.Domains, ur.Domain.Name)
+	}
+	return v, nil
+}
+
+// allows reports whether the access request is visible.
+func (v Visibility) allows(ar entity.AccessRequest) bool {
+	if v.All || ar.Requester.ID == v.UserID {
+		return true
+	}
+	for _, d := range v.Domains {
+		if d == ar.Domain.Name {
+			return true
+		}
+	}
+	return false
+}
+
+// Query returns the access requests the viewer can see of the requester with the status,
+// empty filters match all.
+func (s service) Query(ctx context.Context, viewer, requester, status string, offset, limit int64) (*auth.ListAccessRequestsResponse, error) {
+	visibility, err := s.visibility(ctx, viewer)
+	if err != nil {
+		return nil, err
+	}
+	items, count, err := s.repo.Query(ctx, visibility, requester, status, offset, limit)
+	if err != nil {
+		return nil, err
+	}
+	return &auth.ListAccessRequestsResponse{
+		AccessRequests: entity.AccessRequestToProtoList(items),
+		TotalCount:     int64(count),
+		Offset:         offset,
+		Limit:          limit,
+	}, nil
+}
+
+// Create requests a role in a domain for the requester.
+func (s service) Create(ctx context.Context, requester string, req *auth.CreateAccessRequestRequest) (*auth.AccessRequest, error) {
+	if err := ValidateCreateRequest(req); err != nil {
+		return nil, err
+	}
+
+	user, err := s.usersRepo.FindOne(ctx, "users.username = ?", requester)
+	if err != nil {
+		return nil, fmt.Errorf("user `%s` not found", requester)
+	}
+	role, err := s.rolesRepo.GetByTitle(ctx, req.Role)
+	if err != nil {
+		return nil, err
+	}
+	domain, err := s.domainsRepo.GetByName(ctx, req.Domain)
+	if err != nil {
+		return nil, err
+	}
+
+	rule, err := s.approverRule(ctx, role.Title, domain.Name)
+	if err != nil {
+		return nil, err
+	}
+
+	pending, _, err := s.repo.Query(ctx, Visibility{All: true}, requester, auth.AccessRequestStatus_PENDING.String(), 0, 0)
+	if err != nil {
+		return nil, err
+	}
+	for _, p := range pending {
+		if p.Role.Title == role.Title && p.Domain.Name == domain.Name {
+			return nil, fmt.Errorf("access request `%s` for role `%s` is already pending", p.UUID, role.Title)
+		}
+	}
+
+	duration, _ := time.ParseDuration(req.Duration)
+	requiredApprovals := int64(1)
+	if rule.TwoPerson {
+		requiredApprovals = 2
+	}
+	id, err := s.repo.Create(ctx, entity.AccessRequest{
+		Requester:         user,
+		Role:              role,
+		Domain:            domain,
+		Duration:          duration,
+		Justification:     req.Justification,
+		Status:            auth.AccessRequestStatus_PENDING.String(),
+		RequiredApprovals: requiredApprovals,
+	})
+	if err != nil {
+		return nil, err
+	}
+	s.audit(ctx, user, "create", id, role.Title+"@"+domain.Name)
+	return s.get(ctx, id)
+}
+
+// Approve records the approval of approver and grants the role for the requested
+// duration once the request has the required approvals. The request stays locked from
+// counting its approvals until the role is granted, so concurrent approvals count once.
+func (s service) Approve(ctx context.Context, approver string, req *auth.DecideAccessRequestRequest) (*auth.AccessRequest, error) {
+	var ar entity.AccessRequest
+	var user entity.User
+	var granted bool
+	err := s.repo.Transactional(ctx, func(ctx context.Context) error {
+		var err error
+		ar, user, err = s.decision(ctx, approver, req)
+		if err != nil {
+			return err
+		}
+
+		approvals := int64(1)
+		for _, a := range ar.Approvals {
+			if a.Approve {
+				approvals++
+			}
+		}
+		granted = approvals >= ar.RequiredApprovals
+
+		err = s.repo.AddApproval(ctx, entity.AccessApproval{
+			AccessRequestID: ar.ID,
+			ApproverID:      user.ID,
+			Approver:        user,
+			Approve:         true,
+			Comment:         req.Comment,
+		})
+		if err != nil || !granted {
+			return err
+		}
+
+		now := time.Now()
+		expiresAt := now.Add(ar.Duration)
+		id, err := s.usersRepo.AddUserRole(ctx, entity.UserRole{
+			User:      ar.Requester,
+			Role:      ar.Role,
+			Domain:    ar.Domain,
+			Enable:    true,
+			ValidFrom: &now,
+			ExpiresAt: &expiresAt,
+		})
+		if err != nil {
+			return err
+		}
+		ar.Status = auth.AccessRequestStatus_APPROVED.String()
+		ar.UserRoleUUID = id
+		ar.ExpiresAt = &expiresAt
+		return s.repo.Update(ctx, ar)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	s.audit(ctx, user, "approve", ar.UUID, req.Comment)
+	if granted {
+		s.audit(ctx, ar.Requester, "grant", ar.UUID, ar.Role.Title+"@"+ar.Domain.Name)
+		s.recordRevision(ctx, "grant access request "+ar.UUID)
+		pubsub.Notify(pubsub.UserChange, "grant access request "+ar.UUID)
+	}
+	return s.get(ctx, ar.UUID)
+}
+
+// Deny records the denial of approver and closes the request.
+func (s service) Deny(ctx context.Context, approver string, req *auth.DecideAccessRequestRequest) (*auth.AccessRequest, error) {
+	var ar entity.AccessRequest
+	var user entity.User
+	err := s.repo.Transactional(ctx, func(ctx context.Context) error {
+		var err error
+		ar, user, err = s.decision(ctx, approver, req)
+		if err != nil {
+			return err
+		}
+
+		err = s.repo.AddApproval(ctx, entity.AccessApproval{
+			AccessRequestID: ar.ID,
+			ApproverID:      user.ID,
+			Approver:        user,
+			Approve:         false,
+			Comment:         req.Comment,
+		})
+		if err != nil {
+			return err
+		}
+		ar.Status = auth.AccessRequestStatus_DENIED.String()
+		return s.repo.Update(ctx, ar)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	s.audit(ctx, user, "deny", ar.UUID, req.Comment)
+	return s.get(ctx, ar.UUID)
+}
+
+// Cancel cancels a pending request of the requester.
+func (s service) Cancel(ctx context.Context, requester string, uuid string) (*auth.AccessRequest, error) {
+	var ar entity.AccessRequest
+	err := s.repo.Transactional(ctx, func(ctx context.Context) error {
+		var err error
+		ar, err = s.repo.GetForUpdate(ctx, uuid)
+		if err != nil {
+			return err
+		}
+		if ar.Requester.Username != requester {
+			return errors.New("only the requester can cancel an access request")
+		}
+		if ar.Status != auth.AccessRequestStatus_PENDING.String() {
+			return fmt.Errorf("access request is %s", ar.Status)
+		}
+
+		ar.Status = auth.AccessRequestStatus_CANCELLED.String()
+		return s.repo.Update(ctx, ar)
+	})
+	if err != nil {
+		return nil, err
+	}
+	s.audit(ctx, ar.Requester, "cancel", ar.UUID, "")
+	return s.get(ctx, ar.UUID)
+}
+
+// decision returns the pending request, locked for the transaction of the context, and the
+// approver deciding on it. Approvers must hold the approver role in the requested domain
+// and decide only once.
+func (s service) decision(ctx context.Context, approver string, req *auth.DecideAccessRequestRequest) (entity.AccessRequest, entity.User, error) {
+	if err := ValidateDecideRequest(req); err != nil {
+		return entity.AccessRequest{}, entity.User{}, err
+	}
+
+	ar, err := s.repo.GetForUpdate(ctx, req.Uuid)
+	if err != nil {
+		return entity.AccessRequest{}, entity.User{}, err
+	}
+	if ar.Status != auth.AccessRequestStatus_PENDING.String() {
+		return entity.AccessRequest{}, entity.User{}, fmt.Errorf("access request is %s", ar.Status)
+	}
+	if ar.Requester.Username == approver {
+		return entity.AccessRequest{}, entity.User{}, errors.New("requesters cannot decide on their own access request")
+	}
+	for _, a := range ar.Approvals {
+		if a.Approver.Username == approver {
+			return entity.AccessRequest{}, entity.User{}, errors.New("approver already decided on the access request")
+		}
+	}
+
+	rule, err := s.approverRule(ctx, ar.Role.Title, ar.Domain.Name)
+	if err != nil {
+		return entity.AccessRequest{}, entity.User{}, err
+	}
+	user, err := s.usersRepo.FindOne(ctx, "users.username = ?", approver)
+	if err != nil {
+		return entity.AccessRequest{}, entity.User{}, fmt.Errorf("user `%s` not found", approver)
+	}
+	if !holdsRole(user, rule.ApproverRole, ar.Domain.Name, time.Now()) {
+		return entity.AccessRequest{}, entity.User{}, fmt.Errorf("approvers must hold role `%s` in domain `%s`", rule.ApproverRole, ar.Domain.Name)
+	}
+	return ar, user, nil
+}
+
+// approverRule returns the most specific approver rule for the role in the domain.
+func (s service) approverRule(ctx context.Context, role, domain string) (entity.ApproverRule, error) {
+	rules, err := s.repo.AllApproverRules(ctx)
+	if err != nil {
+		return entity.ApproverRule{}, err
+	}
+
+	best, bestScore := entity.ApproverRule{}, -1
+	for _, r := range rules {
+		score := 0
+		switch r.Role {
+		case role:
+			score += 2
+		case anyName:
+		default:
+			continue
+		}
+		switch r.Domain {
+		case domain:
+			score++
+		case anyName:
+		default:
+			continue
+		}
+		if score > bestScore {
+			best, bestScore = r, score
+		}
+	}
+	if bestScore < 0 {
+		return entity.ApproverRule{}, fmt.Errorf("no approver rule for role `%s` in domain `%s`", role, domain)
+	}
+	return best, nil
+}
+
+// holdsRole reports whether the user has an active assignment of role in domain or in all domains.
+func holdsRole(user entity.User, role, domain string, now time.Time) bool {
+	for _, ur := range user.UserRoles {
+		if ur.Role.Title != role || !ur.Active(now) {
+			continue
+		}
+		if ur.Domain.Name == domain || ur.Domain.Name == "" || ur.Domain.Name == anyName {
+			return true
+		}
+	}
+	return false
+}
+
+// QueryApproverRules returns the approver rules with the specified offset and limit.
+func (s service) QueryApproverRules(ctx context.Context, offset, limit int64) (*auth.ListApproverRulesResponse, error) {
+	items, count, err := s.repo.QueryApproverRules(ctx, offset, limit)
+	if err != nil {
+		return nil, err
+	}
+	return &auth.ListApproverRulesResponse{
+		ApproverRules: entity.ApproverRuleToProtoList(items),
+		TotalCount:    int64(count),
+		Offset:        offset,
+		Limit:         limit,
+	}, nil
+}
+
+// CreateApproverRule creates a new approver rule.
+func (s service) CreateApproverRule(ctx context.Context, req *auth.CreateApproverRuleRequest) (*auth.ApproverRule, error) {
+	if err := ValidateCreateApproverRuleRequest(req); err != nil {
+		return nil, err
+	}
+	if req.Role != anyName {
+		if _, err := s.rolesRepo.GetByTitle(ctx, req.Role); err != nil {
+			return nil, err
+		}
+	}
+	if req.Domain != anyName {
+		if _, err := s.domainsRepo.GetByName(ctx, req.Domain); err != nil {
+			return nil, err
+		}
+	}
+	if _, err := s.rolesRepo.GetByTitle(ctx, req.ApproverRole); err != nil {
+		return nil, err
+	}
+
+	id, err := s.repo.CreateApproverRule(ctx, entity.ApproverRule{
+		Role:         req.Role,
+		Domain:       req.Domain,
+		ApproverRole: req.ApproverRole,
+		TwoPerson:    req.TwoPerson,
+	})
+	if err != nil {
+		return nil, err
+	}
+	rule, err := s.repo.GetApproverRule(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	return rule.ToProto(), nil
+}
+
+// DeleteApproverRule deletes the approver rule with the specified UUID.
+func (s service) DeleteApproverRule(ctx context.Context, uuid string) (*auth.ApproverRule, error) {
+	rule, err := s.repo.GetApproverRule(ctx, uuid)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.repo.DeleteApproverRule(ctx, rule); err != nil {
+		return nil, err
+	}
+	return rule.ToProto(), nil
+}
+
+// audit writes an audit log entry of the user acting on the access request.
+func (s service) audit(ctx context.Context, user entity.User, action, uuid, value string) {
+	if s.auditLogs == nil {
+		return
+	}
+	if len(value) > 128 {
+		value = value[:128]
+	}
+	_, err := s.auditLogs.Create(ctx, &auth.CreateAuditLogRequest{
+		UserUuid: user.UUID,
+		Action:   action,
+		Object:   "access_request:" + uuid,
+		NewValue: value,
+	})
+	if err != nil {
+		log.Error("write access request audit log failed", log.String("access_request", uuid), log.Err(err))
+	}
+}
+
+// recordRevision records the granted role as a policy revision.
+func (s service) recordRevision(ctx context.Context, reason string) {
+	if s.recorder == nil {
+		return
+	}
+	if err := s.recorder.RecordRevision(ctx, reason); err != nil {
+		log.Error("record policy revision failed", log.Err(err))
+	}
+}
@@ -0,0 +1,164 @@
+package access_requests
+
+import (
+	"context"
+	"testing"
+
+	"github.com/golang-tire/auth/internal/domains"
+	"github.com/golang-tire/auth/internal/entity"
+	"github.com/golang-tire/auth/internal/pkg/scope"
+	"github.com/golang-tire/auth/internal/pkg/testutils"
+	auth "github.com/golang-tire/auth/internal/proto/v1"
+	"github.com/golang-tire/auth/internal/roles"
+	"github.com/golang-tire/auth/internal/users"
+	"github.com/stretchr/testify/assert"
+	"gorm.io/gorm"
+)
+
+func TestCreateAccessRequestRequest_Validate(t *testing.T) {
+	testutils.TestUp()
+	tests := []struct {
+		name      string
+		model     *auth.CreateAccessRequestRequest
+		wantError bool
+	}{
+		{"success", &auth.CreateAccessRequestRequest{Role: "oncall", Domain: "foo.bar", Duration: "8h", Justification: "incident"}, false},
+		{"required", &auth.CreateAccessRequestRequest{Role: "oncall", Domain: "foo.bar", Duration: "8h"}, true},
+		{"invalid duration", &auth.CreateAccessRequestRequest{Role: "oncall", Domain: "foo.bar", Duration: "soon", Justification: "incident"}, true},
+		{"too long", &auth.CreateAccessRequestRequest{Role: "oncall", Domain: "foo.bar", Duration: "10000h", Justification: "incident"}, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := ValidateCreateRequest(tt.model)
+			assert.Equal(t, tt.wantError, err != nil)
+		})
+	}
+}
+
+func TestDecideAccessRequestRequest_Validate(t *testing.T) {
+	testutils.TestUp()
+	tests := []struct {
+		name      string
+		model     *auth.DecideAccessRequestRequest
+		wantError bool
+	}{
+		{"success", &auth.DecideAccessRequestRequest{Uuid: "0b1b8c5e-4d36-4b8f-9c1a-6f3a1f1f6a11", Comment: "ok"}, false},
+		{"required", &auth.DecideAccessRequestRequest{Comment: "ok"}, true},
+		{"invalid uuid", &auth.DecideAccessRequestRequest{Uuid: "request", Comment: "ok"}, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := ValidateDecideRequest(tt.model)
+			assert.Equal(t, tt.wantError, err != nil)
+		})
+	}
+}
+
+func Test_service(t *testing.T) {
+	testutils.TestUp()
+	ctx := context.Background()
+
+	rolesRepo := roles.NewMockRepository()
+	domainsRepo := domains.NewMockRepository()
+	usersRepo := users.NewMockRepository()
+	for _, title := range []string{"oncall", "lead"} {
+		_, err := rolesRepo.Create(ctx, entity.Role{Title: title, Enable: true})
+		assert.Nil(t, err)
+	}
+	_, err := domainsRepo.Create(ctx, entity.Domain{Name: "foo.bar", Enable: true})
+	assert.Nil(t, err)
+	lead, _ := rolesRepo.GetByTitle(ctx, "lead")
+	domain, _ := domainsRepo.GetByName(ctx, "foo.bar")
+
+	for _, u := range []entity.User{
+		{Model: gorm.Model{ID: 1}, Username: "requester", Email: "requester@foo.bar"},
+		{Model: gorm.Model{ID: 2}, Username: "approver1", Email: "approver1@foo.bar", UserRoles: []entity.UserRole{{Role: lead, Domain: domain, Enable: true}}},
+		{Model: gorm.Model{ID: 3}, Username: "approver2", Email: "approver2@foo.bar", UserRoles: []entity.UserRole{{Role: lead, Domain: domain, Enable: true}}},
+		{Model: gorm.Model{ID: 4}, Username: "outsider", Email: "outsider@foo.bar"},
+	} {
+		_, err := usersRepo.Create(ctx, u)
+		assert.Nil(t, err)
+	}
+
+	s := NewService(NewMockRepository(), usersRepo, rolesRepo, domainsRepo, nil, nil)
+	create := &auth.CreateAccessRequestRequest{Role: "oncall", Domain: "foo.bar", Duration: "8h", Justification: "incident"}
+
+	// requests need an approver rule
+	_, err = s.Create(ctx, "requester", create)
+	assert.NotNil(t, err)
+
+	_, err = s.CreateApproverRule(ctx, &auth.CreateApproverRuleRequest{Role: "*", Domain: "*", ApproverRole: "admin"})
+	assert.NotNil(t, err)
+	_, err = s.CreateApproverRule(ctx, &auth.CreateApproverRuleRequest{Role: "oncall", Domain: "*", ApproverRole: "lead", TwoPerson: true})
+	assert.Nil(t, err)
+
+	req, err := s.Create(ctx, "requester", create)
+	assert.Nil(t, err)
+	assert.Equal(t, auth.AccessRequestStatus_PENDING, req.Status)
+	assert.Equal(t, int64(2), req.RequiredApprovals)
+	assert.Equal(t, "8h0m0s", req.Duration)
+
+	// one pending request per role and domain
+	_, err = s.Create(ctx, "requester", create)
+	assert.NotNil(t, err)
+
+	// only the requester, the approvers and the admins of the domain see the request
+	for _, viewer := range []string{"requester", "approver1"} {
+		_, err = s.Get(scope.NewContext(ctx, scope.Domains()), viewer, req.Uuid)
+		assert.Nil(t, err)
+		res, err := s.Query(scope.NewContext(ctx, scope.Domains()), viewer, "", "", 0, 10)
+		assert.Nil(t, err)
+		assert.Equal(t, int64(1), res.TotalCount)
+	}
+	_, err = s.Get(scope.NewContext(ctx, scope.Domains()), "outsider", req.Uuid)
+	assert.NotNil(t, err)
+	res, err := s.Query(scope.NewContext(ctx, scope.Domains("other.bar")), "outsider", "", "", 0, 10)
+	assert.Nil(t, err)
+	assert.Equal(t, int64(0), res.TotalCount)
+	_, err = s.Get(scope.NewContext(ctx, scope.Domains("foo.bar")), "outsider", req.Uuid)
+	assert.Nil(t, err)
+
+	decide := &auth.DecideAccessRequestRequest{Uuid: req.Uuid, Comment: "ok"}
+	_, err = s.Approve(ctx, "requester", decide)
+	assert.NotNil(t, err)
+	_, err = s.Approve(ctx, "outsider", decide)
+	assert.NotNil(t, err)
+
+	req, err = s.Approve(ctx, "approver1", decide)
+	assert.Nil(t, err)
+	assert.Equal(t, auth.AccessRequestStatus_PENDING, req.Status)
+	_, err = s.Approve(ctx, "approver1", decide)
+	assert.NotNil(t, err)
+
+	// the second approval grants a time-limited role
+	req, err = s.Approve(ctx, "approver2", decide)
+	assert.Nil(t, err)
+	assert.Equal(t, auth.AccessRequestStatus_APPROVED, req.Status)
+	assert.NotEmpty(t, req.UserRoleUuid)
+	assert.NotNil(t, req.ExpiresAt)
+	assert.Len(t, req.Approvals, 2)
+	userRoles, _ := usersRepo.AllUserRole(ctx)
+	assert.Len(t, userRoles, 1)
+	assert.NotNil(t, userRoles[0].ExpiresAt)
+
+	// deny and cancel
+	req, err = s.Create(ctx, "requester", create)
+	assert.Nil(t, err)
+	_, err = s.Cancel(ctx, "approver1", req.Uuid)
+	assert.NotNil(t, err)
+	req, err = s.Deny(ctx, "approver1", &auth.DecideAccessRequestRequest{Uuid: req.Uuid})
+	assert.Nil(t, err)
+	assert.Equal(t, auth.AccessRequestStatus_DENIED, req.Status)
+	_, err = s.Cancel(ctx, "requester", req.Uuid)
+	assert.NotNil(t, err)
+
+	req, err = s.Create(ctx, "requester", create)
+	assert.Nil(t, err)
+	req, err = s.Cancel(ctx, "requester", req.Uuid)
+	assert.Nil(t, err)
+	assert.Equal(t, auth.AccessRequestStatus_CANCELLED, req.Status)
+
+	res, err = s.Query(ctx, "requester", "requester", auth.AccessRequestStatus_CANCELLED.String(), 0, 10)
+	assert.Nil(t, err)
+	assert.Equal(t, int64(1), res.TotalCount)
+}
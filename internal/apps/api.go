@@ -107,7 +107,7 @@ func (a api) UpdateResource(ctx context.Context, request *auth.UpdateResourceReq
 }
 
 func (a api) DeleteResource(ctx context.Context, request *auth.DeleteResourceRequest) (*empty.Empty, error) {
-	_, err := a.service.DeleteResource(ctx, request.Uuid)
+	_, err := a.service.DeleteResource(ctx, request.Uuid, request.Cascade)
 	if err != nil {
 		return nil, status.Error(codes.InvalidArgument, err.Error())
 	}
@@ -148,13 +148,45 @@ func (a api) UpdateObject(ctx context.Context, request *auth.UpdateObjectRequest
 }
 
 func (a api) DeleteObject(ctx context.Context, request *auth.DeleteObjectRequest) (*empty.Empty, error) {
-	_, err := a.service.DeleteObject(ctx, request.Uuid)
+	_, err := a.service.DeleteObject(ctx, request.Uuid, request.Cascade)
 	if err != nil {
 		return nil, status.Error(codes.InvalidArgument, err.Error())
 	}
 	return &empty.Empty{}, err
 }
 
+func (a api) ListRoutes(ctx context.Context, request *auth.ListRoutesRequest) (*auth.ListRoutesResponse, error) {
+	res, err := a.service.ListRoutes(ctx, request.Uuid)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+	return res, err
+}
+
+func (a api) CreateRoute(ctx context.Context, request *auth.CreateRouteRequest) (*auth.Route, error) {
+	res, err := a.service.CreateRoute(ctx, request)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+	return res, err
+}
+
+func (a api) DeleteRoute(ctx context.Context, request *auth.DeleteRouteRequest) (*empty.Empty, error) {
+	_, err := a.service.DeleteRoute(ctx, request.Uuid)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+	return &empty.Empty{}, err
+}
+
+func (a api) ImportAppSchema(ctx context.Context, request *auth.ImportAppSchemaRequest) (*auth.ImportAppSchemaResponse, error) {
+	res, err := a.service.ImportSchema(ctx, request)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+	return res, nil
+}
+
 func New(srv Service) API {
 	s := api{service: srv}
 	grpcgw.RegisterController(s)
@@ -30,6 +30,8 @@ type Repository interface {
 	UpdateApp(ctx context.Context, app entity.App) error
 	// DeleteApp removes the app with given UUID from the storage.
 	DeleteApp(ctx context.Context, app entity.App) error
+	// AllApps returns all apps.
+	AllApps(ctx context.Context) ([]entity.App, error)
 
 	// GetResource returns the Resource with the specified Resource UUID.
 	GetResource(ctx context.Context, uuid string) (entity.Resource, error)
@@ -45,6 +47,10 @@ type Repository interface {
 	UpdateResource(ctx context.Context, app entity.App, resource entity.Resource) error
 	// DeleteResource removes the Resource with given UUID from the storage.
 	DeleteResource(ctx context.Context, resource entity.Resource) error
+	// AllResources returns all Resources.
+	AllResources(ctx context.Context) ([]entity.Resource, error)
+	// AppResources returns all Resources of the given app.
+	AppResources(ctx context.Context, app entity.App) ([]entity.Resource, error)
 
 	// GetObject returns the Object with the specified Object UUID.
 	GetObject(ctx context.Context, uuid string) (entity.Object, error)
@@ -60,6 +66,17 @@ type Repository interface {
 	UpdateObject(ctx context.Context, app entity.App, object entity.Object) error
 	// DeleteObject removes the Object with given UUID from the storage.
 	DeleteObject(ctx context.Context, object entity.Object) error
+
+	// GetRoute returns the Route with the specified Route UUID.
+	GetRoute(ctx context.Context, uuid string) (entity.Route, error)
+	// AppRoutes returns all Routes of the given app.
+	AppRoutes(ctx context.Context, app entity.App) ([]entity.Route, error)
+	// AllRoutes returns all Routes.
+	AllRoutes(ctx context.Context) ([]entity.Route, error)
+	// CreateRoute saves a new Route in the storage.
+	CreateRoute(ctx context.Context, app entity.App, route entity.Route) (string, error)
+	// DeleteRoute removes the Route with given UUID from the storage.
+	DeleteRoute(ctx context.Context, route entity.Route) error
 }
 
 // repository persists apps in database
@@ -115,6 +132,13 @@ func (r repository) DeleteApp(ctx context.Context, app entity.App) error {
 	return res.Error
 }
 
+// AllApps retrieves all app records from the database.
+func (r repository) AllApps(ctx context.Context) ([]entity.App, error) {
+	var _apps []entity.App
+	res := r.db.With(ctx).Order("id asc").Find(&_apps)
+	return _apps, res.Error
+}
+
 // CountApps returns the number of the app records in the database.
 func (r repository) CountApps(ctx context.Context) (int64, error) {
 	var count int64
@@ -219,6 +243,27 @@ func (r repository) QueryResources(ctx context.Context, query string, offset, li
 	return _resources, int(count), res.Error
 }
 
+// AllResources retrieves all resource records from the database.
+func (r repository) AllResources(ctx context.Context) ([]entity.Resource, error) {
+	var _resources []entity.Resource
+	res := r.db.With(ctx).
+		Order("id asc").
+		Preload("App").
+		Find(&_resources)
+	return _resources, res.Error
+}
+
+// AppResources retrieves all resource records of the given app from the database.
+func (r repository) AppResources(ctx context.Context, app entity.App) ([]entity.Resource, error) {
+	var _resources []entity.Resource
+	res := r.db.With(ctx).
+		Where("app_id = ?", app.ID).
+		Order("id asc").
+		Preload("App").
+		Find(&_resources)
+	return _resources, res.Error
+}
+
 // GetObject reads the object with the specified ID from the database.
 func (r repository) GetObject(ctx context.Context, uuid string) (entity.Object, error) {
 	var object entity.Object
@@ -294,3 +339,53 @@ func (r repository) QueryObjects(ctx context.Context, query string, offset, limi
 	}
 	return _objects, int(count), res.Error
 }
+
+// GetRoute reads the route with the specified ID from the database.
+func (r repository) GetRoute(ctx context.Context, uuid string) (entity.Route, error) {
+	var route entity.Route
+	res := r.db.With(ctx).Where("uuid = ?", uuid).Preload("App").First(&route)
+	if res.Error != nil && res.Error == gorm.ErrRecordNotFound {
+		return entity.Route{}, fmt.Errorf("route with uuid `%s` not found", uuid)
+	}
+	return route, res.Error
+}
+
+// AppRoutes retrieves all route records of the given app from the database.
+func (r repository) AppRoutes(ctx context.Context, app entity.App) ([]entity.Route, error) {
+	var _routes []entity.Route
+	res := r.db.With(ctx).
+		Where("app_id = ?", app.ID).
+		Order("id asc").
+		Preload("App").
+		Find(&_routes)
+	return _routes, res.Error
+}
+
+// AllRoutes retrieves all route records from the database.
+func (r repository) AllRoutes(ctx context.Context) ([]entity.Route, error) {
+	var _routes []entity.Route
+	res := r.db.With(ctx).
+		Order("id asc").
+		Preload("App").
+		Find(&_routes)
+	return _routes, res.Error
+}
+
+// CreateRoute saves a new route record in the database.
+// It returns the UUID of the newly inserted route record.
+func (r repository) CreateRoute(ctx context.Context, app entity.App, route entity.Route) (string, error) {
+	now := time.Now()
+	route.UUID = uuid.New().String()
+	route.CreatedAt = now
+	route.UpdatedAt = now
+	route.App = app
+	route.AppID = app.ID
+	res := r.db.With(ctx).Create(&route)
+	return route.UUID, res.Error
+}
+
+// DeleteRoute deletes a route with the specified ID from the database.
+func (r repository) DeleteRoute(ctx context.Context, route entity.Route) error {
+	res := r.db.With(ctx).Delete(&route)
+	return res.Error
+}
@@ -20,6 +20,7 @@ type mockRepository struct {
 	apps      []entity.App
 	resources []entity.Resource
 	objects   []entity.Object
+	routes    []entity.Route
 }
 
 func (m mockRepository) GetAppByName(ctx context.Context, name string) (entity.App, error) {
@@ -82,6 +83,10 @@ func (m *mockRepository) DeleteApp(ctx context.Context, app entity.App) error {
 	return nil
 }
 
+func (m mockRepository) AllApps(ctx context.Context) ([]entity.App, error) {
+	return m.apps, nil
+}
+
 func (m mockRepository) GetResourceByName(ctx context.Context, name string) (entity.Resource, error) {
 	for _, item := range m.resources {
 		if item.Name == name {
@@ -108,6 +113,20 @@ func (m mockRepository) QueryResources(ctx context.Context, query string, offset
 	return m.resources, len(m.resources), nil
 }
 
+func (m mockRepository) AllResources(ctx context.Context) ([]entity.Resource, error) {
+	return m.resources, nil
+}
+
+func (m mockRepository) AppResources(ctx context.Context, app entity.App) ([]entity.Resource, error) {
+	var items []entity.Resource
+	for _, item := range m.resources {
+		if item.AppID == app.ID {
+			items = append(items, item)
+		}
+	}
+	return items, nil
+}
+
 func (m *mockRepository) CreateResource(ctx context.Context, app entity.App, resource entity.Resource) (string, error) {
 	Uuid := uuid.New().String()
 	resource.App = app
@@ -211,3 +230,49 @@ func (m *mockRepository) DeleteObject(ctx context.Context, object entity.Object)
 	}
 	return nil
 }
+
+func (m mockRepository) GetRoute(ctx context.Context, uuid string) (entity.Route, error) {
+	for _, item := range m.routes {
+		if item.UUID == uuid {
+			return item, nil
+		}
+	}
+	return entity.Route{}, gorm.ErrRecordNotFound
+}
+
+func (m mockRepository) AppRoutes(ctx context.Context, app entity.App) ([]entity.Route, error) {
+	var items []entity.Route
+	for _, item := range m.routes {
+		if item.App.UUID == app.UUID {
+			items = append(items, item)
+		}
+	}
+	return items, nil
+}
+
+func (m mockRepository) AllRoutes(ctx context.Context) ([]entity.Route, error) {
+	return m.routes, nil
+}
+
+func (m *mockRepository) CreateRoute(ctx context.Context, app entity.App, route entity.Route) (string, error) {
+	Uuid := uuid.New().String()
+	route.App = app
+	route.AppID = app.ID
+	route.UUID = Uuid
+	if route.Resource == "error" {
+		return Uuid, errCRUD
+	}
+	m.routes = append(m.routes, route)
+	return Uuid, nil
+}
+
+func (m *mockRepository) DeleteRoute(ctx context.Context, route entity.Route) error {
+	for i, item := range m.routes {
+		if item.UUID == route.UUID {
+			m.routes[i] = m.routes[len(m.routes)-1]
+			m.routes = m.routes[:len(m.routes)-1]
+			break
+		}
+	}
+	return nil
+}
@@ -0,0 +1,271 @@
+package apps
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/golang-tire/auth/internal/entity"
+	"github.com/golang-tire/auth/internal/pkg/helpers"
+)
+
+const (
+	resourceParam = "resource"
+	objectParam   = "object"
+	anyMethod     = "*"
+	restSegment   = "**"
+)
+
+var paramName = regexp.MustCompile(`^\{(\w+)\}$`)
+
+// RouteMatch is the permission a request to an app needs.
+type RouteMatch struct {
+	App      string
+	Template string
+	Resource string
+	Action   string
+	Object   string
+}
+
+// RouteTable finds the app of a forwarded host and matches requests against its routes.
+type RouteTable struct {
+	hosts map[string]*appRoutes
+}
+
+// appRoutes are the compiled routes and the action mappings of an app.
+type appRoutes struct {
+	name         string
+	enable       bool
+	organization uint
+	root         *routeNode
+	actions      map[string]string
+	aliases      map[string]string
+}
+
+// routeNode is a path segment of the route templates, static segments are matched
+// before parameters and a trailing ** last.
+type routeNode struct {
+	static map[string]*routeNode
+	param  *routeNode
+	routes map[string]*compiledRoute
+	rest   map[string]*compiledRoute
+}
+
+type compiledRoute struct {
+	route entity.Route
+	// params are the names of the parameter segments in order, empty for * segments
+	params []string
+}
+
+// templateSegments splits a route template, it fails on templates the table cannot match.
+func templateSegments(template string) ([]string, error) {
+	if !strings.HasPrefix(template, "/") {
+		return nil, fmt.Errorf("template must start with /")
+	}
+	segments := pathSegments(template)
+	for i, seg := range segments {
+		switch {
+		case seg == restSegment:
+			if i != len(segments)-1 {
+				return nil, fmt.Errorf("** must be the last segment")
+			}
+		case seg == "*", paramName.MatchString(seg):
+		case strings.ContainsAny(seg, "{}*"):
+			return nil, fmt.Errorf("invalid segment `%s`", seg)
+		}
+	}
+	return segments, nil
+}
+
+// templateParams returns the names of the parameter segments of a valid template.
+func templateParams(template string) []string {
+	var params []string
+	for _, seg := range pathSegments(template) {
+		if m := paramName.FindStringSubmatch(seg); m != nil {
+			params = append(params, m[1])
+		} else if seg == "*" {
+			params = append(params, "")
+		}
+	}
+	return params
+}
+
+// pathSegments splits the path of a request uri or template, the query is ignored.
+func pathSegments(uri string) []string {
+	if i := strings.IndexAny(uri, "?#"); i >= 0 {
+		uri = uri[:i]
+	}
+	uri = strings.Trim(uri, "/")
+	if uri == "" {
+		return nil
+	}
+	return strings.Split(uri, "/")
+}
+
+// newRouteTable compiles the routes of the apps, routes with invalid templates are skipped.
+func newRouteTable(apps []entity.App, routes []entity.Route) *RouteTable {
+	t := &RouteTable{hosts: map[string]*appRoutes{}}
+	byUUID := map[string]*appRoutes{}
+	for _, app := range apps {
+		ar := &appRoutes{
+			name:         app.Name,
+			enable:       app.Enable,
+			organization: app.OrganizationID,
+			root:         &routeNode{},
+			actions:      app.ActionMap(),
+			aliases:      app.AliasMap(),
+		}
+		byUUID[app.UUID] = ar
+		for _, host := range app.HostList() {
+			t.hosts[helpers.NormalizeHost(host)] = ar
+		}
+	}
+	for _, route := range routes {
+		ar, ok := byUUID[route.App.UUID]
+		if !ok {
+			continue
+		}
+		segments, err := templateSegments(route.Template)
+		if err != nil {
+			continue
+		}
+		ar.root.add(segments, &compiledRoute{route: route, params: templateParams(route.Template)})
+	}
+	return t
+}
+
+func (n *routeNode) add(segments []string, route *compiledRoute) {
+	node := n
+	for i, seg := range segments {
+		if seg == restSegment && i == len(segments)-1 {
+			if node.rest == nil {
+				node.rest = map[string]*compiledRoute{}
+			}
+			node.rest[strings.ToUpper(route.route.Method)] = route
+			return
+		}
+		if seg == "*" || paramName.MatchString(seg) {
+			if node.param == nil {
+				node.param = &routeNode{}
+			}
+			node = node.param
+			continue
+		}
+		if node.static == nil {
+			node.static = map[string]*routeNode{}
+		}
+		next, ok := node.static[seg]
+		if !ok {
+			next = &routeNode{}
+			node.static[seg] = next
+		}
+		node = next
+	}
+	if node.routes == nil {
+		node.routes = map[string]*compiledRoute{}
+	}
+	node.routes[strings.ToUpper(route.route.Method)] = route
+}
+
+func (n *routeNode) match(segments []string, method string, values []string) (*compiledRoute, []string) {
+	if len(segments) == 0 {
+		if r := lookupMethod(n.routes, method); r != nil {
+			return r, values
+		}
+		return lookupMethod(n.rest, method), values
+	}
+	if next, ok := n.static[segments[0]]; ok {
+		if r, v := next.match(segments[1:], method, values); r != nil {
+			return r, v
+		}
+	}
+	if n.param != nil {
+		if r, v := n.param.match(segments[1:], method, append(values, segments[0])); r != nil {
+			return r, v
+		}
+	}
+	return lookupMethod(n.rest, method), values
+}
+
+func lookupMethod(routes map[string]*compiledRoute, method string) *compiledRoute {
+	if r, ok := routes[method]; ok {
+		return r
+	}
+	return routes[anyMethod]
+}
+
+// HasApp reports whether an app serves the forwarded host.
+func (t *RouteTable) HasApp(host string) bool {
+	_, ok := t.hosts[helpers.NormalizeHost(host)]
+	return ok
+}
+
+// AppEnabled reports whether the app serving the forwarded host is enabled.
+func (t *RouteTable) AppEnabled(host string) bool {
+	ar, ok := t.hosts[helpers.NormalizeHost(host)]
+	return ok && ar.enable
+}
+
+// AppOrganization returns the organization of the app serving the forwarded host.
+func (t *RouteTable) AppOrganization(host string) (uint, bool) {
+	ar, ok := t.hosts[helpers.NormalizeHost(host)]
+	if !ok {
+		return 0, false
+	}
+	return ar.organization, true
+}
+
+// Match returns the permission of a request to the app of the forwarded host, it returns
+// false when no enabled app serves the host or none of its routes matches.
+func (t *RouteTable) Match(host, method, uri string) (RouteMatch, bool) {
+	ar, ok := t.hosts[helpers.NormalizeHost(host)]
+	if !ok || !ar.enable {
+		return RouteMatch{}, false
+	}
+	method = strings.ToUpper(method)
+	segments := pathSegments(uri)
+	r, values := ar.root.match(segments, method, nil)
+	if r == nil {
+		return RouteMatch{}, false
+	}
+
+	m := RouteMatch{
+		App:      ar.name,
+		Template: r.route.Template,
+		Resource: r.route.Resource,
+		Action:   r.route.Action,
+		Object:   "*",
+	}
+	for i, name := range r.params {
+		switch {
+		case name == resourceParam && m.Resource == "":
+			m.Resource = values[i]
+		case name == objectParam:
+			m.Object = values[i]
+		}
+	}
+	if m.Action == "" {
+		m.Action = ar.action(method, segments)
+	}
+	if action, ok := ar.aliases[m.Action]; ok {
+		m.Action = action
+	}
+	return m, true
+}
+
+// action returns the action of a request method, full gRPC method names are mapped before
+// the HTTP method and the request method is the action when nothing is mapped.
+func (ar *appRoutes) action(method string, segments []string) string {
+	if len(segments) == 2 {
+		if action, ok := ar.actions["/"+segments[0]+"/"+segments[1]]; ok {
+			return action
+		}
+	}
+	if action, ok := ar.actions[method]; ok {
+		return action
+	}
+	if action, ok := ar.actions[anyMethod]; ok {
+		return action
+	}
+	return method
+}
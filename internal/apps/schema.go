@@ -0,0 +1,415 @@
+package apps
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+
+	"google.golang.org/genproto/googleapis/api/annotations"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protodesc"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/types/descriptorpb"
+
+	"github.com/golang-tire/auth/internal/entity"
+	"github.com/golang-tire/auth/internal/pkg/helpers"
+	auth "github.com/golang-tire/auth/internal/proto/v1"
+)
+
+var (
+	// methodActions are the suggested actions of the HTTP methods
+	methodActions = map[string]string{
+		"GET":    "read",
+		"HEAD":   "read",
+		"POST":   "create",
+		"PUT":    "update",
+		"PATCH":  "update",
+		"DELETE": "delete",
+	}
+	// verbActions are the suggested actions of the leading verbs of gRPC method names
+	verbActions = map[string]string{
+		"get":    "read",
+		"list":   "read",
+		"search": "read",
+		"watch":  "read",
+		"create": "create",
+		"add":    "create",
+		"update": "update",
+		"set":    "update",
+		"patch":  "update",
+		"delete": "delete",
+		"remove": "delete",
+	}
+
+	versionSegment = regexp.MustCompile(`^v\d+$`)
+)
+
+// Schema is the resources, routes and suggested actions an app schema describes.
+type Schema struct {
+	Resources []string
+	Routes    []SchemaRoute
+	// Actions are the suggested actions of the HTTP methods and full gRPC method names
+	Actions map[string]string
+}
+
+// SchemaRoute is a route template of an app schema.
+type SchemaRoute struct {
+	Method   string
+	Template string
+	Resource string
+	Action   string
+}
+
+func (r SchemaRoute) line() string {
+	line := fmt.Sprintf("route %s %s %s", r.Method, r.Template, r.Resource)
+	if r.Action != "" {
+		line += " " + r.Action
+	}
+	return line
+}
+
+func routeLine(r entity.Route) string {
+	return SchemaRoute{Method: r.Method, Template: r.Template, Resource: r.Resource, Action: r.Action}.line()
+}
+
+// ParseSchema parses an OpenAPI document or a serialized FileDescriptorSet.
+func ParseSchema(format auth.SchemaFormat, content []byte) (*Schema, error) {
+	var schema *Schema
+	var err error
+	switch format {
+	case auth.SchemaFormat_OPENAPI:
+		schema, err = parseOpenAPI(content)
+	case auth.SchemaFormat_DESCRIPTOR_SET:
+		schema, err = parseDescriptorSet(content)
+	default:
+		return nil, fmt.Errorf("unsupported schema format `%s`", format)
+	}
+	if err != nil {
+		return nil, err
+	}
+	for _, r := range schema.Routes {
+		if _, err := templateSegments(r.Template); err != nil {
+			return nil, fmt.Errorf("invalid route template `%s`: %v", r.Template, err)
+		}
+	}
+	schema.sort()
+	return schema, nil
+}
+
+func (s *Schema) addRoute(r SchemaRoute) {
+	for _, name := range s.Resources {
+		if name == r.Resource {
+			s.Routes = append(s.Routes, r)
+			return
+		}
+	}
+	s.Resources = append(s.Resources, r.Resource)
+	s.Routes = append(s.Routes, r)
+}
+
+func (s *Schema) sort() {
+	sort.Strings(s.Resources)
+	sort.Slice(s.Routes, func(i, j int) bool {
+		if s.Routes[i].Template != s.Routes[j].Template {
+			return s.Routes[i].Template < s.Routes[j].Template
+		}
+		return s.Routes[i].Method < s.Routes[j].Method
+	})
+}
+
+type openAPIDocument struct {
+	Swagger  string                                `json:"swagger"`
+	OpenAPI  string                                `json:"openapi"`
+	BasePath string                                `json:"basePath"`
+	Paths    map[string]map[string]json.RawMessage `json:"paths"`
+}
+
+// openAPIOperation holds the vendor extensions that set the permission of an operation.
+type openAPIOperation struct {
+	Resource string `json:"x-auth-resource"`
+	Action   string `json:"x-auth-action"`
+}
+
+// parseOpenAPI reads the routes of the paths of an OpenAPI or Swagger JSON document.
+func parseOpenAPI(content []byte) (*Schema, error) {
+	var doc openAPIDocument
+	if err := json.Unmarshal(content, &doc); err != nil {
+		return nil, err
+	}
+	if doc.Swagger == "" && doc.OpenAPI == "" {
+		return nil, fmt.Errorf("not an OpenAPI document")
+	}
+
+	schema := &Schema{Actions: map[string]string{}}
+	for path, item := range doc.Paths {
+		template, resource := pathRoute(strings.TrimSuffix(doc.BasePath, "/") + path)
+		for key, raw := range item {
+			method := strings.ToUpper(key)
+			action, ok := methodActions[method]
+			if !ok {
+				// parameters, summary and the other path item fields
+				continue
+			}
+			var op openAPIOperation
+			if err := json.Unmarshal(raw, &op); err != nil {
+				return nil, fmt.Errorf("invalid operation `%s %s`: %v", method, path, err)
+			}
+			if op.Resource != "" {
+				resource = op.Resource
+			}
+			if resource == "" {
+				continue
+			}
+			schema.addRoute(SchemaRoute{Method: method, Template: template, Resource: resource, Action: op.Action})
+			schema.Actions[method] = action
+		}
+	}
+	return schema, nil
+}
+
+// parseDescriptorSet reads a route of every service, its methods are mapped to the action
+// of their leading verb, and a route of every HTTP binding of the methods.
+func parseDescriptorSet(content []byte) (*Schema, error) {
+	var set descriptorpb.FileDescriptorSet
+	if err := proto.Unmarshal(content, &set); err != nil {
+		return nil, err
+	}
+	files, err := protodesc.NewFiles(&set)
+	if err != nil {
+		return nil, fmt.Errorf("invalid descriptor set, it must include the imports: %v", err)
+	}
+
+	schema := &Schema{Actions: map[string]string{}}
+	files.RangeFiles(func(fd protoreflect.FileDescriptor) bool {
+		services := fd.Services()
+		for i := 0; i < services.Len(); i++ {
+			sd := services.Get(i)
+			resource := helpers.ServiceResource(string(sd.Name()))
+			schema.addRoute(SchemaRoute{Method: "POST", Template: "/" + string(sd.FullName()) + "/*", Resource: resource})
+
+			methods := sd.Methods()
+			for j := 0; j < methods.Len(); j++ {
+				md := methods.Get(j)
+				action := verbAction(string(md.Name()))
+				schema.Actions[fmt.Sprintf("/%s/%s", sd.FullName(), md.Name())] = action
+				for _, b := range httpBindings(md) {
+					template, _ := pathRoute(b.path)
+					schema.addRoute(SchemaRoute{Method: b.method, Template: template, Resource: resource, Action: action})
+				}
+			}
+		}
+		return true
+	})
+	return schema, nil
+}
+
+type httpBinding struct {
+	method string
+	path   string
+}
+
+// httpBindings returns the google.api.http bindings of a method.
+func httpBindings(md protoreflect.MethodDescriptor) []httpBinding {
+	opts, ok := md.Options().(*descriptorpb.MethodOptions)
+	if !ok || opts == nil || !proto.HasExtension(opts, annotations.E_Http) {
+		return nil
+	}
+	rule, ok := proto.GetExtension(opts, annotations.E_Http).(*annotations.HttpRule)
+	if !ok || rule == nil {
+		return nil
+	}
+
+	var bindings []httpBinding
+	rules := append([]*annotations.HttpRule{rule}, rule.AdditionalBindings...)
+	for _, r := range rules {
+		switch p := r.Pattern.(type) {
+		case *annotations.HttpRule_Get:
+			bindings = append(bindings, httpBinding{"GET", p.Get})
+		case *annotations.HttpRule_Put:
+			bindings = append(bindings, httpBinding{"PUT", p.Put})
+		case *annotations.HttpRule_Post:
+			bindings = append(bindings, httpBinding{"POST", p.Post})
+		case *annotations.HttpRule_Delete:
+			bindings = append(bindings, httpBinding{"DELETE", p.Delete})
+		case *annotations.HttpRule_Patch:
+			bindings = append(bindings, httpBinding{"PATCH", p.Patch})
+		case *annotations.HttpRule_Custom:
+			bindings = append(bindings, httpBinding{strings.ToUpper(p.Custom.Kind), p.Custom.Path})
+		}
+	}
+	return bindings
+}
+
+// verbAction returns the suggested action of a gRPC method, e.g. read for ListOrders.
+func verbAction(method string) string {
+	words := helpers.CamelWords(method)
+	if len(words) == 0 {
+		return ""
+	}
+	if action, ok := verbActions[words[0]]; ok {
+		return action
+	}
+	return words[0]
+}
+
+// pathRoute turns an API path into a route template and finds its resource, the last
+// static segment. The parameter right after the resource is the object, the other
+// parameters match any segment.
+func pathRoute(path string) (string, string) {
+	segments := pathSegments(path)
+	resourceAt := -1
+	for i, seg := range segments {
+		if !strings.ContainsAny(seg, "{}") && seg != "-" && !versionSegment.MatchString(seg) {
+			resourceAt = i
+		}
+	}
+
+	template := make([]string, len(segments))
+	for i, seg := range segments {
+		switch {
+		case !strings.ContainsAny(seg, "{}"):
+			template[i] = seg
+		case i == resourceAt+1 && resourceAt >= 0 && strings.HasPrefix(seg, "{") && strings.HasSuffix(seg, "}"):
+			template[i] = "{" + objectParam + "}"
+		default:
+			template[i] = "*"
+		}
+	}
+
+	resource := ""
+	if resourceAt >= 0 {
+		resource = segments[resourceAt]
+	}
+	return "/" + strings.Join(template, "/"), resource
+}
+
+// ImportSchema creates the resources, routes and suggested actions of the schema which the
+// app does not have yet, routes of the same method and template are replaced. With prune
+// the resources and routes which are not in the schema are removed.
+func (s service) ImportSchema(ctx context.Context, req *auth.ImportAppSchemaRequest) (*auth.ImportAppSchemaResponse, error) {
+	app, err := s.repo.GetApp(ctx, req.Uuid)
+	if err != nil {
+		return nil, err
+	}
+	schema, err := ParseSchema(req.Format, req.Content)
+	if err != nil {
+		return nil, err
+	}
+	resources, err := s.repo.AppResources(ctx, app)
+	if err != nil {
+		return nil, err
+	}
+	routes, err := s.repo.AppRoutes(ctx, app)
+	if err != nil {
+		return nil, err
+	}
+
+	res := &auth.ImportAppSchemaResponse{}
+
+	current := map[string]entity.Resource{}
+	for _, r := range resources {
+		current[r.Name] = r
+	}
+	wanted := map[string]bool{}
+	var addResources []string
+	for _, name := range schema.Resources {
+		wanted[name] = true
+		if _, ok := current[name]; !ok {
+			addResources = append(addResources, name)
+			res.Added = append(res.Added, "resource "+name)
+		}
+	}
+
+	currentRoutes := map[string]entity.Route{}
+	for _, r := range routes {
+		currentRoutes[r.Method+" "+r.Template] = r
+	}
+	wantedRoutes := map[string]bool{}
+	var addRoutes []SchemaRoute
+	var removeRoutes []entity.Route
+	for _, r := range schema.Routes {
+		key := r.Method + " " + r.Template
+		wantedRoutes[key] = true
+		cur, ok := currentRoutes[key]
+		if ok && routeLine(cur) == r.line() {
+			continue
+		}
+		if ok {
+			removeRoutes = append(removeRoutes, cur)
+			res.Removed = append(res.Removed, routeLine(cur))
+		}
+		addRoutes = append(addRoutes, r)
+		res.Added = append(res.Added, r.line())
+	}
+
+	var removeResources []entity.Resource
+	if req.Prune {
+		for _, r := range routes {
+			if !wantedRoutes[r.Method+" "+r.Template] {
+				removeRoutes = append(removeRoutes, r)
+				res.Removed = append(res.Removed, routeLine(r))
+			}
+		}
+		for _, r := range resources {
+			if !wanted[r.Name] {
+				removeResources = append(removeResources, r)
+				res.Removed = append(res.Removed, "resource "+r.Name)
+			}
+		}
+	}
+
+	actions := app.ActionMap()
+	methods := make([]string, 0, len(schema.Actions))
+	for method := range schema.Actions {
+		methods = append(methods, method)
+	}
+	sort.Strings(methods)
+	for _, method := range methods {
+		if _, ok := actions[method]; !ok {
+			actions[method] = schema.Actions[method]
+			res.Added = append(res.Added, fmt.Sprintf("action %s %s", method, schema.Actions[method]))
+		}
+	}
+
+	if req.DryRun {
+		return res, nil
+	}
+
+	for _, name := range addResources {
+		if _, err := s.repo.CreateResource(ctx, app, entity.Resource{Name: name}); err != nil {
+			return nil, err
+		}
+	}
+	for _, r := range removeRoutes {
+		if err := s.repo.DeleteRoute(ctx, r); err != nil {
+			return nil, err
+		}
+	}
+	for _, r := range addRoutes {
+		route := entity.Route{Method: r.Method, Template: r.Template, Resource: r.Resource, Action: r.Action}
+		if _, err := s.repo.CreateRoute(ctx, app, route); err != nil {
+			return nil, err
+		}
+	}
+	for _, r := range removeResources {
+		if s.rules != nil {
+			if err := s.rules.ReleaseResource(ctx, r, false); err != nil {
+				return nil, err
+			}
+		}
+		if err := s.repo.DeleteResource(ctx, r); err != nil {
+			return nil, err
+		}
+	}
+	app.Actions = entity.JoinPairs(actions)
+	if err := s.repo.UpdateApp(ctx, app); err != nil {
+		return nil, err
+	}
+
+	s.routesChanged("app schema imported")
+	res.Applied = true
+	return res, nil
+}
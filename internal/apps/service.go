@@ -3,12 +3,19 @@ package apps
 import (
 	"context"
 	"fmt"
+	"regexp"
+	"strings"
 	"time"
 
+	"github.com/ThreeDotsLabs/watermill/message"
 	"github.com/go-ozzo/ozzo-validation/v4/is"
 
 	validation "github.com/go-ozzo/ozzo-validation/v4"
 	"github.com/golang-tire/auth/internal/entity"
+	"github.com/golang-tire/auth/internal/pkg/cache"
+	"github.com/golang-tire/auth/internal/pkg/helpers"
+	"github.com/golang-tire/auth/internal/pkg/pubsub"
+	"github.com/golang-tire/auth/internal/pkg/tenant"
 	auth "github.com/golang-tire/auth/internal/proto/v1"
 )
 
@@ -26,20 +33,50 @@ type Service interface {
 	CountResources(ctx context.Context) (int64, error)
 	CreateResource(ctx context.Context, input *auth.CreateResourceRequest) (*auth.Resource, error)
 	UpdateResource(ctx context.Context, input *auth.UpdateResourceRequest) (*auth.Resource, error)
-	DeleteResource(ctx context.Context, uuid string) (*auth.Resource, error)
+	DeleteResource(ctx context.Context, uuid string, cascade bool) (*auth.Resource, error)
+	AppResources(ctx context.Context, name string) ([]entity.Resource, error)
 
 	GetObject(ctx context.Context, uuid string) (*auth.Object, error)
 	QueryObjects(ctx context.Context, query string, offset, limit int64) (*auth.ListObjectsResponse, error)
 	CountObjects(ctx context.Context) (int64, error)
 	CreateObject(ctx context.Context, input *auth.CreateObjectRequest) (*auth.Object, error)
 	UpdateObject(ctx context.Context, input *auth.UpdateObjectRequest) (*auth.Object, error)
-	DeleteObject(ctx context.Context, uuid string) (*auth.Object, error)
+	DeleteObject(ctx context.Context, uuid string, cascade bool) (*auth.Object, error)
+
+	ListRoutes(ctx context.Context, uuid string) (*auth.ListRoutesResponse, error)
+	CreateRoute(ctx context.Context, input *auth.CreateRouteRequest) (*auth.Route, error)
+	DeleteRoute(ctx context.Context, uuid string) (*auth.Route, error)
+	// ImportSchema creates the resources, routes and actions of an OpenAPI document or a
+	// descriptor set and reports the difference to the app.
+	ImportSchema(ctx context.Context, input *auth.ImportAppSchemaRequest) (*auth.ImportAppSchemaResponse, error)
+	// RouteTable returns the compiled routes of the apps.
+	RouteTable(ctx context.Context) (*RouteTable, error)
+	// OnAppChange drops the compiled routes when the apps or their routes change.
+	OnAppChange(msg *message.Message) error
 }
 
+// Rules keeps the rules referencing app resources and objects in line with them.
+type Rules interface {
+	RenameResource(ctx context.Context, resource entity.Resource) error
+	RenameObject(ctx context.Context, object entity.Object) error
+	ReleaseResource(ctx context.Context, resource entity.Resource, cascade bool) error
+	ReleaseObject(ctx context.Context, object entity.Object, cascade bool) error
+}
+
+var (
+	routeMethod = regexp.MustCompile(`^([A-Za-z]+|\*)$`)
+	// actionMethod is an HTTP method, a full gRPC method name or * for the other methods
+	actionMethod = regexp.MustCompile(`^([A-Za-z]+|\*|/[\w.]+/\w+)$`)
+	actionName   = regexp.MustCompile(`^[\w.:*-]+$`)
+)
+
 // ValidateAppCreateRequest validates the CreateAppRequest fields.
 func ValidateAppCreateRequest(c *auth.CreateAppRequest) error {
 	return validation.ValidateStruct(c,
 		validation.Field(&c.Name, validation.Required, validation.Length(0, 128)),
+		validation.Field(&c.Hosts, validation.Each(validation.Required, is.Host)),
+		validation.Field(&c.Actions, validation.By(validActions)),
+		validation.Field(&c.ActionAliases, validation.By(validAliases)),
 	)
 }
 
@@ -47,9 +84,57 @@ func ValidateAppCreateRequest(c *auth.CreateAppRequest) error {
 func ValidateAppUpdateRequest(u *auth.UpdateAppRequest) error {
 	return validation.ValidateStruct(u,
 		validation.Field(&u.Name, validation.Required, validation.Length(0, 128)),
+		validation.Field(&u.Hosts, validation.Each(validation.Required, is.Host)),
+		validation.Field(&u.Actions, validation.By(validActions)),
+		validation.Field(&u.ActionAliases, validation.By(validAliases)),
 	)
 }
 
+// ValidateRouteCreateRequest validates the CreateRouteRequest fields.
+func ValidateRouteCreateRequest(c *auth.CreateRouteRequest) error {
+	capturesResource := false
+	for _, p := range templateParams(c.Template) {
+		capturesResource = capturesResource || p == resourceParam
+	}
+	return validation.ValidateStruct(c,
+		validation.Field(&c.Uuid, validation.Required, is.UUID),
+		validation.Field(&c.Method, validation.Required, validation.Match(routeMethod)),
+		validation.Field(&c.Template, validation.Required, validation.Length(0, 512), validation.By(validTemplate)),
+		validation.Field(&c.Resource, validation.When(!capturesResource, validation.Required), validation.Length(0, 128)),
+		validation.Field(&c.Action, validation.Length(0, 128), validation.Match(actionName)),
+	)
+}
+
+func validActions(value interface{}) error {
+	for method, action := range value.(map[string]string) {
+		if !actionMethod.MatchString(method) {
+			return fmt.Errorf("invalid method `%s`", method)
+		}
+		if !actionName.MatchString(action) {
+			return fmt.Errorf("invalid action `%s` of method `%s`", action, method)
+		}
+	}
+	return nil
+}
+
+func validAliases(value interface{}) error {
+	aliases := value.(map[string]string)
+	for alias, action := range aliases {
+		if !actionName.MatchString(alias) || !actionName.MatchString(action) {
+			return fmt.Errorf("invalid alias `%s` of action `%s`", alias, action)
+		}
+		if _, ok := aliases[action]; ok {
+			return fmt.Errorf("action `%s` of alias `%s` is an alias", action, alias)
+		}
+	}
+	return nil
+}
+
+func validTemplate(value interface{}) error {
+	_, err := templateSegments(value.(string))
+	return err
+}
+
 // ValidateResourceCreateRequest validates the CreateResourceRequest fields.
 func ValidateResourceCreateRequest(c *auth.CreateResourceRequest) error {
 	return validation.ValidateStruct(c,
@@ -85,12 +170,15 @@ func ValidateObjectUpdateRequest(u *auth.UpdateObjectRequest) error {
 }
 
 type service struct {
-	repo Repository
+	repo   Repository
+	rules  Rules
+	routes *cache.Value
 }
 
-// NewService creates a new app service.
-func NewService(repo Repository) Service {
-	return service{repo}
+// NewService creates a new app service, the rules referencing resources and objects are
+// left alone if rules is nil.
+func NewService(repo Repository, rules Rules) Service {
+	return service{repo, rules, &cache.Value{}}
 }
 
 // GetApp returns the app with the specified the app UUID.
@@ -107,13 +195,21 @@ func (s service) CreateApp(ctx context.Context, req *auth.CreateAppRequest) (*au
 	if err := ValidateAppCreateRequest(req); err != nil {
 		return nil, err
 	}
+	hosts, err := s.appHosts(ctx, "", req.Hosts)
+	if err != nil {
+		return nil, err
+	}
 	id, err := s.repo.CreateApp(ctx, entity.App{
-		Name:   req.Name,
-		Enable: req.Enable,
+		Name:          req.Name,
+		Enable:        req.Enable,
+		Hosts:         hosts,
+		Actions:       entity.JoinPairs(upperMethods(req.Actions)),
+		ActionAliases: entity.JoinPairs(req.ActionAliases),
 	})
 	if err != nil {
 		return nil, err
 	}
+	s.routesChanged("app created")
 	return s.GetApp(ctx, id)
 }
 
@@ -127,14 +223,22 @@ func (s service) UpdateApp(ctx context.Context, req *auth.UpdateAppRequest) (*au
 	if err != nil {
 		return nil, err
 	}
+	hosts, err := s.appHosts(ctx, app.UUID, req.Hosts)
+	if err != nil {
+		return nil, err
+	}
 	now := time.Now()
 	app.Name = req.Name
 	app.Enable = req.Enable
+	app.Hosts = hosts
+	app.Actions = entity.JoinPairs(upperMethods(req.Actions))
+	app.ActionAliases = entity.JoinPairs(req.ActionAliases)
 	app.UpdatedAt = now
 
 	if err := s.repo.UpdateApp(ctx, app); err != nil {
 		return nil, err
 	}
+	s.routesChanged("app updated")
 	return app.ToProto(), nil
 }
 
@@ -144,12 +248,59 @@ func (s service) DeleteApp(ctx context.Context, UUID string) (*auth.App, error)
 	if err != nil {
 		return nil, err
 	}
+	routes, err := s.repo.AppRoutes(ctx, app)
+	if err != nil {
+		return nil, err
+	}
+	for _, route := range routes {
+		if err = s.repo.DeleteRoute(ctx, route); err != nil {
+			return nil, err
+		}
+	}
 	if err = s.repo.DeleteApp(ctx, app); err != nil {
 		return nil, err
 	}
+	s.routesChanged("app deleted")
 	return app.ToProto(), nil
 }
 
+// upperMethods upper cases the HTTP methods of the actions, gRPC method names are kept.
+func upperMethods(actions map[string]string) map[string]string {
+	m := make(map[string]string, len(actions))
+	for method, action := range actions {
+		if !strings.HasPrefix(method, "/") {
+			method = strings.ToUpper(method)
+		}
+		m[method] = action
+	}
+	return m
+}
+
+// appHosts normalizes the hosts of an app, a host can only belong to one app of any
+// organization.
+func (s service) appHosts(ctx context.Context, appUUID string, hosts []string) (string, error) {
+	apps, err := s.repo.AllApps(tenant.All(ctx))
+	if err != nil {
+		return "", err
+	}
+	owners := map[string]entity.App{}
+	for _, app := range apps {
+		for _, host := range app.HostList() {
+			owners[host] = app
+		}
+	}
+
+	var list []string
+	for _, host := range hosts {
+		host = helpers.NormalizeHost(host)
+		if owner, ok := owners[host]; ok && owner.UUID != appUUID {
+			return "", fmt.Errorf("host `%s` belongs to app `%s`", host, owner.Name)
+		}
+		list = append(list, host)
+	}
+	return strings.Join(list, ","), nil
+}
+
 // CountApp returns the number of apps.
 func (s service) CountApps(ctx context.Context) (int64, error) {
 	return s.repo.CountApps(ctx)
@@ -218,21 +369,33 @@ func (s service) UpdateResource(ctx context.Context, req *auth.UpdateResourceReq
 	}
 
 	now := time.Now()
+	renamed := resource.Name != req.Name
 	resource.Name = req.Name
 	resource.UpdatedAt = now
 
 	if err := s.repo.UpdateResource(ctx, app, resource); err != nil {
 		return nil, err
 	}
+	if renamed && s.rules != nil {
+		if err := s.rules.RenameResource(ctx, resource); err != nil {
+			return nil, err
+		}
+	}
 	return resource.ToProto(), nil
 }
 
-// DeleteResource deletes the resource with the specified UUID.
-func (s service) DeleteResource(ctx context.Context, UUID string) (*auth.Resource, error) {
+// DeleteResource deletes the resource with the specified UUID, the rules referencing it
+// are deleted with it if cascade is set, otherwise they block the deletion.
+func (s service) DeleteResource(ctx context.Context, UUID string, cascade bool) (*auth.Resource, error) {
 	app, err := s.repo.GetResource(ctx, UUID)
 	if err != nil {
 		return nil, err
 	}
+	if s.rules != nil {
+		if err = s.rules.ReleaseResource(ctx, app, cascade); err != nil {
+			return nil, err
+		}
+	}
 	if err = s.repo.DeleteResource(ctx, app); err != nil {
 		return nil, err
 	}
@@ -245,6 +408,15 @@ func (s service) CountResources(ctx context.Context) (int64, error) {
 }
 
 // QueryResources returns the resources with the specified offset and limit.
+// AppResources returns all resources of the app with the specified name.
+func (s service) AppResources(ctx context.Context, name string) ([]entity.Resource, error) {
+	app, err := s.repo.GetAppByName(ctx, name)
+	if err != nil {
+		return nil, err
+	}
+	return s.repo.AppResources(ctx, app)
+}
+
 func (s service) QueryResources(ctx context.Context, query string, offset, limit int64) (*auth.ListResourcesResponse, error) {
 	items, count, err := s.repo.QueryResources(ctx, query, offset, limit)
 	if err != nil {
@@ -304,21 +476,33 @@ func (s service) UpdateObject(ctx context.Context, req *auth.UpdateObjectRequest
 	}
 
 	now := time.Now()
+	renamed := object.Identifier != req.Identifier
 	object.Identifier = req.Identifier
 	object.UpdatedAt = now
 
 	if err := s.repo.UpdateObject(ctx, app, object); err != nil {
 		return nil, err
 	}
+	if renamed && s.rules != nil {
+		if err := s.rules.RenameObject(ctx, object); err != nil {
+			return nil, err
+		}
+	}
 	return object.ToProto(), nil
 }
 
-// DeleteObject deletes the object with the specified UUID.
-func (s service) DeleteObject(ctx context.Context, UUID string) (*auth.Object, error) {
+// DeleteObject deletes the object with the specified UUID, the rules referencing it
+// are deleted with it if cascade is set, otherwise they block the deletion.
+func (s service) DeleteObject(ctx context.Context, UUID string, cascade bool) (*auth.Object, error) {
 	object, err := s.repo.GetObject(ctx, UUID)
 	if err != nil {
 		return nil, err
 	}
+	if s.rules != nil {
+		if err = s.rules.ReleaseObject(ctx, object, cascade); err != nil {
+			return nil, err
+		}
+	}
 	if err = s.repo.DeleteObject(ctx, object); err != nil {
 		return nil, err
 	}
@@ -343,3 +527,101 @@ func (s service) QueryObjects(ctx context.Context, query string, offset, limit i
 		Limit:      limit,
 	}, nil
 }
+
+// ListRoutes returns the routes of the app with the specified UUID.
+func (s service) ListRoutes(ctx context.Context, UUID string) (*auth.ListRoutesResponse, error) {
+	app, err := s.repo.GetApp(ctx, UUID)
+	if err != nil {
+		return nil, err
+	}
+	items, err := s.repo.AppRoutes(ctx, app)
+	if err != nil {
+		return nil, err
+	}
+	return &auth.ListRoutesResponse{Routes: entity.RouteToProtoList(items)}, nil
+}
+
+// CreateRoute creates a new route of an app.
+func (s service) CreateRoute(ctx context.Context, req *auth.CreateRouteRequest) (*auth.Route, error) {
+	if err := ValidateRouteCreateRequest(req); err != nil {
+		return nil, err
+	}
+
+	app, err := s.repo.GetApp(ctx, req.Uuid)
+	if err != nil {
+		return nil, err
+	}
+
+	method := strings.ToUpper(req.Method)
+	routes, err := s.repo.AppRoutes(ctx, app)
+	if err != nil {
+		return nil, err
+	}
+	for _, route := range routes {
+		if route.Method == method && route.Template == req.Template {
+			return nil, fmt.Errorf("route `%s %s` already exists", method, req.Template)
+		}
+	}
+
+	id, err := s.repo.CreateRoute(ctx, app, entity.Route{
+		Method:   method,
+		Template: req.Template,
+		Resource: req.Resource,
+		Action:   req.Action,
+	})
+	if err != nil {
+		return nil, err
+	}
+	s.routesChanged("route created")
+
+	route, err := s.repo.GetRoute(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	return route.ToProto(), nil
+}
+
+// DeleteRoute deletes the route with the specified UUID.
+func (s service) DeleteRoute(ctx context.Context, UUID string) (*auth.Route, error) {
+	route, err := s.repo.GetRoute(ctx, UUID)
+	if err != nil {
+		return nil, err
+	}
+	if err = s.repo.DeleteRoute(ctx, route); err != nil {
+		return nil, err
+	}
+	s.routesChanged("route deleted")
+	return route.ToProto(), nil
+}
+
+// RouteTable returns the compiled routes of the apps of every organization, it is compiled
+// again after changes.
+func (s service) RouteTable(ctx context.Context) (*RouteTable, error) {
+	if t, ok := s.routes.Get().(*RouteTable); ok {
+		return t, nil
+	}
+	ctx = tenant.All(ctx)
+	apps, err := s.repo.AllApps(ctx)
+	if err != nil {
+		return nil, err
+	}
+	routes, err := s.repo.AllRoutes(ctx)
+	if err != nil {
+		return nil, err
+	}
+	t := newRouteTable(apps, routes)
+	s.routes.Set(t)
+	return t, nil
+}
+
+func (s service) OnAppChange(msg *message.Message) error {
+	s.routes.Reset()
+	msg.Ack()
+	return nil
+}
+
+// routesChanged drops the compiled routes and notifies the other instances.
+func (s service) routesChanged(reason string) {
+	s.routes.Reset()
+	pubsub.Notify(pubsub.AppChange, reason)
+}
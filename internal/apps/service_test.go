@@ -6,6 +6,9 @@ import (
 
 	auth "github.com/golang-tire/auth/internal/proto/v1"
 	"github.com/stretchr/testify/assert"
+	"google.golang.org/genproto/googleapis/api/annotations"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/descriptorpb"
 )
 
 func TestCreateAppRequest_Validate(t *testing.T) {
@@ -117,7 +120,7 @@ func TestUpdateObjectRequest_Validate(t *testing.T) {
 }
 
 func Test_service_CRUD(t *testing.T) {
-	s := NewService(&mockRepository{})
+	s := NewService(&mockRepository{}, nil)
 	ctx := context.Background()
 
 	// initial count
@@ -251,9 +254,9 @@ func Test_service_CRUD(t *testing.T) {
 	assert.Equal(t, 2, int(_resources.TotalCount))
 
 	// delete
-	_, err = s.DeleteResource(ctx, "none")
+	_, err = s.DeleteResource(ctx, "none", false)
 	assert.NotNil(t, err)
-	resource, err = s.DeleteResource(ctx, resourceId)
+	resource, err = s.DeleteResource(ctx, resourceId, false)
 	assert.Nil(t, err)
 	assert.Equal(t, id, resource.Uuid)
 	resourceCount, _ = s.CountResources(ctx)
@@ -321,11 +324,297 @@ func Test_service_CRUD(t *testing.T) {
 	assert.Equal(t, 2, int(_objects.TotalCount))
 
 	// delete
-	_, err = s.DeleteObject(ctx, "none")
+	_, err = s.DeleteObject(ctx, "none", false)
 	assert.NotNil(t, err)
-	object, err = s.DeleteObject(ctx, objectId)
+	object, err = s.DeleteObject(ctx, objectId, false)
 	assert.Nil(t, err)
 	assert.Equal(t, id, object.Uuid)
 	objectCount, _ = s.CountObjects(ctx)
 	assert.Equal(t, int64(1), objectCount)
 }
+
+func TestCreateRouteRequest_Validate(t *testing.T) {
+	app := "2a8c5e4e-8f6d-4b5c-9d3e-0f1a2b3c4d5e"
+	tests := []struct {
+		name      string
+		model     *auth.CreateRouteRequest
+		wantError bool
+	}{
+		{"success", &auth.CreateRouteRequest{Uuid: app, Method: "GET", Template: "/orders/{object}", Resource: "orders", Action: "read"}, false},
+		{"any method", &auth.CreateRouteRequest{Uuid: app, Method: "*", Template: "/orders/**", Resource: "orders"}, false},
+		{"captured resource", &auth.CreateRouteRequest{Uuid: app, Method: "GET", Template: "/v1/{resource}/{object}"}, false},
+		{"resource required", &auth.CreateRouteRequest{Uuid: app, Method: "GET", Template: "/orders/{object}"}, true},
+		{"relative template", &auth.CreateRouteRequest{Uuid: app, Method: "GET", Template: "orders", Resource: "orders"}, true},
+		{"rest not last", &auth.CreateRouteRequest{Uuid: app, Method: "GET", Template: "/orders/**/items", Resource: "orders"}, true},
+		{"invalid segment", &auth.CreateRouteRequest{Uuid: app, Method: "GET", Template: "/orders/{a-b}", Resource: "orders"}, true},
+		{"invalid method", &auth.CreateRouteRequest{Uuid: app, Method: "GET POST", Template: "/orders", Resource: "orders"}, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := ValidateRouteCreateRequest(tt.model)
+			assert.Equal(t, tt.wantError, err != nil)
+		})
+	}
+}
+
+func Test_service_Routes(t *testing.T) {
+	s := NewService(&mockRepository{}, nil)
+	ctx := context.Background()
+
+	app, err := s.CreateApp(ctx, &auth.CreateAppRequest{Name: "shop", Enable: true, Hosts: []string{"Shop.Example.com"}})
+	assert.Nil(t, err)
+	assert.Equal(t, []string{"shop.example.com"}, app.Hosts)
+
+	// a host belongs to one app
+	_, err = s.CreateApp(ctx, &auth.CreateAppRequest{Name: "other", Hosts: []string{"shop.example.com"}})
+	assert.NotNil(t, err)
+
+	for _, req := range []*auth.CreateRouteRequest{
+		{Uuid: app.Uuid, Method: "get", Template: "/orders/{object}", Resource: "orders", Action: "read"},
+		{Uuid: app.Uuid, Method: "GET", Template: "/orders/export", Resource: "reports", Action: "export"},
+		{Uuid: app.Uuid, Method: "*", Template: "/orders", Resource: "orders"},
+		{Uuid: app.Uuid, Method: "GET", Template: "/v1/{resource}/*/{object}"},
+		{Uuid: app.Uuid, Method: "GET", Template: "/static/**", Resource: "assets"},
+	} {
+		_, err = s.CreateRoute(ctx, req)
+		assert.Nil(t, err)
+	}
+	_, err = s.CreateRoute(ctx, &auth.CreateRouteRequest{Uuid: app.Uuid, Method: "GET", Template: "/orders/{object}", Resource: "orders"})
+	assert.NotNil(t, err)
+
+	routes, err := s.ListRoutes(ctx, app.Uuid)
+	assert.Nil(t, err)
+	assert.Len(t, routes.Routes, 5)
+	assert.Equal(t, "GET", routes.Routes[0].Method)
+
+	table, err := s.RouteTable(ctx)
+	assert.Nil(t, err)
+	assert.True(t, table.HasApp("shop.example.com:443"))
+	assert.False(t, table.HasApp("example.com"))
+
+	tests := []struct {
+		method, uri string
+		want        RouteMatch
+		ok          bool
+	}{
+		{"GET", "/orders/42?x=1", RouteMatch{App: "shop", Template: "/orders/{object}", Resource: "orders", Action: "read", Object: "42"}, true},
+		{"GET", "/orders/export", RouteMatch{App: "shop", Template: "/orders/export", Resource: "reports", Action: "export", Object: "*"}, true},
+		{"POST", "/orders/", RouteMatch{App: "shop", Template: "/orders", Resource: "orders", Action: "POST", Object: "*"}, true},
+		{"GET", "/v1/invoices/2020/7", RouteMatch{App: "shop", Template: "/v1/{resource}/*/{object}", Resource: "invoices", Action: "GET", Object: "7"}, true},
+		{"GET", "/static/css/site.css", RouteMatch{App: "shop", Template: "/static/**", Resource: "assets", Action: "GET", Object: "*"}, true},
+		{"DELETE", "/orders/42", RouteMatch{}, false},
+		{"GET", "/unknown", RouteMatch{}, false},
+	}
+	for _, tt := range tests {
+		m, ok := table.Match("shop.example.com", tt.method, tt.uri)
+		assert.Equal(t, tt.ok, ok, tt.uri)
+		assert.Equal(t, tt.want, m, tt.uri)
+	}
+
+	// changes compile the routes again
+	_, err = s.DeleteRoute(ctx, routes.Routes[0].Uuid)
+	assert.Nil(t, err)
+	table, _ = s.RouteTable(ctx)
+	_, ok := table.Match("shop.example.com", "GET", "/orders/42")
+	assert.False(t, ok)
+
+	_, err = s.DeleteApp(ctx, app.Uuid)
+	assert.Nil(t, err)
+	table, _ = s.RouteTable(ctx)
+	assert.False(t, table.HasApp("shop.example.com"))
+}
+
+func Test_service_Actions(t *testing.T) {
+	s := NewService(&mockRepository{}, nil)
+	ctx := context.Background()
+
+	_, err := s.CreateApp(ctx, &auth.CreateAppRequest{Name: "bad", Actions: map[string]string{"GET read": "read"}})
+	assert.NotNil(t, err)
+	_, err = s.CreateApp(ctx, &auth.CreateAppRequest{Name: "bad", ActionAliases: map[string]string{"list": "get", "get": "read"}})
+	assert.NotNil(t, err)
+
+	app, err := s.CreateApp(ctx, &auth.CreateAppRequest{
+		Name:   "shop",
+		Enable: true,
+		Hosts:  []string{"shop.example.com"},
+		Actions: map[string]string{
+			"get":                           "read",
+			"HEAD":                          "read",
+			"POST":                          "write",
+			"*":                             "admin",
+			"/orders.OrderService/GetOrder": "get",
+		},
+		ActionAliases: map[string]string{"get": "read", "list": "read"},
+	})
+	assert.Nil(t, err)
+	assert.Equal(t, "read", app.Actions["GET"])
+
+	for _, req := range []*auth.CreateRouteRequest{
+		{Uuid: app.Uuid, Method: "*", Template: "/orders/**", Resource: "orders"},
+		{Uuid: app.Uuid, Method: "GET", Template: "/orders/search", Resource: "orders", Action: "list"},
+		{Uuid: app.Uuid, Method: "POST", Template: "/orders.OrderService/*", Resource: "orders"},
+	} {
+		_, err = s.CreateRoute(ctx, req)
+		assert.Nil(t, err)
+	}
+
+	table, err := s.RouteTable(ctx)
+	assert.Nil(t, err)
+	tests := []struct {
+		method, uri, action string
+	}{
+		{"GET", "/orders/1", "read"},
+		{"HEAD", "/orders/1", "read"},
+		{"POST", "/orders", "write"},
+		{"DELETE", "/orders/1", "admin"},
+		{"GET", "/orders/search", "read"},
+		{"POST", "/orders.OrderService/GetOrder", "read"},
+		{"POST", "/orders.OrderService/CreateOrder", "write"},
+	}
+	for _, tt := range tests {
+		m, ok := table.Match("shop.example.com", tt.method, tt.uri)
+		assert.True(t, ok, tt.uri)
+		assert.Equal(t, tt.action, m.Action, tt.method+" "+tt.uri)
+	}
+}
+
+const testOpenAPI = `{
+  "swagger": "2.0",
+  "basePath": "/api",
+  "paths": {
+    "/v1/orders": {
+      "get": {"operationId": "ListOrders"},
+      "post": {"operationId": "CreateOrder"}
+    },
+    "/v1/orders/{uuid}": {
+      "parameters": [],
+      "get": {"operationId": "GetOrder"},
+      "delete": {"operationId": "DeleteOrder", "x-auth-action": "cancel"}
+    },
+    "/v1/orders/{uuid}/items/{item}": {
+      "get": {"operationId": "GetItem", "x-auth-resource": "order-items"}
+    }
+  }
+}`
+
+func Test_service_ImportSchema(t *testing.T) {
+	s := NewService(&mockRepository{}, nil)
+	ctx := context.Background()
+
+	app, err := s.CreateApp(ctx, &auth.CreateAppRequest{Name: "shop", Enable: true, Hosts: []string{"shop.example.com"}, Actions: map[string]string{"GET": "view"}})
+	assert.Nil(t, err)
+	_, err = s.CreateRoute(ctx, &auth.CreateRouteRequest{Uuid: app.Uuid, Method: "GET", Template: "/api/v1/orders", Resource: "legacy"})
+	assert.Nil(t, err)
+	_, err = s.CreateRoute(ctx, &auth.CreateRouteRequest{Uuid: app.Uuid, Method: "GET", Template: "/health", Resource: "health"})
+	assert.Nil(t, err)
+
+	req := &auth.ImportAppSchemaRequest{Uuid: app.Uuid, Format: auth.SchemaFormat_OPENAPI, Content: []byte(testOpenAPI), DryRun: true}
+	res, err := s.ImportSchema(ctx, req)
+	assert.Nil(t, err)
+	assert.False(t, res.Applied)
+	assert.Equal(t, []string{
+		"resource order-items",
+		"resource orders",
+		"route GET /api/v1/orders orders",
+		"route POST /api/v1/orders orders",
+		"route GET /api/v1/orders/*/items/{object} order-items",
+		"route DELETE /api/v1/orders/{object} orders cancel",
+		"route GET /api/v1/orders/{object} orders",
+		"action DELETE delete",
+		"action POST create",
+	}, res.Added)
+	assert.Equal(t, []string{"route GET /api/v1/orders legacy"}, res.Removed)
+
+	routes, _ := s.ListRoutes(ctx, app.Uuid)
+	assert.Len(t, routes.Routes, 2)
+
+	req.DryRun = false
+	req.Prune = true
+	res, err = s.ImportSchema(ctx, req)
+	assert.Nil(t, err)
+	assert.True(t, res.Applied)
+	assert.Equal(t, []string{"route GET /api/v1/orders legacy", "route GET /health health"}, res.Removed)
+
+	routes, _ = s.ListRoutes(ctx, app.Uuid)
+	assert.Len(t, routes.Routes, 5)
+	got, _ := s.GetApp(ctx, app.Uuid)
+	assert.Equal(t, map[string]string{"GET": "view", "POST": "create", "DELETE": "delete"}, got.Actions)
+
+	table, _ := s.RouteTable(ctx)
+	m, ok := table.Match("shop.example.com", "DELETE", "/api/v1/orders/42")
+	assert.True(t, ok)
+	assert.Equal(t, RouteMatch{App: "shop", Template: "/api/v1/orders/{object}", Resource: "orders", Action: "cancel", Object: "42"}, m)
+
+	// a second import has nothing to change
+	res, err = s.ImportSchema(ctx, req)
+	assert.Nil(t, err)
+	assert.Empty(t, res.Added)
+	assert.Empty(t, res.Removed)
+
+	_, err = s.ImportSchema(ctx, &auth.ImportAppSchemaRequest{Uuid: app.Uuid, Format: auth.SchemaFormat_OPENAPI, Content: []byte(`{"paths": {}}`)})
+	assert.NotNil(t, err)
+}
+
+func TestParseSchema_DescriptorSet(t *testing.T) {
+	opts := &descriptorpb.MethodOptions{}
+	proto.SetExtension(opts, annotations.E_Http, &annotations.HttpRule{
+		Pattern: &annotations.HttpRule_Get{Get: "/v1/orders/{uuid}"},
+	})
+	set := &descriptorpb.FileDescriptorSet{File: []*descriptorpb.FileDescriptorProto{{
+		Name:        proto.String("shop.proto"),
+		Package:     proto.String("shop"),
+		Syntax:      proto.String("proto3"),
+		MessageType: []*descriptorpb.DescriptorProto{{Name: proto.String("Request")}},
+		Service: []*descriptorpb.ServiceDescriptorProto{{
+			Name: proto.String("OrderService"),
+			Method: []*descriptorpb.MethodDescriptorProto{
+				{Name: proto.String("GetOrder"), InputType: proto.String(".shop.Request"), OutputType: proto.String(".shop.Request"), Options: opts},
+				{Name: proto.String("RemoveOrder"), InputType: proto.String(".shop.Request"), OutputType: proto.String(".shop.Request")},
+				{Name: proto.String("ShipOrder"), InputType: proto.String(".shop.Request"), OutputType: proto.String(".shop.Request")},
+			},
+		}},
+	}}}
+	content, err := proto.Marshal(set)
+	assert.Nil(t, err)
+
+	schema, err := ParseSchema(auth.SchemaFormat_DESCRIPTOR_SET, content)
+	assert.Nil(t, err)
+	assert.Equal(t, []string{"orders"}, schema.Resources)
+	assert.Equal(t, []SchemaRoute{
+		{Method: "POST", Template: "/shop.OrderService/*", Resource: "orders"},
+		{Method: "GET", Template: "/v1/orders/{object}", Resource: "orders", Action: "read"},
+	}, schema.Routes)
+	assert.Equal(t, map[string]string{
+		"/shop.OrderService/GetOrder":    "read",
+		"/shop.OrderService/RemoveOrder": "delete",
+		"/shop.OrderService/ShipOrder":   "ship",
+	}, schema.Actions)
+
+	_, err = ParseSchema(auth.SchemaFormat_DESCRIPTOR_SET, []byte("invalid"))
+	assert.NotNil(t, err)
+}
+
+func Test_service_DisabledApp(t *testing.T) {
+	s := NewService(&mockRepository{}, nil)
+	ctx := context.Background()
+
+	app, err := s.CreateApp(ctx, &auth.CreateAppRequest{Name: "shop", Enable: true, Hosts: []string{"shop.example.com"}})
+	assert.Nil(t, err)
+	_, err = s.CreateRoute(ctx, &auth.CreateRouteRequest{Uuid: app.Uuid, Method: "GET", Template: "/orders", Resource: "orders"})
+	assert.Nil(t, err)
+
+	table, _ := s.RouteTable(ctx)
+	assert.True(t, table.AppEnabled("shop.example.com"))
+	_, ok := table.Match("shop.example.com", "GET", "/orders")
+	assert.True(t, ok)
+
+	_, err = s.UpdateApp(ctx, &auth.UpdateAppRequest{Uuid: app.Uuid, Name: "shop", Enable: false, Hosts: []string{"shop.example.com"}})
+	assert.Nil(t, err)
+
+	// the host still belongs to the app but its routes match nothing
+	table, _ = s.RouteTable(ctx)
+	assert.True(t, table.HasApp("shop.example.com"))
+	assert.False(t, table.AppEnabled("shop.example.com"))
+	_, ok = table.Match("shop.example.com", "GET", "/orders")
+	assert.False(t, ok)
+}
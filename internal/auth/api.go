@@ -6,10 +6,10 @@ import (
 
 	"github.com/golang/protobuf/ptypes/empty"
 
+	"github.com/golang-tire/auth/internal/domains"
+	"github.com/golang-tire/auth/internal/organizations"
 	"github.com/golang-tire/auth/internal/rules"
 
-	"github.com/golang-tire/pkg/kv"
-
 	"github.com/golang-tire/auth/internal/users"
 
 	auth "github.com/golang-tire/auth/internal/proto/v1"
@@ -83,16 +83,34 @@ func (a api) Validate(ctx context.Context, req *auth.ValidateRequest) (*empty.Em
 	return a.service.Validate(ctx, req)
 }
 
+func (a api) CheckPermission(ctx context.Context, req *auth.CheckPermissionRequest) (*auth.CheckPermissionResponse, error) {
+	return a.service.CheckPermission(ctx, req)
+}
+
+func (a api) BatchCheck(ctx context.Context, req *auth.BatchCheckRequest) (*auth.BatchCheckResponse, error) {
+	return a.service.BatchCheck(ctx, req)
+}
+
+func (a api) ExplainDecision(ctx context.Context, req *auth.ExplainDecisionRequest) (*auth.ExplainDecisionResponse, error) {
+	return a.service.ExplainDecision(ctx, req)
+}
+
+func (a api) GetEffectivePermissions(ctx context.Context, req *auth.GetEffectivePermissionsRequest) (*auth.GetEffectivePermissionsResponse, error) {
+	return a.service.GetEffectivePermissions(ctx, req)
+}
+
+func (a api) ListAuthorizedSubjects(ctx context.Context, req *auth.ListAuthorizedSubjectsRequest) (*auth.ListAuthorizedSubjectsResponse, error) {
+	return a.service.ListAuthorizedSubjects(ctx, req)
+}
+
 // New create an RBAC api service
-func New(ctx context.Context, srv Service, rulesService rules.Service, userService users.Service) (API, error) {
+func New(ctx context.Context, srv Service, rulesService rules.Service, userService users.Service, domainsService domains.Service, organizationsService organizations.Service, rbac *rbacService) (API, error) {
 
 	s := api{ctx: ctx, service: srv, usersSrv: userService}
 	grpcgw.RegisterController(s)
 
-	InitMiddleware(userService)
-	kv.Memory().SetString("/authV1.AuthService/Login", "open")
-	kv.Memory().SetString("/authV1.AuthService/Register", "open")
-	kv.Memory().SetString("/authV1.AuthService/VerifyToken", "open")
-	kv.Memory().SetString("/authV1.AuthService/RefreshToken", "open")
+	if err := InitMiddleware(userService, domainsService, organizationsService, rbac); err != nil {
+		return nil, err
+	}
 	return s, nil
 }
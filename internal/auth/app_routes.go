@@ -0,0 +1,71 @@
+package auth
+
+import (
+	"context"
+	"errors"
+
+	"github.com/golang-tire/pkg/config"
+	"github.com/golang-tire/pkg/log"
+
+	"github.com/golang-tire/auth/internal/pkg/tenant"
+)
+
+// unknownRoutes is the decision, allow or deny, for requests to a host of an app that
+// match none of its routes.
+var unknownRoutes = config.RegisterString("rbac.unknownRoutes", "deny")
+
+var (
+	// errNoRoute is returned when no global route pattern matches the uri.
+	errNoRoute = errors.New("parse forwarded uri failed")
+	// errAppDisabled is returned for requests to a host of a disabled app or of an app of
+	// another organization.
+	errAppDisabled = errors.New("app is disabled")
+)
+
+// permission is what a forwarded request needs.
+type permission struct {
+	resource string
+	object   string
+	action   string
+	// pattern is the app route template or global route pattern that matched
+	pattern string
+}
+
+// resolvePermission finds the permission of a forwarded request with the routes of the app
+// serving the host, hosts without an app use the global route patterns. It returns false
+// when the host has an app but none of its routes matches, the routes of disabled apps
+// match nothing and fail with errAppDisabled, as do the routes of apps of another organization.
+func (s service) resolvePermission(ctx context.Context, host, method, uri string) (permission, bool, error) {
+	table, err := s.appService.RouteTable(ctx)
+	if err != nil {
+		log.Error("load app routes failed", log.Err(err))
+		return permission{}, false, errors.New("load app routes failed")
+	}
+	if table.HasApp(host) {
+		if !table.AppEnabled(host) {
+			return permission{}, false, errAppDisabled
+		}
+		if organization, ok := tenant.FromContext(ctx); ok {
+			if owner, _ := table.AppOrganization(host); owner != organization {
+				return permission{}, false, errAppDisabled
+			}
+		}
+		m, ok := table.Match(host, method, uri)
+		if !ok {
+			return permission{}, false, nil
+		}
+		return permission{resource: m.Resource, object: m.Object, action: m.Action, pattern: m.Template}, true, nil
+	}
+
+	resource, object, pattern, err := s.parseURIPattern(uri)
+	if err != nil {
+		log.Error("parse uri failed", log.Err(err))
+		return permission{}, false, errNoRoute
+	}
+	return permission{resource: resource, object: object, action: method, pattern: pattern}, true, nil
+}
+
+// unknownRouteAllowed returns the decision for unmatched requests to an app.
+func unknownRouteAllowed() bool {
+	return unknownRoutes.String() == "allow"
+}
@@ -0,0 +1,137 @@
+package auth
+
+import (
+	"context"
+	"net"
+	"strings"
+	"time"
+
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+
+	"github.com/golang-tire/auth/internal/pkg/conditions"
+	auth "github.com/golang-tire/auth/internal/proto/v1"
+	"github.com/golang-tire/pkg/config"
+)
+
+const (
+	xForwardedFor = "x-forwarded-for"
+
+	// xAuthAttrPrefix is the header prefix of request attributes, x-auth-attr-tenant
+	// is available to rule conditions as attr(r.ctx.Attrs, "tenant")
+	xAuthAttrPrefix = "x-auth-attr-"
+)
+
+// trustedProxies are the addresses or CIDR ranges of the proxies in front of the service.
+// The client address is read from their x-forwarded-for hops and only requests they send
+// can carry x-auth-attr-* attributes, they must drop the ones their clients send.
+var trustedProxies = config.RegisterStringSlice("auth.trustedProxies", nil)
+
+// requestContext returns the condition context of the incoming request for a user with rawData.
+func requestContext(ctx context.Context, rawData string) *conditions.Context {
+	return conditions.NewContext(clientIP(ctx), time.Now(), rawData, requestAttributes(ctx))
+}
+
+// subjectContext returns the condition context of a check for subject, ip and attrs
+// override the ones of the incoming request.
+func (s service) subjectContext(ctx context.Context, user *auth.User, subject, ip string, attrs map[string]string) *conditions.Context {
+	rawData := user.RawData
+	if subject != user.Username {
+		rawData = ""
+		if u, err := s.userService.GetByUsername(ctx, subject); err == nil {
+			rawData = u.RawData
+		}
+	}
+
+	rc := requestContext(ctx, rawData)
+	if ip != "" {
+		rc.IP = conditions.ClientIP(ip)
+	}
+	for k, v := range attrs {
+		rc.Attrs[k] = v
+	}
+	return rc
+}
+
+// requestHops returns the addresses the request came through, the peer followed by the
+// x-forwarded-for hops from the right. The http gateway runs in the process, it forwards the
+// requests over loopback and appends the address of its client to x-forwarded-for.
+func requestHops(ctx context.Context) []string {
+	var hops []string
+	if p, ok := peer.FromContext(ctx); ok && p.Addr != nil {
+		hops = append(hops, conditions.ClientIP(p.Addr.String()))
+	}
+	md, _ := metadata.FromIncomingContext(ctx)
+	var forwarded []string
+	for _, v := range md.Get(xForwardedFor) {
+		forwarded = append(forwarded, strings.Split(v, ",")...)
+	}
+	for i := len(forwarded) - 1; i >= 0; i-- {
+		if hop := conditions.ClientIP(forwarded[i]); hop != "" {
+			hops = append(hops, hop)
+		}
+	}
+	return hops
+}
+
+// isGateway reports whether the hop is the http gateway, the peer of the requests it forwards.
+func isGateway(hops []string, i int) bool {
+	if i != 0 {
+		return false
+	}
+	ip := net.ParseIP(hops[i])
+	return ip != nil && ip.IsLoopback()
+}
+
+// isTrustedProxy reports whether ip is one of the trusted proxies.
+func isTrustedProxy(ip string) bool {
+	for _, pattern := range trustedProxies.Slice() {
+		if conditions.IPIn(ip, strings.TrimSpace(pattern)) {
+			return true
+		}
+	}
+	return false
+}
+
+// clientIP returns the address of the client, the first hop that is neither the gateway nor
+// a trusted proxy. Requests passing trusted proxies only get the farthest hop.
+func clientIP(ctx context.Context) string {
+	hops := requestHops(ctx)
+	for i, hop := range hops {
+		if !isGateway(hops, i) && !isTrustedProxy(hop) {
+			return hop
+		}
+	}
+	if len(hops) == 0 {
+		return ""
+	}
+	return hops[len(hops)-1]
+}
+
+// fromTrustedProxy reports whether the request was sent by a trusted proxy, directly or
+// through the gateway.
+func fromTrustedProxy(ctx context.Context) bool {
+	hops := requestHops(ctx)
+	for i, hop := range hops {
+		if !isGateway(hops, i) {
+			return isTrustedProxy(hop)
+		}
+	}
+	return false
+}
+
+// requestAttributes returns the x-auth-attr-* headers of the incoming request, they are
+// ignored unless a trusted proxy sent the request.
+func requestAttributes(ctx context.Context) map[string]string {
+	attrs := map[string]string{}
+	md, ok := metadata.FromIncomingContext(ctx)
+	if !ok || !fromTrustedProxy(ctx) {
+		return attrs
+	}
+	for key, values := range md {
+		if strings.HasPrefix(key, xAuthAttrPrefix) && len(values) > 0 {
+			attrs[strings.TrimPrefix(key, xAuthAttrPrefix)] = values[0]
+		}
+	}
+	return attrs
+}
@@ -0,0 +1,70 @@
+package auth
+
+import (
+	"context"
+	"net"
+	"testing"
+
+	"github.com/golang-tire/pkg/config"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+)
+
+// incoming returns a request context from the peer address with the header pairs.
+func incoming(addr string, pairs ...string) context.Context {
+	ctx := metadata.NewIncomingContext(context.Background(), metadata.Pairs(pairs...))
+	if addr == "" {
+		return ctx
+	}
+	tcp, _ := net.ResolveTCPAddr("tcp", addr)
+	return peer.NewContext(ctx, &peer.Peer{Addr: tcp})
+}
+
+func TestClientIP(t *testing.T) {
+	defer func(proxies config.StringSlice) { trustedProxies = proxies }(trustedProxies)
+	trustedProxies = stringSlice{"10.0.0.0/8", "192.168.1.1"}
+
+	tests := []struct {
+		name string
+		ctx  context.Context
+		want string
+	}{
+		{"grpc peer", incoming("203.0.113.7:4000"), "203.0.113.7"},
+		{"grpc peer ignores headers", incoming("203.0.113.7:4000", xForwardedFor, "198.51.100.1"), "203.0.113.7"},
+		{"gateway client", incoming("127.0.0.1:5000", xForwardedFor, "203.0.113.7"), "203.0.113.7"},
+		{"spoofed hop", incoming("127.0.0.1:5000", xForwardedFor, "198.51.100.1, 203.0.113.7"), "203.0.113.7"},
+		{"behind trusted proxy", incoming("127.0.0.1:5000", xForwardedFor, "198.51.100.1, 203.0.113.7, 10.1.2.3"), "203.0.113.7"},
+		{"trusted grpc peer", incoming("192.168.1.1:4000", xForwardedFor, "203.0.113.7:1234"), "203.0.113.7"},
+		{"trusted hops only", incoming("127.0.0.1:5000", xForwardedFor, "10.1.2.3, 10.3.2.1"), "10.1.2.3"},
+		{"gateway without hops", incoming("127.0.0.1:5000"), "127.0.0.1"},
+		{"no peer", incoming(""), ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, clientIP(tt.ctx))
+		})
+	}
+}
+
+func TestRequestAttributes(t *testing.T) {
+	defer func(proxies config.StringSlice) { trustedProxies = proxies }(trustedProxies)
+	trustedProxies = stringSlice{"10.0.0.0/8"}
+
+	tests := []struct {
+		name string
+		ctx  context.Context
+		want map[string]string
+	}{
+		{"trusted proxy", incoming("127.0.0.1:5000", xForwardedFor, "203.0.113.7, 10.1.2.3", "x-auth-attr-tenant", "acme"), map[string]string{"tenant": "acme"}},
+		{"trusted grpc peer", incoming("10.1.2.3:4000", "x-auth-attr-tenant", "acme"), map[string]string{"tenant": "acme"}},
+		{"gateway client", incoming("127.0.0.1:5000", xForwardedFor, "10.1.2.3, 203.0.113.7", "x-auth-attr-tenant", "acme"), map[string]string{}},
+		{"grpc client", incoming("203.0.113.7:4000", "x-auth-attr-tenant", "acme"), map[string]string{}},
+		{"loopback client", incoming("127.0.0.1:5000", "x-auth-attr-tenant", "acme"), map[string]string{}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, requestAttributes(tt.ctx))
+		})
+	}
+}
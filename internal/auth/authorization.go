@@ -0,0 +1,165 @@
+package auth
+
+import (
+	"context"
+	"strings"
+
+	"github.com/golang-tire/pkg/config"
+	"github.com/golang-tire/pkg/log"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+
+	"github.com/golang-tire/auth/internal/pkg/scope"
+	"github.com/golang-tire/auth/internal/pkg/tenant"
+	auth "github.com/golang-tire/auth/internal/proto/v1"
+)
+
+const (
+	// adminResourcePrefix keeps the resources of the admin APIs apart from the app resources.
+	adminResourcePrefix = "auth."
+	// adminRole is the built-in role allowed to call every admin API.
+	adminRole = scope.PlatformAdminRole
+
+	xAuthDomain = "x-auth-domain"
+	// xAuthOrganization selects the organization super administrators act in
+	xAuthOrganization = "x-auth-organization"
+)
+
+var (
+	// bootstrapAdmins are the usernames granted the admin role in the global domain
+	bootstrapAdmins = config.RegisterStringSlice("rbac.bootstrapAdmins", nil)
+	// bootstrapPolicy replaces the default bootstrap policy lines when set
+	bootstrapPolicy = config.RegisterStringSlice("rbac.bootstrapPolicy", nil)
+)
+
+// defaultBootstrapPolicy allows the admin role everything and domain admins to manage the
+// rules, users, groups and role bundles of their domains, the services limit them to those domains.
+func defaultBootstrapPolicy() []string {
+	domainAdmin := scope.AdminRole()
+	lines := []string{"p, " + adminRole + ", *, " + adminResourcePrefix + "*, *, *, allow"}
+	for _, p := range []struct{ resource, action string }{
+		{"domains", "get"},
+		{"domains", "list"},
+		{"domains", "update"},
+		{"roles", "get"},
+		{"roles", "list"},
+		{"apps", "get"},
+		{"apps", "list"},
+		{"rules", "*"},
+		{"users", "*"},
+		{"groups", "*"},
+		{"bundles", "get"},
+		{"bundles", "list"},
+		{"bundles", "attach"},
+		{"bundles", "detach"},
+		{"role-templates", "get"},
+		{"role-templates", "list"},
+	} {
+		lines = append(lines, "p, "+domainAdmin+", *, "+adminResourcePrefix+p.resource+", "+p.action+", *, allow")
+	}
+	return lines
+}
+
+// bootstrapLines returns the bootstrap policy, it is loaded along with the stored policy.
+func bootstrapLines() []line {
+	policy := bootstrapPolicy.Slice()
+	if len(policy) == 0 {
+		policy = defaultBootstrapPolicy()
+	}
+
+	var lines []line
+	for _, text := range policy {
+		tokens := strings.Split(text, ",")
+		for i := range tokens {
+			tokens[i] = strings.TrimSpace(tokens[i])
+		}
+		switch {
+		case tokens[0] == "p" && len(tokens) >= 7:
+			l := line{PType: "p", V0: tokens[1], V1: tokens[2], V2: tokens[3], V3: tokens[4], V4: tokens[5], V5: tokens[6]}
+			l.V6 = condition(strings.Join(tokens[7:], ","))
+			lines = append(lines, l)
+		case tokens[0] == "g" && len(tokens) == 4:
+			lines = append(lines, line{PType: "g", V0: tokens[1], V1: tokens[2], V2: tokens[3]})
+		default:
+			log.Error("invalid bootstrap policy line", log.String("line", text))
+		}
+	}
+	for _, username := range bootstrapAdmins.Slice() {
+		lines = append(lines, line{PType: "g", V0: username, V1: adminRole, V2: scope.GlobalDomain})
+	}
+	return lines
+}
+
+// isBootstrapAdmin reports whether the username is one of the bootstrap admins.
+func isBootstrapAdmin(username string) bool {
+	for _, admin := range bootstrapAdmins.Slice() {
+		if admin == username {
+			return true
+		}
+	}
+	return false
+}
+
+// callerDomain returns the domain of the caller, the domain of the request host or the one
+// of the x-auth-domain header for callers administering it. Unknown hosts are used as they are.
+func callerDomain(ctx context.Context) (string, error) {
+	if md, ok := metadata.FromIncomingContext(ctx); ok {
+		if v := md.Get(xAuthDomain); len(v) > 0 && v[0] != "" {
+			// the header is sent by the caller, it cannot select a domain it does not administer
+			if err := scope.FromContext(ctx).Check(v[0]); err != nil {
+				return "", err
+			}
+			return v[0], nil
+		}
+	}
+	if domain, err := ExtractDomain(ctx); err == nil {
+		return domain.Name, nil
+	}
+	hostname, _ := ExtractHostName(ctx)
+	return hostname, nil
+}
+
+// authorizeHandler checks the permission of authorized methods against the live policy in
+// the caller's domain, roles of the root domain apply to every domain of the organization.
+// Authenticated callers enter the organization of the x-auth-organization header after.
+func (m Middleware) authorizeHandler(ctx context.Context) (context.Context, error) {
+	route, ok := ctx.Value(routeKey).(Route)
+	if !ok || route.Access == auth.MethodAccess_PUBLIC {
+		return ctx, nil
+	}
+	user, err := ExtractUser(ctx)
+	if err != nil {
+		if route.Access != auth.MethodAccess_AUTHORIZED {
+			return ctx, nil
+		}
+		return ctx, status.Errorf(codes.Unauthenticated, "token required")
+	}
+	if route.Access == auth.MethodAccess_AUTHORIZED {
+		if err := m.authorize(ctx, route, user); err != nil {
+			return ctx, err
+		}
+	}
+	return m.enterOrganization(ctx)
+}
+
+// authorize checks the permission of the route in the caller's domain and the root domain of
+// the organization, the global domain on the platform.
+func (m Middleware) authorize(ctx context.Context, route Route, user *auth.User) error {
+	caller, err := callerDomain(ctx)
+	if err != nil {
+		return status.Error(codes.PermissionDenied, err.Error())
+	}
+	organization, _ := tenant.FromContext(ctx)
+	for _, domain := range []string{caller, tenant.RootDomain(organization)} {
+		allowed, err := m.rbac.enforce(ctx, nil, user.Username, domain, route.Resource, route.Action, "*")
+		if err != nil {
+			log.Error("authorize admin api failed", log.String("method", route.Method), log.Err(err))
+			return status.Errorf(codes.Internal, "authorization failed")
+		}
+		if allowed {
+			return nil
+		}
+	}
+	return status.Errorf(codes.PermissionDenied, "access denied")
+}
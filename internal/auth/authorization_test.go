@@ -0,0 +1,130 @@
+package auth
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/golang-tire/pkg/config"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc/metadata"
+
+	"github.com/golang-tire/auth/internal/entity"
+	"github.com/golang-tire/auth/internal/pkg/scope"
+	"github.com/golang-tire/auth/internal/pkg/tenant"
+	auth "github.com/golang-tire/auth/internal/proto/v1"
+)
+
+func TestMiddleware_authorize(t *testing.T) {
+	f := newTestRbac(t)
+	acme := f.organization(t, "acme")
+	acmeDomain := f.domain(t, "acme.com", acme)
+	shopDomain := f.domain(t, "shop.acme.com", acme)
+	platformDomain := f.domain(t, "foo.bar", tenant.Platform)
+	f.assign(t, "alice", acme, scope.AdminRole(), shopDomain)
+	f.assign(t, "owner", acme, adminRole, entity.Domain{})
+	f.assign(t, "bob", tenant.Platform, scope.AdminRole(), platformDomain)
+	f.reload(t)
+
+	m := Middleware{rbac: f.rbacService, domains: f.domainsSrv}
+	createRule := Route{Method: "/authV1.RuleService/CreateRule", Access: auth.MethodAccess_AUTHORIZED, Resource: "auth.rules", Action: "create"}
+	createRole := Route{Method: "/authV1.RoleService/CreateRole", Access: auth.MethodAccess_AUTHORIZED, Resource: "auth.roles", Action: "create"}
+
+	tests := []struct {
+		name     string
+		user     string
+		domain   entity.Domain
+		route    Route
+		wantDeny bool
+	}{
+		{"organization domain admin", "alice", shopDomain, createRule, false},
+		{"organization domain admin in another domain", "alice", acmeDomain, createRule, true},
+		{"organization domain admin without permission", "alice", shopDomain, createRole, true},
+		{"organization admin", "owner", shopDomain, createRole, false},
+		{"platform domain admin", "bob", platformDomain, createRule, false},
+		{"platform domain admin in an organization", "bob", shopDomain, createRule, true},
+	}
+	for i := range tests {
+		tt := &tests[i]
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.WithValue(context.Background(), domainKey, tt.domain)
+			ctx = tenant.NewContext(ctx, tt.domain.OrganizationID)
+			err := m.authorize(ctx, tt.route, &auth.User{Username: tt.user})
+			assert.Equal(t, tt.wantDeny, err != nil, err)
+		})
+	}
+}
+
+func TestMiddleware_authorizeDomainHeader(t *testing.T) {
+	f := newTestRbac(t)
+	fooDomain := f.domain(t, "foo.bar", tenant.Platform)
+	otherDomain := f.domain(t, "other.com", tenant.Platform)
+	f.assign(t, "bob", tenant.Platform, scope.AdminRole(), fooDomain)
+	f.assign(t, "carol", tenant.Platform, adminRole, fooDomain)
+	f.reload(t)
+
+	m := Middleware{rbac: f.rbacService, domains: f.domainsSrv}
+	createRule := Route{Method: "/authV1.RuleService/CreateRule", Access: auth.MethodAccess_AUTHORIZED, Resource: "auth.rules", Action: "create"}
+	tests := []struct {
+		name     string
+		user     *auth.User
+		header   string
+		wantDeny bool
+	}{
+		{"administered domain", domainAdmin("bob", "foo.bar"), "foo.bar", false},
+		{"domain of the host", domainAdmin("bob", "foo.bar"), "", true},
+		{"other domain", domainAdmin("bob", "foo.bar"), "other.com", true},
+		// the admin role in a domain does not make it administered
+		{"admin role in the domain", &auth.User{Username: "carol", Roles: []*auth.UserRole{{Role: adminRole, Domain: "foo.bar", Enable: true}}}, "foo.bar", true},
+	}
+	tree, err := f.domainsSrv.Tree(context.Background())
+	assert.Nil(t, err)
+	for i := range tests {
+		tt := &tests[i]
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			if tt.header != "" {
+				ctx = metadata.NewIncomingContext(ctx, metadata.Pairs(xAuthDomain, tt.header))
+			}
+			ctx = context.WithValue(ctx, domainKey, otherDomain)
+			ctx = tenant.NewContext(ctx, tenant.Platform)
+			ctx = scope.NewContext(ctx, userScope(ctx, tt.user, tree, time.Now()))
+			err := m.authorize(ctx, createRule, tt.user)
+			assert.Equal(t, tt.wantDeny, err != nil, err)
+		})
+	}
+}
+
+func TestUserScope(t *testing.T) {
+	f := newTestRbac(t)
+	acme := f.organization(t, "acme")
+	f.domain(t, "foo.bar", tenant.Platform)
+	f.domain(t, "acme.com", acme)
+	tree, err := f.domainsSrv.Tree(context.Background())
+	assert.Nil(t, err)
+
+	defer func(admins config.StringSlice) { bootstrapAdmins = admins }(bootstrapAdmins)
+	bootstrapAdmins = stringSlice{"root"}
+	platform := tenant.NewContext(context.Background(), tenant.Platform)
+	organization := tenant.NewContext(context.Background(), acme)
+	now := time.Now()
+
+	// bootstrap admins only have a role link, they administer every domain of the platform
+	assert.True(t, userScope(platform, &auth.User{Username: "root"}, tree, now).IsGlobal())
+	assert.False(t, userScope(organization, &auth.User{Username: "root"}, tree, now).IsGlobal())
+
+	owner := &auth.User{Username: "owner", Roles: []*auth.UserRole{{Role: adminRole, Enable: true}}}
+	assert.True(t, userScope(organization, owner, tree, now).IsGlobal())
+	owner.Roles[0].Enable = false
+	assert.False(t, userScope(organization, owner, tree, now).IsGlobal())
+
+	sc := userScope(platform, domainAdmin("bob", "foo.bar"), tree, now)
+	assert.False(t, sc.IsGlobal())
+	assert.True(t, sc.Allows("foo.bar"))
+	assert.False(t, sc.Allows("acme.com"))
+}
+
+// domainAdmin returns a user holding the domain admin role in the domain.
+func domainAdmin(username, domain string) *auth.User {
+	return &auth.User{Username: username, Roles: []*auth.UserRole{{Role: scope.AdminRole(), Domain: domain, Enable: true}}}
+}
@@ -0,0 +1,222 @@
+package auth
+
+import (
+	"context"
+	"errors"
+	"sort"
+	"strconv"
+	"strings"
+
+	validation "github.com/go-ozzo/ozzo-validation/v4"
+	"github.com/golang-tire/auth/internal/domains"
+	"github.com/golang-tire/auth/internal/pkg/conditions"
+	"github.com/golang-tire/pkg/config"
+	"github.com/golang-tire/pkg/log"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+
+	auth "github.com/golang-tire/auth/internal/proto/v1"
+)
+
+const (
+	xAuthDecisionResource = "x-auth-decision-resource"
+	xAuthDecisionObject   = "x-auth-decision-object"
+	xAuthDecisionRoute    = "x-auth-decision-route"
+	xAuthDecisionRoles    = "x-auth-decision-roles"
+	xAuthDecisionRule     = "x-auth-decision-rule"
+)
+
+// debugDecisions makes Validate return its decision explanation in response headers
+var debugDecisions = config.RegisterBool("rbac.debug", false)
+
+// ValidateExplainDecisionRequest validates the ExplainDecisionRequest fields.
+func ValidateExplainDecisionRequest(c *auth.ExplainDecisionRequest) error {
+	return validation.ValidateStruct(c,
+		validation.Field(&c.Subject, validation.Length(0, 128)),
+		validation.Field(&c.Domain, validation.Length(0, 128)),
+		validation.Field(&c.Host, validation.Length(0, 255)),
+		validation.Field(&c.Uri, validation.Required, validation.Length(1, 2048)),
+		validation.Field(&c.Method, validation.Required, validation.Length(1, 16)),
+	)
+}
+
+// ExplainDecision explains the decision Validate makes for a request to the host, the host
+// defaults to the one of the call and the domain to the domain of the host.
+func (s service) ExplainDecision(ctx context.Context, req *auth.ExplainDecisionRequest) (*auth.ExplainDecisionResponse, error) {
+	if err := ValidateExplainDecisionRequest(req); err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	user, err := ExtractUser(ctx)
+	if err != nil {
+		return nil, status.Errorf(codes.Unauthenticated, "user not found")
+	}
+
+	host := req.Host
+	if host == "" {
+		host, _ = ExtractHostName(ctx)
+	}
+	hostDomain, err := s.domains.ResolveHost(ctx, host)
+	if errors.Is(err, domains.ErrUnknownHost) {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+	if err != nil {
+		log.Error("resolve host domain failed", log.Err(err))
+		return nil, status.Errorf(codes.Internal, "resolve domain failed")
+	}
+	domain := req.Domain
+	if domain == "" {
+		domain = hostDomain.Name
+	}
+
+	subject, domain, err := s.resolveSubject(ctx, user, req.Subject, domain)
+	if err != nil {
+		return nil, err
+	}
+	if !hostDomain.Enable {
+		// Validate denies every request to the hosts of disabled domains
+		return &auth.ExplainDecisionResponse{Subject: subject, Domain: domain, Action: req.Method}, nil
+	}
+
+	rc := s.subjectContext(ctx, user, subject, "", nil)
+	res, err := s.explain(ctx, rc, subject, host, domain, req.Uri, req.Method)
+	if err == nil && res.Resource != "" {
+		// every policy is enforced on its own, so only explanations list the matched rules
+		res.MatchedRules, err = s.rbac.matchedRules(s.rbac.request(s.rbac.enforcer, rc, subject, domain, res.Resource, res.Action, res.Object)...)
+	}
+	if err != nil {
+		log.Error("explain decision failed", log.Err(err))
+		return nil, status.Errorf(codes.Internal, "explain decision failed")
+	}
+	return res, nil
+}
+
+// explain runs the same decision as checkRbac and collects how it was made, without the
+// matched rules.
+func (s service) explain(ctx context.Context, rc *conditions.Context, subject, host, domain, uri, method string) (*auth.ExplainDecisionResponse, error) {
+	res := &auth.ExplainDecisionResponse{
+		Subject: subject,
+		Domain:  domain,
+		Action:  method,
+		Roles:   s.rbac.effectiveRoles(subject, domain),
+	}
+
+	p, matched, err := s.resolvePermission(ctx, host, method, uri)
+	if err == errNoRoute || err == errAppDisabled {
+		// no route matched or the app is disabled, so nothing can be allowed
+		return res, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	if !matched {
+		res.Allowed = unknownRouteAllowed()
+		return res, nil
+	}
+	resource, object, method := p.resource, p.object, p.action
+	res.Action = method
+	res.Resource = resource
+	res.Object = object
+	res.RoutePattern = p.pattern
+
+	allowed, decisive, err := s.rbac.enforceEx(ctx, s.rbac.enforcer, rc, subject, domain, resource, method, object)
+	if err != nil {
+		return nil, err
+	}
+	if len(decisive) > 0 {
+		res.DecisiveRule = policyToProto(decisive)
+	}
+	if !allowed {
+		res.RelationAllowed, err = s.checkRelation(ctx, resource, object, method, subject)
+		if err != nil {
+			return nil, err
+		}
+	}
+	res.Allowed = allowed || res.RelationAllowed
+	return res, nil
+}
+
+// sendDecisionHeaders attach the decision explanation to the response headers.
+func (s service) sendDecisionHeaders(ctx context.Context, headers *Headers, domain string, user *auth.User, rc *conditions.Context) error {
+	res, err := s.explain(ctx, rc, user.Username, headers.ForwardedHost, domain, headers.ForwardedURI, headers.ForwardedMethod)
+	if err != nil {
+		return err
+	}
+
+	md := metadata.New(map[string]string{
+		xAuthDecisionResource: res.Resource,
+		xAuthDecisionObject:   res.Object,
+		xAuthDecisionRoute:    res.RoutePattern,
+		xAuthDecisionRoles:    strings.Join(res.Roles, ","),
+	})
+	if res.DecisiveRule != nil {
+		md.Set(xAuthDecisionRule, policyRuleString(res.DecisiveRule))
+	}
+	return grpc.SetHeader(ctx, md)
+}
+
+// effectiveRoles returns the roles of subject in domain, including inherited roles and the
+// roles of the domains above it, the same roles the enforcer checks in the domain.
+func (r *rbacService) effectiveRoles(subject, domain string) []string {
+	roles, err := r.enforcer.GetImplicitRolesForUser(subject, domain)
+	if err != nil {
+		return []string{}
+	}
+	sort.Strings(roles)
+	return roles
+}
+
+// matchedRules evaluates every policy on its own and returns the ones the request matches.
+func (r *rbacService) matchedRules(rvals ...interface{}) ([]*auth.PolicyRule, error) {
+	m := r.enforcer.GetModel()
+	matcher := m["m"]["m"].Value
+	tokens := m["p"]["p"].Tokens
+
+	var rules []*auth.PolicyRule
+	for _, policy := range r.enforcer.GetPolicy() {
+		var conditions []string
+		for i, token := range tokens {
+			if i >= len(policy) {
+				break
+			}
+			// the other fields already identify the policy
+			if token == "p_cond" {
+				continue
+			}
+			conditions = append(conditions, token+" == "+strconv.Quote(policy[i]))
+		}
+
+		_, explain, err := r.enforcer.EnforceExWithMatcher("("+matcher+") && "+strings.Join(conditions, " && "), rvals...)
+		if err != nil {
+			return nil, err
+		}
+		if len(explain) > 0 {
+			rules = append(rules, policyToProto(policy))
+		}
+	}
+	return rules, nil
+}
+
+func policyToProto(p []string) *auth.PolicyRule {
+	rule := &auth.PolicyRule{}
+	fields := []*string{&rule.Subject, &rule.Domain, &rule.Resource, &rule.Action, &rule.Object, &rule.Effect, &rule.Condition}
+	for i, f := range fields {
+		if i < len(p) {
+			*f = p[i]
+		}
+	}
+	if rule.Condition == conditions.Always {
+		rule.Condition = ""
+	}
+	return rule
+}
+
+func policyRuleString(p *auth.PolicyRule) string {
+	fields := []string{p.Subject, p.Domain, p.Resource, p.Action, p.Object, p.Effect}
+	if p.Condition != "" {
+		fields = append(fields, p.Condition)
+	}
+	return strings.Join(fields, ", ")
+}
@@ -0,0 +1,61 @@
+package auth
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"gorm.io/gorm"
+
+	"github.com/golang-tire/auth/internal/apps"
+	"github.com/golang-tire/auth/internal/entity"
+	"github.com/golang-tire/auth/internal/pkg/tenant"
+	auth "github.com/golang-tire/auth/internal/proto/v1"
+	"github.com/golang-tire/auth/internal/relations"
+)
+
+func TestService_explain(t *testing.T) {
+	f := newTestRbac(t)
+	ctx := context.Background()
+	fooDomain := f.domain(t, "foo.bar", tenant.Platform)
+	f.assign(t, "alice", tenant.Platform, "reader", fooDomain)
+	f.rule(t, tenant.Platform, "reader", fooDomain, "docs", "get", "*")
+	f.reload(t)
+
+	appsRepo := apps.NewMockRepository()
+	_, err := appsRepo.CreateApp(ctx, entity.App{Model: gorm.Model{ID: 1}, Name: "docs", Enable: true})
+	assert.Nil(t, err)
+	relationsSrv := relations.NewService(relations.NewMockRepository(), appsRepo)
+	_, err = relationsSrv.CreateDefinition(ctx, &auth.CreateRelationDefinitionRequest{App: "docs", Namespace: "docs", Relation: "get"})
+	assert.Nil(t, err)
+	_, err = relationsSrv.Write(ctx, &auth.WriteRelationTuplesRequest{Tuples: []*auth.RelationTuple{
+		{Object: "docs:1", Relation: "get", Subject: "bob"},
+	}})
+	assert.Nil(t, err)
+
+	s := service{rbac: f.rbacService, appService: apps.NewService(appsRepo, nil), relations: relationsSrv}
+	tests := []struct {
+		name         string
+		subject      string
+		uri          string
+		wantAllowed  bool
+		wantRelation bool
+	}{
+		{"allowed by a rule", "alice", "/v1/docs/1", true, false},
+		{"allowed by a relation tuple", "bob", "/v1/docs/1", true, true},
+		{"object without a tuple", "bob", "/v1/docs/2", false, false},
+		{"every object", "bob", "/v1/docs", false, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			res, err := s.explain(ctx, nil, tt.subject, "foo.bar", "foo.bar", tt.uri, "get")
+			if !assert.Nil(t, err) {
+				return
+			}
+			assert.Equal(t, tt.wantAllowed, res.Allowed)
+			assert.Equal(t, tt.wantRelation, res.RelationAllowed)
+			// only ExplainDecision lists the matched rules
+			assert.Empty(t, res.MatchedRules)
+		})
+	}
+}
@@ -3,18 +3,24 @@ package auth
 import (
 	"context"
 	"errors"
-
-	"github.com/casbin/casbin/v2"
-
-	"github.com/golang-tire/pkg/kv"
+	"fmt"
+	"time"
 
 	auth "github.com/golang-tire/auth/internal/proto/v1"
 
+	"github.com/golang-tire/auth/internal/domains"
+	"github.com/golang-tire/auth/internal/entity"
+	"github.com/golang-tire/auth/internal/organizations"
+	"github.com/golang-tire/auth/internal/pkg/helpers"
+	"github.com/golang-tire/auth/internal/pkg/scope"
+	"github.com/golang-tire/auth/internal/pkg/tenant"
 	"github.com/golang-tire/auth/internal/users"
 	"github.com/golang-tire/pkg/grpcgw"
+	"github.com/golang-tire/pkg/log"
 
 	"google.golang.org/grpc/metadata"
 
+	grpc_middleware "github.com/grpc-ecosystem/go-grpc-middleware"
 	grpc_auth "github.com/grpc-ecosystem/go-grpc-middleware/auth"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
@@ -25,56 +31,81 @@ import (
 type contextKey int
 
 const (
-	resourceKey contextKey = iota
+	routeKey contextKey = iota
 	userKey
 	tokenKey
 	fullMethodKey
 	hostNameKey
+	domainKey
 )
 
 type Middleware struct {
-	enforcer    *casbin.Enforcer
-	userService users.Service
+	rbac          *rbacService
+	userService   users.Service
+	domains       domains.Service
+	organizations organizations.Service
+	routes        *Routes
 }
 
-func streamExtractor(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
-	return handler(srv, ss)
+func (m Middleware) streamExtractor(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+	ctx, err := m.extractor(ss.Context(), info.FullMethod)
+	if err != nil {
+		return err
+	}
+	wrapped := grpc_middleware.WrapServerStream(ss)
+	wrapped.WrappedContext = ctx
+	return handler(srv, wrapped)
 }
 
-func unaryExtractor(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (resp interface{}, err error) {
-	ctx, err = extractor(ctx, info.FullMethod)
+func (m Middleware) unaryExtractor(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (resp interface{}, err error) {
+	ctx, err = m.extractor(ctx, info.FullMethod)
 	if err != nil {
 		return nil, err
 	}
 	return handler(ctx, req)
 }
 
-func extractor(ctx context.Context, fullMethod string) (context.Context, error) {
-	m, ok := metadata.FromIncomingContext(ctx)
+func (m Middleware) extractor(ctx context.Context, fullMethod string) (context.Context, error) {
+	md, ok := metadata.FromIncomingContext(ctx)
 	if !ok {
 		return ctx, status.Errorf(codes.InvalidArgument, "metadata is not readable!")
 	}
 	// TODO find a better way to read x-forward-host and authority field
-	forwardedHost := m.Get("x-forwarded-host")
+	var host string
+	forwardedHost := md.Get("x-forwarded-host")
 	if len(forwardedHost) == 1 {
 		// its came from grpc-gateway
-		ctx = context.WithValue(ctx, hostNameKey, forwardedHost[0])
+		host = forwardedHost[0]
 	} else {
 		// its a grpc call
-		ctx = context.WithValue(ctx, hostNameKey, m.Get(":authority")[0])
+		host = md.Get(":authority")[0]
 	}
+	ctx = context.WithValue(ctx, hostNameKey, helpers.NormalizeHost(host))
+
+	// requests to unknown hosts have no domain, the methods that need one reject them
+	domain, err := m.domains.ResolveHost(ctx, host)
+	if err == nil {
+		ctx = context.WithValue(ctx, domainKey, domain)
+	} else if !errors.Is(err, domains.ErrUnknownHost) {
+		log.Error("resolve host domain failed", log.String("host", host), log.Err(err))
+	}
+	// requests only see the records of the organization of their domain, requests to
+	// unknown hosts the ones of the platform
+	ctx = tenant.NewContext(ctx, domain.OrganizationID)
 
-	res, ok := kv.Memory().Get(fullMethod)
+	// methods of other services, like the reflection service, only need a user
+	route, ok := m.routes.Lookup(fullMethod)
 	if !ok {
-		ctx = context.WithValue(ctx, resourceKey, res)
+		route = Route{Method: fullMethod, Access: auth.MethodAccess_AUTHENTICATED}
 	}
+	ctx = context.WithValue(ctx, routeKey, route)
 	ctx = context.WithValue(ctx, fullMethodKey, fullMethod)
 	return ctx, nil
 }
 
 func (m Middleware) authHandler(ctx context.Context) (context.Context, error) {
-	r := ctx.Value(resourceKey)
-	if r == nil { // No user requested here
+	route, ok := ctx.Value(routeKey).(Route)
+	if !ok || route.Access == auth.MethodAccess_PUBLIC {
 		return ctx, nil
 	}
 	token, err := grpc_auth.AuthFromMD(ctx, "bearer")
@@ -101,9 +132,44 @@ func (m Middleware) authHandler(ctx context.Context) (context.Context, error) {
 		return ctx, status.Errorf(codes.Unauthenticated, "access denied")
 	}
 
+	tree, err := m.domains.Tree(ctx)
+	if err != nil {
+		log.Error("load domain tree failed", log.Err(err))
+		return ctx, status.Errorf(codes.Internal, "load domains failed")
+	}
+	ctx = scope.NewContext(ctx, userScope(ctx, user, tree, time.Now()))
 	return context.WithValue(context.WithValue(ctx, userKey, user), tokenKey, token), nil
 }
 
+// userScope returns the domains the user administers. Holders of the admin role in the
+// global domain, like the bootstrap admins of the platform, administer every domain, the
+// admins of a domain the domains below it as well.
+func userScope(ctx context.Context, user *auth.User, tree domains.Tree, now time.Time) scope.Scope {
+	if scope.HoldsGlobally(user, adminRole, now) || (tenant.IsPlatform(ctx) && isBootstrapAdmin(user.Username)) {
+		return scope.Global()
+	}
+	return scope.ForUser(user, now).WithSubdomains(tree.Subtree)
+}
+
+// enterOrganization limits the request to the organization of the x-auth-organization
+// header, only super administrators, the global administrators of the platform, can
+// act in another organization. It runs after the request is authorized on the platform.
+func (m Middleware) enterOrganization(ctx context.Context) (context.Context, error) {
+	md, _ := metadata.FromIncomingContext(ctx)
+	values := md.Get(xAuthOrganization)
+	if len(values) == 0 || values[0] == "" {
+		return ctx, nil
+	}
+	if !tenant.IsPlatform(ctx) || !scope.FromContext(ctx).IsGlobal() {
+		return ctx, status.Errorf(codes.PermissionDenied, "only super administrators can act in another organization")
+	}
+	organization, err := m.organizations.Resolve(ctx, values[0])
+	if err != nil {
+		return ctx, status.Errorf(codes.InvalidArgument, "unknown organization `%s`", values[0])
+	}
+	return tenant.NewContext(ctx, organization.ID), nil
+}
+
 // ExtractUser try to extract the current user from the context
 func ExtractUser(ctx context.Context) (*auth.User, error) {
 	u, ok := ctx.Value(userKey).(*auth.User)
@@ -131,16 +197,36 @@ func ExtractHostName(ctx context.Context) (string, error) {
 	return tok, nil
 }
 
-func InitMiddleware(userService users.Service) {
+// ExtractDomain returns the domain of the request host, it fails for unknown hosts.
+func ExtractDomain(ctx context.Context) (entity.Domain, error) {
+	domain, ok := ctx.Value(domainKey).(entity.Domain)
+	if !ok {
+		host, _ := ExtractHostName(ctx)
+		return entity.Domain{}, fmt.Errorf("%w `%s`, it is not a domain name or host alias", domains.ErrUnknownHost, host)
+	}
+	return domain, nil
+}
 
-	middleware := Middleware{userService: userService}
+func InitMiddleware(userService users.Service, domainsService domains.Service, organizationsService organizations.Service, rbac *rbacService) error {
+	routes, err := LoadRoutes()
+	if err != nil {
+		return err
+	}
+
+	middleware := Middleware{rbac: rbac, userService: userService, domains: domainsService, organizations: organizationsService, routes: routes}
 	grpcgw.RegisterInterceptors(grpcgw.Interceptor{
-		Unary:  unaryExtractor,
-		Stream: streamExtractor,
+		Unary:  middleware.unaryExtractor,
+		Stream: middleware.streamExtractor,
 	})
 
 	grpcgw.RegisterInterceptors(grpcgw.Interceptor{
 		Unary:  grpc_auth.UnaryServerInterceptor(middleware.authHandler),
 		Stream: grpc_auth.StreamServerInterceptor(middleware.authHandler),
 	})
+
+	grpcgw.RegisterInterceptors(grpcgw.Interceptor{
+		Unary:  grpc_auth.UnaryServerInterceptor(middleware.authorizeHandler),
+		Stream: grpc_auth.StreamServerInterceptor(middleware.authorizeHandler),
+	})
+	return nil
 }
@@ -0,0 +1,112 @@
+package auth
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+
+	"github.com/golang-tire/auth/internal/entity"
+	"github.com/golang-tire/auth/internal/pkg/scope"
+	"github.com/golang-tire/auth/internal/pkg/tenant"
+	auth "github.com/golang-tire/auth/internal/proto/v1"
+)
+
+func TestMiddleware_extractor(t *testing.T) {
+	f := newTestRbac(t)
+	acme := f.organization(t, "acme")
+	acmeDomain := f.domain(t, "acme.com", acme)
+	platformDomain := f.domain(t, "foo.bar", tenant.Platform)
+	routes, err := LoadRoutes()
+	if !assert.Nil(t, err) {
+		return
+	}
+	m := Middleware{domains: f.domainsSrv, routes: routes}
+	createRule, _ := routes.Lookup("/authV1.RuleService/CreateRule")
+
+	tests := []struct {
+		name       string
+		ctx        context.Context
+		method     string
+		wantHost   string
+		wantDomain entity.Domain
+		wantTenant uint
+		wantRoute  Route
+	}{
+		{"gateway request", incoming("", "x-forwarded-host", "Foo.Bar:8080", ":authority", "localhost:9090"),
+			"/authV1.RuleService/CreateRule", "foo.bar", platformDomain, tenant.Platform, createRule},
+		{"grpc request", incoming("", ":authority", "acme.com"),
+			"/authV1.RuleService/CreateRule", "acme.com", acmeDomain, acme, createRule},
+		{"several forwarded hosts", incoming("", "x-forwarded-host", "foo.bar", "x-forwarded-host", "evil.com", ":authority", "acme.com"),
+			"/authV1.RuleService/CreateRule", "acme.com", acmeDomain, acme, createRule},
+		{"unknown host", incoming("", ":authority", "unknown.com"),
+			"/authV1.RuleService/CreateRule", "unknown.com", entity.Domain{}, tenant.Platform, createRule},
+		{"method of another service", incoming("", ":authority", "acme.com"),
+			"/grpc.reflection.v1alpha.ServerReflection/ServerReflectionInfo", "acme.com", acmeDomain, acme,
+			Route{Method: "/grpc.reflection.v1alpha.ServerReflection/ServerReflectionInfo", Access: auth.MethodAccess_AUTHENTICATED}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx, err := m.extractor(tt.ctx, tt.method)
+			if !assert.Nil(t, err) {
+				return
+			}
+			host, _ := ExtractHostName(ctx)
+			assert.Equal(t, tt.wantHost, host)
+			domain, _ := ctx.Value(domainKey).(entity.Domain)
+			assert.Equal(t, tt.wantDomain.UUID, domain.UUID)
+			organization, ok := tenant.FromContext(ctx)
+			assert.True(t, ok)
+			assert.Equal(t, tt.wantTenant, organization)
+			assert.Equal(t, tt.wantRoute, ctx.Value(routeKey))
+			assert.Equal(t, tt.method, ctx.Value(fullMethodKey))
+		})
+	}
+
+	_, err = m.extractor(context.Background(), "/authV1.RuleService/CreateRule")
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+}
+
+func TestMiddleware_enterOrganization(t *testing.T) {
+	f := newTestRbac(t)
+	acme := f.organization(t, "acme")
+	m := Middleware{organizations: f.organizationsSrv}
+	acmeOrganization, err := f.organizationsSrv.Resolve(context.Background(), "acme")
+	if !assert.Nil(t, err) {
+		return
+	}
+
+	tests := []struct {
+		name       string
+		header     string
+		tenant     uint
+		scope      scope.Scope
+		wantTenant uint
+		wantCode   codes.Code
+	}{
+		{"no header", "", tenant.Platform, scope.Domains("foo.bar"), tenant.Platform, codes.OK},
+		{"no header in an organization", "", acme, scope.Global(), acme, codes.OK},
+		{"super administrator by name", "acme", tenant.Platform, scope.Global(), acme, codes.OK},
+		{"super administrator by uuid", acmeOrganization.UUID, tenant.Platform, scope.Global(), acme, codes.OK},
+		{"unknown organization", "other", tenant.Platform, scope.Global(), tenant.Platform, codes.InvalidArgument},
+		{"platform domain admin", "acme", tenant.Platform, scope.Domains("foo.bar"), tenant.Platform, codes.PermissionDenied},
+		{"organization admin", "acme", acme, scope.Global(), acme, codes.PermissionDenied},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			if tt.header != "" {
+				ctx = metadata.NewIncomingContext(ctx, metadata.Pairs(xAuthOrganization, tt.header))
+			}
+			ctx = scope.NewContext(tenant.NewContext(ctx, tt.tenant), tt.scope)
+			ctx, err := m.enterOrganization(ctx)
+			assert.Equal(t, tt.wantCode, status.Code(err), err)
+			organization, ok := tenant.FromContext(ctx)
+			assert.True(t, ok)
+			assert.Equal(t, tt.wantTenant, organization)
+		})
+	}
+}
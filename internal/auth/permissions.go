@@ -0,0 +1,200 @@
+package auth
+
+import (
+	"context"
+
+	"github.com/casbin/casbin/v2/util"
+	validation "github.com/go-ozzo/ozzo-validation/v4"
+	"github.com/golang-tire/auth/internal/pkg/helpers"
+	"github.com/golang-tire/pkg/log"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	auth "github.com/golang-tire/auth/internal/proto/v1"
+)
+
+const (
+	listSubjectsAction = "list"
+	// subjectsBatchSize is the number of users ListAuthorizedSubjects reads at once
+	subjectsBatchSize = 500
+)
+
+// ValidateGetEffectivePermissionsRequest validates the GetEffectivePermissionsRequest fields.
+func ValidateGetEffectivePermissionsRequest(c *auth.GetEffectivePermissionsRequest) error {
+	return validation.ValidateStruct(c,
+		validation.Field(&c.Subject, validation.Length(0, 128)),
+		validation.Field(&c.Domain, validation.Length(0, 128)),
+		validation.Field(&c.App, validation.Length(0, 128)),
+	)
+}
+
+// ValidateListAuthorizedSubjectsRequest validates the ListAuthorizedSubjectsRequest fields.
+func ValidateListAuthorizedSubjectsRequest(c *auth.ListAuthorizedSubjectsRequest) error {
+	return validation.ValidateStruct(c,
+		validation.Field(&c.Domain, validation.Length(0, 128)),
+		validation.Field(&c.Resource, validation.Required, validation.Length(0, 128)),
+		validation.Field(&c.Action, validation.Required, validation.Length(0, 128)),
+		validation.Field(&c.Object, validation.Length(0, 128)),
+		validation.Field(&c.App, validation.Length(0, 128)),
+	)
+}
+
+func (s service) GetEffectivePermissions(ctx context.Context, req *auth.GetEffectivePermissionsRequest) (*auth.GetEffectivePermissionsResponse, error) {
+	if err := ValidateGetEffectivePermissionsRequest(req); err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	user, err := ExtractUser(ctx)
+	if err != nil {
+		return nil, status.Errorf(codes.Unauthenticated, "user not found")
+	}
+
+	subject, domain, err := s.resolveSubject(ctx, user, req.Subject, req.Domain)
+	if err != nil {
+		return nil, err
+	}
+
+	var appResources []string
+	if req.App != "" {
+		appResources, err = s.appResourceNames(ctx, req.App)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	roles := map[string]bool{subject: true}
+	for _, role := range s.rbac.effectiveRoles(subject, domain) {
+		roles[role] = true
+	}
+
+	var permissions []*auth.PolicyRule
+	for _, policy := range s.rbac.enforcer.GetPolicy() {
+		rule := policyToProto(policy)
+		if !roles[rule.Subject] {
+			continue
+		}
+		if !s.rbac.ruleApplies(domain, rule.Domain) {
+			continue
+		}
+		if req.App != "" && !matchAny(appResources, rule.Resource) {
+			continue
+		}
+		permissions = append(permissions, rule)
+	}
+
+	offset, limit := helpers.GetOffsetAndLimit(req.Offset, req.Limit)
+	start, end := pageBounds(len(permissions), offset, limit)
+	return &auth.GetEffectivePermissionsResponse{
+		Permissions: permissions[start:end],
+		TotalCount:  int64(len(permissions)),
+		Offset:      offset,
+		Limit:       limit,
+	}, nil
+}
+
+func (s service) ListAuthorizedSubjects(ctx context.Context, req *auth.ListAuthorizedSubjectsRequest) (*auth.ListAuthorizedSubjectsResponse, error) {
+	if err := ValidateListAuthorizedSubjectsRequest(req); err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	user, err := ExtractUser(ctx)
+	if err != nil {
+		return nil, status.Errorf(codes.Unauthenticated, "user not found")
+	}
+
+	_, domain, err := s.resolveSubject(ctx, user, "", req.Domain)
+	if err != nil {
+		return nil, err
+	}
+
+	ok, err := s.rbac.enforce(ctx, requestContext(ctx, user.RawData), user.Username, domain, checkOnBehalfResource, listSubjectsAction, req.Resource)
+	if err != nil {
+		log.Error("check list subjects permission failed", log.Err(err))
+		return nil, status.Errorf(codes.Internal, "check permission failed")
+	}
+	if !ok {
+		return nil, status.Errorf(codes.PermissionDenied, "not allowed to list subjects of %s", req.Resource)
+	}
+
+	if req.App != "" {
+		appResources, err := s.appResourceNames(ctx, req.App)
+		if err != nil {
+			return nil, err
+		}
+		if !matchAny(appResources, req.Resource) {
+			return nil, status.Errorf(codes.InvalidArgument, "resource %s does not belong to app %s", req.Resource, req.App)
+		}
+	}
+
+	// the subjects are the users of the organization, read in batches to keep only the
+	// requested page in memory, conditions only see the time of the listing
+	offset, limit := helpers.GetOffsetAndLimit(req.Offset, req.Limit)
+	var subjects []string
+	var total int64
+	for batch := int64(0); ; batch += subjectsBatchSize {
+		names, err := s.userService.ListUsernames(ctx, batch, subjectsBatchSize)
+		if err != nil {
+			log.Error("list users failed", log.Err(err))
+			return nil, status.Errorf(codes.Internal, "list users failed")
+		}
+		for _, subject := range names {
+			allowed, err := s.rbac.enforce(ctx, nil, subject, domain, req.Resource, req.Action, req.Object)
+			if err != nil {
+				log.Error("check rbac permission failed", log.Err(err))
+				return nil, status.Errorf(codes.Internal, "check permission failed")
+			}
+			if !allowed {
+				continue
+			}
+			if total >= offset && total < offset+limit {
+				subjects = append(subjects, subject)
+			}
+			total++
+		}
+		if int64(len(names)) < subjectsBatchSize {
+			break
+		}
+	}
+
+	return &auth.ListAuthorizedSubjectsResponse{
+		Subjects:   subjects,
+		TotalCount: total,
+		Offset:     offset,
+		Limit:      limit,
+	}, nil
+}
+
+func (s service) appResourceNames(ctx context.Context, app string) ([]string, error) {
+	resources, err := s.appService.AppResources(ctx, app)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	var names []string
+	for _, resource := range resources {
+		names = append(names, resource.Name)
+	}
+	return names, nil
+}
+
+// matchAny reports whether any of names matches the policy pattern.
+func matchAny(names []string, pattern string) bool {
+	for _, name := range names {
+		if ok, err := util.GlobMatch(name, pattern); err == nil && ok {
+			return true
+		}
+	}
+	return false
+}
+
+func pageBounds(total int, offset, limit int64) (int, int) {
+	start := int(offset)
+	if start > total || start < 0 {
+		start = total
+	}
+	end := start + int(limit)
+	if end > total {
+		end = total
+	}
+	return start, end
+}
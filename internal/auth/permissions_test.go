@@ -0,0 +1,86 @@
+package auth
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/golang-tire/auth/internal/entity"
+	"github.com/golang-tire/auth/internal/pkg/tenant"
+	auth "github.com/golang-tire/auth/internal/proto/v1"
+)
+
+// rule adds an allow rule of the role in the domain, the zero domain is the root domain
+// of the organization.
+func (f testRbac) rule(t *testing.T, organization uint, role string, domain entity.Domain, resource, action, object string) {
+	_, err := f.rulesRepo.Create(context.Background(), entity.Rule{
+		OrganizationID: organization,
+		Role:           entity.Role{Title: role, Enable: true},
+		Domain:         domain,
+		Resource:       resource,
+		Action:         action,
+		Object:         object,
+		Effect:         "allow",
+	})
+	assert.Nil(t, err)
+}
+
+func TestService_GetEffectivePermissions(t *testing.T) {
+	f := newTestRbac(t)
+	acme := f.organization(t, "acme")
+	acmeDomain := f.domain(t, "acme.com", acme)
+	f.domain(t, "foo.bar", tenant.Platform)
+	f.assign(t, "carol", acme, "reader", acmeDomain)
+	f.assign(t, "carol", tenant.Platform, "reader", entity.Domain{})
+	f.rule(t, tenant.Platform, "reader", entity.Domain{}, "docs", "read", "*")
+	f.rule(t, acme, "reader", acmeDomain, "books", "read", "*")
+	f.reload(t)
+
+	s := service{rbac: f.rbacService, userService: f.usersSrv}
+	ctx := tenant.NewContext(context.WithValue(context.Background(), userKey, &auth.User{Username: "carol"}), acme)
+	res, err := s.GetEffectivePermissions(ctx, &auth.GetEffectivePermissionsRequest{Domain: "acme.com"})
+	assert.Nil(t, err)
+	// rules of the global domain do not apply in the domains of an organization
+	if assert.Len(t, res.Permissions, 1) {
+		assert.Equal(t, "books", res.Permissions[0].Resource)
+	}
+}
+
+func TestService_ListAuthorizedSubjects(t *testing.T) {
+	f := newTestRbac(t)
+	acme := f.organization(t, "acme")
+	acmeDomain := f.domain(t, "acme.com", acme)
+	for _, username := range []string{"carol", "dave", "erin"} {
+		_, err := f.usersRepo.Create(context.Background(), entity.User{Username: username, OrganizationID: acme, Enable: true})
+		assert.Nil(t, err)
+	}
+	f.assign(t, "carol", acme, "reader", acmeDomain)
+	f.assign(t, "dave", acme, "reader", acmeDomain)
+	f.rule(t, acme, "reader", acmeDomain, "books", "read", "*")
+	f.rule(t, acme, "reader", acmeDomain, checkOnBehalfResource, listSubjectsAction, "books")
+	f.reload(t)
+
+	s := service{rbac: f.rbacService, userService: f.usersSrv}
+	ctx := tenant.NewContext(context.WithValue(context.Background(), userKey, &auth.User{Username: "carol"}), acme)
+	tests := []struct {
+		name         string
+		req          *auth.ListAuthorizedSubjectsRequest
+		wantSubjects []string
+		wantError    bool
+	}{
+		{"all", &auth.ListAuthorizedSubjectsRequest{Domain: "acme.com", Resource: "books", Action: "read"}, []string{"carol", "dave"}, false},
+		{"page", &auth.ListAuthorizedSubjectsRequest{Domain: "acme.com", Resource: "books", Action: "read", Offset: 1, Limit: 1}, []string{"dave"}, false},
+		{"not allowed", &auth.ListAuthorizedSubjectsRequest{Domain: "acme.com", Resource: "films", Action: "read"}, nil, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			res, err := s.ListAuthorizedSubjects(ctx, tt.req)
+			assert.Equal(t, tt.wantError, err != nil, err)
+			if err == nil {
+				assert.Equal(t, tt.wantSubjects, res.Subjects)
+				assert.Equal(t, int64(2), res.TotalCount)
+			}
+		})
+	}
+}
@@ -2,11 +2,18 @@ package auth
 
 import (
 	"context"
+	"fmt"
 	"regexp"
 	"strings"
+	"sync/atomic"
+	"time"
 
 	"github.com/ThreeDotsLabs/watermill/message"
+	"github.com/golang-tire/auth/internal/pkg/cache"
+	"github.com/golang-tire/auth/internal/pkg/conditions"
 	"github.com/golang-tire/auth/internal/pkg/pubsub"
+	"github.com/golang-tire/auth/internal/pkg/scope"
+	"github.com/golang-tire/auth/internal/pkg/tenant"
 
 	zaplogger "github.com/casbin/zap-logger"
 
@@ -15,6 +22,11 @@ import (
 	"github.com/casbin/casbin/v2"
 	"github.com/casbin/casbin/v2/model"
 	"github.com/casbin/casbin/v2/persist"
+	defaultrolemanager "github.com/casbin/casbin/v2/rbac/default-role-manager"
+	"github.com/golang-tire/auth/internal/domains"
+	"github.com/golang-tire/auth/internal/entity"
+	"github.com/golang-tire/auth/internal/groups"
+	"github.com/golang-tire/auth/internal/organizations"
 	"github.com/golang-tire/auth/internal/rules"
 	"github.com/golang-tire/auth/internal/users"
 	"github.com/golang-tire/pkg/config"
@@ -33,15 +45,27 @@ var (
 )
 
 type rbacService struct {
-	enforcer      *casbin.Enforcer
-	regexPatterns []*regexp.Regexp
+	enforcer       *casbin.Enforcer
+	shadowEnforcer *casbin.Enforcer
+	shadowAdapter  *adapter
+	regexPatterns  []*regexp.Regexp
+	ctx            context.Context
+	domains        domains.Service
+	// tree is the domain tree of the decisions, see loadTree
+	tree cache.Value
 }
 
 type adapter struct {
-	lines    []line
-	ctx      context.Context
-	usersSrv users.Service
-	rulesSrv rules.Service
+	lines            []line
+	ctx              context.Context
+	usersSrv         users.Service
+	rulesSrv         rules.Service
+	groupsSrv        groups.Service
+	organizationsSrv organizations.Service
+	// shadow adapters load the shadow rules along with the live ones
+	shadow bool
+	// shadowRules is the number of shadow rules of the last load, it is read atomically
+	shadowRules int64
 }
 
 type line struct {
@@ -52,6 +76,7 @@ type line struct {
 	V3    string
 	V4    string
 	V5    string
+	V6    string
 }
 
 type Policy struct {
@@ -69,24 +94,39 @@ type Group struct {
 	Domain  string
 }
 
-func newAdapter(ctx context.Context, ruleSrv rules.Service, userSrv users.Service) persist.Adapter {
+func newAdapter(ctx context.Context, ruleSrv rules.Service, userSrv users.Service, groupSrv groups.Service, organizationSrv organizations.Service, shadow bool) *adapter {
 	return &adapter{
-		lines:    []line{},
-		ctx:      ctx,
-		usersSrv: userSrv,
-		rulesSrv: ruleSrv,
+		lines:            []line{},
+		ctx:              ctx,
+		usersSrv:         userSrv,
+		rulesSrv:         ruleSrv,
+		groupsSrv:        groupSrv,
+		organizationsSrv: organizationSrv,
+		shadow:           shadow,
 	}
 }
 
 func (a *adapter) loadFromDb() error {
+	a.lines = bootstrapLines()
+	organizationLines, err := a.organizationLines(a.lines)
+	if err != nil {
+		return err
+	}
+	a.lines = append(a.lines, organizationLines...)
+
 	userRoles, err := a.usersSrv.ListUserRoles(a.ctx)
 	if err != nil {
 		return err
 	}
 
+	now := time.Now()
 	for _, ur := range userRoles {
+		// assignments that are not valid yet or expired grant nothing
+		if !ur.Active(now) || !enabled(ur.Role, ur.Domain) {
+			continue
+		}
 		if ur.Domain.Name == "" {
-			ur.Domain.Name = "*"
+			ur.Domain.Name = tenant.RootDomain(ur.User.OrganizationID)
 		}
 		a.lines = append(a.lines, line{
 			PType: "g",
@@ -96,11 +136,27 @@ func (a *adapter) loadFromDb() error {
 		})
 	}
 
+	groupLines, err := a.groupLines()
+	if err != nil {
+		return err
+	}
+	a.lines = append(a.lines, groupLines...)
+
 	ruleItems, err := a.rulesSrv.All(a.ctx)
+	var shadowRules int64
 	for _, rule := range ruleItems {
+		if rule.Shadow && !a.shadow {
+			continue
+		}
+		if !enabled(rule.Role, rule.Domain) {
+			continue
+		}
+		if rule.Shadow {
+			shadowRules++
+		}
 
 		if rule.Domain.Name == "" {
-			rule.Domain.Name = "*"
+			rule.Domain.Name = tenant.RootDomain(rule.OrganizationID)
 		}
 
 		a.lines = append(a.lines, line{
@@ -111,12 +167,96 @@ func (a *adapter) loadFromDb() error {
 			V3:    rule.Action,
 			V4:    rule.Object,
 			V5:    strings.ToLower(rule.Effect),
+			V6:    condition(rule.Condition),
 		})
 	}
+	atomic.StoreInt64(&a.shadowRules, shadowRules)
 	return nil
 }
 
+// organizationLines returns the bootstrap rules of the global domain for the root domain of
+// every organization, the built-in roles administer an organization like the platform.
+func (a *adapter) organizationLines(bootstrap []line) ([]line, error) {
+	if a.organizationsSrv == nil {
+		return nil, nil
+	}
+	items, err := a.organizationsSrv.All(a.ctx)
+	if err != nil {
+		return nil, err
+	}
+	var lines []line
+	for _, organization := range items {
+		if organization.ID == tenant.Platform {
+			continue
+		}
+		for _, l := range bootstrap {
+			if l.PType == "p" && l.V1 == scope.GlobalDomain {
+				l.V1 = tenant.RootDomain(organization.ID)
+				lines = append(lines, l)
+			}
+		}
+	}
+	return lines, nil
+}
+
+// groupLines returns the group bindings as role links, every member is linked to the
+// group and the group to its roles in the domains the group has roles in.
+func (a *adapter) groupLines() ([]line, error) {
+	if a.groupsSrv == nil {
+		return nil, nil
+	}
+	groupRoles, err := a.groupsSrv.ListGroupRoles(a.ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var lines []line
+	seen := map[line]bool{}
+	add := func(l line) {
+		if !seen[l] {
+			seen[l] = true
+			lines = append(lines, l)
+		}
+	}
+	for _, gr := range groupRoles {
+		if !enabled(gr.Role, gr.Domain) {
+			continue
+		}
+		domain := gr.Domain.Name
+		if domain == "" {
+			domain = tenant.RootDomain(gr.Group.OrganizationID)
+		}
+		group := entity.GroupSubject(gr.Group.Name)
+		for _, m := range gr.Group.Members {
+			add(line{PType: "g", V0: m.User.Username, V1: group, V2: domain})
+		}
+		add(line{PType: "g", V0: group, V1: gr.Role.Title, V2: domain})
+	}
+	return lines, nil
+}
+
+// enabled reports whether the role and the domain of a binding are enabled, bindings of
+// the global domain have no domain record.
+func enabled(role entity.Role, domain entity.Domain) bool {
+	return role.Enable && (domain.ID == 0 || domain.Enable)
+}
+
+// condition returns the rule condition, rules without one always apply.
+func condition(cond string) string {
+	if strings.TrimSpace(cond) == "" {
+		return conditions.Always
+	}
+	return cond
+}
+
 func loadPolicyLine(line line, model model.Model) {
+	// conditions may contain commas and quotes, so policies of models with a
+	// condition are added as they are instead of being parsed as csv
+	if line.PType == "p" && len(model["p"]["p"].Tokens) == 7 {
+		model.AddPolicy("p", "p", []string{line.V0, line.V1, line.V2, line.V3, line.V4, line.V5, line.V6})
+		return
+	}
+
 	lineText := line.PType
 	if line.V0 != "" {
 		lineText += ", " + line.V0
@@ -175,11 +315,112 @@ func (a *rbacService) OnPolicyChange(msg *message.Message) error {
 		log.Error("reload polices failed", log.Err(err))
 		return err
 	}
+	err = a.shadowEnforcer.LoadPolicy()
+	if err != nil {
+		log.Error("reload shadow polices failed", log.Err(err))
+		return err
+	}
 	msg.Ack()
 	return nil
 }
 
-func InitRbac(ctx context.Context, rulesSrv rules.Service, usersSrv users.Service, ps *pubsub.PubSub) (*rbacService, error) {
+// request returns the request values of the enforcer, rc is passed as r.ctx when its
+// model has a request context.
+func (a *rbacService) request(e *casbin.Enforcer, rc *conditions.Context, sub, dom, res, act, obj string) []interface{} {
+	rvals := []interface{}{sub, dom, res, act, obj}
+	if len(e.GetModel()["r"]["r"].Tokens) > len(rvals) {
+		if rc == nil {
+			rc = conditions.NewContext("", time.Now(), "", nil)
+		}
+		rvals = append(rvals, rc)
+	}
+	return rvals
+}
+
+// enforceEx decides the request with the enforcer and returns the decisive policy, requests
+// in domains of another organization are denied.
+func (a *rbacService) enforceEx(ctx context.Context, e *casbin.Enforcer, rc *conditions.Context, sub, dom, res, act, obj string) (bool, []string, error) {
+	// models with eval() fail on an empty policy instead of denying
+	if len(e.GetPolicy()) == 0 {
+		return false, nil, nil
+	}
+	tree, err := a.loadTree(ctx)
+	if err != nil {
+		return false, nil, err
+	}
+	// subjects are user names which are only unique in an organization
+	if !inTenant(ctx, tree, dom) {
+		return false, nil, nil
+	}
+	return e.EnforceEx(a.request(e, rc, sub, dom, res, act, obj)...)
+}
+
+// enforce decides the request with the live policy.
+func (a *rbacService) enforce(ctx context.Context, rc *conditions.Context, sub, dom, res, act, obj string) (bool, error) {
+	ok, _, err := a.enforceEx(ctx, a.enforcer, rc, sub, dom, res, act, obj)
+	return ok, err
+}
+
+// loadTree loads the domain tree the domain matchers use, it is loaded once per decision
+// instead of on every evaluation of the matchers.
+func (a *rbacService) loadTree(ctx context.Context) (domains.Tree, error) {
+	tree, err := a.domains.Tree(ctx)
+	if err != nil {
+		return domains.Tree{}, fmt.Errorf("load domain tree failed: %w", err)
+	}
+	a.tree.Set(tree)
+	return tree, nil
+}
+
+// loadedTree returns the domain tree loaded for the current decisions.
+func (a *rbacService) loadedTree() domains.Tree {
+	tree, _ := a.tree.Get().(domains.Tree)
+	return tree
+}
+
+// inTenant reports whether the casbin domain belongs to the organization of the request,
+// contexts without an organization may use every domain.
+func inTenant(ctx context.Context, tree domains.Tree, domain string) bool {
+	organization, ok := tenant.FromContext(ctx)
+	if !ok {
+		return true
+	}
+	owner, known := tree.Organization(domain)
+	return known && owner == organization
+}
+
+// domainCovers reports whether role assignments and rules of domain apply to requests in
+// the request domain, they apply in the domain itself and the domains below it.
+func (a *rbacService) domainCovers(request, domain string) bool {
+	return request == domain || a.loadedTree().Covers(domain, request)
+}
+
+// domainMatch is the domainMatch(r.dom, p.dom) function of the matchers.
+func (a *rbacService) domainMatch(args ...interface{}) (interface{}, error) {
+	if len(args) != 2 {
+		return false, fmt.Errorf("domainMatch expects 2 arguments, got %d", len(args))
+	}
+	request, _ := args[0].(string)
+	domain, _ := args[1].(string)
+	return a.ruleApplies(request, domain), nil
+}
+
+// ruleApplies reports whether the rules of domain apply to requests in the request domain,
+// rules of the global domain apply in every domain of the platform and in unknown domains.
+func (a *rbacService) ruleApplies(request, domain string) bool {
+	if domain == scope.GlobalDomain {
+		organization, known := a.loadedTree().Organization(request)
+		return !known || organization == tenant.Platform
+	}
+	return a.domainCovers(request, domain)
+}
+
+// hasShadowRules reports whether the shadow policy has shadow rules.
+func (a *rbacService) hasShadowRules() bool {
+	return atomic.LoadInt64(&a.shadowAdapter.shadowRules) > 0
+}
+
+func InitRbac(ctx context.Context, rulesSrv rules.Service, usersSrv users.Service, groupsSrv groups.Service, domainsSrv domains.Service, organizationsSrv organizations.Service, ps *pubsub.PubSub) (*rbacService, error) {
 
 	log.Info("init rbac module")
 	err := config.Load()
@@ -187,14 +428,45 @@ func InitRbac(ctx context.Context, rulesSrv rules.Service, usersSrv users.Servic
 		return nil, err
 	}
 
-	a := newAdapter(ctx, rulesSrv, usersSrv)
-	m, err := model.NewModelFromString(rbacConfig.String())
+	rbacSrv, err := newRbac(ctx, rbacConfig.String(), rulesSrv, usersSrv, groupsSrv, domainsSrv, organizationsSrv)
+	if err != nil {
+		return nil, err
+	}
+
+	err = ps.AddHandler(pubsub.RuleChange, rbacSrv.OnPolicyChange)
+	if err != nil {
+		return nil, err
+	}
+	err = ps.AddHandler(pubsub.UserChange, rbacSrv.OnPolicyChange)
+	if err != nil {
+		return nil, err
+	}
+	return rbacSrv, err
+}
+
+// newRbac creates the live and shadow enforcers of the model conf and loads their policy.
+func newRbac(ctx context.Context, conf string, rulesSrv rules.Service, usersSrv users.Service, groupsSrv groups.Service, domainsSrv domains.Service, organizationsSrv organizations.Service) (*rbacService, error) {
+	a := newAdapter(ctx, rulesSrv, usersSrv, groupsSrv, organizationsSrv, false)
+	m, err := model.NewModelFromString(conf)
 	if err != nil {
 		return nil, err
 	}
 
 	logger := zaplogger.NewLoggerByZap(log.Logger(), true)
 	enf, err := casbin.NewEnforcer(m, a, logger, true)
+	if err != nil {
+		return nil, err
+	}
+
+	sm, err := model.NewModelFromString(conf)
+	if err != nil {
+		return nil, err
+	}
+	sa := newAdapter(ctx, rulesSrv, usersSrv, groupsSrv, organizationsSrv, true)
+	shadowEnf, err := casbin.NewEnforcer(sm, sa)
+	if err != nil {
+		return nil, err
+	}
 
 	ruleList := routePatterns.Slice()
 	var regexRules []*regexp.Regexp
@@ -202,15 +474,17 @@ func InitRbac(ctx context.Context, rulesSrv rules.Service, usersSrv users.Servic
 		regexRules = append(regexRules, regexp.MustCompile(rl))
 	}
 
-	rbacSrv := &rbacService{enforcer: enf, regexPatterns: regexRules}
+	rbacSrv := &rbacService{enforcer: enf, shadowEnforcer: shadowEnf, shadowAdapter: sa, regexPatterns: regexRules, ctx: ctx, domains: domainsSrv}
 
-	err = ps.AddHandler("rule-change", rbacSrv.OnPolicyChange)
-	if err != nil {
-		return nil, err
-	}
-	err = ps.AddHandler("user-change", rbacSrv.OnPolicyChange)
-	if err != nil {
-		return nil, err
+	for _, e := range []*casbin.Enforcer{enf, shadowEnf} {
+		for name, fn := range conditions.Functions() {
+			e.AddFunction(name, fn)
+		}
+		e.AddFunction("domainMatch", rbacSrv.domainMatch)
+		// role assignments of a domain apply in the domains below it
+		if rm, ok := e.GetRoleManager().(*defaultrolemanager.RoleManager); ok {
+			rm.AddDomainMatchingFunc("domainMatch", rbacSrv.domainCovers)
+		}
 	}
-	return rbacSrv, err
+	return rbacSrv, nil
 }
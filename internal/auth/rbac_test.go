@@ -0,0 +1,169 @@
+package auth
+
+import (
+	"context"
+	"io/ioutil"
+	"testing"
+
+	"github.com/golang-tire/pkg/log"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/golang-tire/auth/internal/apps"
+	"github.com/golang-tire/auth/internal/domains"
+	"github.com/golang-tire/auth/internal/entity"
+	"github.com/golang-tire/auth/internal/groups"
+	"github.com/golang-tire/auth/internal/organizations"
+	"github.com/golang-tire/auth/internal/pkg/tenant"
+	"github.com/golang-tire/auth/internal/roles"
+	"github.com/golang-tire/auth/internal/rules"
+	"github.com/golang-tire/auth/internal/users"
+)
+
+// testRbac is an rbac service over the mock repositories, the policy is loaded by reload.
+type testRbac struct {
+	*rbacService
+	domainsRepo       domains.Repository
+	domainsSrv        domains.Service
+	usersRepo         users.Repository
+	usersSrv          users.Service
+	rulesRepo         *rules.MockRepository
+	organizationsRepo organizations.Repository
+	organizationsSrv  organizations.Service
+}
+
+func newTestRbac(t *testing.T) testRbac {
+	ctx := context.Background()
+	assert.Nil(t, log.Init(ctx, false))
+	conf, err := ioutil.ReadFile("../../configs/rbac.conf")
+	assert.Nil(t, err)
+
+	f := testRbac{
+		domainsRepo:       domains.NewMockRepository(),
+		usersRepo:         users.NewMockRepository(),
+		rulesRepo:         rules.NewMockRepository(),
+		organizationsRepo: organizations.NewMockRepository(),
+	}
+	rolesRepo := roles.NewMockRepository()
+	f.domainsSrv = domains.NewService(f.domainsRepo, rolesRepo)
+	f.organizationsSrv = organizations.NewService(f.organizationsRepo)
+	rulesSrv := rules.NewService(f.rulesRepo, f.domainsRepo, f.domainsSrv, rolesRepo, f.usersRepo, apps.NewMockRepository(), groups.NewMockRepository())
+	f.usersSrv = users.NewService(f.usersRepo, f.domainsRepo, rolesRepo, nil)
+	f.rbacService, err = newRbac(ctx, string(conf), rulesSrv, f.usersSrv, nil, f.domainsSrv, f.organizationsSrv)
+	assert.Nil(t, err)
+	return f
+}
+
+// organization creates an organization and returns its ID.
+func (f testRbac) organization(t *testing.T, name string) uint {
+	ctx := context.Background()
+	id, err := f.organizationsRepo.Create(ctx, entity.Organization{Name: name})
+	assert.Nil(t, err)
+	organization, err := f.organizationsRepo.Get(ctx, id)
+	assert.Nil(t, err)
+	return organization.ID
+}
+
+// domain creates an enabled domain of the organization.
+func (f testRbac) domain(t *testing.T, name string, organization uint) entity.Domain {
+	ctx := context.Background()
+	id, err := f.domainsRepo.Create(ctx, entity.Domain{Name: name, OrganizationID: organization, Enable: true})
+	assert.Nil(t, err)
+	domain, err := f.domainsRepo.Get(ctx, id)
+	assert.Nil(t, err)
+	return domain
+}
+
+// assign gives the user of the organization the role in the domain, the zero domain is
+// the root domain of the organization.
+func (f testRbac) assign(t *testing.T, username string, organization uint, role string, domain entity.Domain) {
+	_, err := f.usersRepo.AddUserRole(context.Background(), entity.UserRole{
+		OrganizationID: organization,
+		User:           entity.User{Username: username, OrganizationID: organization, Enable: true},
+		Role:           entity.Role{Title: role, Enable: true},
+		Domain:         domain,
+		Enable:         true,
+	})
+	assert.Nil(t, err)
+}
+
+// reload loads the policy of the mock repositories.
+func (f testRbac) reload(t *testing.T) {
+	assert.Nil(t, f.enforcer.LoadPolicy())
+	assert.Nil(t, f.shadowEnforcer.LoadPolicy())
+}
+
+func TestRbac_OrganizationBootstrap(t *testing.T) {
+	f := newTestRbac(t)
+	acme := f.organization(t, "acme")
+	f.domain(t, "acme.com", acme)
+	f.reload(t)
+
+	// the built-in roles get the bootstrap rules in the root domain of every organization
+	var roots []string
+	for _, policy := range f.enforcer.GetPolicy() {
+		if policy[0] == adminRole {
+			roots = append(roots, policy[1])
+		}
+	}
+	assert.Equal(t, []string{"*", tenant.RootDomain(acme)}, roots)
+}
+
+// stringSlice is a fixed string slice config value.
+type stringSlice []string
+
+func (s stringSlice) Slice() []string {
+	return s
+}
+
+func TestRbac_effectiveRoles(t *testing.T) {
+	f := newTestRbac(t)
+	acme := f.organization(t, "acme")
+	foo := f.domain(t, "foo.acme.com", acme)
+	bar := f.domain(t, "bar.acme.com", acme)
+	f.assign(t, "john", acme, "viewer", entity.Domain{})
+	f.assign(t, "john", acme, "editor", foo)
+	f.assign(t, "john", acme, "owner", bar)
+	x := f.domain(t, "x.com", tenant.Platform)
+	f.domain(t, "y.com", tenant.Platform)
+	f.assign(t, "jane", tenant.Platform, "viewer", entity.Domain{})
+	f.assign(t, "jane", tenant.Platform, "editor", x)
+	f.reload(t)
+	_, err := f.loadTree(context.Background())
+	assert.Nil(t, err)
+
+	// the roles of the organization root apply in its domains, the ones of other domains do not
+	assert.Equal(t, []string{"editor", "viewer"}, f.effectiveRoles("john", "foo.acme.com"))
+	assert.Equal(t, []string{"owner", "viewer"}, f.effectiveRoles("john", "bar.acme.com"))
+	// the global roles of the platform apply in its domains but not in the organizations
+	assert.Equal(t, []string{"editor", "viewer"}, f.effectiveRoles("jane", "x.com"))
+	assert.Equal(t, []string{"viewer"}, f.effectiveRoles("jane", "y.com"))
+	assert.Empty(t, f.effectiveRoles("jane", "foo.acme.com"))
+}
+
+func TestRbac_hasShadowRules(t *testing.T) {
+	f := newTestRbac(t)
+	ctx := context.Background()
+	fooDomain := f.domain(t, "foo.bar", tenant.Platform)
+	rule := entity.Rule{Role: entity.Role{Title: "reader", Enable: true}, Domain: fooDomain, Resource: "docs", Action: "get", Object: "*", Effect: "allow"}
+	_, err := f.rulesRepo.Create(ctx, rule)
+	assert.Nil(t, err)
+	f.reload(t)
+	assert.False(t, f.hasShadowRules())
+
+	// shadow rules of disabled roles are not loaded
+	disabled := rule
+	disabled.Role = entity.Role{Title: "writer"}
+	disabled.Shadow = true
+	_, err = f.rulesRepo.Create(ctx, disabled)
+	assert.Nil(t, err)
+	f.reload(t)
+	assert.False(t, f.hasShadowRules())
+
+	// a shadow copy of a live rule is a shadow rule too
+	shadow := rule
+	shadow.Shadow = true
+	_, err = f.rulesRepo.Create(ctx, shadow)
+	assert.Nil(t, err)
+	f.reload(t)
+	assert.True(t, f.hasShadowRules())
+}
@@ -0,0 +1,155 @@
+package auth
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/golang-tire/pkg/config"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/reflect/protoregistry"
+
+	"github.com/golang-tire/auth/internal/pkg/helpers"
+	auth "github.com/golang-tire/auth/internal/proto/v1"
+)
+
+// apiPackage is the proto package of the services guarded by the route registry.
+const apiPackage = "authV1"
+
+// routeOverrides change the access of methods, each entry is
+// "<full method> public|authenticated" or "<full method> authorized [<resource> <action>]".
+var routeOverrides = config.RegisterStringSlice("rbac.routes", nil)
+
+// defaultAccess is the access of the methods that are not authorized, methods can also
+// declare their access with the authV1.access method option.
+var defaultAccess = map[string]auth.MethodAccess{
+	"/authV1.AuthService/Login":                   auth.MethodAccess_PUBLIC,
+	"/authV1.AuthService/Register":                auth.MethodAccess_PUBLIC,
+	"/authV1.AuthService/VerifyToken":             auth.MethodAccess_PUBLIC,
+	"/authV1.AuthService/RefreshToken":            auth.MethodAccess_PUBLIC,
+	"/authV1.AuthService/Logout":                  auth.MethodAccess_AUTHENTICATED,
+	"/authV1.AuthService/Validate":                auth.MethodAccess_AUTHENTICATED,
+	"/authV1.AuthService/CheckPermission":         auth.MethodAccess_AUTHENTICATED,
+	"/authV1.AuthService/BatchCheck":              auth.MethodAccess_AUTHENTICATED,
+	"/authV1.AuthService/ExplainDecision":         auth.MethodAccess_AUTHENTICATED,
+	"/authV1.AuthService/GetEffectivePermissions": auth.MethodAccess_AUTHENTICATED,
+	"/authV1.AuthService/ListAuthorizedSubjects":  auth.MethodAccess_AUTHENTICATED,
+	// every user can request access, approvers are checked by the service
+	"/authV1.AccessRequestService/ListAccessRequests":   auth.MethodAccess_AUTHENTICATED,
+	"/authV1.AccessRequestService/GetAccessRequest":     auth.MethodAccess_AUTHENTICATED,
+	"/authV1.AccessRequestService/CreateAccessRequest":  auth.MethodAccess_AUTHENTICATED,
+	"/authV1.AccessRequestService/ApproveAccessRequest": auth.MethodAccess_AUTHENTICATED,
+	"/authV1.AccessRequestService/DenyAccessRequest":    auth.MethodAccess_AUTHENTICATED,
+	"/authV1.AccessRequestService/CancelAccessRequest":  auth.MethodAccess_AUTHENTICATED,
+}
+
+// Route is how the middleware guards a gRPC method.
+type Route struct {
+	Method string
+	Access auth.MethodAccess
+	// Resource and Action are the permission authorized methods need
+	Resource string
+	Action   string
+}
+
+// Routes is the route registry of the gRPC methods of the service.
+type Routes struct {
+	routes map[string]Route
+}
+
+// LoadRoutes builds the route registry of the api services from the default access, the
+// method options and the rbac.routes config, it fails on unknown methods.
+func LoadRoutes() (*Routes, error) {
+	r := &Routes{routes: map[string]Route{}}
+	protoregistry.GlobalFiles.RangeFilesByPackage(apiPackage, func(fd protoreflect.FileDescriptor) bool {
+		services := fd.Services()
+		for i := 0; i < services.Len(); i++ {
+			r.addService(services.Get(i))
+		}
+		return true
+	})
+	for method := range defaultAccess {
+		if _, ok := r.routes[method]; !ok {
+			return nil, fmt.Errorf("unknown method `%s` in the default routes", method)
+		}
+	}
+
+	for _, entry := range routeOverrides.Slice() {
+		if err := r.override(entry); err != nil {
+			return nil, fmt.Errorf("invalid rbac.routes entry `%s`: %v", entry, err)
+		}
+	}
+	return r, nil
+}
+
+// addService adds the routes of the service methods, the resource of a service is its
+// kebab cased plural name unless set with the authV1.resource service option.
+func (r *Routes) addService(sd protoreflect.ServiceDescriptor) {
+	resource, _ := proto.GetExtension(sd.Options(), auth.E_Resource).(string)
+	if resource == "" {
+		resource = helpers.ServiceResource(string(sd.Name()))
+	}
+	methods := sd.Methods()
+	for i := 0; i < methods.Len(); i++ {
+		md := methods.Get(i)
+		method := fmt.Sprintf("/%s/%s", sd.FullName(), md.Name())
+		route := Route{
+			Method:   method,
+			Access:   defaultAccess[method],
+			Resource: resource,
+			Action:   methodVerb(string(md.Name())),
+		}
+		opts := md.Options()
+		if proto.HasExtension(opts, auth.E_Access) {
+			route.Access = proto.GetExtension(opts, auth.E_Access).(auth.MethodAccess)
+		}
+		if v, _ := proto.GetExtension(opts, auth.E_MethodResource).(string); v != "" {
+			route.Resource = v
+		}
+		if v, _ := proto.GetExtension(opts, auth.E_Action).(string); v != "" {
+			route.Action = v
+		}
+		route.Resource = adminResourcePrefix + route.Resource
+		r.routes[method] = route
+	}
+}
+
+func (r *Routes) override(entry string) error {
+	fields := strings.Fields(entry)
+	if len(fields) != 2 && len(fields) != 4 {
+		return fmt.Errorf("method and access required")
+	}
+	route, ok := r.routes[fields[0]]
+	if !ok {
+		return fmt.Errorf("unknown method `%s`", fields[0])
+	}
+	access, ok := auth.MethodAccess_value[strings.ToUpper(fields[1])]
+	if !ok {
+		return fmt.Errorf("unknown access `%s`", fields[1])
+	}
+	route.Access = auth.MethodAccess(access)
+	if len(fields) == 4 {
+		if route.Access != auth.MethodAccess_AUTHORIZED {
+			return fmt.Errorf("only authorized methods have a permission")
+		}
+		route.Resource = adminResourcePrefix + fields[2]
+		route.Action = fields[3]
+	}
+	r.routes[route.Method] = route
+	return nil
+}
+
+// Lookup returns the route of the full method.
+func (r *Routes) Lookup(fullMethod string) (Route, bool) {
+	route, ok := r.routes[fullMethod]
+	return route, ok
+}
+
+// methodVerb returns the first word of a method name, e.g. create for CreateRule.
+func methodVerb(method string) string {
+	words := helpers.CamelWords(method)
+	if len(words) == 0 {
+		return ""
+	}
+	return words[0]
+}
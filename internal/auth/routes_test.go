@@ -0,0 +1,121 @@
+package auth
+
+import (
+	"testing"
+
+	"github.com/golang-tire/pkg/config"
+	"github.com/stretchr/testify/assert"
+
+	auth "github.com/golang-tire/auth/internal/proto/v1"
+)
+
+func TestLoadRoutes(t *testing.T) {
+	routes, err := LoadRoutes()
+	if !assert.Nil(t, err) {
+		return
+	}
+	tests := []struct {
+		name   string
+		method string
+		want   Route
+		wantOk bool
+	}{
+		{"public method", "/authV1.AuthService/Login",
+			Route{Method: "/authV1.AuthService/Login", Access: auth.MethodAccess_PUBLIC, Resource: "auth.auths", Action: "login"}, true},
+		{"authenticated method", "/authV1.AuthService/ExplainDecision",
+			Route{Method: "/authV1.AuthService/ExplainDecision", Access: auth.MethodAccess_AUTHENTICATED, Resource: "auth.auths", Action: "explain"}, true},
+		{"authorized method", "/authV1.RuleService/CreateRule",
+			Route{Method: "/authV1.RuleService/CreateRule", Access: auth.MethodAccess_AUTHORIZED, Resource: "auth.rules", Action: "create"}, true},
+		{"kebab cased resource", "/authV1.AccessRequestService/ApproveAccessRequest",
+			Route{Method: "/authV1.AccessRequestService/ApproveAccessRequest", Access: auth.MethodAccess_AUTHENTICATED, Resource: "auth.access-requests", Action: "approve"}, true},
+		{"unknown method", "/authV1.RuleService/Unknown", Route{}, false},
+		{"method of another package", "/grpc.health.v1.Health/Check", Route{}, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, ok := routes.Lookup(tt.method)
+			assert.Equal(t, tt.wantOk, ok)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestLoadRoutes_defaultAccess(t *testing.T) {
+	routes, err := LoadRoutes()
+	if !assert.Nil(t, err) {
+		return
+	}
+	for method, access := range defaultAccess {
+		route, ok := routes.Lookup(method)
+		if assert.True(t, ok, method) {
+			assert.Equal(t, access, route.Access, method)
+		}
+	}
+
+	defaultAccess["/authV1.AuthService/Unknown"] = auth.MethodAccess_PUBLIC
+	defer delete(defaultAccess, "/authV1.AuthService/Unknown")
+	_, err = LoadRoutes()
+	assert.EqualError(t, err, "unknown method `/authV1.AuthService/Unknown` in the default routes")
+}
+
+func TestLoadRoutes_overrides(t *testing.T) {
+	defer func(overrides config.StringSlice) { routeOverrides = overrides }(routeOverrides)
+
+	tests := []struct {
+		name      string
+		overrides stringSlice
+		method    string
+		want      Route
+		wantErr   string
+	}{
+		{"public", stringSlice{"/authV1.RuleService/ListRules public"}, "/authV1.RuleService/ListRules",
+			Route{Method: "/authV1.RuleService/ListRules", Access: auth.MethodAccess_PUBLIC, Resource: "auth.rules", Action: "list"}, ""},
+		{"authorized", stringSlice{"/authV1.AuthService/ExplainDecision AUTHORIZED"}, "/authV1.AuthService/ExplainDecision",
+			Route{Method: "/authV1.AuthService/ExplainDecision", Access: auth.MethodAccess_AUTHORIZED, Resource: "auth.auths", Action: "explain"}, ""},
+		{"permission", stringSlice{"/authV1.AuthService/ExplainDecision authorized decisions read"}, "/authV1.AuthService/ExplainDecision",
+			Route{Method: "/authV1.AuthService/ExplainDecision", Access: auth.MethodAccess_AUTHORIZED, Resource: "auth.decisions", Action: "read"}, ""},
+		{"last entry wins", stringSlice{"/authV1.RuleService/ListRules public", "/authV1.RuleService/ListRules authenticated"}, "/authV1.RuleService/ListRules",
+			Route{Method: "/authV1.RuleService/ListRules", Access: auth.MethodAccess_AUTHENTICATED, Resource: "auth.rules", Action: "list"}, ""},
+		{"unknown method", stringSlice{"/authV1.RuleService/Unknown public"}, "", Route{},
+			"invalid rbac.routes entry `/authV1.RuleService/Unknown public`: unknown method `/authV1.RuleService/Unknown`"},
+		{"unknown access", stringSlice{"/authV1.RuleService/ListRules private"}, "", Route{},
+			"invalid rbac.routes entry `/authV1.RuleService/ListRules private`: unknown access `private`"},
+		{"missing access", stringSlice{"/authV1.RuleService/ListRules"}, "", Route{},
+			"invalid rbac.routes entry `/authV1.RuleService/ListRules`: method and access required"},
+		{"missing action", stringSlice{"/authV1.RuleService/ListRules authorized rules"}, "", Route{},
+			"invalid rbac.routes entry `/authV1.RuleService/ListRules authorized rules`: method and access required"},
+		{"permission of a public method", stringSlice{"/authV1.RuleService/ListRules public rules read"}, "", Route{},
+			"invalid rbac.routes entry `/authV1.RuleService/ListRules public rules read`: only authorized methods have a permission"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			routeOverrides = tt.overrides
+			routes, err := LoadRoutes()
+			if tt.wantErr != "" {
+				assert.EqualError(t, err, tt.wantErr)
+				return
+			}
+			if assert.Nil(t, err) {
+				got, _ := routes.Lookup(tt.method)
+				assert.Equal(t, tt.want, got)
+			}
+		})
+	}
+}
+
+func Test_methodVerb(t *testing.T) {
+	tests := []struct {
+		method string
+		want   string
+	}{
+		{"CreateRule", "create"},
+		{"ListAccessRequests", "list"},
+		{"Login", "login"},
+		{"", ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.method, func(t *testing.T) {
+			assert.Equal(t, tt.want, methodVerb(tt.method))
+		})
+	}
+}
@@ -21,6 +21,12 @@ import (
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
 
+	"github.com/golang-tire/auth/internal/apps"
+	"github.com/golang-tire/auth/internal/domains"
+	"github.com/golang-tire/auth/internal/entity"
+	"github.com/golang-tire/auth/internal/pkg/conditions"
+	"github.com/golang-tire/auth/internal/relations"
+	"github.com/golang-tire/auth/internal/shadow_decisions"
 	"github.com/golang-tire/auth/internal/users"
 
 	auth "github.com/golang-tire/auth/internal/proto/v1"
@@ -35,6 +41,13 @@ const (
 	xAuthUsername  = "x-auth-user-name"
 	xAuthUserEmail = "x-auth-user-email"
 	xAuthUserUuid  = "x-auth-user-uuid"
+
+	// checkOnBehalfResource and checkOnBehalfAction are the casbin resource and action
+	// a caller needs in the domain to check permissions of another user.
+	checkOnBehalfResource = "permissions"
+	checkOnBehalfAction   = "check"
+
+	maxBatchChecks = 100
 )
 
 type Headers struct {
@@ -51,6 +64,11 @@ type Service interface {
 	VerifyToken(ctx context.Context, req *auth.VerifyTokenRequest) (*auth.VerifyTokenResponse, error)
 	RefreshToken(ctx context.Context, req *auth.RefreshTokenRequest) (*auth.RefreshTokenResponse, error)
 	Validate(ctx context.Context, req *auth.ValidateRequest) (*empty.Empty, error)
+	CheckPermission(ctx context.Context, req *auth.CheckPermissionRequest) (*auth.CheckPermissionResponse, error)
+	BatchCheck(ctx context.Context, req *auth.BatchCheckRequest) (*auth.BatchCheckResponse, error)
+	ExplainDecision(ctx context.Context, req *auth.ExplainDecisionRequest) (*auth.ExplainDecisionResponse, error)
+	GetEffectivePermissions(ctx context.Context, req *auth.GetEffectivePermissionsRequest) (*auth.GetEffectivePermissionsResponse, error)
+	ListAuthorizedSubjects(ctx context.Context, req *auth.ListAuthorizedSubjectsRequest) (*auth.ListAuthorizedSubjectsResponse, error)
 }
 
 // ValidateLoginRequest validates the LoginRequest fields.
@@ -70,9 +88,32 @@ func ValidateRegisterRequest(c *auth.RegisterRequest) error {
 	)
 }
 
+// ValidateCheckPermissionRequest validates the CheckPermissionRequest fields.
+func ValidateCheckPermissionRequest(c *auth.CheckPermissionRequest) error {
+	return validation.ValidateStruct(c,
+		validation.Field(&c.Subject, validation.Length(0, 128)),
+		validation.Field(&c.Domain, validation.Length(0, 128)),
+		validation.Field(&c.Resource, validation.Required, validation.Length(1, 128)),
+		validation.Field(&c.Action, validation.Required, validation.Length(1, 128)),
+		validation.Field(&c.Object, validation.Length(0, 128)),
+		validation.Field(&c.ClientIp, validation.Length(0, 64)),
+	)
+}
+
+// ValidateBatchCheckRequest validates the BatchCheckRequest fields.
+func ValidateBatchCheckRequest(c *auth.BatchCheckRequest) error {
+	return validation.ValidateStruct(c,
+		validation.Field(&c.Checks, validation.Required, validation.Length(1, maxBatchChecks)),
+	)
+}
+
 type service struct {
-	rbac        *rbacService
-	userService users.Service
+	rbac            *rbacService
+	userService     users.Service
+	appService      apps.Service
+	domains         domains.Service
+	shadowDecisions shadow_decisions.Service
+	relations       relations.Service
 }
 
 func (s service) Login(ctx context.Context, req *auth.LoginRequest) (*auth.LoginResponse, error) {
@@ -81,12 +122,23 @@ func (s service) Login(ctx context.Context, req *auth.LoginRequest) (*auth.Login
 		return nil, status.Error(codes.Unauthenticated, err.Error())
 	}
 
-	hostname, err := ExtractHostName(ctx)
+	domain, err := ExtractDomain(ctx)
 	if err != nil {
-		return nil, status.Errorf(codes.Unauthenticated, "hostname not found")
+		return nil, status.Error(codes.Unauthenticated, err.Error())
+	}
+
+	log.Info("domain", log.String("domain", domain.Name))
+	if !domain.Enable {
+		return nil, status.Errorf(codes.Unauthenticated, "domain %s is disabled", domain.Name)
 	}
 
-	log.Info("hostname", log.String("hostname", hostname))
+	settings, err := s.domainSettings(ctx, domain)
+	if err != nil {
+		return nil, err
+	}
+	if !settings.AllowsLoginMethod(domains.PasswordLogin) {
+		return nil, status.Errorf(codes.Unauthenticated, "password login is disabled for domain %s", domain.Name)
+	}
 
 	user, err := s.userService.GetByUsername(ctx, req.Username)
 	if err != nil {
@@ -97,7 +149,7 @@ func (s service) Login(ctx context.Context, req *auth.LoginRequest) (*auth.Login
 		return nil, status.Error(codes.Unauthenticated, "username or password is not valid")
 	}
 
-	tokens, err := createToken(user)
+	tokens, err := createToken(user, settings)
 	if err != nil {
 		log.Error("error on create user token", log.String("user", user.Username), log.Err(err))
 		return nil, status.Errorf(codes.Internal, "internal server error, create token")
@@ -121,8 +173,30 @@ func (s service) Register(ctx context.Context, req *auth.RegisterRequest) (*auth
 		return nil, err
 	}
 
+	domain, err := ExtractDomain(ctx)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+	if !domain.Enable {
+		return nil, status.Errorf(codes.PermissionDenied, "domain %s is disabled", domain.Name)
+	}
+	settings, err := s.domainSettings(ctx, domain)
+	if err != nil {
+		return nil, err
+	}
+	if !settings.SelfRegistration {
+		return nil, status.Errorf(codes.PermissionDenied, "registration is closed for domain %s", domain.Name)
+	}
+	if !settings.AllowsEmail(req.Email) {
+		return nil, status.Errorf(codes.InvalidArgument, "email domain is not allowed in domain %s", domain.Name)
+	}
+
+	var defaultRole string
+	if settings.DefaultRole != nil {
+		defaultRole = settings.DefaultRole.UUID
+	}
 	req.Password, _ = helpers.HashPassword(req.Password)
-	user, err := s.userService.Create(ctx, &auth.CreateUserRequest{
+	user, err := s.userService.Register(ctx, &auth.CreateUserRequest{
 		Firstname: req.Firstname,
 		Lastname:  req.Lastname,
 		Gender:    req.Gender,
@@ -132,7 +206,7 @@ func (s service) Register(ctx context.Context, req *auth.RegisterRequest) (*auth
 		Email:     req.Email,
 		RawData:   req.RawData,
 		Enable:    true,
-	})
+	}, defaultRole, domain.UUID)
 
 	if err != nil {
 		return nil, status.Errorf(codes.OutOfRange, "registration failed with %s", err.Error())
@@ -196,13 +270,22 @@ func (s service) RefreshToken(ctx context.Context, req *auth.RefreshTokenRequest
 		return nil, status.Errorf(codes.Unauthenticated, "user is not active")
 	}
 
+	domain, err := ExtractDomain(ctx)
+	if err != nil {
+		return nil, status.Error(codes.Unauthenticated, err.Error())
+	}
+	settings, err := s.domainSettings(ctx, domain)
+	if err != nil {
+		return nil, err
+	}
+
 	err = deleteToken(vToken)
 	if err != nil {
 		log.Error("failed to remove token", log.Err(err))
 		return nil, status.Errorf(codes.Internal, "logout failed")
 	}
 
-	tokens, err := createToken(dbUser)
+	tokens, err := createToken(dbUser, settings)
 	if err != nil {
 		log.Error("error on create user token", log.String("user", dbUser.Username), log.Err(err))
 		return nil, status.Errorf(codes.Internal, "internal server error, create token")
@@ -227,13 +310,33 @@ func (s service) Validate(ctx context.Context, req *auth.ValidateRequest) (*empt
 		return &empty.Empty{}, err
 	}
 	user := ctx.Value(userKey).(*auth.User)
+	rc := requestContext(ctx, user.RawData)
+
+	domain, err := s.domains.ResolveHost(ctx, headers.ForwardedHost)
+	if errors.Is(err, domains.ErrUnknownHost) {
+		return &empty.Empty{}, status.Error(codes.PermissionDenied, err.Error())
+	}
+	if err != nil {
+		log.Error("resolve host domain failed", log.Err(err))
+		return &empty.Empty{}, status.Errorf(codes.Internal, "resolve domain failed")
+	}
+	if !domain.Enable {
+		return &empty.Empty{}, status.Errorf(codes.PermissionDenied, "domain is disabled")
+	}
+
+	if debugDecisions.Bool() {
+		if err := s.sendDecisionHeaders(ctx, headers, domain.Name, user, rc); err != nil {
+			log.Error("explain decision failed", log.Err(err))
+		}
+	}
 
 	// check for rbac
-	ok, err := s.checkRbac(headers.ForwardedURI, headers.ForwardedHost, headers.ForwardedMethod, user)
+	ok, err := s.checkRbac(ctx, rc, headers.ForwardedURI, headers.ForwardedHost, domain.Name, headers.ForwardedMethod, user)
 	if err != nil {
 		log.Error("check rbac permission failed", log.Err(err))
 		return &empty.Empty{}, status.Errorf(codes.Internal, "check permission failed")
 	}
+	s.recordShadowDecision(ctx, headers, domain.Name, user, rc, ok)
 
 	if !ok {
 		return &empty.Empty{}, status.Errorf(codes.PermissionDenied, "forbidden")
@@ -251,19 +354,154 @@ func (s service) Validate(ctx context.Context, req *auth.ValidateRequest) (*empt
 	return &empty.Empty{}, nil
 }
 
-func (s service) checkRbac(uri, domain, method string, user *auth.User) (bool, error) {
-	resource, object, err := s.parseURI(uri)
+func (s service) CheckPermission(ctx context.Context, req *auth.CheckPermissionRequest) (*auth.CheckPermissionResponse, error) {
+	user, err := ExtractUser(ctx)
+	if err != nil {
+		return nil, status.Errorf(codes.Unauthenticated, "user not found")
+	}
+	return s.checkPermission(ctx, user, req)
+}
+
+func (s service) BatchCheck(ctx context.Context, req *auth.BatchCheckRequest) (*auth.BatchCheckResponse, error) {
+	if err := ValidateBatchCheckRequest(req); err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	user, err := ExtractUser(ctx)
+	if err != nil {
+		return nil, status.Errorf(codes.Unauthenticated, "user not found")
+	}
+
+	res := &auth.BatchCheckResponse{}
+	for _, check := range req.Checks {
+		r, err := s.checkPermission(ctx, user, check)
+		if err != nil {
+			return nil, err
+		}
+		res.Results = append(res.Results, r)
+	}
+	return res, nil
+}
+
+// checkPermission evaluates a single check for the caller.
+func (s service) checkPermission(ctx context.Context, user *auth.User, req *auth.CheckPermissionRequest) (*auth.CheckPermissionResponse, error) {
+	if err := ValidateCheckPermissionRequest(req); err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	object := req.Object
+	if object == "" {
+		object = "*"
+	}
+
+	subject, domain, err := s.resolveSubject(ctx, user, req.Subject, req.Domain)
+	if err != nil {
+		return nil, err
+	}
+
+	rc := s.subjectContext(ctx, user, subject, req.ClientIp, req.Attributes)
+	ok, err := s.rbac.enforce(ctx, rc, subject, domain, req.Resource, req.Action, object)
+	if err == nil && !ok {
+		ok, err = s.checkRelation(ctx, req.Resource, object, req.Action, subject)
+	}
+	if err != nil {
+		log.Error("check permission failed", log.Err(err))
+		return nil, status.Errorf(codes.Internal, "check permission failed")
+	}
+
+	return &auth.CheckPermissionResponse{
+		Allowed:  ok,
+		Subject:  subject,
+		Domain:   domain,
+		Resource: req.Resource,
+		Action:   req.Action,
+		Object:   object,
+	}, nil
+}
+
+// resolveSubject returns the subject and domain of a check. The domain falls back to the
+// domain of the request host, it must belong to the organization of the request, and a
+// subject other than the caller requires the on-behalf permission.
+func (s service) resolveSubject(ctx context.Context, user *auth.User, subject, domain string) (string, string, error) {
+	if domain == "" {
+		d, err := ExtractDomain(ctx)
+		if err != nil {
+			return "", "", status.Errorf(codes.InvalidArgument, "domain is required, %v", err)
+		}
+		domain = d.Name
+	}
+	// the roles and rules of another organization are not listed or explained either
+	tree, err := s.rbac.loadTree(ctx)
+	if err != nil {
+		log.Error("check domain organization failed", log.Err(err))
+		return "", "", status.Errorf(codes.Internal, "check permission failed")
+	}
+	if !inTenant(ctx, tree, domain) {
+		return "", "", status.Errorf(codes.PermissionDenied, "domain %s is not in the organization", domain)
+	}
+
+	if subject == "" || subject == user.Username {
+		return user.Username, domain, nil
+	}
+
+	ok, err := s.rbac.enforce(ctx, requestContext(ctx, user.RawData), user.Username, domain, checkOnBehalfResource, checkOnBehalfAction, subject)
+	if err != nil {
+		log.Error("check on behalf permission failed", log.Err(err))
+		return "", "", status.Errorf(codes.Internal, "check permission failed")
+	}
+	if !ok {
+		return "", "", status.Errorf(codes.PermissionDenied, "not allowed to check permissions of %s", subject)
+	}
+	return subject, domain, nil
+}
+
+// domainSettings returns the authentication settings of the domain.
+func (s service) domainSettings(ctx context.Context, domain entity.Domain) (entity.DomainSettings, error) {
+	settings, err := s.domains.Settings(ctx, domain)
+	if err != nil {
+		log.Error("load domain settings failed", log.String("domain", domain.Name), log.Err(err))
+		return entity.DomainSettings{}, status.Errorf(codes.Internal, "load domain settings failed")
+	}
+	return settings, nil
+}
+
+// checkRbac decides a forwarded request, the host selects the app routes and the domain
+// is the casbin domain of the request.
+func (s service) checkRbac(ctx context.Context, rc *conditions.Context, uri, host, domain, method string, user *auth.User) (bool, error) {
+	p, matched, err := s.resolvePermission(ctx, host, method, uri)
+	if err == errAppDisabled {
+		return false, nil
+	}
 	if err != nil {
-		log.Error("parse uri failed", log.Err(err))
-		return false, errors.New("parse forwarded uri failed")
+		return false, err
+	}
+	if !matched {
+		return unknownRouteAllowed(), nil
+	}
+
+	ok, err := s.rbac.enforce(ctx, rc, user.Username, domain, p.resource, p.action, p.object)
+	if err != nil || ok {
+		return ok, err
 	}
+	return s.checkRelation(ctx, p.resource, p.object, p.action, user.Username)
+}
 
-	return s.rbac.enforcer.Enforce(user.Username, domain, resource, method, object)
+// checkRelation consults the relation tuples for a specific object, the resource is the
+// object namespace and the action the relation. Objects of namespaces without the relation
+// defined are denied.
+func (s service) checkRelation(ctx context.Context, resource, object, action, subject string) (bool, error) {
+	if s.relations == nil || object == "*" {
+		return false, nil
+	}
+	_, allowed, err := s.relations.CheckObject(ctx, resource, object, action, subject)
+	return allowed, err
 }
 
-func (s service) parseURI(uri string) (string, string, error) {
+// parseURIPattern extracts the resource and object from uri, it also returns
+// the route pattern that produced them.
+func (s service) parseURIPattern(uri string) (string, string, string, error) {
 
-	var resource, object string
+	var resource, object, pattern string
 	for _, p := range s.rbac.regexPatterns {
 		var n = 0
 		s := p.String()
@@ -281,6 +519,7 @@ func (s service) parseURI(uri string) (string, string, error) {
 			continue
 		}
 
+		pattern = s
 		for i := range res {
 			if i == 0 {
 				continue
@@ -296,7 +535,7 @@ func (s service) parseURI(uri string) (string, string, error) {
 		}
 	}
 	if resource == "" && object == "" {
-		return "", "", errors.New("resource or object not found")
+		return "", "", "", errors.New("resource or object not found")
 	}
 
 	if resource == "" {
@@ -307,7 +546,7 @@ func (s service) parseURI(uri string) (string, string, error) {
 		object = "*"
 	}
 
-	return resource, object, nil
+	return resource, object, pattern, nil
 }
 
 func getHeaders(ctx context.Context) (*Headers, error) {
@@ -338,6 +577,6 @@ func getHeaders(ctx context.Context) (*Headers, error) {
 }
 
 // NewService creates a new auth service.
-func NewService(userService users.Service, rbac *rbacService) Service {
-	return service{rbac, userService}
+func NewService(userService users.Service, appService apps.Service, domainsService domains.Service, shadowDecisions shadow_decisions.Service, relationsService relations.Service, rbac *rbacService) Service {
+	return service{rbac, userService, appService, domainsService, shadowDecisions, relationsService}
 }
@@ -0,0 +1,48 @@
+package auth
+
+import (
+	"context"
+
+	"github.com/golang-tire/auth/internal/entity"
+	"github.com/golang-tire/auth/internal/pkg/conditions"
+	"github.com/golang-tire/pkg/log"
+
+	auth "github.com/golang-tire/auth/internal/proto/v1"
+)
+
+// recordShadowDecision evaluates the request against the shadow policy and records
+// the decision when it differs from the live one, it never changes the response.
+func (s service) recordShadowDecision(ctx context.Context, headers *Headers, domain string, user *auth.User, rc *conditions.Context, live bool) {
+	if !s.rbac.hasShadowRules() {
+		return
+	}
+
+	p, matched, err := s.resolvePermission(ctx, headers.ForwardedHost, headers.ForwardedMethod, headers.ForwardedURI)
+	if err != nil || !matched {
+		return
+	}
+	resource, object := p.resource, p.object
+
+	shadow, _, err := s.rbac.enforceEx(ctx, s.rbac.shadowEnforcer, rc, user.Username, domain, resource, p.action, object)
+	if err != nil {
+		log.Error("check shadow rbac permission failed", log.Err(err))
+		return
+	}
+	if shadow == live {
+		return
+	}
+
+	err = s.shadowDecisions.Create(ctx, entity.ShadowDecision{
+		Username:       user.Username,
+		Domain:         domain,
+		URI:            headers.ForwardedURI,
+		Method:         headers.ForwardedMethod,
+		Resource:       resource,
+		Object:         object,
+		LiveDecision:   live,
+		ShadowDecision: shadow,
+	})
+	if err != nil {
+		log.Error("record shadow decision failed", log.Err(err))
+	}
+}
@@ -9,6 +9,7 @@ import (
 	"github.com/golang-tire/pkg/config"
 	"github.com/golang-tire/pkg/session"
 
+	"github.com/golang-tire/auth/internal/entity"
 	auth "github.com/golang-tire/auth/internal/proto/v1"
 
 	"github.com/dgrijalva/jwt-go"
@@ -38,13 +39,27 @@ type tokenDetails struct {
 	Username        string
 }
 
-// saveTokens save user tokens after login
+// saveTokens save user tokens after login until they expire
 func saveTokens(tokens *tokenDetails) error {
-	err := session.Set(tokens.AccessUuid, tokens, time.Minute*time.Duration(accessTokenLife.Int()))
+	err := session.Set(tokens.AccessUuid, tokens, time.Until(time.Unix(tokens.AccessExpireAt, 0)))
 	if err != nil {
 		return err
 	}
-	return session.Set(tokens.RefreshUuid, tokens, time.Hour*time.Duration(refreshTokenLife.Int()))
+	return session.Set(tokens.RefreshUuid, tokens, time.Until(time.Unix(tokens.RefreshExpireAt, 0)))
+}
+
+// tokenLifetimes returns the access and refresh token lifetimes of the domain settings,
+// zero lifetimes use the global config.
+func tokenLifetimes(settings entity.DomainSettings) (time.Duration, time.Duration) {
+	access := time.Minute * time.Duration(accessTokenLife.Int())
+	if settings.AccessTokenLife > 0 {
+		access = time.Minute * time.Duration(settings.AccessTokenLife)
+	}
+	refresh := time.Hour * time.Duration(refreshTokenLife.Int())
+	if settings.RefreshTokenLife > 0 {
+		refresh = time.Hour * time.Duration(settings.RefreshTokenLife)
+	}
+	return access, refresh
 }
 
 // loadTokenDetails get tokens by access token
@@ -119,12 +134,13 @@ func extractTokenData(token string) (*tokenData, error) {
 	return td, nil
 }
 
-// createToken will create access and refresh token
-func createToken(user *auth.User) (*tokenDetails, error) {
+// createToken will create access and refresh token with the lifetimes of the domain settings
+func createToken(user *auth.User, settings entity.DomainSettings) (*tokenDetails, error) {
 
+	accessLife, refreshLife := tokenLifetimes(settings)
 	td := &tokenDetails{}
-	td.AccessExpireAt = time.Now().Add(time.Minute * time.Duration(accessTokenLife.Int())).Unix()
-	td.RefreshExpireAt = time.Now().Add(time.Hour * time.Duration(refreshTokenLife.Int())).Unix()
+	td.AccessExpireAt = time.Now().Add(accessLife).Unix()
+	td.RefreshExpireAt = time.Now().Add(refreshLife).Unix()
 
 	td.AccessUuid = uuid.New().String()
 	td.RefreshUuid = uuid.New().String()
@@ -0,0 +1,104 @@
+package bundles
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/golang-tire/auth/internal/pkg/helpers"
+	auth "github.com/golang-tire/auth/internal/proto/v1"
+	"github.com/golang-tire/pkg/grpcgw"
+	"github.com/golang/protobuf/ptypes/empty"
+	"github.com/grpc-ecosystem/grpc-gateway/v2/runtime"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+type API interface {
+	grpcgw.Controller
+}
+
+type api struct {
+	service Service
+	auth.BundleServiceServer
+}
+
+func (a api) InitRest(ctx context.Context, conn *grpc.ClientConn, mux *runtime.ServeMux, httpMux *http.ServeMux) {
+	cl := auth.NewBundleServiceClient(conn)
+	_ = auth.RegisterBundleServiceHandlerClient(ctx, mux, cl)
+}
+
+func (a api) InitGrpc(ctx context.Context, server *grpc.Server) {
+	auth.RegisterBundleServiceServer(server, a)
+}
+
+func (a api) ListBundles(ctx context.Context, request *auth.ListBundlesRequest) (*auth.ListBundlesResponse, error) {
+	offset, limit := helpers.GetOffsetAndLimit(request.Offset, request.Limit)
+	res, err := a.service.Query(ctx, request.Query, offset, limit)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+	return res, err
+}
+
+func (a api) GetBundle(ctx context.Context, request *auth.GetBundleRequest) (*auth.PermissionBundle, error) {
+	res, err := a.service.Get(ctx, request.Uuid)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+	return res, err
+}
+
+func (a api) CreateBundle(ctx context.Context, request *auth.CreateBundleRequest) (*auth.PermissionBundle, error) {
+	res, err := a.service.Create(ctx, request)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+	return res, err
+}
+
+func (a api) UpdateBundle(ctx context.Context, request *auth.UpdateBundleRequest) (*auth.PermissionBundle, error) {
+	res, err := a.service.Update(ctx, request)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+	return res, err
+}
+
+func (a api) DeleteBundle(ctx context.Context, request *auth.DeleteBundleRequest) (*empty.Empty, error) {
+	_, err := a.service.Delete(ctx, request.Uuid)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+	return &empty.Empty{}, nil
+}
+
+func (a api) ListRoleBundles(ctx context.Context, request *auth.ListRoleBundlesRequest) (*auth.ListRoleBundlesResponse, error) {
+	res, err := a.service.ListRoleBundles(ctx, request.RoleUuid)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+	return res, err
+}
+
+func (a api) AttachBundle(ctx context.Context, request *auth.AttachBundleRequest) (*auth.RoleBundle, error) {
+	res, err := a.service.Attach(ctx, request)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+	return res, err
+}
+
+func (a api) DetachBundle(ctx context.Context, request *auth.DetachBundleRequest) (*empty.Empty, error) {
+	_, err := a.service.Detach(ctx, request)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+	return &empty.Empty{}, nil
+}
+
+func New(srv Service) API {
+	s := api{service: srv}
+	grpcgw.RegisterController(s)
+	return s
+}
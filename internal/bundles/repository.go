@@ -0,0 +1,216 @@
+package bundles
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+
+	"github.com/golang-tire/auth/internal/pkg/db"
+
+	"github.com/golang-tire/auth/internal/entity"
+)
+
+// Repository encapsulates the logic to access permission bundles from the data source.
+type Repository interface {
+	// Get returns the bundle with the specified bundle UUID.
+	Get(ctx context.Context, uuid string) (entity.PermissionBundle, error)
+	// GetByName returns the bundle with the specified bundle name.
+	GetByName(ctx context.Context, name string) (entity.PermissionBundle, error)
+	// Count returns the number of bundles.
+	Count(ctx context.Context) (int64, error)
+	// Query returns the list of bundles with the given offset and limit.
+	Query(ctx context.Context, query string, offset, limit int64) ([]entity.PermissionBundle, int, error)
+	// Create saves a new bundle with its permissions in the storage.
+	Create(ctx context.Context, bundle entity.PermissionBundle) (string, error)
+	// Update updates the bundle with given UUID and replaces its permissions in the storage.
+	Update(ctx context.Context, bundle entity.PermissionBundle) error
+	// Delete removes the bundle with its permissions from the storage.
+	Delete(ctx context.Context, bundle entity.PermissionBundle) error
+	// IsUsed reports whether the bundle is attached to a role or part of a role template.
+	IsUsed(ctx context.Context, bundle entity.PermissionBundle) (bool, error)
+	// GetRoleBundle returns the bundle attachment with the specified UUID.
+	GetRoleBundle(ctx context.Context, uuid string) (entity.RoleBundle, error)
+	// RoleBundles returns the bundle attachments of the role.
+	RoleBundles(ctx context.Context, role entity.Role) ([]entity.RoleBundle, error)
+	// BundleRoles returns the attachments of the bundle.
+	BundleRoles(ctx context.Context, bundle entity.PermissionBundle) ([]entity.RoleBundle, error)
+	// AddRoleBundle attaches a bundle to a role in a domain, it returns the stored attachment.
+	AddRoleBundle(ctx context.Context, roleBundle entity.RoleBundle) (entity.RoleBundle, error)
+	// DeleteRoleBundle removes a bundle attachment from the storage.
+	DeleteRoleBundle(ctx context.Context, roleBundle entity.RoleBundle) error
+	// Transactional runs f in a transaction, repository calls using the given context join it.
+	Transactional(ctx context.Context, f func(ctx context.Context) error) error
+}
+
+// repository persists bundles in database
+type repository struct {
+	db *db.DB
+}
+
+// NewRepository creates a new bundle repository
+func NewRepository(db *db.DB) Repository {
+	return repository{db}
+}
+
+// Get reads the bundle with the specified UUID from the database.
+func (r repository) Get(ctx context.Context, uuid string) (entity.PermissionBundle, error) {
+	var bundle entity.PermissionBundle
+	res := r.db.With(ctx).Preload("Permissions").Where("uuid = ?", uuid).First(&bundle)
+	if res.Error != nil && res.Error == gorm.ErrRecordNotFound {
+		return entity.PermissionBundle{}, fmt.Errorf("bundle with uuid `%s` not found", uuid)
+	}
+	return bundle, res.Error
+}
+
+// GetByName reads the bundle with the specified name from the database.
+func (r repository) GetByName(ctx context.Context, name string) (entity.PermissionBundle, error) {
+	var bundle entity.PermissionBundle
+	res := r.db.With(ctx).Preload("Permissions").Where("name = ?", name).First(&bundle)
+	if res.Error != nil && res.Error == gorm.ErrRecordNotFound {
+		return entity.PermissionBundle{}, fmt.Errorf("bundle with name `%s` not found", name)
+	}
+	return bundle, res.Error
+}
+
+// Create saves a new bundle record with its permissions in the database.
+// It returns the UUID of the newly inserted bundle record.
+func (r repository) Create(ctx context.Context, bundle entity.PermissionBundle) (string, error) {
+	now := time.Now()
+	bundle.UUID = uuid.New().String()
+	bundle.CreatedAt = now
+	bundle.UpdatedAt = now
+	res := r.db.With(ctx).Create(&bundle)
+	return bundle.UUID, res.Error
+}
+
+// Update saves the changes to a bundle and replaces its permissions in the database.
+func (r repository) Update(ctx context.Context, bundle entity.PermissionBundle) error {
+	return r.db.Transactional(ctx, func(ctx context.Context) error {
+		if res := r.db.With(ctx).Where("bundle_id = ?", bundle.ID).Delete(&entity.BundlePermission{}); res.Error != nil {
+			return res.Error
+		}
+		for _, p := range bundle.Permissions {
+			p.ID = 0
+			p.BundleID = bundle.ID
+			if res := r.db.With(ctx).Create(&p); res.Error != nil {
+				return res.Error
+			}
+		}
+		return r.db.With(ctx).Omit("Permissions").Save(&bundle).Error
+	})
+}
+
+// Delete deletes a bundle with its permissions from the database.
+func (r repository) Delete(ctx context.Context, bundle entity.PermissionBundle) error {
+	return r.db.Transactional(ctx, func(ctx context.Context) error {
+		if res := r.db.With(ctx).Where("bundle_id = ?", bundle.ID).Delete(&entity.BundlePermission{}); res.Error != nil {
+			return res.Error
+		}
+		return r.db.With(ctx).Omit("Permissions").Delete(&bundle).Error
+	})
+}
+
+// Count returns the number of the bundle records in the database.
+func (r repository) Count(ctx context.Context) (int64, error) {
+	var count int64
+	res := r.db.With(ctx).Model(&entity.PermissionBundle{}).Count(&count)
+	return count, res.Error
+}
+
+// Query retrieves the bundle records with the specified offset and limit from the database.
+func (r repository) Query(ctx context.Context, query string, offset, limit int64) ([]entity.PermissionBundle, int, error) {
+	var _bundles []entity.PermissionBundle
+	var count int64
+
+	res := r.db.With(ctx).Model(&entity.PermissionBundle{})
+	if len(query) >= 1 {
+		res = res.Where("name LIKE ?", "%"+query+"%")
+	}
+	if err := res.Count(&count).Error; err != nil {
+		return nil, 0, err
+	}
+
+	res = res.
+		Limit(int(limit)).
+		Offset(int(offset)).
+		Order("id asc").
+		Preload("Permissions").
+		Find(&_bundles)
+	return _bundles, int(count), res.Error
+}
+
+// IsUsed counts the attachments and role template entries of the bundle in the database.
+func (r repository) IsUsed(ctx context.Context, bundle entity.PermissionBundle) (bool, error) {
+	var attachments, templates int64
+	if res := r.db.With(ctx).Model(&entity.RoleBundle{}).Where("bundle_id = ?", bundle.ID).Count(&attachments); res.Error != nil {
+		return false, res.Error
+	}
+	if res := r.db.With(ctx).Model(&entity.RoleTemplateBundle{}).Where("bundle_id = ?", bundle.ID).Count(&templates); res.Error != nil {
+		return false, res.Error
+	}
+	return attachments+templates > 0, nil
+}
+
+// GetRoleBundle reads the bundle attachment with the specified UUID from the database.
+func (r repository) GetRoleBundle(ctx context.Context, uuid string) (entity.RoleBundle, error) {
+	var roleBundle entity.RoleBundle
+	res := r.db.With(ctx).
+		Preload("Role").
+		Preload("Bundle").
+		Preload("Domain").
+		Where("uuid = ?", uuid).First(&roleBundle)
+	if res.Error != nil && res.Error == gorm.ErrRecordNotFound {
+		return entity.RoleBundle{}, fmt.Errorf("role bundle with uuid `%s` not found", uuid)
+	}
+	return roleBundle, res.Error
+}
+
+// RoleBundles retrieves the bundle attachments of the role from the database.
+func (r repository) RoleBundles(ctx context.Context, role entity.Role) ([]entity.RoleBundle, error) {
+	var _roleBundles []entity.RoleBundle
+	res := r.db.With(ctx).
+		Where("role_id = ?", role.ID).
+		Order("id asc").
+		Preload("Role").
+		Preload("Bundle").
+		Preload("Domain").
+		Find(&_roleBundles)
+	return _roleBundles, res.Error
+}
+
+// BundleRoles retrieves the attachments of the bundle from the database.
+func (r repository) BundleRoles(ctx context.Context, bundle entity.PermissionBundle) ([]entity.RoleBundle, error) {
+	var _roleBundles []entity.RoleBundle
+	res := r.db.With(ctx).
+		Where("bundle_id = ?", bundle.ID).
+		Order("id asc").
+		Preload("Role").
+		Preload("Bundle").
+		Preload("Domain").
+		Find(&_roleBundles)
+	return _roleBundles, res.Error
+}
+
+// AddRoleBundle saves a new bundle attachment record in the database.
+func (r repository) AddRoleBundle(ctx context.Context, roleBundle entity.RoleBundle) (entity.RoleBundle, error) {
+	now := time.Now()
+	roleBundle.UUID = uuid.New().String()
+	roleBundle.CreatedAt = now
+	roleBundle.UpdatedAt = now
+	res := r.db.With(ctx).Omit("Role", "Bundle", "Domain").Create(&roleBundle)
+	return roleBundle, res.Error
+}
+
+// DeleteRoleBundle deletes a bundle attachment from the database.
+func (r repository) DeleteRoleBundle(ctx context.Context, roleBundle entity.RoleBundle) error {
+	res := r.db.With(ctx).Omit("Role", "Bundle", "Domain").Delete(&roleBundle)
+	return res.Error
+}
+
+// Transactional runs f in a database transaction.
+func (r repository) Transactional(ctx context.Context, f func(ctx context.Context) error) error {
+	return r.db.Transactional(ctx, f)
+}
@@ -0,0 +1,146 @@
+package bundles
+
+import (
+	"context"
+	"errors"
+
+	"github.com/google/uuid"
+
+	"github.com/golang-tire/auth/internal/entity"
+)
+
+var errCRUD = errors.New("error crud")
+var errNotFound = errors.New("not found")
+
+func NewMockRepository() *mockRepository {
+	return &mockRepository{templated: map[uint]bool{}}
+}
+
+type mockRepository struct {
+	items       []entity.PermissionBundle
+	roleBundles []entity.RoleBundle
+	lastID      uint
+	// templated are the bundles which are part of a role template
+	templated map[uint]bool
+}
+
+func (m mockRepository) Get(ctx context.Context, uuid string) (entity.PermissionBundle, error) {
+	for _, item := range m.items {
+		if item.UUID == uuid {
+			return item, nil
+		}
+	}
+	return entity.PermissionBundle{}, errNotFound
+}
+
+func (m mockRepository) GetByName(ctx context.Context, name string) (entity.PermissionBundle, error) {
+	for _, item := range m.items {
+		if item.Name == name {
+			return item, nil
+		}
+	}
+	return entity.PermissionBundle{}, errNotFound
+}
+
+func (m mockRepository) Count(ctx context.Context) (int64, error) {
+	return int64(len(m.items)), nil
+}
+
+func (m mockRepository) Query(ctx context.Context, query string, offset, limit int64) ([]entity.PermissionBundle, int, error) {
+	return m.items, len(m.items), nil
+}
+
+func (m *mockRepository) Create(ctx context.Context, bundle entity.PermissionBundle) (string, error) {
+	bundle.UUID = uuid.New().String()
+	if bundle.Name == "error" {
+		return bundle.UUID, errCRUD
+	}
+	m.lastID++
+	bundle.ID = m.lastID
+	m.items = append(m.items, bundle)
+	return bundle.UUID, nil
+}
+
+func (m *mockRepository) Update(ctx context.Context, bundle entity.PermissionBundle) error {
+	if bundle.Name == "error" {
+		return errCRUD
+	}
+	for i, item := range m.items {
+		if item.UUID == bundle.UUID {
+			m.items[i] = bundle
+			break
+		}
+	}
+	return nil
+}
+
+func (m *mockRepository) Delete(ctx context.Context, bundle entity.PermissionBundle) error {
+	for i, item := range m.items {
+		if item.UUID == bundle.UUID {
+			m.items[i] = m.items[len(m.items)-1]
+			m.items = m.items[:len(m.items)-1]
+			break
+		}
+	}
+	return nil
+}
+
+func (m mockRepository) IsUsed(ctx context.Context, bundle entity.PermissionBundle) (bool, error) {
+	for _, rb := range m.roleBundles {
+		if rb.BundleID == bundle.ID {
+			return true, nil
+		}
+	}
+	return m.templated[bundle.ID], nil
+}
+
+func (m mockRepository) GetRoleBundle(ctx context.Context, uuid string) (entity.RoleBundle, error) {
+	for _, rb := range m.roleBundles {
+		if rb.UUID == uuid {
+			return rb, nil
+		}
+	}
+	return entity.RoleBundle{}, errNotFound
+}
+
+func (m mockRepository) RoleBundles(ctx context.Context, role entity.Role) ([]entity.RoleBundle, error) {
+	var items []entity.RoleBundle
+	for _, rb := range m.roleBundles {
+		if rb.RoleID == role.ID {
+			items = append(items, rb)
+		}
+	}
+	return items, nil
+}
+
+func (m mockRepository) BundleRoles(ctx context.Context, bundle entity.PermissionBundle) ([]entity.RoleBundle, error) {
+	var items []entity.RoleBundle
+	for _, rb := range m.roleBundles {
+		if rb.BundleID == bundle.ID {
+			items = append(items, rb)
+		}
+	}
+	return items, nil
+}
+
+func (m *mockRepository) AddRoleBundle(ctx context.Context, roleBundle entity.RoleBundle) (entity.RoleBundle, error) {
+	roleBundle.UUID = uuid.New().String()
+	m.lastID++
+	roleBundle.ID = m.lastID
+	m.roleBundles = append(m.roleBundles, roleBundle)
+	return roleBundle, nil
+}
+
+func (m *mockRepository) DeleteRoleBundle(ctx context.Context, roleBundle entity.RoleBundle) error {
+	for i, rb := range m.roleBundles {
+		if rb.UUID == roleBundle.UUID {
+			m.roleBundles = append(m.roleBundles[:i], m.roleBundles[i+1:]...)
+			break
+		}
+	}
+	return nil
+}
+
+func (m mockRepository) Transactional(ctx context.Context, f func(ctx context.Context) error) error {
+	return f(ctx)
+}
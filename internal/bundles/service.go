@@ -0,0 +1,352 @@
+package bundles
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"time"
+
+	validation "github.com/go-ozzo/ozzo-validation/v4"
+	"github.com/go-ozzo/ozzo-validation/v4/is"
+
+	"github.com/golang-tire/auth/internal/domains"
+	"github.com/golang-tire/auth/internal/entity"
+	"github.com/golang-tire/auth/internal/pkg/conditions"
+	"github.com/golang-tire/auth/internal/pkg/pubsub"
+	"github.com/golang-tire/auth/internal/pkg/scope"
+	auth "github.com/golang-tire/auth/internal/proto/v1"
+	"github.com/golang-tire/auth/internal/roles"
+	"github.com/golang-tire/auth/internal/rules"
+)
+
+// Service encapsulates use case logic for permission bundles.
+type Service interface {
+	Get(ctx context.Context, uuid string) (*auth.PermissionBundle, error)
+	Query(ctx context.Context, query string, offset, limit int64) (*auth.ListBundlesResponse, error)
+	Count(ctx context.Context) (int64, error)
+	Create(ctx context.Context, req *auth.CreateBundleRequest) (*auth.PermissionBundle, error)
+	Update(ctx context.Context, req *auth.UpdateBundleRequest) (*auth.PermissionBundle, error)
+	Delete(ctx context.Context, uuid string) (*auth.PermissionBundle, error)
+
+	ListRoleBundles(ctx context.Context, roleUUID string) (*auth.ListRoleBundlesResponse, error)
+	Attach(ctx context.Context, req *auth.AttachBundleRequest) (*auth.RoleBundle, error)
+	Detach(ctx context.Context, req *auth.DetachBundleRequest) (*auth.RoleBundle, error)
+	// AttachBundles attaches the bundles to the role in the domain and generates their rules,
+	// it joins the transaction of the context and the caller publishes the policy change.
+	AttachBundles(ctx context.Context, role entity.Role, domain entity.Domain, bundles []entity.PermissionBundle) ([]entity.RoleBundle, error)
+}
+
+// ValidateCreateRequest validates the CreateBundleRequest fields.
+func ValidateCreateRequest(c *auth.CreateBundleRequest) error {
+	return validation.ValidateStruct(c,
+		validation.Field(&c.Name, validation.Required, validation.Length(0, 128)),
+		validation.Field(&c.Description, validation.Length(0, 512)),
+		validation.Field(&c.Permissions, validation.Required, validation.By(validPermissions)),
+	)
+}
+
+// ValidateUpdateRequest validates the UpdateBundleRequest fields.
+func ValidateUpdateRequest(u *auth.UpdateBundleRequest) error {
+	return validation.ValidateStruct(u,
+		validation.Field(&u.Name, validation.Required, validation.Length(0, 128)),
+		validation.Field(&u.Description, validation.Length(0, 512)),
+		validation.Field(&u.Permissions, validation.Required, validation.By(validPermissions)),
+	)
+}
+
+// ValidateAttachRequest validates the AttachBundleRequest fields.
+func ValidateAttachRequest(c *auth.AttachBundleRequest) error {
+	return validation.ValidateStruct(c,
+		validation.Field(&c.RoleUuid, validation.Required, is.UUID),
+		validation.Field(&c.BundleUuid, validation.Required, is.UUID),
+		validation.Field(&c.DomainUuid, validation.Required, is.UUID),
+	)
+}
+
+// validPermissions validates the permissions of a bundle like the fields of a rule.
+func validPermissions(value interface{}) error {
+	items, _ := value.([]*auth.BundlePermission)
+	errs := validation.Errors{}
+	for i, p := range items {
+		if p == nil {
+			errs[strconv.Itoa(i)] = fmt.Errorf("permission is required")
+			continue
+		}
+		err := validation.ValidateStruct(p,
+			validation.Field(&p.Resource, validation.Required, validation.Length(0, 128)),
+			validation.Field(&p.Action, validation.Required, validation.Length(0, 128)),
+			validation.Field(&p.Object, validation.Required, validation.Length(0, 128)),
+			validation.Field(&p.Condition, validation.Length(0, 1024), validation.By(validCondition)),
+		)
+		if err != nil {
+			errs[strconv.Itoa(i)] = err
+		}
+	}
+	return errs.Filter()
+}
+
+func validCondition(value interface{}) error {
+	cond, _ := value.(string)
+	return conditions.Validate(cond)
+}
+
+type service struct {
+	repo         Repository
+	rolesRepo    roles.Repository
+	domainsRepo  domains.Repository
+	rulesService rules.Service
+}
+
+// NewService creates a new bundle service.
+func NewService(repo Repository, rolesRepo roles.Repository, domainsRepo domains.Repository, rulesService rules.Service) Service {
+	return service{repo, rolesRepo, domainsRepo, rulesService}
+}
+
+// Get returns the bundle with the specified the bundle UUID.
+func (s service) Get(ctx context.Context, uuid string) (*auth.PermissionBundle, error) {
+	bundle, err := s.repo.Get(ctx, uuid)
+	if err != nil {
+		return nil, err
+	}
+	return bundle.ToProto(), nil
+}
+
+// Create creates a new bundle.
+func (s service) Create(ctx context.Context, req *auth.CreateBundleRequest) (*auth.PermissionBundle, error) {
+	if err := ValidateCreateRequest(req); err != nil {
+		return nil, err
+	}
+	if err := scope.FromContext(ctx).CheckGlobal("create bundles"); err != nil {
+		return nil, err
+	}
+	if _, err := s.repo.GetByName(ctx, req.Name); err == nil {
+		return nil, fmt.Errorf("bundle `%s` already exists", req.Name)
+	}
+	id, err := s.repo.Create(ctx, entity.PermissionBundle{
+		Name:        req.Name,
+		Description: req.Description,
+		Permissions: permissions(req.Permissions),
+	})
+	if err != nil {
+		return nil, err
+	}
+	return s.Get(ctx, id)
+}
+
+// Update updates the bundle with the specified UUID, the rules of every role it is
+// attached to follow the new permissions.
+func (s service) Update(ctx context.Context, req *auth.UpdateBundleRequest) (*auth.PermissionBundle, error) {
+	if err := ValidateUpdateRequest(req); err != nil {
+		return nil, err
+	}
+	// the bundle changes the rules of roles in every domain it is attached in
+	if err := scope.FromContext(ctx).CheckGlobal("update bundles"); err != nil {
+		return nil, err
+	}
+
+	bundle, err := s.repo.Get(ctx, req.Uuid)
+	if err != nil {
+		return nil, err
+	}
+	if other, err := s.repo.GetByName(ctx, req.Name); err == nil && other.UUID != bundle.UUID {
+		return nil, fmt.Errorf("bundle `%s` already exists", req.Name)
+	}
+	bundle.Name = req.Name
+	bundle.Description = req.Description
+	bundle.Permissions = permissions(req.Permissions)
+	bundle.UpdatedAt = time.Now()
+
+	var attached []entity.RoleBundle
+	err = s.repo.Transactional(ctx, func(ctx context.Context) error {
+		if err := s.repo.Update(ctx, bundle); err != nil {
+			return err
+		}
+		attached, err = s.repo.BundleRoles(ctx, bundle)
+		if err != nil {
+			return err
+		}
+		if len(attached) == 0 {
+			return nil
+		}
+		for i := range attached {
+			attached[i].Bundle = bundle
+		}
+		return s.rulesService.SyncBundleRules(ctx, attached, "update bundle "+bundle.UUID)
+	})
+	if err != nil {
+		return nil, err
+	}
+	if len(attached) > 0 {
+		pubsub.Notify(pubsub.RuleChange, "update bundle "+bundle.UUID)
+	}
+	return s.Get(ctx, bundle.UUID)
+}
+
+// Delete deletes the bundle with the specified UUID, bundles which are attached to a role or
+// part of a role template cannot be deleted.
+func (s service) Delete(ctx context.Context, uuid string) (*auth.PermissionBundle, error) {
+	if err := scope.FromContext(ctx).CheckGlobal("delete bundles"); err != nil {
+		return nil, err
+	}
+	bundle, err := s.repo.Get(ctx, uuid)
+	if err != nil {
+		return nil, err
+	}
+	used, err := s.repo.IsUsed(ctx, bundle)
+	if err != nil {
+		return nil, err
+	}
+	if used {
+		return nil, fmt.Errorf("bundle `%s` is attached to roles or part of role templates", bundle.Name)
+	}
+	if err = s.repo.Delete(ctx, bundle); err != nil {
+		return nil, err
+	}
+	return bundle.ToProto(), nil
+}
+
+// Count returns the number of bundles.
+func (s service) Count(ctx context.Context) (int64, error) {
+	return s.repo.Count(ctx)
+}
+
+// Query returns the bundles with the specified offset and limit.
+func (s service) Query(ctx context.Context, query string, offset, limit int64) (*auth.ListBundlesResponse, error) {
+	items, count, err := s.repo.Query(ctx, query, offset, limit)
+	if err != nil {
+		return nil, err
+	}
+	return &auth.ListBundlesResponse{
+		Bundles:    entity.PermissionBundleToProtoList(items),
+		TotalCount: int64(count),
+		Offset:     offset,
+		Limit:      limit,
+	}, nil
+}
+
+// ListRoleBundles returns the bundles attached to the role, domain admins only see the
+// attachments in the domains they administer.
+func (s service) ListRoleBundles(ctx context.Context, roleUUID string) (*auth.ListRoleBundlesResponse, error) {
+	role, err := s.rolesRepo.Get(ctx, roleUUID)
+	if err != nil {
+		return nil, err
+	}
+	items, err := s.repo.RoleBundles(ctx, role)
+	if err != nil {
+		return nil, err
+	}
+	sc := scope.FromContext(ctx)
+	res := &auth.ListRoleBundlesResponse{}
+	for _, rb := range items {
+		if sc.Allows(rb.Domain.Name) {
+			res.RoleBundles = append(res.RoleBundles, rb.ToProto())
+		}
+	}
+	return res, nil
+}
+
+// Attach attaches the bundle to the role in the domain.
+func (s service) Attach(ctx context.Context, req *auth.AttachBundleRequest) (*auth.RoleBundle, error) {
+	if err := ValidateAttachRequest(req); err != nil {
+		return nil, err
+	}
+	domain, err := s.domainsRepo.Get(ctx, req.DomainUuid)
+	if err != nil {
+		return nil, err
+	}
+	if err := scope.FromContext(ctx).Check(domain.Name); err != nil {
+		return nil, err
+	}
+	role, err := s.rolesRepo.Get(ctx, req.RoleUuid)
+	if err != nil {
+		return nil, err
+	}
+	bundle, err := s.repo.Get(ctx, req.BundleUuid)
+	if err != nil {
+		return nil, err
+	}
+
+	var attached []entity.RoleBundle
+	err = s.repo.Transactional(ctx, func(ctx context.Context) error {
+		attached, err = s.AttachBundles(ctx, role, domain, []entity.PermissionBundle{bundle})
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	pubsub.Notify(pubsub.RuleChange, "attach bundle "+bundle.UUID)
+	return attached[0].ToProto(), nil
+}
+
+// AttachBundles attaches the bundles to the role in the domain and generates their rules.
+func (s service) AttachBundles(ctx context.Context, role entity.Role, domain entity.Domain, bundles []entity.PermissionBundle) ([]entity.RoleBundle, error) {
+	existing, err := s.repo.RoleBundles(ctx, role)
+	if err != nil {
+		return nil, err
+	}
+	var items []entity.RoleBundle
+	for _, bundle := range bundles {
+		for _, rb := range existing {
+			if rb.BundleID == bundle.ID && rb.DomainID == domain.ID {
+				return nil, fmt.Errorf("bundle `%s` is already attached to role `%s` in domain `%s`", bundle.Name, role.Title, domain.Name)
+			}
+		}
+		rb, err := s.repo.AddRoleBundle(ctx, entity.RoleBundle{
+			RoleID:   role.ID,
+			Role:     role,
+			BundleID: bundle.ID,
+			Bundle:   bundle,
+			DomainID: domain.ID,
+			Domain:   domain,
+		})
+		if err != nil {
+			return nil, err
+		}
+		existing = append(existing, rb)
+		items = append(items, rb)
+	}
+	if err := s.rulesService.SyncBundleRules(ctx, items, "attach bundles to role "+role.UUID); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+// Detach detaches the bundle from the role and removes the rules generated from it.
+func (s service) Detach(ctx context.Context, req *auth.DetachBundleRequest) (*auth.RoleBundle, error) {
+	roleBundle, err := s.repo.GetRoleBundle(ctx, req.RoleBundleUuid)
+	if err != nil {
+		return nil, err
+	}
+	if roleBundle.Role.UUID != req.RoleUuid {
+		return nil, fmt.Errorf("role bundle `%s` does not belong to role `%s`", roleBundle.UUID, req.RoleUuid)
+	}
+	if err := scope.FromContext(ctx).Check(roleBundle.Domain.Name); err != nil {
+		return nil, err
+	}
+	err = s.repo.Transactional(ctx, func(ctx context.Context) error {
+		if err := s.rulesService.RemoveBundleRules(ctx, roleBundle, "detach bundle "+roleBundle.UUID); err != nil {
+			return err
+		}
+		return s.repo.DeleteRoleBundle(ctx, roleBundle)
+	})
+	if err != nil {
+		return nil, err
+	}
+	pubsub.Notify(pubsub.RuleChange, "detach bundle "+roleBundle.UUID)
+	return roleBundle.ToProto(), nil
+}
+
+// permissions converts the permissions of a request to bundle permissions.
+func permissions(items []*auth.BundlePermission) []entity.BundlePermission {
+	var r []entity.BundlePermission
+	for _, p := range items {
+		r = append(r, entity.BundlePermission{
+			Resource:  p.Resource,
+			Action:    p.Action,
+			Object:    p.Object,
+			Effect:    p.Effect.String(),
+			Condition: p.Condition,
+		})
+	}
+	return r
+}
@@ -0,0 +1,155 @@
+package bundles
+
+import (
+	"context"
+	"testing"
+
+	"github.com/golang-tire/auth/internal/apps"
+	"github.com/golang-tire/auth/internal/domains"
+	"github.com/golang-tire/auth/internal/entity"
+	"github.com/golang-tire/auth/internal/groups"
+	"github.com/golang-tire/auth/internal/pkg/scope"
+	auth "github.com/golang-tire/auth/internal/proto/v1"
+	"github.com/golang-tire/auth/internal/roles"
+	"github.com/golang-tire/auth/internal/rules"
+	"github.com/golang-tire/auth/internal/users"
+	"github.com/stretchr/testify/assert"
+)
+
+var readPermissions = []*auth.BundlePermission{
+	{Resource: "articles", Action: "get", Object: "*", Effect: auth.Effect_ALLOW},
+	{Resource: "articles", Action: "list", Object: "*", Effect: auth.Effect_ALLOW},
+}
+
+func TestCreateBundleRequest_Validate(t *testing.T) {
+	tests := []struct {
+		name      string
+		model     *auth.CreateBundleRequest
+		wantError bool
+	}{
+		{"success", &auth.CreateBundleRequest{Name: "articles-read", Permissions: readPermissions}, false},
+		{"required", &auth.CreateBundleRequest{Name: "", Permissions: readPermissions}, true},
+		{"no permissions", &auth.CreateBundleRequest{Name: "articles-read"}, true},
+		{"permission without action", &auth.CreateBundleRequest{Name: "articles-read", Permissions: []*auth.BundlePermission{{Resource: "articles", Object: "*"}}}, true},
+		{"invalid condition", &auth.CreateBundleRequest{Name: "articles-read", Permissions: []*auth.BundlePermission{{Resource: "articles", Action: "get", Object: "*", Condition: "user.age >"}}}, true},
+		{"too long", &auth.CreateBundleRequest{Name: "1234567890123456789012345678901234567890123456789012345678901234567890123456789012345678901234567890123456789012345678901234567890", Permissions: readPermissions}, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := ValidateCreateRequest(tt.model)
+			assert.Equal(t, tt.wantError, err != nil)
+		})
+	}
+}
+
+func TestAttachBundleRequest_Validate(t *testing.T) {
+	id := "4e5c3a52-3b4f-4c38-9d61-4a4e1b9f2a10"
+	tests := []struct {
+		name      string
+		model     *auth.AttachBundleRequest
+		wantError bool
+	}{
+		{"success", &auth.AttachBundleRequest{RoleUuid: id, BundleUuid: id, DomainUuid: id}, false},
+		{"required", &auth.AttachBundleRequest{RoleUuid: id, BundleUuid: id}, true},
+		{"invalid uuid", &auth.AttachBundleRequest{RoleUuid: id, BundleUuid: "articles-read", DomainUuid: id}, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := ValidateAttachRequest(tt.model)
+			assert.Equal(t, tt.wantError, err != nil)
+		})
+	}
+}
+
+func Test_service_CRUD(t *testing.T) {
+	rolesRepo := roles.NewMockRepository()
+	domainsRepo := domains.NewMockRepository()
+	rulesSrv := rules.NewService(rules.NewMockRepository(), domainsRepo, domains.NewService(domainsRepo, rolesRepo), rolesRepo, users.NewMockRepository(), apps.NewMockRepository(), groups.NewMockRepository())
+	s := NewService(NewMockRepository(), rolesRepo, domainsRepo, rulesSrv)
+	ctx := context.Background()
+
+	bundle, err := s.Create(ctx, &auth.CreateBundleRequest{Name: "articles-read", Permissions: readPermissions})
+	assert.Nil(t, err)
+	assert.NotEmpty(t, bundle.Uuid)
+	assert.Len(t, bundle.Permissions, 2)
+
+	_, err = s.Create(ctx, &auth.CreateBundleRequest{Name: "articles-read", Permissions: readPermissions})
+	assert.NotNil(t, err)
+	_, err = s.Create(ctx, &auth.CreateBundleRequest{Name: "error", Permissions: readPermissions})
+	assert.Equal(t, errCRUD, err)
+	count, _ := s.Count(ctx)
+	assert.Equal(t, int64(1), count)
+
+	bundle, err = s.Update(ctx, &auth.UpdateBundleRequest{Uuid: bundle.Uuid, Name: "articles-reader", Description: "read articles", Permissions: readPermissions[:1]})
+	assert.Nil(t, err)
+	assert.Equal(t, "articles-reader", bundle.Name)
+	assert.Len(t, bundle.Permissions, 1)
+
+	// bundles apply to every domain, domain admins cannot change them
+	adminCtx := scope.NewContext(ctx, scope.Domains("foo.bar"))
+	_, err = s.Create(adminCtx, &auth.CreateBundleRequest{Name: "articles-write", Permissions: readPermissions})
+	assert.NotNil(t, err)
+	_, err = s.Delete(adminCtx, bundle.Uuid)
+	assert.NotNil(t, err)
+
+	_, err = s.Delete(ctx, bundle.Uuid)
+	assert.Nil(t, err)
+	_, err = s.Get(ctx, bundle.Uuid)
+	assert.NotNil(t, err)
+}
+
+func Test_service_Attach(t *testing.T) {
+	rulesRepo := rules.NewMockRepository()
+	rolesRepo := roles.NewMockRepository()
+	domainsRepo := domains.NewMockRepository()
+	rulesSrv := rules.NewService(rulesRepo, domainsRepo, domains.NewService(domainsRepo, rolesRepo), rolesRepo, users.NewMockRepository(), apps.NewMockRepository(), groups.NewMockRepository())
+	s := NewService(NewMockRepository(), rolesRepo, domainsRepo, rulesSrv)
+	ctx := context.Background()
+
+	roleUUID, _ := rolesRepo.Create(ctx, entity.Role{Title: "reader", Enable: true})
+	domainUUID, _ := domainsRepo.Create(ctx, entity.Domain{Name: "foo.bar", Enable: true})
+	otherUUID, _ := domainsRepo.Create(ctx, entity.Domain{Name: "other.com", Enable: true})
+	bundle, err := s.Create(ctx, &auth.CreateBundleRequest{Name: "articles-read", Permissions: readPermissions})
+	assert.Nil(t, err)
+
+	// the bundle generates a rule per permission
+	roleBundle, err := s.Attach(ctx, &auth.AttachBundleRequest{RoleUuid: roleUUID, BundleUuid: bundle.Uuid, DomainUuid: domainUUID})
+	assert.Nil(t, err)
+	assert.Equal(t, "articles-read", roleBundle.Bundle)
+	assert.Equal(t, "foo.bar", roleBundle.Domain)
+	ruleList, _, _ := rulesRepo.Query(ctx, "", 0, 10)
+	assert.Len(t, ruleList, 2)
+	assert.Equal(t, "reader", ruleList[0].Role.Title)
+	assert.Equal(t, "foo.bar", ruleList[0].Domain.Name)
+
+	_, err = s.Attach(ctx, &auth.AttachBundleRequest{RoleUuid: roleUUID, BundleUuid: bundle.Uuid, DomainUuid: domainUUID})
+	assert.NotNil(t, err)
+
+	// generated rules change with the bundle only
+	_, err = rulesSrv.Delete(ctx, ruleList[0].UUID)
+	assert.NotNil(t, err)
+	_, err = s.Delete(ctx, bundle.Uuid)
+	assert.NotNil(t, err)
+
+	_, err = s.Update(ctx, &auth.UpdateBundleRequest{Uuid: bundle.Uuid, Name: "articles-read", Permissions: readPermissions[:1]})
+	assert.Nil(t, err)
+	ruleList, _, _ = rulesRepo.Query(ctx, "", 0, 10)
+	assert.Len(t, ruleList, 1)
+	assert.Equal(t, "get", ruleList[0].Action)
+
+	// domain admins only attach in their domains
+	adminCtx := scope.NewContext(ctx, scope.Domains("foo.bar"))
+	_, err = s.Attach(adminCtx, &auth.AttachBundleRequest{RoleUuid: roleUUID, BundleUuid: bundle.Uuid, DomainUuid: otherUUID})
+	assert.NotNil(t, err)
+	_, err = s.Attach(ctx, &auth.AttachBundleRequest{RoleUuid: roleUUID, BundleUuid: bundle.Uuid, DomainUuid: otherUUID})
+	assert.Nil(t, err)
+	list, err := s.ListRoleBundles(adminCtx, roleUUID)
+	assert.Nil(t, err)
+	assert.Len(t, list.RoleBundles, 1)
+
+	_, err = s.Detach(adminCtx, &auth.DetachBundleRequest{RoleUuid: roleUUID, RoleBundleUuid: roleBundle.Uuid})
+	assert.Nil(t, err)
+	ruleList, _, _ = rulesRepo.Query(ctx, "", 0, 10)
+	assert.Len(t, ruleList, 1)
+	assert.Equal(t, "other.com", ruleList[0].Domain.Name)
+}
@@ -34,7 +34,7 @@ func (a api) InitGrpc(ctx context.Context, server *grpc.Server) {
 
 func (a api) ListDomains(ctx context.Context, request *auth.ListDomainsRequest) (*auth.ListDomainsResponse, error) {
 	offset, limit := helpers.GetOffsetAndLimit(request.Offset, request.Limit)
-	res, err := a.service.Query(ctx, request.Query, offset, limit)
+	res, err := a.service.Query(ctx, request.Query, request.SubtreeUuid, offset, limit)
 	if err != nil {
 		return nil, status.Error(codes.InvalidArgument, err.Error())
 	}
@@ -73,6 +73,38 @@ func (a api) DeleteDomain(ctx context.Context, request *auth.DeleteDomainRequest
 	return &empty.Empty{}, err
 }
 
+func (a api) GetDomainSettings(ctx context.Context, request *auth.GetDomainSettingsRequest) (*auth.DomainSettings, error) {
+	res, err := a.service.GetSettings(ctx, request.Uuid)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+	return res, err
+}
+
+func (a api) CreateDomainSettings(ctx context.Context, request *auth.CreateDomainSettingsRequest) (*auth.DomainSettings, error) {
+	res, err := a.service.CreateSettings(ctx, request)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+	return res, err
+}
+
+func (a api) UpdateDomainSettings(ctx context.Context, request *auth.UpdateDomainSettingsRequest) (*auth.DomainSettings, error) {
+	res, err := a.service.UpdateSettings(ctx, request)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+	return res, err
+}
+
+func (a api) DeleteDomainSettings(ctx context.Context, request *auth.DeleteDomainSettingsRequest) (*empty.Empty, error) {
+	_, err := a.service.DeleteSettings(ctx, request.Uuid)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+	return &empty.Empty{}, err
+}
+
 func New(srv Service) API {
 	s := api{service: srv}
 	grpcgw.RegisterController(s)
@@ -0,0 +1,136 @@
+package domains
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/ThreeDotsLabs/watermill/message"
+	"github.com/go-ozzo/ozzo-validation/v4/is"
+
+	"github.com/golang-tire/auth/internal/entity"
+	"github.com/golang-tire/auth/internal/pkg/helpers"
+	"github.com/golang-tire/auth/internal/pkg/pubsub"
+	"github.com/golang-tire/auth/internal/pkg/tenant"
+)
+
+// wildcardPrefix starts the host patterns matching any single label, e.g. *.tenant.example.com.
+const wildcardPrefix = "*."
+
+// ErrUnknownHost is returned when no domain is named after a host or has it as an alias.
+var ErrUnknownHost = errors.New("unknown host")
+
+// hostTable resolves hosts to their domains, names and aliases are matched before the
+// wildcard patterns. It keeps the domain tree as well, both change with the domains.
+type hostTable struct {
+	exact map[string]entity.Domain
+	// wildcards are keyed by the pattern without its *, e.g. .tenant.example.com
+	wildcards map[string]entity.Domain
+	tree      Tree
+}
+
+func newHostTable(items []entity.Domain) *hostTable {
+	t := &hostTable{exact: map[string]entity.Domain{}, wildcards: map[string]entity.Domain{}, tree: NewTree(items)}
+	for _, domain := range items {
+		t.exact[helpers.NormalizeHost(domain.Name)] = domain
+		for _, host := range domain.HostList() {
+			if strings.HasPrefix(host, wildcardPrefix) {
+				t.wildcards[host[1:]] = domain
+				continue
+			}
+			t.exact[host] = domain
+		}
+	}
+	return t
+}
+
+// resolve returns the domain of a host.
+func (t *hostTable) resolve(host string) (entity.Domain, bool) {
+	host = helpers.NormalizeHost(host)
+	if domain, ok := t.exact[host]; ok {
+		return domain, true
+	}
+	if i := strings.Index(host, "."); i > 0 {
+		domain, ok := t.wildcards[host[i:]]
+		return domain, ok
+	}
+	return entity.Domain{}, false
+}
+
+// validHost validates a host alias or wildcard pattern.
+func validHost(value interface{}) error {
+	host, _ := value.(string)
+	return is.Host.Validate(strings.TrimPrefix(host, wildcardPrefix))
+}
+
+// domainHosts normalizes the hosts of a domain, it fails on hosts which name or belong to
+// another domain. The name of the domain cannot be taken by another domain either, hosts
+// and names select the domain and organization of every request.
+func (s service) domainHosts(ctx context.Context, domainUUID, name string, hosts []string) (string, error) {
+	items, err := s.repo.All(tenant.All(ctx))
+	if err != nil {
+		return "", err
+	}
+	owners := map[string]entity.Domain{}
+	for _, domain := range items {
+		owners[helpers.NormalizeHost(domain.Name)] = domain
+		for _, host := range domain.HostList() {
+			owners[host] = domain
+		}
+	}
+	if owner, ok := owners[helpers.NormalizeHost(name)]; ok && owner.UUID != domainUUID {
+		return "", fmt.Errorf("name `%s` belongs to domain `%s`", name, owner.Name)
+	}
+
+	var list []string
+	for _, host := range hosts {
+		host = helpers.NormalizeHost(host)
+		if owner, ok := owners[host]; ok && owner.UUID != domainUUID {
+			return "", fmt.Errorf("host `%s` belongs to domain `%s`", host, owner.Name)
+		}
+		list = append(list, host)
+	}
+	return strings.Join(list, ","), nil
+}
+
+// ResolveHost returns the domain of a forwarded host or authority, the host is matched
+// without its port against the domain names, host aliases and wildcard patterns.
+func (s service) ResolveHost(ctx context.Context, host string) (entity.Domain, error) {
+	t, err := s.table(ctx)
+	if err != nil {
+		return entity.Domain{}, err
+	}
+	domain, ok := t.resolve(host)
+	if !ok {
+		return entity.Domain{}, fmt.Errorf("%w `%s`, it is not a domain name or host alias", ErrUnknownHost, helpers.NormalizeHost(host))
+	}
+	return domain, nil
+}
+
+// table returns the cached host table of the domains of every organization, it is built
+// again after the domains change.
+func (s service) table(ctx context.Context) (*hostTable, error) {
+	if t, ok := s.hosts.Get().(*hostTable); ok {
+		return t, nil
+	}
+	items, err := s.repo.All(tenant.All(ctx))
+	if err != nil {
+		return nil, err
+	}
+	t := newHostTable(items)
+	s.hosts.Set(t)
+	return t, nil
+}
+
+func (s service) OnDomainChange(msg *message.Message) error {
+	s.hosts.Reset()
+	msg.Ack()
+	return nil
+}
+
+// hostsChanged drops the host table and notifies the other instances.
+func (s service) hostsChanged(reason string) {
+	s.hosts.Reset()
+	pubsub.Notify(pubsub.DomainChange, reason)
+}
@@ -24,12 +24,25 @@ type Repository interface {
 	Count(ctx context.Context) (int64, error)
 	// Query returns the list of domains with the given offset and limit.
 	Query(ctx context.Context, query string, offset, limit int64) ([]entity.Domain, int, error)
+	// QueryInDomains returns the list of the named domains with the given offset and limit.
+	QueryInDomains(ctx context.Context, query string, names []string, offset, limit int64) ([]entity.Domain, int, error)
 	// Create saves a new domain in the storage.
 	Create(ctx context.Context, domain entity.Domain) (string, error)
 	// Update updates the domain with given UUID in the storage.
 	Update(ctx context.Context, domain entity.Domain) error
 	// Delete removes the domain with given UUID from the storage.
 	Delete(ctx context.Context, domain entity.Domain) error
+	// All returns all domains.
+	All(ctx context.Context) ([]entity.Domain, error)
+
+	// GetSettings returns the settings of the domain.
+	GetSettings(ctx context.Context, domain entity.Domain) (entity.DomainSettings, error)
+	// CreateSettings saves new settings of the domain in the storage.
+	CreateSettings(ctx context.Context, domain entity.Domain, settings entity.DomainSettings) (string, error)
+	// UpdateSettings updates the settings with given UUID in the storage.
+	UpdateSettings(ctx context.Context, settings entity.DomainSettings) error
+	// DeleteSettings removes the settings with given UUID from the storage.
+	DeleteSettings(ctx context.Context, settings entity.DomainSettings) error
 }
 
 // repository persists domains in database
@@ -45,7 +58,7 @@ func NewRepository(db *db.DB) Repository {
 // Get reads the domain with the specified ID from the database.
 func (r repository) Get(ctx context.Context, uuid string) (entity.Domain, error) {
 	var domain entity.Domain
-	res := r.db.With(ctx).Where("uuid = ?", uuid).First(&domain)
+	res := r.db.With(ctx).Preload("Parent").Where("uuid = ?", uuid).First(&domain)
 	if res.Error != nil && res.Error == gorm.ErrRecordNotFound {
 		return entity.Domain{}, fmt.Errorf("domain with uuid `%s` not found", uuid)
 	}
@@ -55,7 +68,7 @@ func (r repository) Get(ctx context.Context, uuid string) (entity.Domain, error)
 // GetByName returns the domain with the specified domain name.
 func (r repository) GetByName(ctx context.Context, name string) (entity.Domain, error) {
 	var domain entity.Domain
-	res := r.db.With(ctx).Where("name = ?", name).First(&domain)
+	res := r.db.With(ctx).Preload("Parent").Where("name = ?", name).First(&domain)
 	if res.Error != nil && res.Error == gorm.ErrRecordNotFound {
 		return entity.Domain{}, fmt.Errorf("domain with name `%s` not found", name)
 	}
@@ -96,6 +109,7 @@ func (r repository) Count(ctx context.Context) (int64, error) {
 func (r repository) Query(ctx context.Context, query string, offset, limit int64) ([]entity.Domain, int, error) {
 	var _domains []entity.Domain
 	res := r.db.With(ctx).
+		Preload("Parent").
 		Limit(int(limit)).
 		Offset(int(offset)).
 		Order("id asc")
@@ -112,3 +126,71 @@ func (r repository) Query(ctx context.Context, query string, offset, limit int64
 	}
 	return _domains, int(count), res.Error
 }
+
+// QueryInDomains retrieves the named domain records with the specified offset and limit from the database.
+func (r repository) QueryInDomains(ctx context.Context, query string, names []string, offset, limit int64) ([]entity.Domain, int, error) {
+	var _domains []entity.Domain
+	var count int64
+
+	res := r.db.With(ctx).Model(&entity.Domain{}).Where("name IN (?)", names)
+	if len(query) >= 1 {
+		res = res.Where("name LIKE ?", "%"+query+"%")
+	}
+	if err := res.Count(&count).Error; err != nil {
+		return nil, 0, err
+	}
+
+	res = res.
+		Preload("Parent").
+		Limit(int(limit)).
+		Offset(int(offset)).
+		Order("id asc").
+		Find(&_domains)
+	return _domains, int(count), res.Error
+}
+
+// All retrieves all domain records from the database.
+func (r repository) All(ctx context.Context) ([]entity.Domain, error) {
+	var _domains []entity.Domain
+	res := r.db.With(ctx).Order("id asc").Find(&_domains)
+	return _domains, res.Error
+}
+
+// GetSettings reads the settings of the domain from the database.
+func (r repository) GetSettings(ctx context.Context, domain entity.Domain) (entity.DomainSettings, error) {
+	var settings entity.DomainSettings
+	res := r.db.With(ctx).
+		Preload("Domain").
+		Preload("DefaultRole").
+		Where("domain_id = ?", domain.ID).
+		First(&settings)
+	if res.Error != nil && res.Error == gorm.ErrRecordNotFound {
+		return entity.DomainSettings{}, fmt.Errorf("settings of domain `%s` not found: %w", domain.Name, res.Error)
+	}
+	return settings, res.Error
+}
+
+// CreateSettings saves new settings of the domain in the database.
+// It returns the UUID of the newly inserted settings record.
+func (r repository) CreateSettings(ctx context.Context, domain entity.Domain, settings entity.DomainSettings) (string, error) {
+	now := time.Now()
+	settings.UUID = uuid.New().String()
+	settings.Domain = domain
+	settings.DomainID = domain.ID
+	settings.CreatedAt = now
+	settings.UpdatedAt = now
+	res := r.db.With(ctx).Create(&settings)
+	return settings.UUID, res.Error
+}
+
+// UpdateSettings saves the changes to domain settings in the database.
+func (r repository) UpdateSettings(ctx context.Context, settings entity.DomainSettings) error {
+	res := r.db.With(ctx).Save(&settings)
+	return res.Error
+}
+
+// DeleteSettings deletes domain settings from the database.
+func (r repository) DeleteSettings(ctx context.Context, settings entity.DomainSettings) error {
+	res := r.db.With(ctx).Delete(&settings)
+	return res.Error
+}
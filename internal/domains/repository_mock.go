@@ -17,7 +17,9 @@ func NewMockRepository() *mockRepository {
 }
 
 type mockRepository struct {
-	items []entity.Domain
+	items    []entity.Domain
+	settings []entity.DomainSettings
+	lastID   uint
 }
 
 func (m mockRepository) GetByName(ctx context.Context, name string) (entity.Domain, error) {
@@ -46,12 +48,27 @@ func (m mockRepository) Query(ctx context.Context, query string, offset, limit i
 	return m.items, len(m.items), nil
 }
 
+func (m mockRepository) QueryInDomains(ctx context.Context, query string, names []string, offset, limit int64) ([]entity.Domain, int, error) {
+	var items []entity.Domain
+	for _, item := range m.items {
+		for _, name := range names {
+			if item.Name == name {
+				items = append(items, item)
+				break
+			}
+		}
+	}
+	return items, len(items), nil
+}
+
 func (m *mockRepository) Create(ctx context.Context, domain entity.Domain) (string, error) {
 	Uuid := uuid.New().String()
 	domain.UUID = Uuid
 	if domain.Name == "error" {
 		return Uuid, errCRUD
 	}
+	m.lastID++
+	domain.ID = m.lastID
 	m.items = append(m.items, domain)
 	return Uuid, nil
 }
@@ -79,3 +96,45 @@ func (m *mockRepository) Delete(ctx context.Context, domain entity.Domain) error
 	}
 	return nil
 }
+
+func (m mockRepository) All(ctx context.Context) ([]entity.Domain, error) {
+	return m.items, nil
+}
+
+func (m mockRepository) GetSettings(ctx context.Context, domain entity.Domain) (entity.DomainSettings, error) {
+	for _, item := range m.settings {
+		if item.Domain.UUID == domain.UUID {
+			return item, nil
+		}
+	}
+	return entity.DomainSettings{}, gorm.ErrRecordNotFound
+}
+
+func (m *mockRepository) CreateSettings(ctx context.Context, domain entity.Domain, settings entity.DomainSettings) (string, error) {
+	Uuid := uuid.New().String()
+	settings.UUID = Uuid
+	settings.Domain = domain
+	m.settings = append(m.settings, settings)
+	return Uuid, nil
+}
+
+func (m *mockRepository) UpdateSettings(ctx context.Context, settings entity.DomainSettings) error {
+	for i, item := range m.settings {
+		if item.UUID == settings.UUID {
+			m.settings[i] = settings
+			break
+		}
+	}
+	return nil
+}
+
+func (m *mockRepository) DeleteSettings(ctx context.Context, settings entity.DomainSettings) error {
+	for i, item := range m.settings {
+		if item.UUID == settings.UUID {
+			m.settings[i] = m.settings[len(m.settings)-1]
+			m.settings = m.settings[:len(m.settings)-1]
+			break
+		}
+	}
+	return nil
+}
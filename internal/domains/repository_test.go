@@ -49,6 +49,11 @@ func TestRepository(t *testing.T) {
 	assert.Nil(t, err)
 	assert.Equal(t, count2, int64(count3))
 
+	// all
+	all, err := repo.All(ctx)
+	assert.Nil(t, err)
+	assert.Equal(t, count2, int64(len(all)))
+
 	// delete
 	err = repo.Delete(ctx, domain)
 	assert.Nil(t, err)
@@ -2,44 +2,74 @@ package domains
 
 import (
 	"context"
+	"fmt"
 	"time"
 
+	"github.com/ThreeDotsLabs/watermill/message"
 	validation "github.com/go-ozzo/ozzo-validation/v4"
+	"github.com/go-ozzo/ozzo-validation/v4/is"
+
 	"github.com/golang-tire/auth/internal/entity"
+	"github.com/golang-tire/auth/internal/pkg/cache"
+	"github.com/golang-tire/auth/internal/pkg/pubsub"
+	"github.com/golang-tire/auth/internal/pkg/scope"
 	auth "github.com/golang-tire/auth/internal/proto/v1"
+	"github.com/golang-tire/auth/internal/roles"
 )
 
 // Service encapsulates use case logic for domains.
 type Service interface {
 	Get(ctx context.Context, uuid string) (*auth.Domain, error)
-	Query(ctx context.Context, query string, offset, limit int64) (*auth.ListDomainsResponse, error)
+	Query(ctx context.Context, query, subtree string, offset, limit int64) (*auth.ListDomainsResponse, error)
 	Count(ctx context.Context) (int64, error)
 	Create(ctx context.Context, input *auth.CreateDomainRequest) (*auth.Domain, error)
 	Update(ctx context.Context, input *auth.UpdateDomainRequest) (*auth.Domain, error)
 	Delete(ctx context.Context, uuid string) (*auth.Domain, error)
+	// ResolveHost returns the domain of a host, it fails with ErrUnknownHost for unknown hosts.
+	ResolveHost(ctx context.Context, host string) (entity.Domain, error)
+	// OnDomainChange drops the resolved hosts when the domains change.
+	OnDomainChange(msg *message.Message) error
+	// Tree returns the parent relation of the domains.
+	Tree(ctx context.Context) (Tree, error)
+
+	GetSettings(ctx context.Context, uuid string) (*auth.DomainSettings, error)
+	CreateSettings(ctx context.Context, input *auth.CreateDomainSettingsRequest) (*auth.DomainSettings, error)
+	UpdateSettings(ctx context.Context, input *auth.UpdateDomainSettingsRequest) (*auth.DomainSettings, error)
+	DeleteSettings(ctx context.Context, uuid string) (*auth.DomainSettings, error)
+	// Settings returns the authentication settings of the domain.
+	Settings(ctx context.Context, domain entity.Domain) (entity.DomainSettings, error)
 }
 
+// notGlobal rejects the name of the global domain, global rules and roles have no domain record.
+var notGlobal = validation.NotIn(scope.GlobalDomain).Error("is reserved for the global domain")
+
 // ValidateCreateRequest validates the CreateDomainRequest fields.
 func ValidateCreateRequest(c *auth.CreateDomainRequest) error {
 	return validation.ValidateStruct(c,
-		validation.Field(&c.Name, validation.Required, validation.Length(0, 128)),
+		validation.Field(&c.Name, validation.Required, validation.Length(0, 128), notGlobal),
+		validation.Field(&c.Hosts, validation.Each(validation.Required, validation.By(validHost))),
+		validation.Field(&c.ParentUuid, is.UUID),
 	)
 }
 
 // Validate validates the UpdateDomainRequest fields.
 func ValidateUpdateRequest(u *auth.UpdateDomainRequest) error {
 	return validation.ValidateStruct(u,
-		validation.Field(&u.Name, validation.Required, validation.Length(0, 128)),
+		validation.Field(&u.Name, validation.Required, validation.Length(0, 128), notGlobal),
+		validation.Field(&u.Hosts, validation.Each(validation.Required, validation.By(validHost))),
+		validation.Field(&u.ParentUuid, is.UUID),
 	)
 }
 
 type service struct {
-	repo Repository
+	repo      Repository
+	rolesRepo roles.Repository
+	hosts     *cache.Value
 }
 
 // NewService creates a new domain service.
-func NewService(repo Repository) Service {
-	return service{repo}
+func NewService(repo Repository, rolesRepo roles.Repository) Service {
+	return service{repo: repo, rolesRepo: rolesRepo, hosts: &cache.Value{}}
 }
 
 // Get returns the domain with the specified the domain UUID.
@@ -48,6 +78,9 @@ func (s service) Get(ctx context.Context, UUID string) (*auth.Domain, error) {
 	if err != nil {
 		return nil, err
 	}
+	if err := scope.FromContext(ctx).Check(domain.Name); err != nil {
+		return nil, err
+	}
 	return domain.ToProto(), nil
 }
 
@@ -56,13 +89,26 @@ func (s service) Create(ctx context.Context, req *auth.CreateDomainRequest) (*au
 	if err := ValidateCreateRequest(req); err != nil {
 		return nil, err
 	}
-	id, err := s.repo.Create(ctx, entity.Domain{
+	if err := scope.FromContext(ctx).CheckGlobal("create domains"); err != nil {
+		return nil, err
+	}
+	hosts, err := s.domainHosts(ctx, "", req.Name, req.Hosts)
+	if err != nil {
+		return nil, err
+	}
+	domain := entity.Domain{
 		Name:   req.Name,
 		Enable: req.Enable,
-	})
+		Hosts:  hosts,
+	}
+	if err := s.setParent(ctx, &domain, req.ParentUuid); err != nil {
+		return nil, err
+	}
+	id, err := s.repo.Create(ctx, domain)
 	if err != nil {
 		return nil, err
 	}
+	s.hostsChanged("domain created")
 	return s.Get(ctx, id)
 }
 
@@ -76,14 +122,54 @@ func (s service) Update(ctx context.Context, req *auth.UpdateDomainRequest) (*au
 	if err != nil {
 		return nil, err
 	}
+	sc := scope.FromContext(ctx)
+	if err := sc.Check(domain.Name); err != nil {
+		return nil, err
+	}
+	// rules and role assignments refer to domains by name
+	if domain.Name != req.Name {
+		if err := sc.CheckGlobal("rename domains"); err != nil {
+			return nil, err
+		}
+	}
+	hosts, err := s.domainHosts(ctx, domain.UUID, req.Name, req.Hosts)
+	if err != nil {
+		return nil, err
+	}
+	// hosts decide the domain of every request
+	if domain.Hosts != hosts {
+		if err := sc.CheckGlobal("change domain hosts"); err != nil {
+			return nil, err
+		}
+	}
+	parentUUID := ""
+	if domain.Parent != nil {
+		parentUUID = domain.Parent.UUID
+	}
+	// moving a domain changes the assignments and rules which apply in its subtree
+	if parentUUID != req.ParentUuid {
+		if err := sc.CheckGlobal("move domains"); err != nil {
+			return nil, err
+		}
+	}
+	changed := domain.Name != req.Name || domain.Enable != req.Enable
 	now := time.Now()
 	domain.Name = req.Name
 	domain.Enable = req.Enable
+	domain.Hosts = hosts
 	domain.UpdatedAt = now
+	if err := s.setParent(ctx, &domain, req.ParentUuid); err != nil {
+		return nil, err
+	}
 
 	if err := s.repo.Update(ctx, domain); err != nil {
 		return nil, err
 	}
+	// the enforcers match domains against the tree, dropping it applies a new parent
+	s.hostsChanged("domain updated")
+	if changed {
+		pubsub.Notify(pubsub.RuleChange, "update domain "+domain.UUID)
+	}
 	return domain.ToProto(), nil
 }
 
@@ -93,9 +179,20 @@ func (s service) Delete(ctx context.Context, UUID string) (*auth.Domain, error)
 	if err != nil {
 		return nil, err
 	}
+	if err := scope.FromContext(ctx).CheckGlobal("delete domains"); err != nil {
+		return nil, err
+	}
+	tree, err := s.Tree(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if len(tree.Subtree(domain.Name)) > 1 {
+		return nil, fmt.Errorf("domain `%s` has subdomains, move or delete them first", domain.Name)
+	}
 	if err = s.repo.Delete(ctx, domain); err != nil {
 		return nil, err
 	}
+	s.hostsChanged("domain deleted")
 	return domain.ToProto(), nil
 }
 
@@ -104,9 +201,27 @@ func (s service) Count(ctx context.Context) (int64, error) {
 	return s.repo.Count(ctx)
 }
 
-// Query returns the domains with the specified offset and limit.
-func (s service) Query(ctx context.Context, query string, offset, limit int64) (*auth.ListDomainsResponse, error) {
-	items, count, err := s.repo.Query(ctx, query, offset, limit)
+// Query returns the domains with the specified offset and limit, domain admins only see
+// the domains they administer. A non-empty subtree limits the list to the domain with that
+// UUID and the domains below it.
+func (s service) Query(ctx context.Context, query, subtree string, offset, limit int64) (*auth.ListDomainsResponse, error) {
+	sc := scope.FromContext(ctx)
+	var items []entity.Domain
+	var count int
+	var err error
+	switch {
+	case subtree != "":
+		var names []string
+		names, err = s.subtreeNames(ctx, subtree)
+		if err != nil {
+			return nil, err
+		}
+		items, count, err = s.repo.QueryInDomains(ctx, query, names, offset, limit)
+	case sc.IsGlobal():
+		items, count, err = s.repo.Query(ctx, query, offset, limit)
+	default:
+		items, count, err = s.repo.QueryInDomains(ctx, query, sc.DomainList(), offset, limit)
+	}
 	if err != nil {
 		return nil, err
 	}
@@ -117,3 +232,24 @@ func (s service) Query(ctx context.Context, query string, offset, limit int64) (
 		Limit:      limit,
 	}, nil
 }
+
+// subtreeNames returns the names of the domain with the specified UUID and the domains
+// below it which the scope covers.
+func (s service) subtreeNames(ctx context.Context, uuid string) ([]string, error) {
+	root, err := s.repo.Get(ctx, uuid)
+	if err != nil {
+		return nil, err
+	}
+	tree, err := s.Tree(ctx)
+	if err != nil {
+		return nil, err
+	}
+	sc := scope.FromContext(ctx)
+	var names []string
+	for _, name := range tree.Subtree(root.Name) {
+		if sc.Allows(name) {
+			names = append(names, name)
+		}
+	}
+	return names, nil
+}
@@ -2,10 +2,15 @@ package domains
 
 import (
 	"context"
+	"errors"
 	"testing"
 
+	"github.com/golang-tire/auth/internal/entity"
+	"github.com/golang-tire/auth/internal/pkg/scope"
 	auth "github.com/golang-tire/auth/internal/proto/v1"
+	"github.com/golang-tire/auth/internal/roles"
 	"github.com/stretchr/testify/assert"
+	"gorm.io/gorm"
 )
 
 func TestCreateDomainRequest_Validate(t *testing.T) {
@@ -16,6 +21,7 @@ func TestCreateDomainRequest_Validate(t *testing.T) {
 	}{
 		{"success", auth.CreateDomainRequest{Name: "test"}, false},
 		{"required", auth.CreateDomainRequest{Name: ""}, true},
+		{"global", auth.CreateDomainRequest{Name: "*"}, true},
 		{"too long", auth.CreateDomainRequest{Name: "1234567890123456789012345678901234567890123456789012345678901234567890123456789012345678901234567890123456789012345678901234567890"}, true},
 	}
 	for _, tt := range tests {
@@ -34,6 +40,7 @@ func TestUpdateDomainRequest_Validate(t *testing.T) {
 	}{
 		{"success", auth.UpdateDomainRequest{Name: "test"}, false},
 		{"required", auth.UpdateDomainRequest{Name: ""}, true},
+		{"global", auth.UpdateDomainRequest{Name: "*"}, true},
 		{"too long", auth.UpdateDomainRequest{Name: "1234567890123456789012345678901234567890123456789012345678901234567890123456789012345678901234567890123456789012345678901234567890"}, true},
 	}
 	for _, tt := range tests {
@@ -45,7 +52,7 @@ func TestUpdateDomainRequest_Validate(t *testing.T) {
 }
 
 func Test_service_CRUD(t *testing.T) {
-	s := NewService(&mockRepository{})
+	s := NewService(&mockRepository{}, roles.NewMockRepository())
 	ctx := context.Background()
 
 	// initial count
@@ -105,7 +112,7 @@ func Test_service_CRUD(t *testing.T) {
 	assert.Equal(t, id, domain.Uuid)
 
 	// query
-	_domains, _ := s.Query(ctx, "", 0, 0)
+	_domains, _ := s.Query(ctx, "", "", 0, 0)
 	assert.Equal(t, 2, int(_domains.TotalCount))
 
 	// delete
@@ -117,3 +124,259 @@ func Test_service_CRUD(t *testing.T) {
 	count, _ = s.Count(ctx)
 	assert.Equal(t, int64(1), count)
 }
+
+func Test_service_DomainAdmin(t *testing.T) {
+	s := NewService(&mockRepository{}, roles.NewMockRepository())
+	ctx := context.Background()
+
+	own, err := s.Create(ctx, &auth.CreateDomainRequest{Name: "foo.bar", Enable: true})
+	assert.Nil(t, err)
+	other, err := s.Create(ctx, &auth.CreateDomainRequest{Name: "bar.baz", Enable: true})
+	assert.Nil(t, err)
+
+	adminCtx := scope.NewContext(ctx, scope.Domains("foo.bar"))
+	_, err = s.Create(adminCtx, &auth.CreateDomainRequest{Name: "new.domain", Enable: true})
+	assert.NotNil(t, err)
+	_, err = s.Get(adminCtx, own.Uuid)
+	assert.Nil(t, err)
+	_, err = s.Get(adminCtx, other.Uuid)
+	assert.NotNil(t, err)
+	_, err = s.Update(adminCtx, &auth.UpdateDomainRequest{Uuid: own.Uuid, Name: "foo.bar", Enable: false})
+	assert.Nil(t, err)
+	_, err = s.Update(adminCtx, &auth.UpdateDomainRequest{Uuid: own.Uuid, Name: "renamed", Enable: true})
+	assert.NotNil(t, err)
+	_, err = s.Delete(adminCtx, own.Uuid)
+	assert.NotNil(t, err)
+
+	res, err := s.Query(adminCtx, "", "", 0, 10)
+	assert.Nil(t, err)
+	assert.Equal(t, int64(1), res.TotalCount)
+	assert.Equal(t, "foo.bar", res.Domains[0].Name)
+}
+
+func Test_service_ResolveHost(t *testing.T) {
+	s := NewService(&mockRepository{}, roles.NewMockRepository())
+	ctx := context.Background()
+
+	_, err := s.Create(ctx, &auth.CreateDomainRequest{Name: "bad", Hosts: []string{"not a host"}})
+	assert.NotNil(t, err)
+
+	example, err := s.Create(ctx, &auth.CreateDomainRequest{Name: "example.com", Enable: true, Hosts: []string{"WWW.example.com", "*.tenant.example.com"}})
+	assert.Nil(t, err)
+	assert.Equal(t, []string{"www.example.com", "*.tenant.example.com"}, example.Hosts)
+	_, err = s.Create(ctx, &auth.CreateDomainRequest{Name: "admin.tenant.example.com", Enable: true})
+	assert.Nil(t, err)
+
+	// a host belongs to one domain
+	_, err = s.Create(ctx, &auth.CreateDomainRequest{Name: "other", Hosts: []string{"www.example.com"}})
+	assert.NotNil(t, err)
+	_, err = s.Create(ctx, &auth.CreateDomainRequest{Name: "other", Hosts: []string{"example.com"}})
+	assert.NotNil(t, err)
+
+	tests := []struct {
+		host   string
+		domain string
+	}{
+		{"example.com", "example.com"},
+		{"Example.COM:443", "example.com"},
+		{"www.example.com", "example.com"},
+		{"shop.tenant.example.com:8080", "example.com"},
+		{"admin.tenant.example.com", "admin.tenant.example.com"},
+		{"a.shop.tenant.example.com", ""},
+		{"tenant.example.com", ""},
+		{"unknown.org", ""},
+	}
+	for _, tt := range tests {
+		domain, err := s.ResolveHost(ctx, tt.host)
+		if tt.domain == "" {
+			assert.True(t, errors.Is(err, ErrUnknownHost), tt.host)
+			continue
+		}
+		assert.Nil(t, err, tt.host)
+		assert.Equal(t, tt.domain, domain.Name, tt.host)
+	}
+
+	// changes resolve the hosts again
+	_, err = s.Update(ctx, &auth.UpdateDomainRequest{Uuid: example.Uuid, Name: "example.com", Enable: true, Hosts: []string{"www.example.com"}})
+	assert.Nil(t, err)
+	_, err = s.ResolveHost(ctx, "shop.tenant.example.com")
+	assert.True(t, errors.Is(err, ErrUnknownHost))
+
+	// domain admins cannot change the hosts of their domain
+	adminCtx := scope.NewContext(ctx, scope.Domains("example.com"))
+	_, err = s.Update(adminCtx, &auth.UpdateDomainRequest{Uuid: example.Uuid, Name: "example.com", Enable: true, Hosts: []string{"www.example.com", "example.org"}})
+	assert.NotNil(t, err)
+}
+
+func Test_service_Settings(t *testing.T) {
+	rolesRepo := roles.NewMockRepository()
+	s := NewService(&mockRepository{}, rolesRepo)
+	ctx := context.Background()
+
+	roleUUID, _ := rolesRepo.Create(ctx, entity.Role{Title: "member", Enable: true})
+	domain, err := s.Create(ctx, &auth.CreateDomainRequest{Name: "example.com", Enable: true})
+	assert.Nil(t, err)
+	resolved, err := s.ResolveHost(ctx, "example.com")
+	assert.Nil(t, err)
+
+	// domains without settings use the defaults
+	_, err = s.GetSettings(ctx, domain.Uuid)
+	assert.NotNil(t, err)
+	settings, err := s.Settings(ctx, resolved)
+	assert.Nil(t, err)
+	assert.True(t, settings.SelfRegistration)
+	assert.True(t, settings.AllowsLoginMethod(PasswordLogin))
+	assert.True(t, settings.AllowsEmail("user@any.org"))
+
+	// validation
+	_, err = s.CreateSettings(ctx, &auth.CreateDomainSettingsRequest{Uuid: domain.Uuid, LoginMethods: []string{"sms"}})
+	assert.NotNil(t, err)
+	_, err = s.CreateSettings(ctx, &auth.CreateDomainSettingsRequest{Uuid: domain.Uuid, LoginMethods: []string{PasswordLogin}, AccessTokenLife: -1})
+	assert.NotNil(t, err)
+	_, err = s.CreateSettings(ctx, &auth.CreateDomainSettingsRequest{Uuid: domain.Uuid, LoginMethods: []string{PasswordLogin}, MfaRequired: true})
+	assert.NotNil(t, err)
+
+	created, err := s.CreateSettings(ctx, &auth.CreateDomainSettingsRequest{
+		Uuid:            domain.Uuid,
+		DefaultRoleUuid: roleUUID,
+		AccessTokenLife: 30,
+		LoginMethods:    []string{PasswordLogin},
+		EmailDomains:    []string{"Example.com"},
+	})
+	assert.Nil(t, err)
+	assert.False(t, created.SelfRegistration)
+	assert.Equal(t, "member", created.DefaultRole)
+	assert.Equal(t, int32(30), created.AccessTokenLife)
+
+	// a domain has one settings record
+	_, err = s.CreateSettings(ctx, &auth.CreateDomainSettingsRequest{Uuid: domain.Uuid, LoginMethods: []string{PasswordLogin}})
+	assert.NotNil(t, err)
+
+	settings, err = s.Settings(ctx, resolved)
+	assert.Nil(t, err)
+	assert.True(t, settings.AllowsEmail("user@example.com"))
+	assert.False(t, settings.AllowsEmail("user@other.com"))
+
+	updated, err := s.UpdateSettings(ctx, &auth.UpdateDomainSettingsRequest{Uuid: domain.Uuid, SelfRegistration: true, LoginMethods: []string{PasswordLogin}})
+	assert.Nil(t, err)
+	assert.True(t, updated.SelfRegistration)
+	assert.Equal(t, "", updated.DefaultRole)
+
+	// domain admins manage the settings of their domains only
+	otherCtx := scope.NewContext(ctx, scope.Domains("other.com"))
+	_, err = s.GetSettings(otherCtx, domain.Uuid)
+	assert.NotNil(t, err)
+
+	_, err = s.DeleteSettings(ctx, domain.Uuid)
+	assert.Nil(t, err)
+	_, err = s.GetSettings(ctx, domain.Uuid)
+	assert.NotNil(t, err)
+}
+
+func Test_service_Tree(t *testing.T) {
+	s := NewService(&mockRepository{}, roles.NewMockRepository())
+	ctx := context.Background()
+
+	acme, err := s.Create(ctx, &auth.CreateDomainRequest{Name: "acme", Enable: true})
+	assert.Nil(t, err)
+	eu, err := s.Create(ctx, &auth.CreateDomainRequest{Name: "eu.acme", Enable: true, ParentUuid: acme.Uuid})
+	assert.Nil(t, err)
+	assert.Equal(t, "acme", eu.Parent)
+	assert.Equal(t, acme.Uuid, eu.ParentUuid)
+	_, err = s.Create(ctx, &auth.CreateDomainRequest{Name: "us.acme", Enable: true, ParentUuid: acme.Uuid})
+	assert.Nil(t, err)
+	de, err := s.Create(ctx, &auth.CreateDomainRequest{Name: "de.eu.acme", Enable: true, ParentUuid: eu.Uuid})
+	assert.Nil(t, err)
+	other, err := s.Create(ctx, &auth.CreateDomainRequest{Name: "other", Enable: true})
+	assert.Nil(t, err)
+
+	tree, err := s.Tree(ctx)
+	assert.Nil(t, err)
+	assert.True(t, tree.Covers("acme", "de.eu.acme"))
+	assert.True(t, tree.Covers("eu.acme", "eu.acme"))
+	assert.False(t, tree.Covers("eu.acme", "acme"))
+	assert.False(t, tree.Covers("us.acme", "de.eu.acme"))
+	assert.False(t, tree.Covers("acme", "other"))
+	assert.Equal(t, []string{"acme", "de.eu.acme", "eu.acme", "us.acme"}, tree.Subtree("acme"))
+	assert.Equal(t, []string{"unknown"}, tree.Subtree("unknown"))
+
+	// subtree listing
+	res, err := s.Query(ctx, "", eu.Uuid, 0, 10)
+	assert.Nil(t, err)
+	assert.Equal(t, int64(2), res.TotalCount)
+	adminCtx := scope.NewContext(ctx, scope.Domains("eu.acme", "de.eu.acme"))
+	res, err = s.Query(adminCtx, "", acme.Uuid, 0, 10)
+	assert.Nil(t, err)
+	assert.Equal(t, int64(2), res.TotalCount)
+
+	// a domain cannot be moved below itself
+	_, err = s.Update(ctx, &auth.UpdateDomainRequest{Uuid: eu.Uuid, Name: "eu.acme", Enable: true, ParentUuid: de.Uuid})
+	assert.NotNil(t, err)
+	_, err = s.Update(ctx, &auth.UpdateDomainRequest{Uuid: acme.Uuid, Name: "acme", Enable: true, ParentUuid: acme.Uuid})
+	assert.NotNil(t, err)
+	// domain admins cannot move domains
+	_, err = s.Update(adminCtx, &auth.UpdateDomainRequest{Uuid: de.Uuid, Name: "de.eu.acme", Enable: true, ParentUuid: other.Uuid})
+	assert.NotNil(t, err)
+
+	moved, err := s.Update(ctx, &auth.UpdateDomainRequest{Uuid: de.Uuid, Name: "de.eu.acme", Enable: true, ParentUuid: other.Uuid})
+	assert.Nil(t, err)
+	assert.Equal(t, "other", moved.Parent)
+	tree, _ = s.Tree(ctx)
+	assert.True(t, tree.Covers("other", "de.eu.acme"))
+	assert.False(t, tree.Covers("acme", "de.eu.acme"))
+
+	// domains with subdomains cannot be deleted
+	_, err = s.Delete(ctx, other.Uuid)
+	assert.NotNil(t, err)
+	_, err = s.Delete(ctx, de.Uuid)
+	assert.Nil(t, err)
+	_, err = s.Delete(ctx, other.Uuid)
+	assert.Nil(t, err)
+}
+
+func TestTree_Organizations(t *testing.T) {
+	parent := uint(2)
+	tree := NewTree([]entity.Domain{
+		{Model: gorm.Model{ID: 1}, Name: "platform"},
+		{Model: gorm.Model{ID: 2}, Name: "acme", OrganizationID: 5},
+		{Model: gorm.Model{ID: 3}, Name: "eu.acme", OrganizationID: 5, ParentID: &parent},
+	})
+
+	// the root domain of an organization covers all of its domains, the global domain the
+	// ones of the platform
+	assert.True(t, tree.Covers("org:5", "eu.acme"))
+	assert.False(t, tree.Covers("org:5", "platform"))
+	assert.True(t, tree.Covers(scope.GlobalDomain, "platform"))
+	assert.False(t, tree.Covers(scope.GlobalDomain, "acme"))
+	assert.False(t, tree.Covers(scope.GlobalDomain, "eu.acme"))
+
+	organization, ok := tree.Organization("eu.acme")
+	assert.True(t, ok)
+	assert.Equal(t, uint(5), organization)
+	organization, ok = tree.Organization("org:5")
+	assert.True(t, ok)
+	assert.Equal(t, uint(5), organization)
+	organization, ok = tree.Organization(scope.GlobalDomain)
+	assert.True(t, ok)
+	assert.Equal(t, uint(0), organization)
+	_, ok = tree.Organization("org:6")
+	assert.False(t, ok)
+}
+
+func Test_service_GlobalNames(t *testing.T) {
+	s := NewService(&mockRepository{}, roles.NewMockRepository())
+	ctx := context.Background()
+
+	_, err := s.Create(ctx, &auth.CreateDomainRequest{Name: "acme.com", Enable: true})
+	assert.Nil(t, err)
+	// names select the domain and organization of requests, a name cannot be the host of another domain
+	_, err = s.Create(ctx, &auth.CreateDomainRequest{Name: "other", Enable: true, Hosts: []string{"acme.com"}})
+	assert.NotNil(t, err)
+	_, err = s.Create(ctx, &auth.CreateDomainRequest{Name: "shop", Enable: true, Hosts: []string{"shop.com"}})
+	assert.Nil(t, err)
+	_, err = s.Create(ctx, &auth.CreateDomainRequest{Name: "shop.com", Enable: true})
+	assert.NotNil(t, err)
+	// nor can another organization take the name
+	_, err = s.Create(ctx, &auth.CreateDomainRequest{Name: "acme.com", Enable: true})
+	assert.NotNil(t, err)
+}
@@ -0,0 +1,186 @@
+package domains
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"time"
+
+	validation "github.com/go-ozzo/ozzo-validation/v4"
+	"github.com/go-ozzo/ozzo-validation/v4/is"
+	"gorm.io/gorm"
+
+	"github.com/golang-tire/auth/internal/entity"
+	"github.com/golang-tire/auth/internal/pkg/scope"
+	auth "github.com/golang-tire/auth/internal/proto/v1"
+)
+
+// PasswordLogin is the username and password login method.
+const PasswordLogin = "password"
+
+// loginMethods are the login methods the auth service supports.
+var loginMethods = []interface{}{PasswordLogin}
+
+// noSecondFactor rejects requiring multi-factor authentication, there is no second factor to
+// verify yet and requiring one would reject every login.
+var noSecondFactor = validation.Empty.Error("is not supported, there is no second factor yet")
+
+// DefaultSettings returns the settings of domains without settings, registration is open
+// and the token lifetimes are the global config.
+func DefaultSettings(domain entity.Domain) entity.DomainSettings {
+	return entity.DomainSettings{
+		DomainID:         domain.ID,
+		Domain:           domain,
+		SelfRegistration: true,
+		LoginMethods:     PasswordLogin,
+	}
+}
+
+// ValidateSettingsCreateRequest validates the CreateDomainSettingsRequest fields.
+func ValidateSettingsCreateRequest(c *auth.CreateDomainSettingsRequest) error {
+	return validation.ValidateStruct(c,
+		validation.Field(&c.Uuid, validation.Required, is.UUID),
+		validation.Field(&c.DefaultRoleUuid, is.UUID),
+		validation.Field(&c.AccessTokenLife, validation.Min(int32(0))),
+		validation.Field(&c.RefreshTokenLife, validation.Min(int32(0))),
+		validation.Field(&c.LoginMethods, validation.Required, validation.Each(validation.In(loginMethods...))),
+		validation.Field(&c.MfaRequired, noSecondFactor),
+		validation.Field(&c.EmailDomains, validation.Each(validation.Required, is.Domain)),
+	)
+}
+
+// ValidateSettingsUpdateRequest validates the UpdateDomainSettingsRequest fields.
+func ValidateSettingsUpdateRequest(u *auth.UpdateDomainSettingsRequest) error {
+	return validation.ValidateStruct(u,
+		validation.Field(&u.Uuid, validation.Required, is.UUID),
+		validation.Field(&u.DefaultRoleUuid, is.UUID),
+		validation.Field(&u.AccessTokenLife, validation.Min(int32(0))),
+		validation.Field(&u.RefreshTokenLife, validation.Min(int32(0))),
+		validation.Field(&u.LoginMethods, validation.Required, validation.Each(validation.In(loginMethods...))),
+		validation.Field(&u.MfaRequired, noSecondFactor),
+		validation.Field(&u.EmailDomains, validation.Each(validation.Required, is.Domain)),
+	)
+}
+
+// scopedDomain returns the domain with the specified UUID when the scope covers it.
+func (s service) scopedDomain(ctx context.Context, uuid string) (entity.Domain, error) {
+	domain, err := s.repo.Get(ctx, uuid)
+	if err != nil {
+		return entity.Domain{}, err
+	}
+	if err := scope.FromContext(ctx).Check(domain.Name); err != nil {
+		return entity.Domain{}, err
+	}
+	return domain, nil
+}
+
+// GetSettings returns the settings of the domain with the specified UUID.
+func (s service) GetSettings(ctx context.Context, uuid string) (*auth.DomainSettings, error) {
+	domain, err := s.scopedDomain(ctx, uuid)
+	if err != nil {
+		return nil, err
+	}
+	settings, err := s.repo.GetSettings(ctx, domain)
+	if err != nil {
+		return nil, err
+	}
+	return settings.ToProto(), nil
+}
+
+// CreateSettings creates the settings of a domain which has none.
+func (s service) CreateSettings(ctx context.Context, req *auth.CreateDomainSettingsRequest) (*auth.DomainSettings, error) {
+	if err := ValidateSettingsCreateRequest(req); err != nil {
+		return nil, err
+	}
+	domain, err := s.scopedDomain(ctx, req.Uuid)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := s.repo.GetSettings(ctx, domain); err == nil {
+		return nil, errors.New("domain already has settings")
+	}
+
+	settings := entity.DomainSettings{
+		SelfRegistration: req.SelfRegistration,
+		AccessTokenLife:  int(req.AccessTokenLife),
+		RefreshTokenLife: int(req.RefreshTokenLife),
+		LoginMethods:     strings.Join(req.LoginMethods, ","),
+		MFARequired:      req.MfaRequired,
+		EmailDomains:     strings.ToLower(strings.Join(req.EmailDomains, ",")),
+	}
+	if err := s.setDefaultRole(ctx, &settings, req.DefaultRoleUuid); err != nil {
+		return nil, err
+	}
+	if _, err := s.repo.CreateSettings(ctx, domain, settings); err != nil {
+		return nil, err
+	}
+	return s.GetSettings(ctx, domain.UUID)
+}
+
+// UpdateSettings updates the settings of a domain.
+func (s service) UpdateSettings(ctx context.Context, req *auth.UpdateDomainSettingsRequest) (*auth.DomainSettings, error) {
+	if err := ValidateSettingsUpdateRequest(req); err != nil {
+		return nil, err
+	}
+	domain, err := s.scopedDomain(ctx, req.Uuid)
+	if err != nil {
+		return nil, err
+	}
+	settings, err := s.repo.GetSettings(ctx, domain)
+	if err != nil {
+		return nil, err
+	}
+
+	settings.SelfRegistration = req.SelfRegistration
+	settings.AccessTokenLife = int(req.AccessTokenLife)
+	settings.RefreshTokenLife = int(req.RefreshTokenLife)
+	settings.LoginMethods = strings.Join(req.LoginMethods, ",")
+	settings.MFARequired = req.MfaRequired
+	settings.EmailDomains = strings.ToLower(strings.Join(req.EmailDomains, ","))
+	settings.UpdatedAt = time.Now()
+	if err := s.setDefaultRole(ctx, &settings, req.DefaultRoleUuid); err != nil {
+		return nil, err
+	}
+	if err := s.repo.UpdateSettings(ctx, settings); err != nil {
+		return nil, err
+	}
+	return settings.ToProto(), nil
+}
+
+// DeleteSettings deletes the settings of a domain, the domain uses the global config again.
+func (s service) DeleteSettings(ctx context.Context, uuid string) (*auth.DomainSettings, error) {
+	domain, err := s.scopedDomain(ctx, uuid)
+	if err != nil {
+		return nil, err
+	}
+	settings, err := s.repo.GetSettings(ctx, domain)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.repo.DeleteSettings(ctx, settings); err != nil {
+		return nil, err
+	}
+	return settings.ToProto(), nil
+}
+
+// Settings returns the settings of the domain, or the default settings when it has none.
+func (s service) Settings(ctx context.Context, domain entity.Domain) (entity.DomainSettings, error) {
+	settings, err := s.repo.GetSettings(ctx, domain)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return DefaultSettings(domain), nil
+	}
+	return settings, err
+}
+
+func (s service) setDefaultRole(ctx context.Context, settings *entity.DomainSettings, roleUUID string) error {
+	settings.DefaultRoleID, settings.DefaultRole = nil, nil
+	if roleUUID == "" {
+		return nil
+	}
+	role, err := s.rolesRepo.Get(ctx, roleUUID)
+	if err != nil {
+		return err
+	}
+	settings.DefaultRoleID, settings.DefaultRole = &role.ID, &role
+	return nil
+}
@@ -0,0 +1,109 @@
+package domains
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	"github.com/golang-tire/auth/internal/entity"
+	"github.com/golang-tire/auth/internal/pkg/scope"
+	"github.com/golang-tire/auth/internal/pkg/tenant"
+)
+
+// Tree is the parent relation of the domains, role assignments and rules of a domain apply
+// to the domains below it. The top level domains of an organization are below its root
+// domain, the one of its role assignments and rules without a domain, the ones of the
+// platform below the global domain.
+type Tree struct {
+	// parents maps every domain name to the name of its parent
+	parents       map[string]string
+	organizations map[string]uint
+}
+
+// NewTree returns the tree of the domains.
+func NewTree(items []entity.Domain) Tree {
+	names := map[uint]string{}
+	for _, domain := range items {
+		names[domain.ID] = domain.Name
+	}
+	t := Tree{parents: map[string]string{}, organizations: map[string]uint{scope.GlobalDomain: tenant.Platform}}
+	for _, domain := range items {
+		t.organizations[domain.Name] = domain.OrganizationID
+		if domain.OrganizationID != tenant.Platform {
+			t.organizations[tenant.RootDomain(domain.OrganizationID)] = domain.OrganizationID
+		}
+		if domain.ParentID != nil {
+			t.parents[domain.Name] = names[*domain.ParentID]
+		} else {
+			t.parents[domain.Name] = tenant.RootDomain(domain.OrganizationID)
+		}
+	}
+	return t
+}
+
+// Organization returns the organization of a domain or root domain, the global domain
+// belongs to the platform. It returns false for unknown domains.
+func (t Tree) Organization(domain string) (uint, bool) {
+	organization, ok := t.organizations[domain]
+	return organization, ok
+}
+
+// Covers reports whether domain is ancestor or one of the domains below it.
+func (t Tree) Covers(ancestor, domain string) bool {
+	// the depth is bounded in case the stored relation has a cycle, the root domain of the
+	// organization or the global domain is one level above the domains
+	for i := 0; domain != "" && i <= len(t.parents)+1; i++ {
+		if domain == ancestor {
+			return true
+		}
+		domain = t.parents[domain]
+	}
+	return false
+}
+
+// Subtree returns the sorted names of domain and the domains below it.
+func (t Tree) Subtree(domain string) []string {
+	if _, ok := t.parents[domain]; !ok {
+		return []string{domain}
+	}
+	var names []string
+	for name := range t.parents {
+		if t.Covers(domain, name) {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+	return names
+}
+
+// Tree returns the domain tree.
+func (s service) Tree(ctx context.Context) (Tree, error) {
+	t, err := s.table(ctx)
+	if err != nil {
+		return Tree{}, err
+	}
+	return t.tree, nil
+}
+
+// setParent sets the parent of the domain, a domain cannot be moved below itself.
+func (s service) setParent(ctx context.Context, domain *entity.Domain, parentUUID string) error {
+	domain.ParentID, domain.Parent = nil, nil
+	if parentUUID == "" {
+		return nil
+	}
+	parent, err := s.repo.Get(ctx, parentUUID)
+	if err != nil {
+		return err
+	}
+	if domain.ID != 0 {
+		tree, err := s.Tree(ctx)
+		if err != nil {
+			return err
+		}
+		if tree.Covers(domain.Name, parent.Name) {
+			return fmt.Errorf("domain `%s` cannot be moved below itself", domain.Name)
+		}
+	}
+	domain.ParentID, domain.Parent = &parent.ID, &parent
+	return nil
+}
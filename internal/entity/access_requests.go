@@ -0,0 +1,120 @@
+package entity
+
+import (
+	"time"
+
+	auth "github.com/golang-tire/auth/internal/proto/v1"
+	"github.com/golang/protobuf/ptypes"
+	"gorm.io/gorm"
+)
+
+type AccessRequest struct {
+	gorm.Model
+	UUID              string `gorm:"index"`
+	OrganizationID    uint   `gorm:"index"`
+	RequesterID       uint
+	Requester         User `gorm:"foreignKey:RequesterID"`
+	RoleID            uint
+	Role              Role `gorm:"foreignKey:RoleID"`
+	DomainID          uint
+	Domain            Domain `gorm:"foreignKey:DomainID"`
+	Duration          time.Duration
+	Justification     string
+	Status            string `gorm:"index"`
+	RequiredApprovals int64
+	Approvals         []AccessApproval
+	UserRoleUUID      string
+	ExpiresAt         *time.Time
+}
+
+type AccessApproval struct {
+	gorm.Model
+	OrganizationID  uint `gorm:"index"`
+	AccessRequestID uint
+	ApproverID      uint
+	Approver        User `gorm:"foreignKey:ApproverID"`
+	Approve         bool
+	Comment         string
+}
+
+// ApproverRule names the role whose holders decide on requests for a role in a domain.
+type ApproverRule struct {
+	gorm.Model
+	UUID           string `gorm:"index"`
+	OrganizationID uint   `gorm:"index"`
+	Role           string
+	Domain         string
+	ApproverRole   string
+	TwoPerson      bool
+}
+
+func (ar AccessRequest) ToProto() *auth.AccessRequest {
+	c, _ := ptypes.TimestampProto(ar.CreatedAt)
+	u, _ := ptypes.TimestampProto(ar.UpdatedAt)
+
+	status := auth.AccessRequestStatus_PENDING
+	if v, ok := auth.AccessRequestStatus_value[ar.Status]; ok {
+		status = auth.AccessRequestStatus(v)
+	}
+
+	req := &auth.AccessRequest{
+		Uuid:              ar.UUID,
+		Requester:         ar.Requester.Username,
+		Role:              ar.Role.Title,
+		Domain:            ar.Domain.Name,
+		Duration:          ar.Duration.String(),
+		Justification:     ar.Justification,
+		Status:            status,
+		RequiredApprovals: ar.RequiredApprovals,
+		UserRoleUuid:      ar.UserRoleUUID,
+		CreatedAt:         c,
+		UpdatedAt:         u,
+	}
+	for _, a := range ar.Approvals {
+		req.Approvals = append(req.Approvals, a.ToProto())
+	}
+	if ar.ExpiresAt != nil {
+		req.ExpiresAt, _ = ptypes.TimestampProto(*ar.ExpiresAt)
+	}
+	return req
+}
+
+func (aa AccessApproval) ToProto() *auth.AccessApproval {
+	c, _ := ptypes.TimestampProto(aa.CreatedAt)
+	return &auth.AccessApproval{
+		Approver:  aa.Approver.Username,
+		Approve:   aa.Approve,
+		Comment:   aa.Comment,
+		CreatedAt: c,
+	}
+}
+
+func AccessRequestToProtoList(arl []AccessRequest) []*auth.AccessRequest {
+	var r []*auth.AccessRequest
+	for _, i := range arl {
+		r = append(r, i.ToProto())
+	}
+	return r
+}
+
+func (ar ApproverRule) ToProto() *auth.ApproverRule {
+	c, _ := ptypes.TimestampProto(ar.CreatedAt)
+	u, _ := ptypes.TimestampProto(ar.UpdatedAt)
+	return &auth.ApproverRule{
+		Uuid:         ar.UUID,
+		Role:         ar.Role,
+		Domain:       ar.Domain,
+		ApproverRole: ar.ApproverRole,
+		TwoPerson:    ar.TwoPerson,
+		CreatedAt:    c,
+		UpdatedAt:    u,
+	}
+}
+
+func ApproverRuleToProtoList(arl []ApproverRule) []*auth.ApproverRule {
+	var r []*auth.ApproverRule
+	for _, i := range arl {
+		r = append(r, i.ToProto())
+	}
+	return r
+}
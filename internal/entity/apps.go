@@ -1,6 +1,9 @@
 package entity
 
 import (
+	"sort"
+	"strings"
+
 	auth "github.com/golang-tire/auth/internal/proto/v1"
 	"github.com/golang/protobuf/ptypes"
 	"gorm.io/gorm"
@@ -8,25 +11,88 @@ import (
 
 type App struct {
 	gorm.Model
-	UUID   string `gorm:"index"`
-	Name   string
-	Enable bool
+	UUID           string `gorm:"index"`
+	OrganizationID uint   `gorm:"index"`
+	Name           string
+	Enable         bool
+	// Hosts is the comma separated list of the forwarded hosts of the app
+	Hosts string
+	// Actions is the comma separated list of method=action mappings
+	Actions string
+	// ActionAliases is the comma separated list of alias=action mappings
+	ActionAliases string
 }
 
 type Resource struct {
 	gorm.Model
-	UUID  string `gorm:"index"`
-	Name  string `gorm:"index:idx_app_resource"`
-	AppID uint   `gorm:"index:idx_app_resource"`
-	App   App
+	UUID           string `gorm:"index"`
+	OrganizationID uint   `gorm:"index"`
+	Name           string `gorm:"index:idx_app_resource"`
+	AppID          uint   `gorm:"index:idx_app_resource"`
+	App            App
 }
 
 type Object struct {
 	gorm.Model
-	UUID       string `gorm:"index"`
-	Identifier string `gorm:"index:idx_app_object"`
-	AppID      uint   `gorm:"index:idx_app_object"`
-	App        App
+	UUID           string `gorm:"index"`
+	OrganizationID uint   `gorm:"index"`
+	Identifier     string `gorm:"index:idx_app_object"`
+	AppID          uint   `gorm:"index:idx_app_object"`
+	App            App
+}
+
+// Route maps the requests to an app matching its method and template to a permission.
+type Route struct {
+	gorm.Model
+	UUID           string `gorm:"index"`
+	OrganizationID uint   `gorm:"index"`
+	AppID          uint   `gorm:"index"`
+	App            App
+	Method         string
+	Template       string
+	Resource       string
+	Action         string
+}
+
+// HostList returns the forwarded hosts of the app.
+func (ap App) HostList() []string {
+	if ap.Hosts == "" {
+		return nil
+	}
+	return strings.Split(ap.Hosts, ",")
+}
+
+// ActionMap returns the actions of the request methods of the app.
+func (ap App) ActionMap() map[string]string {
+	return splitPairs(ap.Actions)
+}
+
+// AliasMap returns the actions of the action aliases of the app.
+func (ap App) AliasMap() map[string]string {
+	return splitPairs(ap.ActionAliases)
+}
+
+// JoinPairs joins a mapping into the stored key=value list, sorted by key.
+func JoinPairs(m map[string]string) string {
+	pairs := make([]string, 0, len(m))
+	for k, v := range m {
+		pairs = append(pairs, k+"="+v)
+	}
+	sort.Strings(pairs)
+	return strings.Join(pairs, ",")
+}
+
+func splitPairs(s string) map[string]string {
+	m := map[string]string{}
+	if s == "" {
+		return m
+	}
+	for _, pair := range strings.Split(s, ",") {
+		if i := strings.Index(pair, "="); i > 0 {
+			m[pair[:i]] = pair[i+1:]
+		}
+	}
+	return m
 }
 
 func (ap App) ToProto() *auth.App {
@@ -34,11 +100,14 @@ func (ap App) ToProto() *auth.App {
 	u, _ := ptypes.TimestampProto(ap.UpdatedAt)
 
 	app := &auth.App{
-		Uuid:      ap.UUID,
-		Name:      ap.Name,
-		Enable:    ap.Enable,
-		CreatedAt: c,
-		UpdatedAt: u,
+		Uuid:          ap.UUID,
+		Name:          ap.Name,
+		Enable:        ap.Enable,
+		Hosts:         ap.HostList(),
+		Actions:       ap.ActionMap(),
+		ActionAliases: ap.AliasMap(),
+		CreatedAt:     c,
+		UpdatedAt:     u,
 	}
 	return app
 }
@@ -94,3 +163,27 @@ func ObjectToProtoList(ol []Object) []*auth.Object {
 	}
 	return o
 }
+
+func (r Route) ToProto() *auth.Route {
+	c, _ := ptypes.TimestampProto(r.CreatedAt)
+	u, _ := ptypes.TimestampProto(r.UpdatedAt)
+
+	return &auth.Route{
+		Uuid:      r.UUID,
+		App:       r.App.ToProto(),
+		Method:    r.Method,
+		Template:  r.Template,
+		Resource:  r.Resource,
+		Action:    r.Action,
+		CreatedAt: c,
+		UpdatedAt: u,
+	}
+}
+
+func RouteToProtoList(rl []Route) []*auth.Route {
+	var r []*auth.Route
+	for _, i := range rl {
+		r = append(r, i.ToProto())
+	}
+	return r
+}
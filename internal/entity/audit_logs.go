@@ -8,13 +8,14 @@ import (
 
 type AuditLog struct {
 	gorm.Model
-	UUID     string `gorm:"index"`
-	User     User   `gorm:"foreignKey:UserID"`
-	UserID   uint
-	Action   string
-	Object   string
-	OldValue string
-	NewValue string
+	UUID           string `gorm:"index"`
+	OrganizationID uint   `gorm:"index"`
+	User           User   `gorm:"foreignKey:UserID"`
+	UserID         uint
+	Action         string
+	Object         string
+	OldValue       string
+	NewValue       string
 }
 
 func (al AuditLog) ToProto() *auth.AuditLog {
@@ -0,0 +1,103 @@
+package entity
+
+import (
+	auth "github.com/golang-tire/auth/internal/proto/v1"
+	"github.com/golang/protobuf/ptypes"
+	"gorm.io/gorm"
+)
+
+// PermissionBundle is a named set of permissions, a role it is attached to in a domain has
+// a rule for every permission in the domain.
+type PermissionBundle struct {
+	gorm.Model
+	UUID           string `gorm:"index"`
+	OrganizationID uint   `gorm:"uniqueIndex:idx_org_bundle_name"`
+	Name           string `gorm:"uniqueIndex:idx_org_bundle_name"`
+	Description    string
+	Permissions    []BundlePermission `gorm:"foreignKey:BundleID"`
+}
+
+type BundlePermission struct {
+	gorm.Model
+	OrganizationID uint `gorm:"index"`
+	BundleID       uint `gorm:"index"`
+	Resource       string
+	Action         string
+	Object         string
+	Effect         string
+	Condition      string
+}
+
+// RoleBundle attaches a bundle to a role in a domain, the rules generated from the bundle
+// refer to it.
+type RoleBundle struct {
+	gorm.Model
+	UUID           string `gorm:"index"`
+	OrganizationID uint   `gorm:"index"`
+	RoleID         uint   `gorm:"index"`
+	Role           Role
+	BundleID       uint `gorm:"index"`
+	Bundle         PermissionBundle
+	DomainID       uint
+	Domain         Domain
+}
+
+func (b PermissionBundle) ToProto() *auth.PermissionBundle {
+	c, _ := ptypes.TimestampProto(b.CreatedAt)
+	u, _ := ptypes.TimestampProto(b.UpdatedAt)
+
+	bundle := &auth.PermissionBundle{
+		Uuid:        b.UUID,
+		Name:        b.Name,
+		Description: b.Description,
+		CreatedAt:   c,
+		UpdatedAt:   u,
+	}
+	for _, p := range b.Permissions {
+		bundle.Permissions = append(bundle.Permissions, p.ToProto())
+	}
+	return bundle
+}
+
+func PermissionBundleToProtoList(bl []PermissionBundle) []*auth.PermissionBundle {
+	var r []*auth.PermissionBundle
+	for _, i := range bl {
+		r = append(r, i.ToProto())
+	}
+	return r
+}
+
+func (p BundlePermission) ToProto() *auth.BundlePermission {
+	var effect auth.Effect = auth.Effect_DENY
+	if p.Effect == "ALLOW" {
+		effect = auth.Effect_ALLOW
+	}
+	return &auth.BundlePermission{
+		Resource:  p.Resource,
+		Action:    p.Action,
+		Object:    p.Object,
+		Effect:    effect,
+		Condition: p.Condition,
+	}
+}
+
+func (rb RoleBundle) ToProto() *auth.RoleBundle {
+	c, _ := ptypes.TimestampProto(rb.CreatedAt)
+	return &auth.RoleBundle{
+		Uuid:       rb.UUID,
+		Role:       rb.Role.Title,
+		RoleUuid:   rb.Role.UUID,
+		Bundle:     rb.Bundle.Name,
+		BundleUuid: rb.Bundle.UUID,
+		Domain:     rb.Domain.Name,
+		CreatedAt:  c,
+	}
+}
+
+func RoleBundleToProtoList(rbl []RoleBundle) []*auth.RoleBundle {
+	var r []*auth.RoleBundle
+	for _, i := range rbl {
+		r = append(r, i.ToProto())
+	}
+	return r
+}
@@ -1,6 +1,8 @@
 package entity
 
 import (
+	"strings"
+
 	"gorm.io/gorm"
 
 	auth "github.com/golang-tire/auth/internal/proto/v1"
@@ -9,9 +11,23 @@ import (
 
 type Domain struct {
 	gorm.Model
-	UUID   string `gorm:"index"`
-	Name   string `gorm:"index"`
-	Enable bool
+	UUID           string `gorm:"index"`
+	OrganizationID uint   `gorm:"index"`
+	Name           string `gorm:"index"`
+	Enable         bool
+	// Hosts is the comma separated list of the host aliases and wildcard patterns of the domain
+	Hosts string
+	// Parent is the domain above this one, its role assignments and rules apply here too
+	ParentID *uint
+	Parent   *Domain `gorm:"foreignKey:ParentID"`
+}
+
+// HostList returns the host aliases and wildcard patterns of the domain.
+func (dm Domain) HostList() []string {
+	if dm.Hosts == "" {
+		return nil
+	}
+	return strings.Split(dm.Hosts, ",")
 }
 
 func (dm Domain) ToProto() *auth.Domain {
@@ -22,9 +38,14 @@ func (dm Domain) ToProto() *auth.Domain {
 		Uuid:      dm.UUID,
 		Name:      dm.Name,
 		Enable:    dm.Enable,
+		Hosts:     dm.HostList(),
 		CreatedAt: c,
 		UpdatedAt: u,
 	}
+	if dm.Parent != nil {
+		role.Parent = dm.Parent.Name
+		role.ParentUuid = dm.Parent.UUID
+	}
 	return role
 }
 
@@ -35,3 +56,93 @@ func DomainToProtoList(dml []Domain) []*auth.Domain {
 	}
 	return r
 }
+
+// DomainSettings are the authentication settings of a domain.
+type DomainSettings struct {
+	gorm.Model
+	UUID           string `gorm:"index"`
+	OrganizationID uint   `gorm:"index"`
+	DomainID       uint   `gorm:"uniqueIndex"`
+	Domain         Domain
+	// SelfRegistration opens Register to everyone
+	SelfRegistration bool
+	// DefaultRole is assigned in the domain to the registered users
+	DefaultRoleID *uint
+	DefaultRole   *Role `gorm:"foreignKey:DefaultRoleID"`
+	// AccessTokenLife is in minutes and RefreshTokenLife in hours, zero uses the global config
+	AccessTokenLife  int
+	RefreshTokenLife int
+	// LoginMethods is the comma separated list of the allowed login methods
+	LoginMethods string
+	// MFARequired is reserved for a second login factor, it can not be enabled yet
+	MFARequired bool
+	// EmailDomains is the comma separated list of the allowed email domains, empty allows all
+	EmailDomains string
+}
+
+// LoginMethodList returns the allowed login methods.
+func (ds DomainSettings) LoginMethodList() []string {
+	if ds.LoginMethods == "" {
+		return nil
+	}
+	return strings.Split(ds.LoginMethods, ",")
+}
+
+// EmailDomainList returns the allowed email domains.
+func (ds DomainSettings) EmailDomainList() []string {
+	if ds.EmailDomains == "" {
+		return nil
+	}
+	return strings.Split(ds.EmailDomains, ",")
+}
+
+// AllowsLoginMethod reports whether the login method is allowed.
+func (ds DomainSettings) AllowsLoginMethod(method string) bool {
+	for _, m := range ds.LoginMethodList() {
+		if m == method {
+			return true
+		}
+	}
+	return false
+}
+
+// AllowsEmail reports whether the email belongs to an allowed email domain.
+func (ds DomainSettings) AllowsEmail(email string) bool {
+	list := ds.EmailDomainList()
+	if len(list) == 0 {
+		return true
+	}
+	i := strings.LastIndex(email, "@")
+	if i < 0 {
+		return false
+	}
+	domain := strings.ToLower(email[i+1:])
+	for _, d := range list {
+		if d == domain {
+			return true
+		}
+	}
+	return false
+}
+
+func (ds DomainSettings) ToProto() *auth.DomainSettings {
+	c, _ := ptypes.TimestampProto(ds.CreatedAt)
+	u, _ := ptypes.TimestampProto(ds.UpdatedAt)
+
+	settings := &auth.DomainSettings{
+		Uuid:             ds.UUID,
+		Domain:           ds.Domain.Name,
+		SelfRegistration: ds.SelfRegistration,
+		AccessTokenLife:  int32(ds.AccessTokenLife),
+		RefreshTokenLife: int32(ds.RefreshTokenLife),
+		LoginMethods:     ds.LoginMethodList(),
+		MfaRequired:      ds.MFARequired,
+		EmailDomains:     ds.EmailDomainList(),
+		CreatedAt:        c,
+		UpdatedAt:        u,
+	}
+	if ds.DefaultRole != nil {
+		settings.DefaultRole = ds.DefaultRole.Title
+	}
+	return settings
+}
@@ -0,0 +1,116 @@
+package entity
+
+import (
+	"strings"
+
+	auth "github.com/golang-tire/auth/internal/proto/v1"
+	"github.com/golang/protobuf/ptypes"
+	"gorm.io/gorm"
+)
+
+// groupSubjectPrefix keeps group subjects apart from usernames and role titles in the policy.
+const groupSubjectPrefix = "group:"
+
+type Group struct {
+	gorm.Model
+	UUID           string `gorm:"index"`
+	OrganizationID uint   `gorm:"uniqueIndex:idx_org_group_name"`
+	Name           string `gorm:"uniqueIndex:idx_org_group_name"`
+	Description    string
+	Enable         bool
+	Members        []GroupMember
+	Roles          []GroupRole
+}
+
+type GroupMember struct {
+	gorm.Model
+	OrganizationID uint `gorm:"index"`
+	GroupID        uint `gorm:"index"`
+	UserID         uint `gorm:"index"`
+	User           User
+}
+
+type GroupRole struct {
+	gorm.Model
+	UUID           string `gorm:"index"`
+	OrganizationID uint   `gorm:"index"`
+	GroupID        uint   `gorm:"index"`
+	Group          Group
+	RoleID         uint
+	Role           Role
+	DomainID       uint
+	Domain         Domain
+	Enable         bool
+}
+
+// Subject returns the policy subject of the group.
+func (g Group) Subject() string {
+	return GroupSubject(g.Name)
+}
+
+// GroupSubject returns the policy subject of the group with the given name.
+func GroupSubject(name string) string {
+	return groupSubjectPrefix + name
+}
+
+// IsGroupSubject reports whether the policy subject is a group.
+func IsGroupSubject(subject string) bool {
+	return strings.HasPrefix(subject, groupSubjectPrefix)
+}
+
+func (g Group) ToProto() *auth.Group {
+	c, _ := ptypes.TimestampProto(g.CreatedAt)
+	u, _ := ptypes.TimestampProto(g.UpdatedAt)
+
+	group := &auth.Group{
+		Uuid:        g.UUID,
+		Name:        g.Name,
+		Description: g.Description,
+		Enable:      g.Enable,
+		Roles:       GroupRoleToProtoList(g.Roles),
+		CreatedAt:   c,
+		UpdatedAt:   u,
+	}
+	for _, m := range g.Members {
+		group.Members = append(group.Members, m.ToProto())
+	}
+	return group
+}
+
+func GroupToProtoList(gl []Group) []*auth.Group {
+	var r []*auth.Group
+	for _, i := range gl {
+		r = append(r, i.ToProto())
+	}
+	return r
+}
+
+func (gm GroupMember) ToProto() *auth.GroupMember {
+	c, _ := ptypes.TimestampProto(gm.CreatedAt)
+	return &auth.GroupMember{
+		UserUuid:  gm.User.UUID,
+		Username:  gm.User.Username,
+		CreatedAt: c,
+	}
+}
+
+func (gr GroupRole) ToProto() *auth.GroupRole {
+	c, _ := ptypes.TimestampProto(gr.CreatedAt)
+	u, _ := ptypes.TimestampProto(gr.UpdatedAt)
+	return &auth.GroupRole{
+		Uuid:      gr.UUID,
+		Role:      gr.Role.Title,
+		Domain:    gr.Domain.Name,
+		Enable:    gr.Enable,
+		CreatedAt: c,
+		UpdatedAt: u,
+	}
+}
+
+func GroupRoleToProtoList(grl []GroupRole) []*auth.GroupRole {
+	var r []*auth.GroupRole
+	for _, i := range grl {
+		r = append(r, i.ToProto())
+	}
+	return r
+}
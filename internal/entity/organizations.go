@@ -0,0 +1,38 @@
+package entity
+
+import (
+	"gorm.io/gorm"
+
+	auth "github.com/golang-tire/auth/internal/proto/v1"
+	"github.com/golang/protobuf/ptypes"
+)
+
+// Organization is a tenant, the records of an organization are only visible to the
+// requests made to its domains.
+type Organization struct {
+	gorm.Model
+	UUID  string `gorm:"index"`
+	Name  string `gorm:"uniqueIndex"`
+	Title string
+}
+
+func (om Organization) ToProto() *auth.Organization {
+	c, _ := ptypes.TimestampProto(om.CreatedAt)
+	u, _ := ptypes.TimestampProto(om.UpdatedAt)
+
+	return &auth.Organization{
+		Uuid:      om.UUID,
+		Name:      om.Name,
+		Title:     om.Title,
+		CreatedAt: c,
+		UpdatedAt: u,
+	}
+}
+
+func OrganizationToProtoList(oml []Organization) []*auth.Organization {
+	var r []*auth.Organization
+	for _, i := range oml {
+		r = append(r, i.ToProto())
+	}
+	return r
+}
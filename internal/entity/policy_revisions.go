@@ -0,0 +1,38 @@
+package entity
+
+import (
+	auth "github.com/golang-tire/auth/internal/proto/v1"
+	"github.com/golang/protobuf/ptypes"
+	"gorm.io/gorm"
+)
+
+type PolicyRevision struct {
+	gorm.Model
+	UUID           string `gorm:"index"`
+	OrganizationID uint   `gorm:"index"`
+	Number         int64  `gorm:"uniqueIndex"`
+	Reason         string
+	// Snapshot is the policy file of the revision, older revisions are csv files
+	Snapshot string
+	Format   auth.PolicyFormat
+}
+
+func (pr PolicyRevision) ToProto() *auth.PolicyRevision {
+	c, _ := ptypes.TimestampProto(pr.CreatedAt)
+
+	revision := &auth.PolicyRevision{
+		Uuid:      pr.UUID,
+		Number:    pr.Number,
+		Reason:    pr.Reason,
+		CreatedAt: c,
+	}
+	return revision
+}
+
+func PolicyRevisionToProtoList(prl []PolicyRevision) []*auth.PolicyRevision {
+	var r []*auth.PolicyRevision
+	for _, i := range prl {
+		r = append(r, i.ToProto())
+	}
+	return r
+}
@@ -0,0 +1,95 @@
+package entity
+
+import (
+	"strings"
+
+	auth "github.com/golang-tire/auth/internal/proto/v1"
+	"github.com/golang/protobuf/ptypes"
+	"gorm.io/gorm"
+)
+
+// RelationTuple states that Subject has Relation to the object Namespace:ObjectID.
+type RelationTuple struct {
+	gorm.Model
+	OrganizationID uint   `gorm:"index"`
+	Namespace      string `gorm:"index:idx_relation_object"`
+	ObjectID       string `gorm:"index:idx_relation_object"`
+	Relation       string `gorm:"index:idx_relation_object"`
+	Subject        string `gorm:"index"`
+	// Revision is the relation change that wrote the tuple
+	Revision int64
+}
+
+// RelationChange records a write or delete of relation tuples, its ID is the store revision.
+type RelationChange struct {
+	gorm.Model
+	OrganizationID uint `gorm:"index"`
+	Operation      string
+	Tuples         int
+}
+
+// RelationDefinition declares a relation of an object namespace owned by an app.
+type RelationDefinition struct {
+	gorm.Model
+	UUID           string `gorm:"index"`
+	OrganizationID uint   `gorm:"index"`
+	AppID          uint
+	App            App
+	Namespace      string `gorm:"index"`
+	Relation       string
+	// Rewrites is the comma separated list of the included relations
+	Rewrites string
+}
+
+// Object returns the namespace:id form of the tuple object.
+func (rt RelationTuple) Object() string {
+	return rt.Namespace + ":" + rt.ObjectID
+}
+
+func (rt RelationTuple) ToProto() *auth.RelationTuple {
+	c, _ := ptypes.TimestampProto(rt.CreatedAt)
+	return &auth.RelationTuple{
+		Object:    rt.Object(),
+		Relation:  rt.Relation,
+		Subject:   rt.Subject,
+		CreatedAt: c,
+	}
+}
+
+func RelationTupleToProtoList(rtl []RelationTuple) []*auth.RelationTuple {
+	var r []*auth.RelationTuple
+	for _, i := range rtl {
+		r = append(r, i.ToProto())
+	}
+	return r
+}
+
+// RewriteList returns the included relations of the definition.
+func (rd RelationDefinition) RewriteList() []string {
+	if rd.Rewrites == "" {
+		return nil
+	}
+	return strings.Split(rd.Rewrites, ",")
+}
+
+func (rd RelationDefinition) ToProto() *auth.RelationDefinition {
+	c, _ := ptypes.TimestampProto(rd.CreatedAt)
+	u, _ := ptypes.TimestampProto(rd.UpdatedAt)
+	return &auth.RelationDefinition{
+		Uuid:      rd.UUID,
+		App:       rd.App.Name,
+		Namespace: rd.Namespace,
+		Relation:  rd.Relation,
+		Rewrites:  rd.RewriteList(),
+		CreatedAt: c,
+		UpdatedAt: u,
+	}
+}
+
+func RelationDefinitionToProtoList(rdl []RelationDefinition) []*auth.RelationDefinition {
+	var r []*auth.RelationDefinition
+	for _, i := range rdl {
+		r = append(r, i.ToProto())
+	}
+	return r
+}
@@ -0,0 +1,52 @@
+package entity
+
+import (
+	auth "github.com/golang-tire/auth/internal/proto/v1"
+	"github.com/golang/protobuf/ptypes"
+	"gorm.io/gorm"
+)
+
+// RoleTemplate is a blueprint of roles, a role created from it gets the bundles of the
+// template attached in a domain.
+type RoleTemplate struct {
+	gorm.Model
+	UUID           string `gorm:"index"`
+	OrganizationID uint   `gorm:"uniqueIndex:idx_org_template_name"`
+	Name           string `gorm:"uniqueIndex:idx_org_template_name"`
+	Description    string
+	Bundles        []RoleTemplateBundle `gorm:"foreignKey:TemplateID"`
+}
+
+type RoleTemplateBundle struct {
+	gorm.Model
+	OrganizationID uint `gorm:"index"`
+	TemplateID     uint `gorm:"index"`
+	BundleID       uint `gorm:"index"`
+	Bundle         PermissionBundle
+}
+
+func (t RoleTemplate) ToProto() *auth.RoleTemplate {
+	c, _ := ptypes.TimestampProto(t.CreatedAt)
+	u, _ := ptypes.TimestampProto(t.UpdatedAt)
+
+	template := &auth.RoleTemplate{
+		Uuid:        t.UUID,
+		Name:        t.Name,
+		Description: t.Description,
+		CreatedAt:   c,
+		UpdatedAt:   u,
+	}
+	for _, b := range t.Bundles {
+		template.Bundles = append(template.Bundles, b.Bundle.Name)
+		template.BundleUuids = append(template.BundleUuids, b.Bundle.UUID)
+	}
+	return template
+}
+
+func RoleTemplateToProtoList(tl []RoleTemplate) []*auth.RoleTemplate {
+	var r []*auth.RoleTemplate
+	for _, i := range tl {
+		r = append(r, i.ToProto())
+	}
+	return r
+}
@@ -9,9 +9,10 @@ import (
 
 type Role struct {
 	gorm.Model
-	UUID   string `gorm:"index"`
-	Title  string `gorm:"index"`
-	Enable bool
+	UUID           string `gorm:"index"`
+	OrganizationID uint   `gorm:"index"`
+	Title          string `gorm:"index"`
+	Enable         bool
 }
 
 func (rm Role) ToProto() *auth.Role {
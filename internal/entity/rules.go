@@ -1,9 +1,7 @@
 package entity
 
 import (
-	"github.com/ThreeDotsLabs/watermill/message"
 	"github.com/golang-tire/auth/internal/pkg/pubsub"
-	"github.com/golang-tire/pkg/log"
 	"google.golang.org/protobuf/proto"
 
 	auth "github.com/golang-tire/auth/internal/proto/v1"
@@ -13,41 +11,37 @@ import (
 
 type Rule struct {
 	gorm.Model
-	UUID     string `gorm:"index"`
-	RoleID   uint
-	Role     Role `gorm:"foreignKey:RoleID"`
-	DomainID uint
-	Domain   Domain `gorm:"foreignKey:DomainID"`
-	Resource string
-	Object   string
-	Action   string
-	Effect   string
+	UUID           string `gorm:"index"`
+	OrganizationID uint   `gorm:"index"`
+	RoleID         uint
+	Role           Role `gorm:"foreignKey:RoleID"`
+	DomainID       uint
+	Domain         Domain `gorm:"foreignKey:DomainID"`
+	Resource       string
+	Object         string
+	Action         string
+	Effect         string
+	Shadow         bool
+	// Condition is an optional casbin expression evaluated against the request context
+	Condition string
+	// AppResource and AppObject are the optional app resource and object the rule references
+	AppResourceID *uint
+	AppResource   *Resource `gorm:"foreignKey:AppResourceID"`
+	AppObjectID   *uint
+	AppObject     *Object `gorm:"foreignKey:AppObjectID"`
+	// RoleBundle is the bundle attachment the rule is generated from, if any
+	RoleBundleID *uint       `gorm:"index"`
+	RoleBundle   *RoleBundle `gorm:"foreignKey:RoleBundleID"`
 }
 
 func (r *Rule) AfterCreate(tx *gorm.DB) (err error) {
-	b, err := r.Bytes()
-	if err != nil {
-		log.Error("encode AfterCreate rule-change message to bytes failed", log.Err(err))
-	}
-	p := pubsub.Get()
-	pubErr := p.Publish("rule-change", message.NewMessage(r.UUID, b))
-	if pubErr != nil {
-		log.Error("send AfterCreate rule-change event failed", log.Err(pubErr))
-	}
+	pubsub.Notify(pubsub.RuleChange, "create rule "+r.UUID)
 	return nil
 }
 
 func (r *Rule) AfterUpdate(tx *gorm.DB) (err error) {
-	b, err := r.Bytes()
-	if err != nil {
-		log.Error("encode afterUpdate rule-change message to bytes failed", log.Err(err))
-	}
-	p := pubsub.Get()
-	pubErr := p.Publish("rule-change", message.NewMessage(r.UUID, b))
-	if pubErr != nil {
-		log.Error("send afterUpdate rule-change event failed", log.Err(pubErr))
-	}
-	return
+	pubsub.Notify(pubsub.RuleChange, "update rule "+r.UUID)
+	return nil
 }
 
 func (r *Rule) AfterDelete(tx *gorm.DB) (err error) {
@@ -71,9 +65,22 @@ func (r Rule) ToProto() *auth.Rule {
 		Domain:    r.Domain.Name,
 		Resource:  r.Resource,
 		Effect:    effect,
+		Shadow:    r.Shadow,
+		Condition: r.Condition,
 		CreatedAt: c,
 		UpdatedAt: u,
 	}
+	if r.AppResource != nil {
+		rule.ResourceUuid = r.AppResource.UUID
+		rule.App = r.AppResource.App.Name
+	}
+	if r.AppObject != nil {
+		rule.ObjectUuid = r.AppObject.UUID
+		rule.App = r.AppObject.App.Name
+	}
+	if r.RoleBundle != nil {
+		rule.Bundle = r.RoleBundle.Bundle.Name
+	}
 	return rule
 }
 
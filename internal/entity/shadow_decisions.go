@@ -0,0 +1,47 @@
+package entity
+
+import (
+	auth "github.com/golang-tire/auth/internal/proto/v1"
+	"github.com/golang/protobuf/ptypes"
+	"gorm.io/gorm"
+)
+
+type ShadowDecision struct {
+	gorm.Model
+	UUID           string `gorm:"index"`
+	OrganizationID uint   `gorm:"index"`
+	Username       string `gorm:"index"`
+	Domain         string
+	URI            string
+	Method         string
+	Resource       string
+	Object         string
+	LiveDecision   bool
+	ShadowDecision bool
+}
+
+func (sd ShadowDecision) ToProto() *auth.ShadowDecision {
+	c, _ := ptypes.TimestampProto(sd.CreatedAt)
+
+	decision := &auth.ShadowDecision{
+		Uuid:           sd.UUID,
+		Username:       sd.Username,
+		Domain:         sd.Domain,
+		Uri:            sd.URI,
+		Method:         sd.Method,
+		Resource:       sd.Resource,
+		Object:         sd.Object,
+		LiveDecision:   sd.LiveDecision,
+		ShadowDecision: sd.ShadowDecision,
+		CreatedAt:      c,
+	}
+	return decision
+}
+
+func ShadowDecisionToProtoList(sdl []ShadowDecision) []*auth.ShadowDecision {
+	var r []*auth.ShadowDecision
+	for _, i := range sdl {
+		r = append(r, i.ToProto())
+	}
+	return r
+}
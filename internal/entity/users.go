@@ -1,6 +1,8 @@
 package entity
 
 import (
+	"time"
+
 	auth "github.com/golang-tire/auth/internal/proto/v1"
 	"github.com/golang/protobuf/ptypes"
 	"gorm.io/gorm"
@@ -8,17 +10,19 @@ import (
 
 type User struct {
 	gorm.Model
-	UUID      string `gorm:"index"`
-	Firstname string
-	Lastname  string
-	Username  string `gorm:"index"`
-	Password  string
-	Gender    string
-	AvatarURL string
-	Email     string `gorm:"unique"`
-	Enable    bool
-	RawData   string
-	UserRoles []UserRole
+	UUID string `gorm:"index"`
+	// usernames and emails are unique in the organization of the user
+	OrganizationID uint `gorm:"uniqueIndex:idx_org_username;uniqueIndex:idx_org_email"`
+	Firstname      string
+	Lastname       string
+	Username       string `gorm:"uniqueIndex:idx_org_username"`
+	Password       string
+	Gender         string
+	AvatarURL      string
+	Email          string `gorm:"uniqueIndex:idx_org_email"`
+	Enable         bool
+	RawData        string
+	UserRoles      []UserRole
 }
 
 //
@@ -45,14 +49,32 @@ func (r *User) AfterDelete(tx *gorm.DB) (err error) {
 
 type UserRole struct {
 	gorm.Model
-	UUID     string `gorm:"index"`
-	RoleID   uint
-	Role     Role
-	User     User
-	UserID   uint
-	DomainID uint
-	Domain   Domain
-	Enable   bool
+	UUID           string `gorm:"index"`
+	OrganizationID uint   `gorm:"index"`
+	RoleID         uint
+	Role           Role
+	User           User
+	UserID         uint
+	DomainID       uint
+	Domain         Domain
+	Enable         bool
+	// ValidFrom and ExpiresAt bound the assignment in time, nil means unbounded
+	ValidFrom *time.Time
+	ExpiresAt *time.Time `gorm:"index"`
+}
+
+// Active reports whether the user role is enabled and valid at the given time.
+func (dr UserRole) Active(now time.Time) bool {
+	if !dr.Enable {
+		return false
+	}
+	if dr.ValidFrom != nil && now.Before(*dr.ValidFrom) {
+		return false
+	}
+	if dr.ExpiresAt != nil && !now.Before(*dr.ExpiresAt) {
+		return false
+	}
+	return true
 }
 
 func (r User) ToProto(secure bool) *auth.User {
@@ -98,9 +120,17 @@ func (dr UserRole) ToProto() *auth.UserRole {
 		Role:      dr.Role.Title,
 		Domain:    dr.Domain.Name,
 		Enable:    dr.Enable,
+		UserUuid:  dr.User.UUID,
+		Username:  dr.User.Username,
 		CreatedAt: c,
 		UpdatedAt: u,
 	}
+	if dr.ValidFrom != nil {
+		domainRole.ValidFrom, _ = ptypes.TimestampProto(*dr.ValidFrom)
+	}
+	if dr.ExpiresAt != nil {
+		domainRole.ExpiresAt, _ = ptypes.TimestampProto(*dr.ExpiresAt)
+	}
 	return domainRole
 }
 
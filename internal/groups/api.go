@@ -0,0 +1,112 @@
+package groups
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/golang-tire/auth/internal/pkg/helpers"
+	auth "github.com/golang-tire/auth/internal/proto/v1"
+	"github.com/golang-tire/pkg/grpcgw"
+	"github.com/golang/protobuf/ptypes/empty"
+	"github.com/grpc-ecosystem/grpc-gateway/v2/runtime"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+type API interface {
+	grpcgw.Controller
+}
+
+type api struct {
+	service Service
+	auth.GroupServiceServer
+}
+
+func (a api) InitRest(ctx context.Context, conn *grpc.ClientConn, mux *runtime.ServeMux, httpMux *http.ServeMux) {
+	cl := auth.NewGroupServiceClient(conn)
+	_ = auth.RegisterGroupServiceHandlerClient(ctx, mux, cl)
+}
+
+func (a api) InitGrpc(ctx context.Context, server *grpc.Server) {
+	auth.RegisterGroupServiceServer(server, a)
+}
+
+func (a api) ListGroups(ctx context.Context, request *auth.ListGroupsRequest) (*auth.ListGroupsResponse, error) {
+	offset, limit := helpers.GetOffsetAndLimit(request.Offset, request.Limit)
+	res, err := a.service.Query(ctx, request.Query, offset, limit)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+	return res, err
+}
+
+func (a api) GetGroup(ctx context.Context, request *auth.GetGroupRequest) (*auth.Group, error) {
+	res, err := a.service.Get(ctx, request.Uuid)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+	return res, err
+}
+
+func (a api) CreateGroup(ctx context.Context, request *auth.CreateGroupRequest) (*auth.Group, error) {
+	res, err := a.service.Create(ctx, request)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+	return res, err
+}
+
+func (a api) UpdateGroup(ctx context.Context, request *auth.UpdateGroupRequest) (*auth.Group, error) {
+	res, err := a.service.Update(ctx, request)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+	return res, err
+}
+
+func (a api) DeleteGroup(ctx context.Context, request *auth.DeleteGroupRequest) (*empty.Empty, error) {
+	_, err := a.service.Delete(ctx, request.Uuid)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+	return &empty.Empty{}, nil
+}
+
+func (a api) AddGroupMembers(ctx context.Context, request *auth.AddGroupMembersRequest) (*auth.Group, error) {
+	res, err := a.service.AddMembers(ctx, request)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+	return res, err
+}
+
+func (a api) RemoveGroupMember(ctx context.Context, request *auth.RemoveGroupMemberRequest) (*auth.Group, error) {
+	res, err := a.service.RemoveMember(ctx, request)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+	return res, err
+}
+
+func (a api) AddGroupRole(ctx context.Context, request *auth.AddGroupRoleRequest) (*auth.Group, error) {
+	res, err := a.service.AddGroupRole(ctx, request)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+	return res, err
+}
+
+func (a api) DeleteGroupRole(ctx context.Context, request *auth.DeleteGroupRoleRequest) (*auth.Group, error) {
+	res, err := a.service.DeleteGroupRole(ctx, request)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+	return res, err
+}
+
+func New(srv Service) API {
+	s := api{service: srv}
+	grpcgw.RegisterController(s)
+	return s
+}
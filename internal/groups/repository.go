@@ -0,0 +1,184 @@
+package groups
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+
+	"github.com/golang-tire/auth/internal/pkg/db"
+
+	"github.com/golang-tire/auth/internal/entity"
+)
+
+// Repository encapsulates the logic to access groups from the data source.
+type Repository interface {
+	// Get returns the group with the specified group UUID.
+	Get(ctx context.Context, uuid string) (entity.Group, error)
+	// Count returns the number of groups.
+	Count(ctx context.Context) (int64, error)
+	// Query returns the list of groups with the given offset and limit.
+	Query(ctx context.Context, query string, offset, limit int64) ([]entity.Group, int, error)
+	// Create saves a new group in the storage.
+	Create(ctx context.Context, group entity.Group) (string, error)
+	// Update updates the group with given UUID in the storage.
+	Update(ctx context.Context, group entity.Group) error
+	// Delete removes the group with its members and role bindings from the storage.
+	Delete(ctx context.Context, group entity.Group) error
+	// AddMember adds a user to a group.
+	AddMember(ctx context.Context, member entity.GroupMember) error
+	// RemoveMember removes a user from a group.
+	RemoveMember(ctx context.Context, member entity.GroupMember) error
+	// AddGroupRole binds a role in a domain to a group.
+	AddGroupRole(ctx context.Context, groupRole entity.GroupRole) (string, error)
+	// GetGroupRole returns the group role with the specified UUID.
+	GetGroupRole(ctx context.Context, uuid string) (entity.GroupRole, error)
+	// DeleteGroupRole removes a role binding from a group.
+	DeleteGroupRole(ctx context.Context, groupRole entity.GroupRole) error
+	// AllGroupRoles returns the enabled group roles with their group members.
+	AllGroupRoles(ctx context.Context) ([]entity.GroupRole, error)
+}
+
+// repository persists groups in database
+type repository struct {
+	db *db.DB
+}
+
+// NewRepository creates a new group repository
+func NewRepository(db *db.DB) Repository {
+	return repository{db}
+}
+
+// Get reads the group with the specified UUID from the database.
+func (r repository) Get(ctx context.Context, uuid string) (entity.Group, error) {
+	var group entity.Group
+	res := r.db.With(ctx).
+		Preload("Members.User").
+		Preload("Roles.Role").
+		Preload("Roles.Domain").
+		Where("uuid = ?", uuid).First(&group)
+	if res.Error != nil && res.Error == gorm.ErrRecordNotFound {
+		return entity.Group{}, fmt.Errorf("group with uuid `%s` not found", uuid)
+	}
+	return group, res.Error
+}
+
+// Create saves a new group record in the database.
+// It returns the UUID of the newly inserted group record.
+func (r repository) Create(ctx context.Context, group entity.Group) (string, error) {
+	now := time.Now()
+	group.UUID = uuid.New().String()
+	group.CreatedAt = now
+	group.UpdatedAt = now
+	res := r.db.With(ctx).Create(&group)
+	return group.UUID, res.Error
+}
+
+// Update saves the changes to a group in the database.
+func (r repository) Update(ctx context.Context, group entity.Group) error {
+	res := r.db.With(ctx).Omit("Members", "Roles").Save(&group)
+	return res.Error
+}
+
+// Delete deletes a group with its members and role bindings from the database.
+func (r repository) Delete(ctx context.Context, group entity.Group) error {
+	return r.db.Transactional(ctx, func(ctx context.Context) error {
+		if res := r.db.With(ctx).Where("group_id = ?", group.ID).Delete(&entity.GroupMember{}); res.Error != nil {
+			return res.Error
+		}
+		if res := r.db.With(ctx).Where("group_id = ?", group.ID).Delete(&entity.GroupRole{}); res.Error != nil {
+			return res.Error
+		}
+		return r.db.With(ctx).Omit("Members", "Roles").Delete(&group).Error
+	})
+}
+
+// Count returns the number of the group records in the database.
+func (r repository) Count(ctx context.Context) (int64, error) {
+	var count int64
+	res := r.db.With(ctx).Model(&entity.Group{}).Count(&count)
+	return count, res.Error
+}
+
+// Query retrieves the group records with the specified offset and limit from the database.
+func (r repository) Query(ctx context.Context, query string, offset, limit int64) ([]entity.Group, int, error) {
+	var _groups []entity.Group
+	var count int64
+
+	res := r.db.With(ctx).Model(&entity.Group{})
+	if len(query) >= 1 {
+		res = res.Where("name LIKE ?", "%"+query+"%")
+	}
+	if err := res.Count(&count).Error; err != nil {
+		return nil, 0, err
+	}
+
+	res = res.
+		Limit(int(limit)).
+		Offset(int(offset)).
+		Order("id asc").
+		Preload("Members.User").
+		Preload("Roles.Role").
+		Preload("Roles.Domain").
+		Find(&_groups)
+	return _groups, int(count), res.Error
+}
+
+// AddMember saves a group membership in the database.
+func (r repository) AddMember(ctx context.Context, member entity.GroupMember) error {
+	now := time.Now()
+	member.CreatedAt = now
+	member.UpdatedAt = now
+	res := r.db.With(ctx).Omit("User").Create(&member)
+	return res.Error
+}
+
+// RemoveMember deletes a group membership from the database.
+func (r repository) RemoveMember(ctx context.Context, member entity.GroupMember) error {
+	res := r.db.With(ctx).
+		Where("group_id = ? AND user_id = ?", member.GroupID, member.UserID).
+		Delete(&entity.GroupMember{})
+	return res.Error
+}
+
+// AddGroupRole saves a new group role record in the database.
+func (r repository) AddGroupRole(ctx context.Context, groupRole entity.GroupRole) (string, error) {
+	now := time.Now()
+	groupRole.UUID = uuid.New().String()
+	groupRole.CreatedAt = now
+	groupRole.UpdatedAt = now
+	res := r.db.With(ctx).Omit("Group").Create(&groupRole)
+	return groupRole.UUID, res.Error
+}
+
+// GetGroupRole reads the group role with the specified UUID from the database.
+func (r repository) GetGroupRole(ctx context.Context, uuid string) (entity.GroupRole, error) {
+	var groupRole entity.GroupRole
+	res := r.db.With(ctx).Preload("Domain").Where("uuid = ?", uuid).First(&groupRole)
+	if res.Error != nil && res.Error == gorm.ErrRecordNotFound {
+		return entity.GroupRole{}, fmt.Errorf("group role with uuid `%s` not found", uuid)
+	}
+	return groupRole, res.Error
+}
+
+// DeleteGroupRole deletes a group role from the database.
+func (r repository) DeleteGroupRole(ctx context.Context, groupRole entity.GroupRole) error {
+	res := r.db.With(ctx).Delete(&groupRole)
+	return res.Error
+}
+
+// AllGroupRoles returns the enabled group roles of the enabled groups in the database.
+func (r repository) AllGroupRoles(ctx context.Context) ([]entity.GroupRole, error) {
+	var _groupRoles []entity.GroupRole
+	res := r.db.With(ctx).
+		Order("group_roles.id asc").
+		Joins("JOIN groups ON groups.id = group_roles.group_id AND groups.deleted_at IS NULL").
+		Preload("Group.Members.User").
+		Preload("Role").
+		Preload("Domain").
+		Where("group_roles.enable = ? AND groups.enable = ?", true, true).
+		Find(&_groupRoles)
+	return _groupRoles, res.Error
+}
@@ -0,0 +1,148 @@
+package groups
+
+import (
+	"context"
+	"errors"
+
+	"github.com/google/uuid"
+
+	"github.com/golang-tire/auth/internal/entity"
+)
+
+var errCRUD = errors.New("error crud")
+var errNotFound = errors.New("not found")
+
+func NewMockRepository() *mockRepository {
+	return &mockRepository{}
+}
+
+type mockRepository struct {
+	items []entity.Group
+}
+
+func (m mockRepository) Get(ctx context.Context, uuid string) (entity.Group, error) {
+	for _, item := range m.items {
+		if item.UUID == uuid {
+			return item, nil
+		}
+	}
+	return entity.Group{}, errNotFound
+}
+
+func (m mockRepository) Count(ctx context.Context) (int64, error) {
+	return int64(len(m.items)), nil
+}
+
+func (m mockRepository) Query(ctx context.Context, query string, offset, limit int64) ([]entity.Group, int, error) {
+	return m.items, len(m.items), nil
+}
+
+func (m *mockRepository) Create(ctx context.Context, group entity.Group) (string, error) {
+	group.UUID = uuid.New().String()
+	if group.Name == "error" {
+		return group.UUID, errCRUD
+	}
+	group.ID = uint(len(m.items) + 1)
+	m.items = append(m.items, group)
+	return group.UUID, nil
+}
+
+func (m *mockRepository) Update(ctx context.Context, group entity.Group) error {
+	if group.Name == "error" {
+		return errCRUD
+	}
+	for i, item := range m.items {
+		if item.UUID == group.UUID {
+			group.Members = item.Members
+			group.Roles = item.Roles
+			m.items[i] = group
+			break
+		}
+	}
+	return nil
+}
+
+func (m *mockRepository) Delete(ctx context.Context, group entity.Group) error {
+	for i, item := range m.items {
+		if item.UUID == group.UUID {
+			m.items = append(m.items[:i], m.items[i+1:]...)
+			break
+		}
+	}
+	return nil
+}
+
+func (m *mockRepository) AddMember(ctx context.Context, member entity.GroupMember) error {
+	for i, item := range m.items {
+		if item.ID == member.GroupID {
+			m.items[i].Members = append(m.items[i].Members, member)
+			return nil
+		}
+	}
+	return errNotFound
+}
+
+func (m *mockRepository) RemoveMember(ctx context.Context, member entity.GroupMember) error {
+	for i, item := range m.items {
+		if item.ID != member.GroupID {
+			continue
+		}
+		for j, gm := range item.Members {
+			if gm.UserID == member.UserID {
+				m.items[i].Members = append(item.Members[:j], item.Members[j+1:]...)
+				break
+			}
+		}
+	}
+	return nil
+}
+
+func (m *mockRepository) AddGroupRole(ctx context.Context, groupRole entity.GroupRole) (string, error) {
+	groupRole.UUID = uuid.New().String()
+	for i, item := range m.items {
+		if item.ID == groupRole.GroupID {
+			m.items[i].Roles = append(m.items[i].Roles, groupRole)
+			return groupRole.UUID, nil
+		}
+	}
+	return "", errNotFound
+}
+
+func (m mockRepository) GetGroupRole(ctx context.Context, uuid string) (entity.GroupRole, error) {
+	for _, item := range m.items {
+		for _, gr := range item.Roles {
+			if gr.UUID == uuid {
+				return gr, nil
+			}
+		}
+	}
+	return entity.GroupRole{}, errNotFound
+}
+
+func (m *mockRepository) DeleteGroupRole(ctx context.Context, groupRole entity.GroupRole) error {
+	for i, item := range m.items {
+		for j, gr := range item.Roles {
+			if gr.UUID == groupRole.UUID {
+				m.items[i].Roles = append(item.Roles[:j], item.Roles[j+1:]...)
+				return nil
+			}
+		}
+	}
+	return nil
+}
+
+func (m mockRepository) AllGroupRoles(ctx context.Context) ([]entity.GroupRole, error) {
+	var items []entity.GroupRole
+	for _, item := range m.items {
+		if !item.Enable {
+			continue
+		}
+		for _, gr := range item.Roles {
+			if gr.Enable {
+				gr.Group = item
+				items = append(items, gr)
+			}
+		}
+	}
+	return items, nil
+}
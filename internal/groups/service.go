@@ -0,0 +1,325 @@
+package groups
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"time"
+
+	validation "github.com/go-ozzo/ozzo-validation/v4"
+	"github.com/go-ozzo/ozzo-validation/v4/is"
+
+	"github.com/golang-tire/auth/internal/domains"
+	"github.com/golang-tire/auth/internal/entity"
+	"github.com/golang-tire/auth/internal/pkg/pubsub"
+	"github.com/golang-tire/auth/internal/pkg/scope"
+	auth "github.com/golang-tire/auth/internal/proto/v1"
+	"github.com/golang-tire/auth/internal/roles"
+	"github.com/golang-tire/auth/internal/users"
+)
+
+// Service encapsulates use case logic for groups.
+type Service interface {
+	Get(ctx context.Context, uuid string) (*auth.Group, error)
+	Query(ctx context.Context, query string, offset, limit int64) (*auth.ListGroupsResponse, error)
+	Count(ctx context.Context) (int64, error)
+	Create(ctx context.Context, req *auth.CreateGroupRequest) (*auth.Group, error)
+	Update(ctx context.Context, req *auth.UpdateGroupRequest) (*auth.Group, error)
+	Delete(ctx context.Context, uuid string) (*auth.Group, error)
+
+	AddMembers(ctx context.Context, req *auth.AddGroupMembersRequest) (*auth.Group, error)
+	RemoveMember(ctx context.Context, req *auth.RemoveGroupMemberRequest) (*auth.Group, error)
+	AddGroupRole(ctx context.Context, req *auth.AddGroupRoleRequest) (*auth.Group, error)
+	DeleteGroupRole(ctx context.Context, req *auth.DeleteGroupRoleRequest) (*auth.Group, error)
+	// ListGroupRoles returns the enabled role bindings of the enabled groups with their members
+	ListGroupRoles(ctx context.Context) ([]entity.GroupRole, error)
+}
+
+// groupName limits group names to characters that are safe in policy lines
+var groupName = regexp.MustCompile(`^[\w.-]+$`)
+
+// ValidateCreateRequest validates the CreateGroupRequest fields.
+func ValidateCreateRequest(c *auth.CreateGroupRequest) error {
+	return validation.ValidateStruct(c,
+		validation.Field(&c.Name, validation.Required, validation.Length(0, 128), validation.Match(groupName)),
+		validation.Field(&c.Description, validation.Length(0, 512)),
+	)
+}
+
+// ValidateUpdateRequest validates the UpdateGroupRequest fields.
+func ValidateUpdateRequest(u *auth.UpdateGroupRequest) error {
+	return validation.ValidateStruct(u,
+		validation.Field(&u.Name, validation.Required, validation.Length(0, 128), validation.Match(groupName)),
+		validation.Field(&u.Description, validation.Length(0, 512)),
+	)
+}
+
+// ValidateAddGroupMembersRequest validates the AddGroupMembersRequest fields.
+func ValidateAddGroupMembersRequest(c *auth.AddGroupMembersRequest) error {
+	return validation.ValidateStruct(c,
+		validation.Field(&c.Uuid, validation.Required, is.UUID),
+		validation.Field(&c.UserUuids, validation.Required, validation.Each(is.UUID)),
+	)
+}
+
+// ValidateAddGroupRoleRequest validates the AddGroupRoleRequest fields.
+func ValidateAddGroupRoleRequest(c *auth.AddGroupRoleRequest) error {
+	return validation.ValidateStruct(c,
+		validation.Field(&c.Uuid, validation.Required, is.UUID),
+		validation.Field(&c.RoleUuid, validation.Required, is.UUID),
+		validation.Field(&c.DomainUuid, validation.Required, is.UUID),
+	)
+}
+
+type service struct {
+	repo        Repository
+	usersRepo   users.Repository
+	rolesRepo   roles.Repository
+	domainsRepo domains.Repository
+}
+
+// NewService creates a new group service.
+func NewService(repo Repository, usersRepo users.Repository, rolesRepo roles.Repository, domainsRepo domains.Repository) Service {
+	return service{repo, usersRepo, rolesRepo, domainsRepo}
+}
+
+// checkManaged returns an error unless the scope covers every domain the group holds roles in,
+// domain admins can not change groups that grant roles in other domains.
+func checkManaged(ctx context.Context, group entity.Group) error {
+	sc := scope.FromContext(ctx)
+	for _, gr := range group.Roles {
+		domain := gr.Domain.Name
+		if domain == "" {
+			domain = scope.GlobalDomain
+		}
+		if err := sc.Check(domain); err != nil {
+			return fmt.Errorf("group `%s` has roles outside of the administered domains", group.Name)
+		}
+	}
+	return nil
+}
+
+// Get returns the group with the specified the group UUID.
+func (s service) Get(ctx context.Context, uuid string) (*auth.Group, error) {
+	group, err := s.repo.Get(ctx, uuid)
+	if err != nil {
+		return nil, err
+	}
+	return group.ToProto(), nil
+}
+
+// Create creates a new group, new groups hold no roles so domain admins can create them.
+func (s service) Create(ctx context.Context, req *auth.CreateGroupRequest) (*auth.Group, error) {
+	if err := ValidateCreateRequest(req); err != nil {
+		return nil, err
+	}
+	id, err := s.repo.Create(ctx, entity.Group{
+		Name:        req.Name,
+		Description: req.Description,
+		Enable:      req.Enable,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return s.Get(ctx, id)
+}
+
+// Update updates the group with the specified UUID.
+func (s service) Update(ctx context.Context, req *auth.UpdateGroupRequest) (*auth.Group, error) {
+	if err := ValidateUpdateRequest(req); err != nil {
+		return nil, err
+	}
+
+	group, err := s.repo.Get(ctx, req.Uuid)
+	if err != nil {
+		return nil, err
+	}
+	if err := checkManaged(ctx, group); err != nil {
+		return nil, err
+	}
+
+	changed := group.Name != req.Name || group.Enable != req.Enable
+	group.Name = req.Name
+	group.Description = req.Description
+	group.Enable = req.Enable
+	group.UpdatedAt = time.Now()
+	if err := s.repo.Update(ctx, group); err != nil {
+		return nil, err
+	}
+	if changed {
+		pubsub.Notify(pubsub.UserChange, "update group "+group.UUID)
+	}
+	return s.Get(ctx, group.UUID)
+}
+
+// Delete deletes the group with the specified UUID along with its members and role bindings.
+func (s service) Delete(ctx context.Context, uuid string) (*auth.Group, error) {
+	group, err := s.repo.Get(ctx, uuid)
+	if err != nil {
+		return nil, err
+	}
+	if err := checkManaged(ctx, group); err != nil {
+		return nil, err
+	}
+	if err = s.repo.Delete(ctx, group); err != nil {
+		return nil, err
+	}
+	pubsub.Notify(pubsub.UserChange, "delete group "+group.UUID)
+	return group.ToProto(), nil
+}
+
+// Count returns the number of groups.
+func (s service) Count(ctx context.Context) (int64, error) {
+	return s.repo.Count(ctx)
+}
+
+// Query returns the groups with the specified offset and limit.
+func (s service) Query(ctx context.Context, query string, offset, limit int64) (*auth.ListGroupsResponse, error) {
+	items, count, err := s.repo.Query(ctx, query, offset, limit)
+	if err != nil {
+		return nil, err
+	}
+	return &auth.ListGroupsResponse{
+		Groups:     entity.GroupToProtoList(items),
+		TotalCount: int64(count),
+		Offset:     offset,
+		Limit:      limit,
+	}, nil
+}
+
+// AddMembers adds the users to the group, users who are already members are skipped.
+func (s service) AddMembers(ctx context.Context, req *auth.AddGroupMembersRequest) (*auth.Group, error) {
+	if err := ValidateAddGroupMembersRequest(req); err != nil {
+		return nil, err
+	}
+	group, err := s.repo.Get(ctx, req.Uuid)
+	if err != nil {
+		return nil, err
+	}
+	if err := checkManaged(ctx, group); err != nil {
+		return nil, err
+	}
+
+	members := map[uint]bool{}
+	for _, m := range group.Members {
+		members[m.UserID] = true
+	}
+
+	var added int
+	for _, userUUID := range req.UserUuids {
+		user, err := s.usersRepo.Get(ctx, userUUID)
+		if err != nil {
+			return nil, err
+		}
+		if members[user.ID] {
+			continue
+		}
+		err = s.repo.AddMember(ctx, entity.GroupMember{GroupID: group.ID, UserID: user.ID, User: user})
+		if err != nil {
+			return nil, err
+		}
+		members[user.ID] = true
+		added++
+	}
+	if added > 0 {
+		pubsub.Notify(pubsub.UserChange, fmt.Sprintf("add %d members to group %s", added, group.UUID))
+	}
+	return s.Get(ctx, group.UUID)
+}
+
+// RemoveMember removes the user from the group.
+func (s service) RemoveMember(ctx context.Context, req *auth.RemoveGroupMemberRequest) (*auth.Group, error) {
+	group, err := s.repo.Get(ctx, req.Uuid)
+	if err != nil {
+		return nil, err
+	}
+	if err := checkManaged(ctx, group); err != nil {
+		return nil, err
+	}
+
+	var member *entity.GroupMember
+	for i := range group.Members {
+		if group.Members[i].User.UUID == req.UserUuid {
+			member = &group.Members[i]
+			break
+		}
+	}
+	if member == nil {
+		return nil, fmt.Errorf("user `%s` is not a member of group `%s`", req.UserUuid, group.Name)
+	}
+
+	if err = s.repo.RemoveMember(ctx, *member); err != nil {
+		return nil, err
+	}
+	pubsub.Notify(pubsub.UserChange, "remove member from group "+group.UUID)
+	return s.Get(ctx, group.UUID)
+}
+
+// AddGroupRole binds the role in the domain to the group.
+func (s service) AddGroupRole(ctx context.Context, req *auth.AddGroupRoleRequest) (*auth.Group, error) {
+	if err := ValidateAddGroupRoleRequest(req); err != nil {
+		return nil, err
+	}
+	group, err := s.repo.Get(ctx, req.Uuid)
+	if err != nil {
+		return nil, err
+	}
+
+	domain, err := s.domainsRepo.Get(ctx, req.DomainUuid)
+	if err != nil {
+		return nil, err
+	}
+	if err := scope.FromContext(ctx).Check(domain.Name); err != nil {
+		return nil, err
+	}
+
+	role, err := s.rolesRepo.Get(ctx, req.RoleUuid)
+	if err != nil {
+		return nil, err
+	}
+	if err := roles.CheckGrant(ctx, s.rolesRepo, role); err != nil {
+		return nil, err
+	}
+
+	_, err = s.repo.AddGroupRole(ctx, entity.GroupRole{
+		GroupID:  group.ID,
+		Role:     role,
+		RoleID:   role.ID,
+		Domain:   domain,
+		DomainID: domain.ID,
+		Enable:   req.Enable,
+	})
+	if err != nil {
+		return nil, err
+	}
+	pubsub.Notify(pubsub.UserChange, "add group role to group "+group.UUID)
+	return s.Get(ctx, group.UUID)
+}
+
+// DeleteGroupRole removes the role binding from the group.
+func (s service) DeleteGroupRole(ctx context.Context, req *auth.DeleteGroupRoleRequest) (*auth.Group, error) {
+	group, err := s.repo.Get(ctx, req.Uuid)
+	if err != nil {
+		return nil, err
+	}
+	groupRole, err := s.repo.GetGroupRole(ctx, req.GroupRoleUuid)
+	if err != nil {
+		return nil, err
+	}
+	if groupRole.GroupID != group.ID {
+		return nil, fmt.Errorf("group role `%s` does not belong to group `%s`", groupRole.UUID, group.Name)
+	}
+	if err := scope.FromContext(ctx).Check(groupRole.Domain.Name); err != nil {
+		return nil, err
+	}
+	if err = s.repo.DeleteGroupRole(ctx, groupRole); err != nil {
+		return nil, err
+	}
+	pubsub.Notify(pubsub.UserChange, "delete group role "+groupRole.UUID)
+	return s.Get(ctx, group.UUID)
+}
+
+// ListGroupRoles returns the enabled role bindings of the enabled groups.
+func (s service) ListGroupRoles(ctx context.Context) ([]entity.GroupRole, error) {
+	return s.repo.AllGroupRoles(ctx)
+}
@@ -0,0 +1,163 @@
+package groups
+
+import (
+	"context"
+	"testing"
+
+	"github.com/golang-tire/auth/internal/domains"
+	"github.com/golang-tire/auth/internal/entity"
+	"github.com/golang-tire/auth/internal/pkg/scope"
+	auth "github.com/golang-tire/auth/internal/proto/v1"
+	"github.com/golang-tire/auth/internal/roles"
+	"github.com/golang-tire/auth/internal/users"
+	"github.com/stretchr/testify/assert"
+	"gorm.io/gorm"
+)
+
+func TestCreateGroupRequest_Validate(t *testing.T) {
+	tests := []struct {
+		name      string
+		model     *auth.CreateGroupRequest
+		wantError bool
+	}{
+		{"success", &auth.CreateGroupRequest{Name: "backend-team"}, false},
+		{"required", &auth.CreateGroupRequest{Name: ""}, true},
+		{"invalid name", &auth.CreateGroupRequest{Name: "backend, team"}, true},
+		{"too long", &auth.CreateGroupRequest{Name: "1234567890123456789012345678901234567890123456789012345678901234567890123456789012345678901234567890123456789012345678901234567890"}, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := ValidateCreateRequest(tt.model)
+			assert.Equal(t, tt.wantError, err != nil)
+		})
+	}
+}
+
+func Test_service_CRUD(t *testing.T) {
+	s := NewService(NewMockRepository(), users.NewMockRepository(), roles.NewMockRepository(), domains.NewMockRepository())
+	ctx := context.Background()
+
+	group, err := s.Create(ctx, &auth.CreateGroupRequest{Name: "backend", Enable: true})
+	assert.Nil(t, err)
+	assert.NotEmpty(t, group.Uuid)
+	assert.Equal(t, "backend", group.Name)
+
+	_, err = s.Create(ctx, &auth.CreateGroupRequest{Name: "error"})
+	assert.Equal(t, errCRUD, err)
+	count, _ := s.Count(ctx)
+	assert.Equal(t, int64(1), count)
+
+	group, err = s.Update(ctx, &auth.UpdateGroupRequest{Uuid: group.Uuid, Name: "backend-team", Description: "backend developers", Enable: true})
+	assert.Nil(t, err)
+	assert.Equal(t, "backend-team", group.Name)
+	assert.Equal(t, "backend developers", group.Description)
+
+	_, err = s.Delete(ctx, group.Uuid)
+	assert.Nil(t, err)
+	_, err = s.Get(ctx, group.Uuid)
+	assert.NotNil(t, err)
+}
+
+func Test_service_Bindings(t *testing.T) {
+	ctx := context.Background()
+	usersRepo := users.NewMockRepository()
+	rolesRepo := roles.NewMockRepository()
+	domainsRepo := domains.NewMockRepository()
+	s := NewService(NewMockRepository(), usersRepo, rolesRepo, domainsRepo)
+
+	roleUUID, _ := rolesRepo.Create(ctx, entity.Role{Title: "writer", Enable: true})
+	domainUUID, _ := domainsRepo.Create(ctx, entity.Domain{Name: "foo.bar", Enable: true})
+	var userUUIDs []string
+	for i, name := range []string{"alice1", "bobby1"} {
+		id, _ := usersRepo.Create(ctx, entity.User{Model: gorm.Model{ID: uint(i + 1)}, Username: name, Email: name + "@foo.bar"})
+		userUUIDs = append(userUUIDs, id)
+	}
+
+	group, err := s.Create(ctx, &auth.CreateGroupRequest{Name: "backend", Enable: true})
+	assert.Nil(t, err)
+
+	// members are added once
+	group, err = s.AddMembers(ctx, &auth.AddGroupMembersRequest{Uuid: group.Uuid, UserUuids: userUUIDs})
+	assert.Nil(t, err)
+	assert.Len(t, group.Members, 2)
+	group, err = s.AddMembers(ctx, &auth.AddGroupMembersRequest{Uuid: group.Uuid, UserUuids: userUUIDs[:1]})
+	assert.Nil(t, err)
+	assert.Len(t, group.Members, 2)
+
+	_, err = s.AddGroupRole(ctx, &auth.AddGroupRoleRequest{Uuid: group.Uuid, RoleUuid: roleUUID, DomainUuid: "not-a-uuid"})
+	assert.NotNil(t, err)
+	group, err = s.AddGroupRole(ctx, &auth.AddGroupRoleRequest{Uuid: group.Uuid, RoleUuid: roleUUID, DomainUuid: domainUUID, Enable: true})
+	assert.Nil(t, err)
+	assert.Len(t, group.Roles, 1)
+	assert.Equal(t, "writer", group.Roles[0].Role)
+	assert.Equal(t, "foo.bar", group.Roles[0].Domain)
+
+	groupRoles, err := s.ListGroupRoles(ctx)
+	assert.Nil(t, err)
+	assert.Len(t, groupRoles, 1)
+	assert.Len(t, groupRoles[0].Group.Members, 2)
+
+	group, err = s.RemoveMember(ctx, &auth.RemoveGroupMemberRequest{Uuid: group.Uuid, UserUuid: userUUIDs[1]})
+	assert.Nil(t, err)
+	assert.Len(t, group.Members, 1)
+	assert.Equal(t, "alice1", group.Members[0].Username)
+	_, err = s.RemoveMember(ctx, &auth.RemoveGroupMemberRequest{Uuid: group.Uuid, UserUuid: userUUIDs[1]})
+	assert.NotNil(t, err)
+
+	// disabled groups grant nothing
+	_, err = s.Update(ctx, &auth.UpdateGroupRequest{Uuid: group.Uuid, Name: "backend"})
+	assert.Nil(t, err)
+	groupRoles, _ = s.ListGroupRoles(ctx)
+	assert.Len(t, groupRoles, 0)
+
+	group, err = s.DeleteGroupRole(ctx, &auth.DeleteGroupRoleRequest{Uuid: group.Uuid, GroupRoleUuid: group.Roles[0].Uuid})
+	assert.Nil(t, err)
+	assert.Len(t, group.Roles, 0)
+}
+
+func Test_service_DomainAdmin(t *testing.T) {
+	ctx := context.Background()
+	usersRepo := users.NewMockRepository()
+	rolesRepo := roles.NewMockRepository()
+	domainsRepo := domains.NewMockRepository()
+	s := NewService(NewMockRepository(), usersRepo, rolesRepo, domainsRepo)
+
+	roleUUID, _ := rolesRepo.Create(ctx, entity.Role{Title: "writer", Enable: true})
+	platformUUID, _ := rolesRepo.Create(ctx, entity.Role{Title: scope.PlatformAdminRole, Enable: true})
+	fooUUID, _ := domainsRepo.Create(ctx, entity.Domain{Name: "foo.bar", Enable: true})
+	barUUID, _ := domainsRepo.Create(ctx, entity.Domain{Name: "bar.baz", Enable: true})
+	userUUID, _ := usersRepo.Create(ctx, entity.User{Model: gorm.Model{ID: 1}, Username: "alice1", Email: "alice1@foo.bar"})
+	adminCtx := scope.NewContext(ctx, scope.Domains("foo.bar"))
+
+	// the domain admin manages groups holding roles in foo.bar only
+	foo, err := s.Create(adminCtx, &auth.CreateGroupRequest{Name: "foo-team", Enable: true})
+	assert.Nil(t, err)
+	_, err = s.AddGroupRole(adminCtx, &auth.AddGroupRoleRequest{Uuid: foo.Uuid, RoleUuid: roleUUID, DomainUuid: fooUUID, Enable: true})
+	assert.Nil(t, err)
+	_, err = s.AddGroupRole(adminCtx, &auth.AddGroupRoleRequest{Uuid: foo.Uuid, RoleUuid: platformUUID, DomainUuid: fooUUID, Enable: true})
+	assert.NotNil(t, err)
+	foo, err = s.AddMembers(adminCtx, &auth.AddGroupMembersRequest{Uuid: foo.Uuid, UserUuids: []string{userUUID}})
+	assert.Nil(t, err)
+	assert.Len(t, foo.Members, 1)
+
+	bar, err := s.Create(ctx, &auth.CreateGroupRequest{Name: "bar-team", Enable: true})
+	assert.Nil(t, err)
+	_, err = s.AddGroupRole(ctx, &auth.AddGroupRoleRequest{Uuid: bar.Uuid, RoleUuid: roleUUID, DomainUuid: barUUID, Enable: true})
+	assert.Nil(t, err)
+	_, err = s.AddMembers(ctx, &auth.AddGroupMembersRequest{Uuid: bar.Uuid, UserUuids: []string{userUUID}})
+	assert.Nil(t, err)
+
+	_, err = s.AddMembers(adminCtx, &auth.AddGroupMembersRequest{Uuid: bar.Uuid, UserUuids: []string{userUUID}})
+	assert.NotNil(t, err)
+	_, err = s.RemoveMember(adminCtx, &auth.RemoveGroupMemberRequest{Uuid: bar.Uuid, UserUuid: userUUID})
+	assert.NotNil(t, err)
+	_, err = s.Update(adminCtx, &auth.UpdateGroupRequest{Uuid: bar.Uuid, Name: "bar-team"})
+	assert.NotNil(t, err)
+	_, err = s.Delete(adminCtx, bar.Uuid)
+	assert.NotNil(t, err)
+
+	_, err = s.RemoveMember(adminCtx, &auth.RemoveGroupMemberRequest{Uuid: foo.Uuid, UserUuid: userUUID})
+	assert.Nil(t, err)
+	_, err = s.Delete(adminCtx, foo.Uuid)
+	assert.Nil(t, err)
+}
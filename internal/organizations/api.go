@@ -0,0 +1,80 @@
+package organizations
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/golang-tire/auth/internal/pkg/helpers"
+	auth "github.com/golang-tire/auth/internal/proto/v1"
+	"github.com/golang-tire/pkg/grpcgw"
+	"github.com/golang/protobuf/ptypes/empty"
+	"github.com/grpc-ecosystem/grpc-gateway/v2/runtime"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+type API interface {
+	grpcgw.Controller
+}
+
+type api struct {
+	service Service
+	auth.OrganizationServiceServer
+}
+
+func (a api) InitRest(ctx context.Context, conn *grpc.ClientConn, mux *runtime.ServeMux, httpMux *http.ServeMux) {
+	cl := auth.NewOrganizationServiceClient(conn)
+	_ = auth.RegisterOrganizationServiceHandlerClient(ctx, mux, cl)
+}
+
+func (a api) InitGrpc(ctx context.Context, server *grpc.Server) {
+	auth.RegisterOrganizationServiceServer(server, a)
+}
+
+func (a api) ListOrganizations(ctx context.Context, request *auth.ListOrganizationsRequest) (*auth.ListOrganizationsResponse, error) {
+	offset, limit := helpers.GetOffsetAndLimit(request.Offset, request.Limit)
+	res, err := a.service.Query(ctx, request.Query, offset, limit)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+	return res, err
+}
+
+func (a api) GetOrganization(ctx context.Context, request *auth.GetOrganizationRequest) (*auth.Organization, error) {
+	res, err := a.service.Get(ctx, request.Uuid)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+	return res, err
+}
+
+func (a api) CreateOrganization(ctx context.Context, request *auth.CreateOrganizationRequest) (*auth.Organization, error) {
+	res, err := a.service.Create(ctx, request)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+	return res, err
+}
+
+func (a api) UpdateOrganization(ctx context.Context, request *auth.UpdateOrganizationRequest) (*auth.Organization, error) {
+	res, err := a.service.Update(ctx, request)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+	return res, err
+}
+
+func (a api) DeleteOrganization(ctx context.Context, request *auth.DeleteOrganizationRequest) (*empty.Empty, error) {
+	_, err := a.service.Delete(ctx, request.Uuid)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+	return &empty.Empty{}, nil
+}
+
+func New(srv Service) API {
+	s := api{service: srv}
+	grpcgw.RegisterController(s)
+	return s
+}
@@ -0,0 +1,141 @@
+package organizations
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"gorm.io/gorm"
+
+	"github.com/golang-tire/auth/internal/pkg/db"
+	"github.com/golang-tire/auth/internal/pkg/tenant"
+
+	"github.com/google/uuid"
+
+	"github.com/golang-tire/auth/internal/entity"
+)
+
+// Repository encapsulates the logic to access organizations from the data source.
+type Repository interface {
+	// Get returns the organization with the specified organization UUID.
+	Get(ctx context.Context, uuid string) (entity.Organization, error)
+	// GetByName returns the organization with the specified organization name.
+	GetByName(ctx context.Context, name string) (entity.Organization, error)
+	// Count returns the number of organizations.
+	Count(ctx context.Context) (int64, error)
+	// Query returns the list of organizations with the given offset and limit.
+	Query(ctx context.Context, query string, offset, limit int64) ([]entity.Organization, int, error)
+	// Create saves a new organization in the storage.
+	Create(ctx context.Context, organization entity.Organization) (string, error)
+	// Update updates the organization with given UUID in the storage.
+	Update(ctx context.Context, organization entity.Organization) error
+	// Delete removes the organization with given UUID from the storage.
+	Delete(ctx context.Context, organization entity.Organization) error
+	// All returns every organization.
+	All(ctx context.Context) ([]entity.Organization, error)
+	// HasRecords reports whether domains or users belong to the organization.
+	HasRecords(ctx context.Context, organization entity.Organization) (bool, error)
+}
+
+// repository persists organizations in database
+type repository struct {
+	db *db.DB
+}
+
+// NewRepository creates a new organization repository
+func NewRepository(db *db.DB) Repository {
+	return repository{db}
+}
+
+// Get reads the organization with the specified ID from the database.
+func (r repository) Get(ctx context.Context, uuid string) (entity.Organization, error) {
+	var organization entity.Organization
+	res := r.db.With(ctx).Where("uuid = ?", uuid).First(&organization)
+	if res.Error != nil && res.Error == gorm.ErrRecordNotFound {
+		return entity.Organization{}, fmt.Errorf("organization with uuid `%s` not found", uuid)
+	}
+	return organization, res.Error
+}
+
+// GetByName returns the organization with the specified organization name.
+func (r repository) GetByName(ctx context.Context, name string) (entity.Organization, error) {
+	var organization entity.Organization
+	res := r.db.With(ctx).Where("name = ?", name).First(&organization)
+	if res.Error != nil && res.Error == gorm.ErrRecordNotFound {
+		return entity.Organization{}, fmt.Errorf("organization with name `%s` not found", name)
+	}
+	return organization, res.Error
+}
+
+// Create saves a new organization record in the database.
+// It returns the UUID of the newly inserted organization record.
+func (r repository) Create(ctx context.Context, organization entity.Organization) (string, error) {
+	now := time.Now()
+	organization.UUID = uuid.New().String()
+	organization.CreatedAt = now
+	organization.UpdatedAt = now
+	res := r.db.With(ctx).Create(&organization)
+	return organization.UUID, res.Error
+}
+
+// Update saves the changes to an organization in the database.
+func (r repository) Update(ctx context.Context, organization entity.Organization) error {
+	res := r.db.With(ctx).Save(&organization)
+	return res.Error
+}
+
+// Delete deletes an organization with the specified ID from the database.
+func (r repository) Delete(ctx context.Context, organization entity.Organization) error {
+	res := r.db.With(ctx).Delete(&organization)
+	return res.Error
+}
+
+// Count returns the number of the organization records in the database.
+func (r repository) Count(ctx context.Context) (int64, error) {
+	var count int64
+	res := r.db.With(ctx).Model(&entity.Organization{}).Count(&count)
+	return count, res.Error
+}
+
+// Query retrieves the organization records with the specified offset and limit from the database.
+func (r repository) Query(ctx context.Context, query string, offset, limit int64) ([]entity.Organization, int, error) {
+	var _organizations []entity.Organization
+	res := r.db.With(ctx).
+		Limit(int(limit)).
+		Offset(int(offset)).
+		Order("id asc")
+
+	if len(query) >= 1 {
+		res = res.Where("name LIKE ?", "%"+query+"%").Find(&_organizations)
+	} else {
+		res = res.Find(&_organizations)
+	}
+
+	count, err := r.Count(ctx)
+	if err != nil {
+		return nil, 0, err
+	}
+	return _organizations, int(count), res.Error
+}
+
+// All retrieves every organization record from the database.
+func (r repository) All(ctx context.Context) ([]entity.Organization, error) {
+	var items []entity.Organization
+	res := r.db.With(ctx).Order("id asc").Find(&items)
+	return items, res.Error
+}
+
+// HasRecords counts the domains and users of the organization in the database.
+func (r repository) HasRecords(ctx context.Context, organization entity.Organization) (bool, error) {
+	ctx = tenant.NewContext(ctx, organization.ID)
+	for _, model := range []interface{}{&entity.Domain{}, &entity.User{}} {
+		var count int64
+		if err := r.db.With(ctx).Model(model).Count(&count).Error; err != nil {
+			return false, err
+		}
+		if count > 0 {
+			return true, nil
+		}
+	}
+	return false, nil
+}
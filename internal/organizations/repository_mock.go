@@ -0,0 +1,94 @@
+package organizations
+
+import (
+	"context"
+	"errors"
+
+	"gorm.io/gorm"
+
+	"github.com/golang-tire/auth/internal/entity"
+	"github.com/google/uuid"
+)
+
+var errCRUD = errors.New("error crud")
+
+func NewMockRepository() *mockRepository {
+	return &mockRepository{records: map[uint]bool{}}
+}
+
+type mockRepository struct {
+	items  []entity.Organization
+	lastID uint
+	// records are the organizations with domains or users
+	records map[uint]bool
+}
+
+func (m mockRepository) GetByName(ctx context.Context, name string) (entity.Organization, error) {
+	for _, item := range m.items {
+		if item.Name == name {
+			return item, nil
+		}
+	}
+	return entity.Organization{}, gorm.ErrRecordNotFound
+}
+
+func (m mockRepository) Get(ctx context.Context, id string) (entity.Organization, error) {
+	for _, item := range m.items {
+		if item.UUID == id {
+			return item, nil
+		}
+	}
+	return entity.Organization{}, gorm.ErrRecordNotFound
+}
+
+func (m mockRepository) Count(ctx context.Context) (int64, error) {
+	return int64(len(m.items)), nil
+}
+
+func (m mockRepository) Query(ctx context.Context, query string, offset, limit int64) ([]entity.Organization, int, error) {
+	return m.items, len(m.items), nil
+}
+
+func (m mockRepository) All(ctx context.Context) ([]entity.Organization, error) {
+	return m.items, nil
+}
+
+func (m *mockRepository) Create(ctx context.Context, organization entity.Organization) (string, error) {
+	Uuid := uuid.New().String()
+	organization.UUID = Uuid
+	if organization.Name == "error" {
+		return Uuid, errCRUD
+	}
+	m.lastID++
+	organization.ID = m.lastID
+	m.items = append(m.items, organization)
+	return Uuid, nil
+}
+
+func (m *mockRepository) Update(ctx context.Context, organization entity.Organization) error {
+	if organization.Name == "error" {
+		return errCRUD
+	}
+	for i, item := range m.items {
+		if item.UUID == organization.UUID {
+			m.items[i] = organization
+			break
+		}
+	}
+	return nil
+}
+
+func (m *mockRepository) Delete(ctx context.Context, organization entity.Organization) error {
+	for i, item := range m.items {
+		if item.UUID == organization.UUID {
+			m.items[i] = m.items[len(m.items)-1]
+			m.items = m.items[:len(m.items)-1]
+			break
+		}
+	}
+	return nil
+}
+
+func (m mockRepository) HasRecords(ctx context.Context, organization entity.Organization) (bool, error) {
+	return m.records[organization.ID], nil
+}
@@ -0,0 +1,69 @@
+package organizations
+
+import (
+	"context"
+	"testing"
+
+	"github.com/golang-tire/auth/internal/pkg/db"
+
+	"github.com/golang-tire/auth/internal/entity"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRepository(t *testing.T) {
+	database := db.NewForTest(t, []interface{}{&entity.Organization{}, &entity.Domain{}, &entity.User{}})
+	err := db.ResetTables(t, database, "organizations")
+	assert.Nil(t, err)
+	repo := NewRepository(database)
+
+	ctx := context.Background()
+	// initial count
+	count, err := repo.Count(ctx)
+	assert.Nil(t, err)
+
+	// create
+	testUuid, err := repo.Create(ctx, entity.Organization{
+		Name:  "acme",
+		Title: "Acme",
+	})
+	assert.Nil(t, err)
+	count2, _ := repo.Count(ctx)
+	assert.Equal(t, int64(1), count2-count)
+
+	// get
+	organization, err := repo.Get(ctx, testUuid)
+	assert.Nil(t, err)
+	assert.Equal(t, "acme", organization.Name)
+	_, err = repo.Get(ctx, "test0")
+	assert.NotNil(t, err)
+
+	// get by name
+	organization, err = repo.GetByName(ctx, "acme")
+	assert.Nil(t, err)
+	assert.Equal(t, testUuid, organization.UUID)
+	_, err = repo.GetByName(ctx, "test0")
+	assert.NotNil(t, err)
+
+	// records
+	inUse, err := repo.HasRecords(ctx, organization)
+	assert.Nil(t, err)
+	assert.False(t, inUse)
+
+	// update
+	organization.Name = "acme-corp"
+	err = repo.Update(ctx, organization)
+	assert.Nil(t, err)
+	organization, _ = repo.Get(ctx, testUuid)
+	assert.Equal(t, "acme-corp", organization.Name)
+
+	// query
+	_, count3, err := repo.Query(ctx, "", 0, count2)
+	assert.Nil(t, err)
+	assert.Equal(t, count2, int64(count3))
+
+	// delete
+	err = repo.Delete(ctx, organization)
+	assert.Nil(t, err)
+	_, err = repo.Get(ctx, testUuid)
+	assert.NotNil(t, err)
+}
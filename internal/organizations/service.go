@@ -0,0 +1,187 @@
+package organizations
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"regexp"
+	"time"
+
+	validation "github.com/go-ozzo/ozzo-validation/v4"
+
+	"github.com/golang-tire/auth/internal/entity"
+	"github.com/golang-tire/auth/internal/pkg/pubsub"
+	"github.com/golang-tire/auth/internal/pkg/scope"
+	"github.com/golang-tire/auth/internal/pkg/tenant"
+	auth "github.com/golang-tire/auth/internal/proto/v1"
+)
+
+// namePattern is the form of the organization names, they are used in headers and logs.
+var namePattern = regexp.MustCompile(`^[a-z0-9][a-z0-9-]*$`)
+
+// Service encapsulates use case logic for organizations.
+type Service interface {
+	Get(ctx context.Context, uuid string) (*auth.Organization, error)
+	Query(ctx context.Context, query string, offset, limit int64) (*auth.ListOrganizationsResponse, error)
+	Count(ctx context.Context) (int64, error)
+	Create(ctx context.Context, input *auth.CreateOrganizationRequest) (*auth.Organization, error)
+	Update(ctx context.Context, input *auth.UpdateOrganizationRequest) (*auth.Organization, error)
+	Delete(ctx context.Context, uuid string) (*auth.Organization, error)
+	// Resolve returns the organization with the specified UUID or name.
+	Resolve(ctx context.Context, organization string) (entity.Organization, error)
+	// All returns every organization, it is meant for the server itself.
+	All(ctx context.Context) ([]entity.Organization, error)
+}
+
+// ValidateCreateRequest validates the CreateOrganizationRequest fields.
+func ValidateCreateRequest(c *auth.CreateOrganizationRequest) error {
+	return validation.ValidateStruct(c,
+		validation.Field(&c.Name, validation.Required, validation.Length(0, 64), validation.Match(namePattern)),
+		validation.Field(&c.Title, validation.Length(0, 128)),
+	)
+}
+
+// ValidateUpdateRequest validates the UpdateOrganizationRequest fields.
+func ValidateUpdateRequest(u *auth.UpdateOrganizationRequest) error {
+	return validation.ValidateStruct(u,
+		validation.Field(&u.Name, validation.Required, validation.Length(0, 64), validation.Match(namePattern)),
+		validation.Field(&u.Title, validation.Length(0, 128)),
+	)
+}
+
+type service struct {
+	repo Repository
+}
+
+// NewService creates a new organization service.
+func NewService(repo Repository) Service {
+	return service{repo}
+}
+
+// checkSuperAdmin returns an error unless the caller is a super administrator, a global
+// administrator of the platform.
+func checkSuperAdmin(ctx context.Context) error {
+	if !tenant.IsPlatform(ctx) {
+		return errors.New("organizations are managed by the super administrators of the platform")
+	}
+	return scope.FromContext(ctx).CheckGlobal("manage organizations")
+}
+
+// Get returns the organization with the specified the organization UUID.
+func (s service) Get(ctx context.Context, UUID string) (*auth.Organization, error) {
+	if err := checkSuperAdmin(ctx); err != nil {
+		return nil, err
+	}
+	organization, err := s.repo.Get(ctx, UUID)
+	if err != nil {
+		return nil, err
+	}
+	return organization.ToProto(), nil
+}
+
+// Create creates a new organization.
+func (s service) Create(ctx context.Context, req *auth.CreateOrganizationRequest) (*auth.Organization, error) {
+	if err := ValidateCreateRequest(req); err != nil {
+		return nil, err
+	}
+	if err := checkSuperAdmin(ctx); err != nil {
+		return nil, err
+	}
+	if _, err := s.repo.GetByName(ctx, req.Name); err == nil {
+		return nil, fmt.Errorf("organization `%s` already exists", req.Name)
+	}
+	id, err := s.repo.Create(ctx, entity.Organization{
+		Name:  req.Name,
+		Title: req.Title,
+	})
+	if err != nil {
+		return nil, err
+	}
+	// the built-in roles get their policy in the organization
+	pubsub.Notify(pubsub.RuleChange, "create organization "+id)
+	return s.Get(ctx, id)
+}
+
+// Update updates the organization with the specified UUID.
+func (s service) Update(ctx context.Context, req *auth.UpdateOrganizationRequest) (*auth.Organization, error) {
+	if err := ValidateUpdateRequest(req); err != nil {
+		return nil, err
+	}
+	if err := checkSuperAdmin(ctx); err != nil {
+		return nil, err
+	}
+
+	organization, err := s.repo.Get(ctx, req.Uuid)
+	if err != nil {
+		return nil, err
+	}
+	if other, err := s.repo.GetByName(ctx, req.Name); err == nil && other.UUID != organization.UUID {
+		return nil, fmt.Errorf("organization `%s` already exists", req.Name)
+	}
+	organization.Name = req.Name
+	organization.Title = req.Title
+	organization.UpdatedAt = time.Now()
+	if err := s.repo.Update(ctx, organization); err != nil {
+		return nil, err
+	}
+	return organization.ToProto(), nil
+}
+
+// Delete deletes the organization with the specified UUID, organizations with domains or
+// users cannot be deleted.
+func (s service) Delete(ctx context.Context, UUID string) (*auth.Organization, error) {
+	if err := checkSuperAdmin(ctx); err != nil {
+		return nil, err
+	}
+	organization, err := s.repo.Get(ctx, UUID)
+	if err != nil {
+		return nil, err
+	}
+	inUse, err := s.repo.HasRecords(ctx, organization)
+	if err != nil {
+		return nil, err
+	}
+	if inUse {
+		return nil, fmt.Errorf("organization `%s` still has domains or users", organization.Name)
+	}
+	if err = s.repo.Delete(ctx, organization); err != nil {
+		return nil, err
+	}
+	pubsub.Notify(pubsub.RuleChange, "delete organization "+organization.UUID)
+	return organization.ToProto(), nil
+}
+
+// Count returns the number of organizations.
+func (s service) Count(ctx context.Context) (int64, error) {
+	return s.repo.Count(ctx)
+}
+
+// Query returns the organizations with the specified offset and limit.
+func (s service) Query(ctx context.Context, query string, offset, limit int64) (*auth.ListOrganizationsResponse, error) {
+	if err := checkSuperAdmin(ctx); err != nil {
+		return nil, err
+	}
+	items, count, err := s.repo.Query(ctx, query, offset, limit)
+	if err != nil {
+		return nil, err
+	}
+	return &auth.ListOrganizationsResponse{
+		Organizations: entity.OrganizationToProtoList(items),
+		TotalCount:    int64(count),
+		Offset:        offset,
+		Limit:         limit,
+	}, nil
+}
+
+// Resolve returns the organization with the specified UUID or name.
+func (s service) Resolve(ctx context.Context, organization string) (entity.Organization, error) {
+	if item, err := s.repo.Get(ctx, organization); err == nil {
+		return item, nil
+	}
+	return s.repo.GetByName(ctx, organization)
+}
+
+// All returns every organization.
+func (s service) All(ctx context.Context) ([]entity.Organization, error) {
+	return s.repo.All(ctx)
+}
@@ -0,0 +1,143 @@
+package organizations
+
+import (
+	"context"
+	"testing"
+
+	"github.com/golang-tire/auth/internal/pkg/scope"
+	"github.com/golang-tire/auth/internal/pkg/tenant"
+	auth "github.com/golang-tire/auth/internal/proto/v1"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCreateOrganizationRequest_Validate(t *testing.T) {
+	tests := []struct {
+		name      string
+		model     *auth.CreateOrganizationRequest
+		wantError bool
+	}{
+		{"success", &auth.CreateOrganizationRequest{Name: "acme", Title: "Acme Inc."}, false},
+		{"required", &auth.CreateOrganizationRequest{Name: ""}, true},
+		{"invalid name", &auth.CreateOrganizationRequest{Name: "Acme Inc"}, true},
+		{"too long", &auth.CreateOrganizationRequest{Name: "12345678901234567890123456789012345678901234567890123456789012345"}, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := ValidateCreateRequest(tt.model)
+			assert.Equal(t, tt.wantError, err != nil)
+		})
+	}
+}
+
+func TestUpdateOrganizationRequest_Validate(t *testing.T) {
+	tests := []struct {
+		name      string
+		model     *auth.UpdateOrganizationRequest
+		wantError bool
+	}{
+		{"success", &auth.UpdateOrganizationRequest{Name: "acme"}, false},
+		{"required", &auth.UpdateOrganizationRequest{Name: ""}, true},
+		{"invalid name", &auth.UpdateOrganizationRequest{Name: "-acme"}, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := ValidateUpdateRequest(tt.model)
+			assert.Equal(t, tt.wantError, err != nil)
+		})
+	}
+}
+
+func Test_service_CRUD(t *testing.T) {
+	repo := NewMockRepository()
+	s := NewService(repo)
+	ctx := context.Background()
+
+	// initial count
+	count, _ := s.Count(ctx)
+	assert.Equal(t, int64(0), count)
+
+	// successful creation
+	organization, err := s.Create(ctx, &auth.CreateOrganizationRequest{Name: "acme", Title: "Acme"})
+	assert.Nil(t, err)
+	assert.NotEmpty(t, organization.Uuid)
+	id := organization.Uuid
+	assert.Equal(t, "acme", organization.Name)
+	count, _ = s.Count(ctx)
+	assert.Equal(t, int64(1), count)
+
+	// names are unique
+	_, err = s.Create(ctx, &auth.CreateOrganizationRequest{Name: "acme"})
+	assert.NotNil(t, err)
+
+	// unexpected error in creation
+	_, err = s.Create(ctx, &auth.CreateOrganizationRequest{Name: "error"})
+	assert.Equal(t, errCRUD, err)
+
+	other, _ := s.Create(ctx, &auth.CreateOrganizationRequest{Name: "globex"})
+
+	// update
+	organization, err = s.Update(ctx, &auth.UpdateOrganizationRequest{Uuid: id, Name: "acme-corp", Title: "Acme Corp"})
+	assert.Nil(t, err)
+	assert.Equal(t, "acme-corp", organization.Name)
+	_, err = s.Update(ctx, &auth.UpdateOrganizationRequest{Uuid: id, Name: "globex"})
+	assert.NotNil(t, err)
+	_, err = s.Update(ctx, &auth.UpdateOrganizationRequest{Uuid: "none", Name: "test"})
+	assert.NotNil(t, err)
+
+	// resolve by UUID or name
+	item, err := s.Resolve(ctx, id)
+	assert.Nil(t, err)
+	assert.Equal(t, "acme-corp", item.Name)
+	item, err = s.Resolve(ctx, "acme-corp")
+	assert.Nil(t, err)
+	assert.Equal(t, id, item.UUID)
+	_, err = s.Resolve(ctx, "none")
+	assert.NotNil(t, err)
+
+	// query
+	res, _ := s.Query(ctx, "", 0, 0)
+	assert.Equal(t, 2, int(res.TotalCount))
+
+	// organizations with domains or users cannot be deleted
+	repo.records[item.ID] = true
+	_, err = s.Delete(ctx, id)
+	assert.NotNil(t, err)
+	repo.records[item.ID] = false
+	_, err = s.Delete(ctx, id)
+	assert.Nil(t, err)
+	_, err = s.Delete(ctx, "none")
+	assert.NotNil(t, err)
+	count, _ = s.Count(ctx)
+	assert.Equal(t, int64(1), count)
+	_, err = s.Get(ctx, other.Uuid)
+	assert.Nil(t, err)
+}
+
+func Test_service_SuperAdmin(t *testing.T) {
+	s := NewService(NewMockRepository())
+	ctx := context.Background()
+
+	organization, err := s.Create(ctx, &auth.CreateOrganizationRequest{Name: "acme"})
+	assert.Nil(t, err)
+
+	// global administrators of the platform manage organizations
+	platformCtx := tenant.NewContext(ctx, tenant.Platform)
+	_, err = s.Get(platformCtx, organization.Uuid)
+	assert.Nil(t, err)
+
+	// domain admins of the platform do not
+	domainAdminCtx := scope.NewContext(platformCtx, scope.Domains("example.com"))
+	_, err = s.Get(domainAdminCtx, organization.Uuid)
+	assert.NotNil(t, err)
+	_, err = s.Create(domainAdminCtx, &auth.CreateOrganizationRequest{Name: "globex"})
+	assert.NotNil(t, err)
+
+	// neither do the global administrators of an organization
+	orgAdminCtx := tenant.NewContext(ctx, 1)
+	_, err = s.Query(orgAdminCtx, "", 0, 10)
+	assert.NotNil(t, err)
+	_, err = s.Update(orgAdminCtx, &auth.UpdateOrganizationRequest{Uuid: organization.Uuid, Name: "acme"})
+	assert.NotNil(t, err)
+	_, err = s.Delete(orgAdminCtx, organization.Uuid)
+	assert.NotNil(t, err)
+}
@@ -0,0 +1,30 @@
+// Package cache keeps values built from the database, like the host table or the compiled
+// app routes, until a change event drops them.
+package cache
+
+import "sync"
+
+// Value is a cached value, it is safe for concurrent use.
+type Value struct {
+	mu    sync.RWMutex
+	value interface{}
+}
+
+// Get returns the cached value, it is nil after Reset.
+func (c *Value) Get() interface{} {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.value
+}
+
+// Set caches the value.
+func (c *Value) Set(v interface{}) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.value = v
+}
+
+// Reset drops the cached value, the next Get returns nil.
+func (c *Value) Reset() {
+	c.Set(nil)
+}
@@ -0,0 +1,162 @@
+package conditions
+
+import (
+	"encoding/json"
+	"fmt"
+	"net"
+	"strings"
+	"time"
+
+	"github.com/Knetic/govaluate"
+	"github.com/casbin/casbin/v2/util"
+)
+
+// Always is the condition of rules without one.
+const Always = "true"
+
+// Context holds the request attributes a rule condition can use as r.ctx, e.g.
+//
+//	ipIn(r.ctx.IP, "10.0.0.0/8") && timeBetween(r.ctx.Time, "09:00", "17:00")
+//	attr(r.ctx.User, "department") == "sales" && r.ctx.Weekday != "Sunday"
+type Context struct {
+	// IP is the client address of the request
+	IP string
+	// Time is the request time formatted as 15:04
+	Time string
+	// Hour is the request hour, between 0 and 23
+	Hour float64
+	// Weekday is the request day name, e.g. Monday
+	Weekday string
+	// User holds the attributes of the user raw data
+	User map[string]interface{}
+	// Attrs holds the attributes supplied with the request
+	Attrs map[string]interface{}
+}
+
+// NewContext creates a request context at the given time, rawData is the json encoded
+// user attributes and is ignored if it is not a json object.
+func NewContext(ip string, now time.Time, rawData string, attrs map[string]string) *Context {
+	c := &Context{
+		IP:      ip,
+		Time:    now.Format("15:04"),
+		Hour:    float64(now.Hour()),
+		Weekday: now.Weekday().String(),
+		User:    map[string]interface{}{},
+		Attrs:   map[string]interface{}{},
+	}
+	if rawData != "" {
+		_ = json.Unmarshal([]byte(rawData), &c.User)
+	}
+	for k, v := range attrs {
+		c.Attrs[k] = v
+	}
+	return c
+}
+
+// ClientIP returns the address without its port.
+func ClientIP(addr string) string {
+	addr = strings.TrimSpace(addr)
+	if host, _, err := net.SplitHostPort(addr); err == nil {
+		return host
+	}
+	return addr
+}
+
+// Functions returns the custom functions available to rule conditions.
+func Functions() map[string]govaluate.ExpressionFunction {
+	return map[string]govaluate.ExpressionFunction{
+		"timeBetween": TimeBetweenFunc,
+		"attr":        AttrFunc,
+		"ipIn":        IPInFunc,
+	}
+}
+
+// IPIn reports whether ip is the address or in the CIDR range of pattern, unlike the
+// casbin ipMatch it does not fail on requests without a valid client address.
+func IPIn(ip, pattern string) bool {
+	addr := net.ParseIP(ip)
+	if addr == nil {
+		return false
+	}
+	if _, cidr, err := net.ParseCIDR(pattern); err == nil {
+		return cidr.Contains(addr)
+	}
+	return addr.Equal(net.ParseIP(pattern))
+}
+
+// IPInFunc is the wrapper of IPIn.
+func IPInFunc(args ...interface{}) (interface{}, error) {
+	if len(args) != 2 {
+		return false, fmt.Errorf("ipIn: expected 2 arguments, got %d", len(args))
+	}
+	ip, ok1 := args[0].(string)
+	pattern, ok2 := args[1].(string)
+	if !ok1 || !ok2 {
+		return false, fmt.Errorf("ipIn: arguments must be strings")
+	}
+	return IPIn(ip, pattern), nil
+}
+
+// TimeBetween reports whether t is in the window from start to end, all formatted
+// as 15:04. Windows that end before they start span midnight.
+func TimeBetween(t, start, end string) bool {
+	if start <= end {
+		return t >= start && t < end
+	}
+	return t >= start || t < end
+}
+
+// TimeBetweenFunc is the wrapper of TimeBetween.
+func TimeBetweenFunc(args ...interface{}) (interface{}, error) {
+	if len(args) != 3 {
+		return false, fmt.Errorf("timeBetween: expected 3 arguments, got %d", len(args))
+	}
+	t, ok1 := args[0].(string)
+	start, ok2 := args[1].(string)
+	end, ok3 := args[2].(string)
+	if !ok1 || !ok2 || !ok3 {
+		return false, fmt.Errorf("timeBetween: arguments must be strings")
+	}
+	return TimeBetween(t, start, end), nil
+}
+
+// AttrFunc returns the value of key in an attribute map, missing keys give an empty string.
+func AttrFunc(args ...interface{}) (interface{}, error) {
+	if len(args) != 2 {
+		return "", fmt.Errorf("attr: expected 2 arguments, got %d", len(args))
+	}
+	key, ok := args[1].(string)
+	if !ok {
+		return "", fmt.Errorf("attr: key must be a string")
+	}
+	attrs, ok := args[0].(map[string]interface{})
+	if !ok {
+		return "", fmt.Errorf("attr: first argument must be an attribute map")
+	}
+
+	switch v := attrs[key].(type) {
+	case nil:
+		return "", nil
+	case string, float64, bool:
+		return v, nil
+	default:
+		return fmt.Sprint(v), nil
+	}
+}
+
+// Validate checks that expr is a valid condition expression.
+func Validate(expr string) error {
+	if strings.TrimSpace(expr) == "" {
+		return nil
+	}
+
+	functions := Functions()
+	for _, name := range []string{"keyMatch", "keyMatch2", "keyMatch3", "keyMatch4", "regexMatch", "ipMatch", "globMatch"} {
+		functions[name] = func(args ...interface{}) (interface{}, error) { return true, nil }
+	}
+	_, err := govaluate.NewEvaluableExpressionWithFunctions(util.EscapeAssertion(expr), functions)
+	if err != nil {
+		return fmt.Errorf("invalid condition: %v", err)
+	}
+	return nil
+}
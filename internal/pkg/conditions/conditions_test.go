@@ -0,0 +1,104 @@
+package conditions
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTimeBetweenFunc(t *testing.T) {
+	tests := []struct {
+		name    string
+		args    []interface{}
+		want    bool
+		wantErr bool
+	}{
+		{"inside", []interface{}{"10:30", "09:00", "17:00"}, true, false},
+		{"start is inclusive", []interface{}{"09:00", "09:00", "17:00"}, true, false},
+		{"end is exclusive", []interface{}{"17:00", "09:00", "17:00"}, false, false},
+		{"before", []interface{}{"08:59", "09:00", "17:00"}, false, false},
+		{"midnight window late", []interface{}{"23:30", "22:00", "06:00"}, true, false},
+		{"midnight window early", []interface{}{"05:59", "22:00", "06:00"}, true, false},
+		{"midnight window at midnight", []interface{}{"00:00", "22:00", "06:00"}, true, false},
+		{"outside midnight window", []interface{}{"12:00", "22:00", "06:00"}, false, false},
+		{"empty window", []interface{}{"12:00", "12:00", "12:00"}, false, false},
+		{"missing argument", []interface{}{"12:00", "09:00"}, false, true},
+		{"not a string", []interface{}{12.0, "09:00", "17:00"}, false, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := TimeBetweenFunc(tt.args...)
+			assert.Equal(t, tt.wantErr, err != nil)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestIPInFunc(t *testing.T) {
+	tests := []struct {
+		name    string
+		args    []interface{}
+		want    bool
+		wantErr bool
+	}{
+		{"in range", []interface{}{"10.1.2.3", "10.0.0.0/8"}, true, false},
+		{"out of range", []interface{}{"11.1.2.3", "10.0.0.0/8"}, false, false},
+		{"same address", []interface{}{"192.168.1.1", "192.168.1.1"}, true, false},
+		{"other address", []interface{}{"192.168.1.2", "192.168.1.1"}, false, false},
+		{"ipv6 range", []interface{}{"2001:db8::1", "2001:db8::/32"}, true, false},
+		{"malformed cidr", []interface{}{"10.1.2.3", "10.0.0.0/33"}, false, false},
+		{"malformed pattern", []interface{}{"10.1.2.3", "not-an-ip"}, false, false},
+		{"no client address", []interface{}{"", "10.0.0.0/8"}, false, false},
+		{"malformed client address", []interface{}{"10.1.2", "10.0.0.0/8"}, false, false},
+		{"missing argument", []interface{}{"10.1.2.3"}, false, true},
+		{"not a string", []interface{}{"10.1.2.3", 8.0}, false, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := IPInFunc(tt.args...)
+			assert.Equal(t, tt.wantErr, err != nil)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestAttrFunc(t *testing.T) {
+	attrs := map[string]interface{}{"department": "sales", "level": 3.0, "admin": true, "teams": []interface{}{"a", "b"}}
+	tests := []struct {
+		name    string
+		args    []interface{}
+		want    interface{}
+		wantErr bool
+	}{
+		{"string", []interface{}{attrs, "department"}, "sales", false},
+		{"number", []interface{}{attrs, "level"}, 3.0, false},
+		{"bool", []interface{}{attrs, "admin"}, true, false},
+		{"other values are formatted", []interface{}{attrs, "teams"}, "[a b]", false},
+		{"missing key", []interface{}{attrs, "region"}, "", false},
+		{"not a map", []interface{}{"sales", "department"}, "", true},
+		{"key not a string", []interface{}{attrs, 1.0}, "", true},
+		{"missing argument", []interface{}{attrs}, "", true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := AttrFunc(tt.args...)
+			assert.Equal(t, tt.wantErr, err != nil)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestNewContext(t *testing.T) {
+	now := time.Date(2026, 10, 18, 23, 45, 0, 0, time.UTC)
+	c := NewContext("10.1.2.3", now, `{"department": "sales"}`, map[string]string{"tenant": "acme"})
+	assert.Equal(t, "23:45", c.Time)
+	assert.Equal(t, 23.0, c.Hour)
+	assert.Equal(t, "Sunday", c.Weekday)
+	assert.Equal(t, "sales", c.User["department"])
+	assert.Equal(t, "acme", c.Attrs["tenant"])
+
+	// raw data that is not a json object is ignored
+	c = NewContext("", now, `["sales"]`, nil)
+	assert.Empty(t, c.User)
+}
@@ -45,6 +45,20 @@ func (db *DB) With(ctx context.Context) *gorm.DB {
 	return db.db.WithContext(ctx)
 }
 
+// Transactional starts a transaction and calls f with a context carrying it.
+// The transaction is committed if f returns nil, otherwise it is rolled back.
+// A transaction of the given context is joined with a savepoint.
+func (db *DB) Transactional(ctx context.Context, f func(ctx context.Context) error) error {
+	if tx, ok := ctx.Value(txKey).(*gorm.DB); ok {
+		return tx.Transaction(func(tx *gorm.DB) error {
+			return f(context.WithValue(ctx, txKey, tx))
+		})
+	}
+	return db.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		return f(context.WithValue(ctx, txKey, tx))
+	})
+}
+
 func Init(ctx context.Context) (*DB, error) {
 
 	host = config.RegisterString("db.host", "localhost")
@@ -67,6 +81,9 @@ func Init(ctx context.Context) (*DB, error) {
 		log.Error("open database failed", log.Err(err))
 		return nil, err
 	}
+	if err := registerTenantCallbacks(database); err != nil {
+		return nil, err
+	}
 
 	go func() {
 		<-ctx.Done()
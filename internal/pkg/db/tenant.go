@@ -0,0 +1,73 @@
+package db
+
+import (
+	"reflect"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+	"gorm.io/gorm/schema"
+
+	"github.com/golang-tire/auth/internal/pkg/tenant"
+)
+
+// tenantField is the field of the models whose records belong to an organization.
+const tenantField = "OrganizationID"
+
+// registerTenantCallbacks limits the queries, updates and deletes of contexts with an
+// organization to its records and stamps it on the records they create, so repositories
+// cannot reach the records of another organization.
+func registerTenantCallbacks(gdb *gorm.DB) error {
+	cb := gdb.Callback()
+	if err := cb.Create().Before("gorm:create").Register("tenant:create", stampTenant); err != nil {
+		return err
+	}
+	if err := cb.Query().Before("gorm:query").Register("tenant:query", filterTenant); err != nil {
+		return err
+	}
+	if err := cb.Update().Before("gorm:update").Register("tenant:update", filterTenant); err != nil {
+		return err
+	}
+	if err := cb.Delete().Before("gorm:delete").Register("tenant:delete", filterTenant); err != nil {
+		return err
+	}
+	return cb.Row().Before("gorm:row").Register("tenant:row", filterTenant)
+}
+
+// tenantOf returns the organization of the statement and the tenant field of its model.
+func tenantOf(db *gorm.DB) (uint, *schema.Field, bool) {
+	organization, ok := tenant.FromContext(db.Statement.Context)
+	if !ok || db.Statement.Schema == nil {
+		return 0, nil, false
+	}
+	field := db.Statement.Schema.LookUpField(tenantField)
+	return organization, field, field != nil
+}
+
+func filterTenant(db *gorm.DB) {
+	organization, field, ok := tenantOf(db)
+	if !ok {
+		return
+	}
+	db.Statement.AddClause(clause.Where{Exprs: []clause.Expression{
+		clause.Eq{Column: clause.Column{Table: clause.CurrentTable, Name: field.DBName}, Value: organization},
+	}})
+}
+
+func stampTenant(db *gorm.DB) {
+	organization, field, ok := tenantOf(db)
+	if !ok {
+		return
+	}
+	switch rv := db.Statement.ReflectValue; rv.Kind() {
+	case reflect.Slice, reflect.Array:
+		for i := 0; i < rv.Len(); i++ {
+			if err := field.Set(reflect.Indirect(rv.Index(i)), organization); err != nil {
+				_ = db.AddError(err)
+			}
+		}
+	case reflect.Struct:
+		if err := field.Set(rv, organization); err != nil {
+			_ = db.AddError(err)
+		}
+	}
+}
@@ -30,6 +30,11 @@ func NewForTest(t *testing.T, models []interface{}) *DB {
 		return nil
 	}
 
+	if err = registerTenantCallbacks(dbc); err != nil {
+		t.Error(err)
+		t.FailNow()
+	}
+
 	err = CreateSchema(dbc, models)
 	if err != nil {
 		t.Error(err)
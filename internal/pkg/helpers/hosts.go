@@ -0,0 +1,15 @@
+package helpers
+
+import (
+	"net"
+	"strings"
+)
+
+// NormalizeHost lower cases a host and strips its port.
+func NormalizeHost(host string) string {
+	host = strings.ToLower(strings.TrimSpace(host))
+	if h, _, err := net.SplitHostPort(host); err == nil {
+		return h
+	}
+	return host
+}
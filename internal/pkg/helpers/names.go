@@ -0,0 +1,29 @@
+package helpers
+
+import (
+	"strings"
+	"unicode"
+)
+
+// ServiceResource returns the resource of a gRPC service, its kebab cased plural name without
+// the Service suffix, e.g. audit-logs for AuditLogService.
+func ServiceResource(service string) string {
+	words := CamelWords(strings.TrimSuffix(service, "Service"))
+	return strings.Join(words, "-") + "s"
+}
+
+// CamelWords splits a camel case name into its lower cased words.
+func CamelWords(name string) []string {
+	var words []string
+	start := 0
+	for i, c := range name {
+		if i > 0 && unicode.IsUpper(c) {
+			words = append(words, strings.ToLower(name[start:i]))
+			start = i
+		}
+	}
+	if start < len(name) {
+		words = append(words, strings.ToLower(name[start:]))
+	}
+	return words
+}
@@ -19,6 +19,14 @@ import (
 	"github.com/golang-tire/pkg/log"
 )
 
+// Topics of the change events, the instances drop their caches or reload the policy on them.
+const (
+	RuleChange   = "rule-change"
+	UserChange   = "user-change"
+	DomainChange = "domain-change"
+	AppChange    = "app-change"
+)
+
 type PubSub struct {
 	ctx        context.Context
 	publisher  message.Publisher
@@ -93,3 +101,15 @@ func Get() *PubSub {
 func (ps *PubSub) Publish(topic string, message *message.Message) error {
 	return ps.publisher.Publish(topic, message)
 }
+
+// Notify publishes a change event with the reason on the topic, it does nothing before the
+// pubsub is initialized. Failures are logged, the change itself is already stored.
+func Notify(topic, reason string) {
+	p := Get()
+	if p == nil {
+		return
+	}
+	if err := p.Publish(topic, message.NewMessage(watermill.NewUUID(), []byte(reason))); err != nil {
+		log.Error("send "+topic+" event failed", log.Err(err))
+	}
+}
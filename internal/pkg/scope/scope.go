@@ -0,0 +1,162 @@
+// Package scope limits admin operations to the domains the caller administers.
+//
+// Callers administer a domain when they hold the built-in domain admin role in it, holding
+// it in the global domain makes them global administrators. Calls without a scope in the
+// context, like the ones made by the server itself, are not limited.
+package scope
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"time"
+
+	auth "github.com/golang-tire/auth/internal/proto/v1"
+	"github.com/golang-tire/pkg/config"
+)
+
+const (
+	// GlobalDomain is the domain of global role assignments.
+	GlobalDomain = "*"
+	// PlatformAdminRole is the built-in role allowed to call every admin API.
+	PlatformAdminRole = "auth-admin"
+)
+
+type contextKey int
+
+const scopeKey contextKey = iota
+
+// adminRole is the title of the built-in domain admin role
+var adminRole = config.RegisterString("domainAdmin.role", "domain-admin")
+
+// Scope is the set of domains a caller administers.
+type Scope struct {
+	global  bool
+	domains map[string]bool
+}
+
+// AdminRole returns the title of the built-in domain admin role.
+func AdminRole() string {
+	return adminRole.String()
+}
+
+// Global returns the scope of global administrators.
+func Global() Scope {
+	return Scope{global: true}
+}
+
+// Domains returns the scope of an administrator of the given domains.
+func Domains(domains ...string) Scope {
+	s := Scope{domains: map[string]bool{}}
+	for _, d := range domains {
+		if d == GlobalDomain {
+			return Global()
+		}
+		s.domains[d] = true
+	}
+	return s
+}
+
+// ForUser returns the scope of the user from the domain admin roles active at now.
+func ForUser(user *auth.User, now time.Time) Scope {
+	var domains []string
+	for _, r := range user.Roles {
+		if r.Role != AdminRole() || !active(r, now) {
+			continue
+		}
+		if r.Domain == "" {
+			domains = append(domains, GlobalDomain)
+			continue
+		}
+		domains = append(domains, r.Domain)
+	}
+	return Domains(domains...)
+}
+
+// HoldsGlobally reports whether the user holds the role in the global domain at now.
+func HoldsGlobally(user *auth.User, role string, now time.Time) bool {
+	for _, r := range user.Roles {
+		if r.Role == role && r.Domain == "" && active(r, now) {
+			return true
+		}
+	}
+	return false
+}
+
+// active reports whether the role assignment is enabled and valid at now.
+func active(r *auth.UserRole, now time.Time) bool {
+	if !r.Enable {
+		return false
+	}
+	if r.ValidFrom != nil && now.Before(r.ValidFrom.AsTime()) {
+		return false
+	}
+	return r.ExpiresAt == nil || now.Before(r.ExpiresAt.AsTime())
+}
+
+// WithSubdomains returns the scope extended to the domains below the ones it covers,
+// subtree returns a domain along with the domains below it.
+func (s Scope) WithSubdomains(subtree func(domain string) []string) Scope {
+	if s.global {
+		return s
+	}
+	extended := Scope{domains: map[string]bool{}}
+	for d := range s.domains {
+		for _, sub := range subtree(d) {
+			extended.domains[sub] = true
+		}
+	}
+	return extended
+}
+
+// NewContext returns a context carrying the scope.
+func NewContext(ctx context.Context, s Scope) context.Context {
+	return context.WithValue(ctx, scopeKey, s)
+}
+
+// FromContext returns the scope of the caller, contexts without one have the global scope.
+func FromContext(ctx context.Context) Scope {
+	if s, ok := ctx.Value(scopeKey).(Scope); ok {
+		return s
+	}
+	return Global()
+}
+
+// IsGlobal reports whether the scope covers every domain.
+func (s Scope) IsGlobal() bool {
+	return s.global
+}
+
+// Allows reports whether the scope covers the domain, only global scopes cover the global domain.
+func (s Scope) Allows(domain string) bool {
+	return s.global || s.domains[domain]
+}
+
+// DomainList returns the sorted domains of the scope, it is empty for global scopes.
+func (s Scope) DomainList() []string {
+	domains := make([]string, 0, len(s.domains))
+	for d := range s.domains {
+		domains = append(domains, d)
+	}
+	sort.Strings(domains)
+	return domains
+}
+
+// Check returns an error if the scope does not cover the domain.
+func (s Scope) Check(domain string) error {
+	if s.Allows(domain) {
+		return nil
+	}
+	if domain == GlobalDomain {
+		return fmt.Errorf("only global administrators can manage the global domain")
+	}
+	return fmt.Errorf("not allowed to administer domain `%s`", domain)
+}
+
+// CheckGlobal returns an error unless the scope is global, action describes the operation.
+func (s Scope) CheckGlobal(action string) error {
+	if s.global {
+		return nil
+	}
+	return fmt.Errorf("only global administrators can %s", action)
+}
@@ -0,0 +1,62 @@
+// Package tenant isolates the data of the organizations.
+//
+// Requests carry the organization of the domain they are made to, the database layer
+// limits every query of them to the records of that organization and stamps it on the
+// records they create. Requests to hosts without a domain belong to the platform, the
+// organization 0 of the super administrators. Contexts without an organization, like the
+// ones of the server itself, see the records of every organization.
+package tenant
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/golang-tire/auth/internal/pkg/scope"
+)
+
+// Platform is the organization of the super administrators and of the records which do
+// not belong to a customer organization.
+const Platform uint = 0
+
+type contextKey int
+
+const organizationKey contextKey = iota
+
+type limit struct {
+	organization uint
+	set          bool
+}
+
+// NewContext returns a context limited to the records of the organization.
+func NewContext(ctx context.Context, organization uint) context.Context {
+	return context.WithValue(ctx, organizationKey, limit{organization: organization, set: true})
+}
+
+// All returns a context which sees the records of every organization, it is meant for the
+// lookups the server makes on its own behalf, like resolving hosts.
+func All(ctx context.Context) context.Context {
+	return context.WithValue(ctx, organizationKey, limit{})
+}
+
+// FromContext returns the organization the context is limited to, it returns false for
+// contexts which see every organization.
+func FromContext(ctx context.Context) (uint, bool) {
+	l, _ := ctx.Value(organizationKey).(limit)
+	return l.organization, l.set
+}
+
+// IsPlatform reports whether the context belongs to the platform or sees every organization.
+func IsPlatform(ctx context.Context) bool {
+	organization, ok := FromContext(ctx)
+	return !ok || organization == Platform
+}
+
+// RootDomain returns the casbin domain of the role assignments and rules of an
+// organization which have no domain, it is the parent of the top level domains of the
+// organization. The platform uses the global domain.
+func RootDomain(organization uint) string {
+	if organization == Platform {
+		return scope.GlobalDomain
+	}
+	return fmt.Sprintf("org:%d", organization)
+}
@@ -0,0 +1,404 @@
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+
+package auth
+
+import (
+	context "context"
+	empty "github.com/golang/protobuf/ptypes/empty"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+const _ = grpc.SupportPackageIsVersion7
+
+// AccessRequestServiceClient is the client API for AccessRequestService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+type AccessRequestServiceClient interface {
+	// List AccessRequests
+	ListAccessRequests(ctx context.Context, in *ListAccessRequestsRequest, opts ...grpc.CallOption) (*ListAccessRequestsResponse, error)
+	// Get AccessRequest
+	GetAccessRequest(ctx context.Context, in *GetAccessRequestRequest, opts ...grpc.CallOption) (*AccessRequest, error)
+	// CreateAccessRequest requests a role in a domain for a period of time
+	CreateAccessRequest(ctx context.Context, in *CreateAccessRequestRequest, opts ...grpc.CallOption) (*AccessRequest, error)
+	// ApproveAccessRequest approves a pending request, the role is granted once enough approvers approved it
+	ApproveAccessRequest(ctx context.Context, in *DecideAccessRequestRequest, opts ...grpc.CallOption) (*AccessRequest, error)
+	// DenyAccessRequest denies a pending request
+	DenyAccessRequest(ctx context.Context, in *DecideAccessRequestRequest, opts ...grpc.CallOption) (*AccessRequest, error)
+	// CancelAccessRequest cancels a pending request of the caller
+	CancelAccessRequest(ctx context.Context, in *CancelAccessRequestRequest, opts ...grpc.CallOption) (*AccessRequest, error)
+	// List ApproverRules
+	ListApproverRules(ctx context.Context, in *ListApproverRulesRequest, opts ...grpc.CallOption) (*ListApproverRulesResponse, error)
+	// Create ApproverRule
+	CreateApproverRule(ctx context.Context, in *CreateApproverRuleRequest, opts ...grpc.CallOption) (*ApproverRule, error)
+	// Delete ApproverRule
+	DeleteApproverRule(ctx context.Context, in *DeleteApproverRuleRequest, opts ...grpc.CallOption) (*empty.Empty, error)
+}
+
+type accessRequestServiceClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewAccessRequestServiceClient(cc grpc.ClientConnInterface) AccessRequestServiceClient {
+	return &accessRequestServiceClient{cc}
+}
+
+func (c *accessRequestServiceClient) ListAccessRequests(ctx context.Context, in *ListAccessRequestsRequest, opts ...grpc.CallOption) (*ListAccessRequestsResponse, error) {
+	out := new(ListAccessRequestsResponse)
+	err := c.cc.Invoke(ctx, "/authV1.AccessRequestService/ListAccessRequests", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *accessRequestServiceClient) GetAccessRequest(ctx context.Context, in *GetAccessRequestRequest, opts ...grpc.CallOption) (*AccessRequest, error) {
+	out := new(AccessRequest)
+	err := c.cc.Invoke(ctx, "/authV1.AccessRequestService/GetAccessRequest", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *accessRequestServiceClient) CreateAccessRequest(ctx context.Context, in *CreateAccessRequestRequest, opts ...grpc.CallOption) (*AccessRequest, error) {
+	out := new(AccessRequest)
+	err := c.cc.Invoke(ctx, "/authV1.AccessRequestService/CreateAccessRequest", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *accessRequestServiceClient) ApproveAccessRequest(ctx context.Context, in *DecideAccessRequestRequest, opts ...grpc.CallOption) (*AccessRequest, error) {
+	out := new(AccessRequest)
+	err := c.cc.Invoke(ctx, "/authV1.AccessRequestService/ApproveAccessRequest", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *accessRequestServiceClient) DenyAccessRequest(ctx context.Context, in *DecideAccessRequestRequest, opts ...grpc.CallOption) (*AccessRequest, error) {
+	out := new(AccessRequest)
+	err := c.cc.Invoke(ctx, "/authV1.AccessRequestService/DenyAccessRequest", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *accessRequestServiceClient) CancelAccessRequest(ctx context.Context, in *CancelAccessRequestRequest, opts ...grpc.CallOption) (*AccessRequest, error) {
+	out := new(AccessRequest)
+	err := c.cc.Invoke(ctx, "/authV1.AccessRequestService/CancelAccessRequest", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *accessRequestServiceClient) ListApproverRules(ctx context.Context, in *ListApproverRulesRequest, opts ...grpc.CallOption) (*ListApproverRulesResponse, error) {
+	out := new(ListApproverRulesResponse)
+	err := c.cc.Invoke(ctx, "/authV1.AccessRequestService/ListApproverRules", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *accessRequestServiceClient) CreateApproverRule(ctx context.Context, in *CreateApproverRuleRequest, opts ...grpc.CallOption) (*ApproverRule, error) {
+	out := new(ApproverRule)
+	err := c.cc.Invoke(ctx, "/authV1.AccessRequestService/CreateApproverRule", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *accessRequestServiceClient) DeleteApproverRule(ctx context.Context, in *DeleteApproverRuleRequest, opts ...grpc.CallOption) (*empty.Empty, error) {
+	out := new(empty.Empty)
+	err := c.cc.Invoke(ctx, "/authV1.AccessRequestService/DeleteApproverRule", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// AccessRequestServiceServer is the server API for AccessRequestService service.
+// All implementations must embed UnimplementedAccessRequestServiceServer
+// for forward compatibility
+type AccessRequestServiceServer interface {
+	// List AccessRequests
+	ListAccessRequests(context.Context, *ListAccessRequestsRequest) (*ListAccessRequestsResponse, error)
+	// Get AccessRequest
+	GetAccessRequest(context.Context, *GetAccessRequestRequest) (*AccessRequest, error)
+	// CreateAccessRequest requests a role in a domain for a period of time
+	CreateAccessRequest(context.Context, *CreateAccessRequestRequest) (*AccessRequest, error)
+	// ApproveAccessRequest approves a pending request, the role is granted once enough approvers approved it
+	ApproveAccessRequest(context.Context, *DecideAccessRequestRequest) (*AccessRequest, error)
+	// DenyAccessRequest denies a pending request
+	DenyAccessRequest(context.Context, *DecideAccessRequestRequest) (*AccessRequest, error)
+	// CancelAccessRequest cancels a pending request of the caller
+	CancelAccessRequest(context.Context, *CancelAccessRequestRequest) (*AccessRequest, error)
+	// List ApproverRules
+	ListApproverRules(context.Context, *ListApproverRulesRequest) (*ListApproverRulesResponse, error)
+	// Create ApproverRule
+	CreateApproverRule(context.Context, *CreateApproverRuleRequest) (*ApproverRule, error)
+	// Delete ApproverRule
+	DeleteApproverRule(context.Context, *DeleteApproverRuleRequest) (*empty.Empty, error)
+	mustEmbedUnimplementedAccessRequestServiceServer()
+}
+
+// UnimplementedAccessRequestServiceServer must be embedded to have forward compatible implementations.
+type UnimplementedAccessRequestServiceServer struct {
+}
+
+func (UnimplementedAccessRequestServiceServer) ListAccessRequests(context.Context, *ListAccessRequestsRequest) (*ListAccessRequestsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListAccessRequests not implemented")
+}
+func (UnimplementedAccessRequestServiceServer) GetAccessRequest(context.Context, *GetAccessRequestRequest) (*AccessRequest, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetAccessRequest not implemented")
+}
+func (UnimplementedAccessRequestServiceServer) CreateAccessRequest(context.Context, *CreateAccessRequestRequest) (*AccessRequest, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreateAccessRequest not implemented")
+}
+func (UnimplementedAccessRequestServiceServer) ApproveAccessRequest(context.Context, *DecideAccessRequestRequest) (*AccessRequest, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ApproveAccessRequest not implemented")
+}
+func (UnimplementedAccessRequestServiceServer) DenyAccessRequest(context.Context, *DecideAccessRequestRequest) (*AccessRequest, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DenyAccessRequest not implemented")
+}
+func (UnimplementedAccessRequestServiceServer) CancelAccessRequest(context.Context, *CancelAccessRequestRequest) (*AccessRequest, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CancelAccessRequest not implemented")
+}
+func (UnimplementedAccessRequestServiceServer) ListApproverRules(context.Context, *ListApproverRulesRequest) (*ListApproverRulesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListApproverRules not implemented")
+}
+func (UnimplementedAccessRequestServiceServer) CreateApproverRule(context.Context, *CreateApproverRuleRequest) (*ApproverRule, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreateApproverRule not implemented")
+}
+func (UnimplementedAccessRequestServiceServer) DeleteApproverRule(context.Context, *DeleteApproverRuleRequest) (*empty.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DeleteApproverRule not implemented")
+}
+func (UnimplementedAccessRequestServiceServer) mustEmbedUnimplementedAccessRequestServiceServer() {}
+
+// UnsafeAccessRequestServiceServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to AccessRequestServiceServer will
+// result in compilation errors.
+type UnsafeAccessRequestServiceServer interface {
+	mustEmbedUnimplementedAccessRequestServiceServer()
+}
+
+func RegisterAccessRequestServiceServer(s *grpc.Server, srv AccessRequestServiceServer) {
+	s.RegisterService(&_AccessRequestService_serviceDesc, srv)
+}
+
+func _AccessRequestService_ListAccessRequests_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListAccessRequestsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AccessRequestServiceServer).ListAccessRequests(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/authV1.AccessRequestService/ListAccessRequests",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AccessRequestServiceServer).ListAccessRequests(ctx, req.(*ListAccessRequestsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AccessRequestService_GetAccessRequest_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetAccessRequestRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AccessRequestServiceServer).GetAccessRequest(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/authV1.AccessRequestService/GetAccessRequest",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AccessRequestServiceServer).GetAccessRequest(ctx, req.(*GetAccessRequestRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AccessRequestService_CreateAccessRequest_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateAccessRequestRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AccessRequestServiceServer).CreateAccessRequest(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/authV1.AccessRequestService/CreateAccessRequest",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AccessRequestServiceServer).CreateAccessRequest(ctx, req.(*CreateAccessRequestRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AccessRequestService_ApproveAccessRequest_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DecideAccessRequestRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AccessRequestServiceServer).ApproveAccessRequest(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/authV1.AccessRequestService/ApproveAccessRequest",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AccessRequestServiceServer).ApproveAccessRequest(ctx, req.(*DecideAccessRequestRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AccessRequestService_DenyAccessRequest_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DecideAccessRequestRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AccessRequestServiceServer).DenyAccessRequest(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/authV1.AccessRequestService/DenyAccessRequest",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AccessRequestServiceServer).DenyAccessRequest(ctx, req.(*DecideAccessRequestRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AccessRequestService_CancelAccessRequest_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CancelAccessRequestRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AccessRequestServiceServer).CancelAccessRequest(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/authV1.AccessRequestService/CancelAccessRequest",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AccessRequestServiceServer).CancelAccessRequest(ctx, req.(*CancelAccessRequestRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AccessRequestService_ListApproverRules_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListApproverRulesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AccessRequestServiceServer).ListApproverRules(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/authV1.AccessRequestService/ListApproverRules",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AccessRequestServiceServer).ListApproverRules(ctx, req.(*ListApproverRulesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AccessRequestService_CreateApproverRule_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateApproverRuleRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AccessRequestServiceServer).CreateApproverRule(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/authV1.AccessRequestService/CreateApproverRule",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AccessRequestServiceServer).CreateApproverRule(ctx, req.(*CreateApproverRuleRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AccessRequestService_DeleteApproverRule_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeleteApproverRuleRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AccessRequestServiceServer).DeleteApproverRule(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/authV1.AccessRequestService/DeleteApproverRule",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AccessRequestServiceServer).DeleteApproverRule(ctx, req.(*DeleteApproverRuleRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+var _AccessRequestService_serviceDesc = grpc.ServiceDesc{
+	ServiceName: "authV1.AccessRequestService",
+	HandlerType: (*AccessRequestServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "ListAccessRequests",
+			Handler:    _AccessRequestService_ListAccessRequests_Handler,
+		},
+		{
+			MethodName: "GetAccessRequest",
+			Handler:    _AccessRequestService_GetAccessRequest_Handler,
+		},
+		{
+			MethodName: "CreateAccessRequest",
+			Handler:    _AccessRequestService_CreateAccessRequest_Handler,
+		},
+		{
+			MethodName: "ApproveAccessRequest",
+			Handler:    _AccessRequestService_ApproveAccessRequest_Handler,
+		},
+		{
+			MethodName: "DenyAccessRequest",
+			Handler:    _AccessRequestService_DenyAccessRequest_Handler,
+		},
+		{
+			MethodName: "CancelAccessRequest",
+			Handler:    _AccessRequestService_CancelAccessRequest_Handler,
+		},
+		{
+			MethodName: "ListApproverRules",
+			Handler:    _AccessRequestService_ListApproverRules_Handler,
+		},
+		{
+			MethodName: "CreateApproverRule",
+			Handler:    _AccessRequestService_CreateApproverRule_Handler,
+		},
+		{
+			MethodName: "DeleteApproverRule",
+			Handler:    _AccessRequestService_DeleteApproverRule_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "api/proto/v1/access_requests.proto",
+}
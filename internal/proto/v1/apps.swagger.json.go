@@ -7,8 +7,8 @@ import (
 	"github.com/golang-tire/pkg/grpcgw"
 )
 
-const apps_paths = "{\"/v1/apps\":{\"get\":{\"operationId\":\"AppService_ListApps\",\"parameters\":[{\"format\":\"int64\",\"in\":\"query\",\"name\":\"limit\",\"required\":false,\"type\":\"string\"},{\"format\":\"int64\",\"in\":\"query\",\"name\":\"offset\",\"required\":false,\"type\":\"string\"},{\"in\":\"query\",\"name\":\"query\",\"required\":false,\"type\":\"string\"}],\"responses\":{\"200\":{\"description\":\"A successful response.\",\"schema\":{\"$ref\":\"#/definitions/authV1ListAppsResponse\"}},\"default\":{\"description\":\"An unexpected error response.\",\"schema\":{\"$ref\":\"#/definitions/runtimeError\"}}},\"summary\":\"List Apps\",\"tags\":[\"AppService\"]},\"post\":{\"operationId\":\"AppService_CreateApp\",\"parameters\":[{\"in\":\"body\",\"name\":\"body\",\"required\":true,\"schema\":{\"$ref\":\"#/definitions/authV1CreateAppRequest\"}}],\"responses\":{\"200\":{\"description\":\"A successful response.\",\"schema\":{\"$ref\":\"#/definitions/authV1App\"}},\"default\":{\"description\":\"An unexpected error response.\",\"schema\":{\"$ref\":\"#/definitions/runtimeError\"}}},\"summary\":\"Create App request\",\"tags\":[\"AppService\"]}},\"/v1/apps/-/objects\":{\"get\":{\"operationId\":\"AppService_ListObjects\",\"parameters\":[{\"format\":\"int64\",\"in\":\"query\",\"name\":\"limit\",\"required\":false,\"type\":\"string\"},{\"format\":\"int64\",\"in\":\"query\",\"name\":\"offset\",\"required\":false,\"type\":\"string\"},{\"in\":\"query\",\"name\":\"query\",\"required\":false,\"type\":\"string\"},{\"in\":\"query\",\"name\":\"uuid\",\"required\":false,\"type\":\"string\"}],\"responses\":{\"200\":{\"description\":\"A successful response.\",\"schema\":{\"$ref\":\"#/definitions/authV1ListObjectsResponse\"}},\"default\":{\"description\":\"An unexpected error response.\",\"schema\":{\"$ref\":\"#/definitions/runtimeError\"}}},\"summary\":\"List App Objects\",\"tags\":[\"AppService\"]}},\"/v1/apps/-/objects/{uuid}\":{\"delete\":{\"operationId\":\"AppService_DeleteObject\",\"parameters\":[{\"in\":\"path\",\"name\":\"uuid\",\"required\":true,\"type\":\"string\"}],\"responses\":{\"200\":{\"description\":\"A successful response.\",\"schema\":{\"properties\":{}}},\"default\":{\"description\":\"An unexpected error response.\",\"schema\":{\"$ref\":\"#/definitions/runtimeError\"}}},\"summary\":\"Delete App Object request\",\"tags\":[\"AppService\"]},\"get\":{\"operationId\":\"AppService_GetObject\",\"parameters\":[{\"in\":\"path\",\"name\":\"uuid\",\"required\":true,\"type\":\"string\"}],\"responses\":{\"200\":{\"description\":\"A successful response.\",\"schema\":{\"$ref\":\"#/definitions/authV1Object\"}},\"default\":{\"description\":\"An unexpected error response.\",\"schema\":{\"$ref\":\"#/definitions/runtimeError\"}}},\"summary\":\"Get App Object\",\"tags\":[\"AppService\"]},\"put\":{\"operationId\":\"AppService_UpdateObject\",\"parameters\":[{\"in\":\"path\",\"name\":\"uuid\",\"required\":true,\"type\":\"string\"},{\"in\":\"body\",\"name\":\"body\",\"required\":true,\"schema\":{\"$ref\":\"#/definitions/authV1UpdateObjectRequest\"}}],\"responses\":{\"200\":{\"description\":\"A successful response.\",\"schema\":{\"$ref\":\"#/definitions/authV1Object\"}},\"default\":{\"description\":\"An unexpected error response.\",\"schema\":{\"$ref\":\"#/definitions/runtimeError\"}}},\"summary\":\"Update App Object request\",\"tags\":[\"AppService\"]}},\"/v1/apps/-/resources\":{\"get\":{\"operationId\":\"AppService_ListResources\",\"parameters\":[{\"format\":\"int64\",\"in\":\"query\",\"name\":\"limit\",\"required\":false,\"type\":\"string\"},{\"format\":\"int64\",\"in\":\"query\",\"name\":\"offset\",\"required\":false,\"type\":\"string\"},{\"in\":\"query\",\"name\":\"query\",\"required\":false,\"type\":\"string\"},{\"in\":\"query\",\"name\":\"uuid\",\"required\":false,\"type\":\"string\"}],\"responses\":{\"200\":{\"description\":\"A successful response.\",\"schema\":{\"$ref\":\"#/definitions/authV1ListResourcesResponse\"}},\"default\":{\"description\":\"An unexpected error response.\",\"schema\":{\"$ref\":\"#/definitions/runtimeError\"}}},\"summary\":\"List App Resources\",\"tags\":[\"AppService\"]}},\"/v1/apps/-/resources/{uuid}\":{\"delete\":{\"operationId\":\"AppService_DeleteResource\",\"parameters\":[{\"in\":\"path\",\"name\":\"uuid\",\"required\":true,\"type\":\"string\"}],\"responses\":{\"200\":{\"description\":\"A successful response.\",\"schema\":{\"properties\":{}}},\"default\":{\"description\":\"An unexpected error response.\",\"schema\":{\"$ref\":\"#/definitions/runtimeError\"}}},\"summary\":\"Delete App Resource object request\",\"tags\":[\"AppService\"]},\"get\":{\"operationId\":\"AppService_GetResource\",\"parameters\":[{\"in\":\"path\",\"name\":\"uuid\",\"required\":true,\"type\":\"string\"}],\"responses\":{\"200\":{\"description\":\"A successful response.\",\"schema\":{\"$ref\":\"#/definitions/authV1Resource\"}},\"default\":{\"description\":\"An unexpected error response.\",\"schema\":{\"$ref\":\"#/definitions/runtimeError\"}}},\"summary\":\"Get App Resource\",\"tags\":[\"AppService\"]},\"put\":{\"operationId\":\"AppService_UpdateResource\",\"parameters\":[{\"in\":\"path\",\"name\":\"uuid\",\"required\":true,\"type\":\"string\"},{\"in\":\"body\",\"name\":\"body\",\"required\":true,\"schema\":{\"$ref\":\"#/definitions/authV1UpdateResourceRequest\"}}],\"responses\":{\"200\":{\"description\":\"A successful response.\",\"schema\":{\"$ref\":\"#/definitions/authV1Resource\"}},\"default\":{\"description\":\"An unexpected error response.\",\"schema\":{\"$ref\":\"#/definitions/runtimeError\"}}},\"summary\":\"Update App Resource object request\",\"tags\":[\"AppService\"]}},\"/v1/apps/{uuid}\":{\"delete\":{\"operationId\":\"AppService_DeleteApp\",\"parameters\":[{\"in\":\"path\",\"name\":\"uuid\",\"required\":true,\"type\":\"string\"}],\"responses\":{\"200\":{\"description\":\"A successful response.\",\"schema\":{\"properties\":{}}},\"default\":{\"description\":\"An unexpected error response.\",\"schema\":{\"$ref\":\"#/definitions/runtimeError\"}}},\"summary\":\"Delete App request\",\"tags\":[\"AppService\"]},\"get\":{\"operationId\":\"AppService_GetApp\",\"parameters\":[{\"in\":\"path\",\"name\":\"uuid\",\"required\":true,\"type\":\"string\"}],\"responses\":{\"200\":{\"description\":\"A successful response.\",\"schema\":{\"$ref\":\"#/definitions/authV1App\"}},\"default\":{\"description\":\"An unexpected error response.\",\"schema\":{\"$ref\":\"#/definitions/runtimeError\"}}},\"summary\":\"Get App\",\"tags\":[\"AppService\"]},\"put\":{\"operationId\":\"AppService_UpdateApp\",\"parameters\":[{\"in\":\"path\",\"name\":\"uuid\",\"required\":true,\"type\":\"string\"},{\"in\":\"body\",\"name\":\"body\",\"required\":true,\"schema\":{\"$ref\":\"#/definitions/authV1UpdateAppRequest\"}}],\"responses\":{\"200\":{\"description\":\"A successful response.\",\"schema\":{\"$ref\":\"#/definitions/authV1App\"}},\"default\":{\"description\":\"An unexpected error response.\",\"schema\":{\"$ref\":\"#/definitions/runtimeError\"}}},\"summary\":\"Update App request\",\"tags\":[\"AppService\"]}},\"/v1/apps/{uuid}/objects\":{\"post\":{\"operationId\":\"AppService_CreateObject\",\"parameters\":[{\"in\":\"path\",\"name\":\"uuid\",\"required\":true,\"type\":\"string\"},{\"in\":\"body\",\"name\":\"body\",\"required\":true,\"schema\":{\"$ref\":\"#/definitions/authV1CreateObjectRequest\"}}],\"responses\":{\"200\":{\"description\":\"A successful response.\",\"schema\":{\"$ref\":\"#/definitions/authV1Object\"}},\"default\":{\"description\":\"An unexpected error response.\",\"schema\":{\"$ref\":\"#/definitions/runtimeError\"}}},\"summary\":\"Create App Object request\",\"tags\":[\"AppService\"]}},\"/v1/apps/{uuid}/resources\":{\"post\":{\"operationId\":\"AppService_CreateResource\",\"parameters\":[{\"in\":\"path\",\"name\":\"uuid\",\"required\":true,\"type\":\"string\"},{\"in\":\"body\",\"name\":\"body\",\"required\":true,\"schema\":{\"$ref\":\"#/definitions/authV1CreateResourceRequest\"}}],\"responses\":{\"200\":{\"description\":\"A successful response.\",\"schema\":{\"$ref\":\"#/definitions/authV1Resource\"}},\"default\":{\"description\":\"An unexpected error response.\",\"schema\":{\"$ref\":\"#/definitions/runtimeError\"}}},\"summary\":\"Create App Resource object request\",\"tags\":[\"AppService\"]}}}"
-const apps_definitions = "{\"authV1App\":{\"properties\":{\"created_at\":{\"format\":\"date-time\",\"type\":\"string\"},\"enable\":{\"type\":\"boolean\"},\"name\":{\"type\":\"string\"},\"updated_at\":{\"format\":\"date-time\",\"type\":\"string\"},\"uuid\":{\"type\":\"string\"}},\"type\":\"object\"},\"authV1CreateAppRequest\":{\"properties\":{\"enable\":{\"type\":\"boolean\"},\"name\":{\"type\":\"string\"}},\"type\":\"object\"},\"authV1CreateObjectRequest\":{\"properties\":{\"identifier\":{\"type\":\"string\"},\"uuid\":{\"type\":\"string\"}},\"type\":\"object\"},\"authV1CreateResourceRequest\":{\"properties\":{\"name\":{\"type\":\"string\"},\"uuid\":{\"type\":\"string\"}},\"type\":\"object\"},\"authV1ListAppsResponse\":{\"properties\":{\"apps\":{\"items\":{\"$ref\":\"#/definitions/authV1App\"},\"type\":\"array\"},\"limit\":{\"format\":\"int64\",\"type\":\"string\"},\"offset\":{\"format\":\"int64\",\"type\":\"string\"},\"total_count\":{\"format\":\"int64\",\"type\":\"string\"}},\"type\":\"object\"},\"authV1ListObjectsResponse\":{\"properties\":{\"limit\":{\"format\":\"int64\",\"type\":\"string\"},\"objects\":{\"items\":{\"$ref\":\"#/definitions/authV1Object\"},\"type\":\"array\"},\"offset\":{\"format\":\"int64\",\"type\":\"string\"},\"total_count\":{\"format\":\"int64\",\"type\":\"string\"}},\"type\":\"object\"},\"authV1ListResourcesResponse\":{\"properties\":{\"limit\":{\"format\":\"int64\",\"type\":\"string\"},\"offset\":{\"format\":\"int64\",\"type\":\"string\"},\"resources\":{\"items\":{\"$ref\":\"#/definitions/authV1Resource\"},\"type\":\"array\"},\"total_count\":{\"format\":\"int64\",\"type\":\"string\"}},\"type\":\"object\"},\"authV1Object\":{\"properties\":{\"app\":{\"$ref\":\"#/definitions/authV1App\"},\"created_at\":{\"format\":\"date-time\",\"type\":\"string\"},\"identifier\":{\"type\":\"string\"},\"updated_at\":{\"format\":\"date-time\",\"type\":\"string\"},\"uuid\":{\"type\":\"string\"}},\"type\":\"object\"},\"authV1Resource\":{\"properties\":{\"app\":{\"$ref\":\"#/definitions/authV1App\"},\"created_at\":{\"format\":\"date-time\",\"type\":\"string\"},\"name\":{\"type\":\"string\"},\"updated_at\":{\"format\":\"date-time\",\"type\":\"string\"},\"uuid\":{\"type\":\"string\"}},\"type\":\"object\"},\"authV1UpdateAppRequest\":{\"properties\":{\"enable\":{\"type\":\"boolean\"},\"name\":{\"type\":\"string\"},\"uuid\":{\"type\":\"string\"}},\"type\":\"object\"},\"authV1UpdateObjectRequest\":{\"properties\":{\"app_uuid\":{\"type\":\"string\"},\"identifier\":{\"type\":\"string\"},\"uuid\":{\"type\":\"string\"}},\"type\":\"object\"},\"authV1UpdateResourceRequest\":{\"properties\":{\"app_uuid\":{\"type\":\"string\"},\"name\":{\"type\":\"string\"},\"uuid\":{\"type\":\"string\"}},\"type\":\"object\"},\"protobufAny\":{\"properties\":{\"type_url\":{\"type\":\"string\"},\"value\":{\"format\":\"byte\",\"type\":\"string\"}},\"type\":\"object\"},\"runtimeError\":{\"properties\":{\"code\":{\"format\":\"int32\",\"type\":\"integer\"},\"details\":{\"items\":{\"$ref\":\"#/definitions/protobufAny\"},\"type\":\"array\"},\"error\":{\"type\":\"string\"},\"message\":{\"type\":\"string\"}},\"type\":\"object\"}}"
+const apps_paths = "{\"/v1/apps\":{\"get\":{\"operationId\":\"AppService_ListApps\",\"parameters\":[{\"format\":\"int64\",\"in\":\"query\",\"name\":\"limit\",\"required\":false,\"type\":\"string\"},{\"format\":\"int64\",\"in\":\"query\",\"name\":\"offset\",\"required\":false,\"type\":\"string\"},{\"in\":\"query\",\"name\":\"query\",\"required\":false,\"type\":\"string\"}],\"responses\":{\"200\":{\"description\":\"A successful response.\",\"schema\":{\"$ref\":\"#/definitions/authV1ListAppsResponse\"}},\"default\":{\"description\":\"An unexpected error response.\",\"schema\":{\"$ref\":\"#/definitions/runtimeError\"}}},\"summary\":\"List Apps\",\"tags\":[\"AppService\"]},\"post\":{\"operationId\":\"AppService_CreateApp\",\"parameters\":[{\"in\":\"body\",\"name\":\"body\",\"required\":true,\"schema\":{\"$ref\":\"#/definitions/authV1CreateAppRequest\"}}],\"responses\":{\"200\":{\"description\":\"A successful response.\",\"schema\":{\"$ref\":\"#/definitions/authV1App\"}},\"default\":{\"description\":\"An unexpected error response.\",\"schema\":{\"$ref\":\"#/definitions/runtimeError\"}}},\"summary\":\"Create App request\",\"tags\":[\"AppService\"]}},\"/v1/apps/-/objects\":{\"get\":{\"operationId\":\"AppService_ListObjects\",\"parameters\":[{\"format\":\"int64\",\"in\":\"query\",\"name\":\"limit\",\"required\":false,\"type\":\"string\"},{\"format\":\"int64\",\"in\":\"query\",\"name\":\"offset\",\"required\":false,\"type\":\"string\"},{\"in\":\"query\",\"name\":\"query\",\"required\":false,\"type\":\"string\"},{\"in\":\"query\",\"name\":\"uuid\",\"required\":false,\"type\":\"string\"}],\"responses\":{\"200\":{\"description\":\"A successful response.\",\"schema\":{\"$ref\":\"#/definitions/authV1ListObjectsResponse\"}},\"default\":{\"description\":\"An unexpected error response.\",\"schema\":{\"$ref\":\"#/definitions/runtimeError\"}}},\"summary\":\"List App Objects\",\"tags\":[\"AppService\"]}},\"/v1/apps/-/objects/{uuid}\":{\"delete\":{\"operationId\":\"AppService_DeleteObject\",\"parameters\":[{\"in\":\"path\",\"name\":\"uuid\",\"required\":true,\"type\":\"string\"},{\"description\":\"cascade deletes the rules referencing the object, otherwise they block the deletion.\",\"in\":\"query\",\"name\":\"cascade\",\"required\":false,\"type\":\"boolean\"}],\"responses\":{\"200\":{\"description\":\"A successful response.\",\"schema\":{\"properties\":{}}},\"default\":{\"description\":\"An unexpected error response.\",\"schema\":{\"$ref\":\"#/definitions/runtimeError\"}}},\"summary\":\"Delete App Object request\",\"tags\":[\"AppService\"]},\"get\":{\"operationId\":\"AppService_GetObject\",\"parameters\":[{\"in\":\"path\",\"name\":\"uuid\",\"required\":true,\"type\":\"string\"}],\"responses\":{\"200\":{\"description\":\"A successful response.\",\"schema\":{\"$ref\":\"#/definitions/authV1Object\"}},\"default\":{\"description\":\"An unexpected error response.\",\"schema\":{\"$ref\":\"#/definitions/runtimeError\"}}},\"summary\":\"Get App Object\",\"tags\":[\"AppService\"]},\"put\":{\"operationId\":\"AppService_UpdateObject\",\"parameters\":[{\"in\":\"path\",\"name\":\"uuid\",\"required\":true,\"type\":\"string\"},{\"in\":\"body\",\"name\":\"body\",\"required\":true,\"schema\":{\"$ref\":\"#/definitions/authV1UpdateObjectRequest\"}}],\"responses\":{\"200\":{\"description\":\"A successful response.\",\"schema\":{\"$ref\":\"#/definitions/authV1Object\"}},\"default\":{\"description\":\"An unexpected error response.\",\"schema\":{\"$ref\":\"#/definitions/runtimeError\"}}},\"summary\":\"Update App Object request\",\"tags\":[\"AppService\"]}},\"/v1/apps/-/resources\":{\"get\":{\"operationId\":\"AppService_ListResources\",\"parameters\":[{\"format\":\"int64\",\"in\":\"query\",\"name\":\"limit\",\"required\":false,\"type\":\"string\"},{\"format\":\"int64\",\"in\":\"query\",\"name\":\"offset\",\"required\":false,\"type\":\"string\"},{\"in\":\"query\",\"name\":\"query\",\"required\":false,\"type\":\"string\"},{\"in\":\"query\",\"name\":\"uuid\",\"required\":false,\"type\":\"string\"}],\"responses\":{\"200\":{\"description\":\"A successful response.\",\"schema\":{\"$ref\":\"#/definitions/authV1ListResourcesResponse\"}},\"default\":{\"description\":\"An unexpected error response.\",\"schema\":{\"$ref\":\"#/definitions/runtimeError\"}}},\"summary\":\"List App Resources\",\"tags\":[\"AppService\"]}},\"/v1/apps/-/resources/{uuid}\":{\"delete\":{\"operationId\":\"AppService_DeleteResource\",\"parameters\":[{\"in\":\"path\",\"name\":\"uuid\",\"required\":true,\"type\":\"string\"},{\"description\":\"cascade deletes the rules referencing the resource, otherwise they block the deletion.\",\"in\":\"query\",\"name\":\"cascade\",\"required\":false,\"type\":\"boolean\"}],\"responses\":{\"200\":{\"description\":\"A successful response.\",\"schema\":{\"properties\":{}}},\"default\":{\"description\":\"An unexpected error response.\",\"schema\":{\"$ref\":\"#/definitions/runtimeError\"}}},\"summary\":\"Delete App Resource object request\",\"tags\":[\"AppService\"]},\"get\":{\"operationId\":\"AppService_GetResource\",\"parameters\":[{\"in\":\"path\",\"name\":\"uuid\",\"required\":true,\"type\":\"string\"}],\"responses\":{\"200\":{\"description\":\"A successful response.\",\"schema\":{\"$ref\":\"#/definitions/authV1Resource\"}},\"default\":{\"description\":\"An unexpected error response.\",\"schema\":{\"$ref\":\"#/definitions/runtimeError\"}}},\"summary\":\"Get App Resource\",\"tags\":[\"AppService\"]},\"put\":{\"operationId\":\"AppService_UpdateResource\",\"parameters\":[{\"in\":\"path\",\"name\":\"uuid\",\"required\":true,\"type\":\"string\"},{\"in\":\"body\",\"name\":\"body\",\"required\":true,\"schema\":{\"$ref\":\"#/definitions/authV1UpdateResourceRequest\"}}],\"responses\":{\"200\":{\"description\":\"A successful response.\",\"schema\":{\"$ref\":\"#/definitions/authV1Resource\"}},\"default\":{\"description\":\"An unexpected error response.\",\"schema\":{\"$ref\":\"#/definitions/runtimeError\"}}},\"summary\":\"Update App Resource object request\",\"tags\":[\"AppService\"]}},\"/v1/apps/-/routes/{uuid}\":{\"delete\":{\"operationId\":\"AppService_DeleteRoute\",\"parameters\":[{\"in\":\"path\",\"name\":\"uuid\",\"required\":true,\"type\":\"string\"}],\"responses\":{\"200\":{\"description\":\"A successful response.\",\"schema\":{\"properties\":{}}},\"default\":{\"description\":\"An unexpected error response.\",\"schema\":{\"$ref\":\"#/definitions/runtimeError\"}}},\"summary\":\"Delete App Route request\",\"tags\":[\"AppService\"]}},\"/v1/apps/{uuid}\":{\"delete\":{\"operationId\":\"AppService_DeleteApp\",\"parameters\":[{\"in\":\"path\",\"name\":\"uuid\",\"required\":true,\"type\":\"string\"}],\"responses\":{\"200\":{\"description\":\"A successful response.\",\"schema\":{\"properties\":{}}},\"default\":{\"description\":\"An unexpected error response.\",\"schema\":{\"$ref\":\"#/definitions/runtimeError\"}}},\"summary\":\"Delete App request\",\"tags\":[\"AppService\"]},\"get\":{\"operationId\":\"AppService_GetApp\",\"parameters\":[{\"in\":\"path\",\"name\":\"uuid\",\"required\":true,\"type\":\"string\"}],\"responses\":{\"200\":{\"description\":\"A successful response.\",\"schema\":{\"$ref\":\"#/definitions/authV1App\"}},\"default\":{\"description\":\"An unexpected error response.\",\"schema\":{\"$ref\":\"#/definitions/runtimeError\"}}},\"summary\":\"Get App\",\"tags\":[\"AppService\"]},\"put\":{\"operationId\":\"AppService_UpdateApp\",\"parameters\":[{\"in\":\"path\",\"name\":\"uuid\",\"required\":true,\"type\":\"string\"},{\"in\":\"body\",\"name\":\"body\",\"required\":true,\"schema\":{\"$ref\":\"#/definitions/authV1UpdateAppRequest\"}}],\"responses\":{\"200\":{\"description\":\"A successful response.\",\"schema\":{\"$ref\":\"#/definitions/authV1App\"}},\"default\":{\"description\":\"An unexpected error response.\",\"schema\":{\"$ref\":\"#/definitions/runtimeError\"}}},\"summary\":\"Update App request\",\"tags\":[\"AppService\"]}},\"/v1/apps/{uuid}/objects\":{\"post\":{\"operationId\":\"AppService_CreateObject\",\"parameters\":[{\"in\":\"path\",\"name\":\"uuid\",\"required\":true,\"type\":\"string\"},{\"in\":\"body\",\"name\":\"body\",\"required\":true,\"schema\":{\"$ref\":\"#/definitions/authV1CreateObjectRequest\"}}],\"responses\":{\"200\":{\"description\":\"A successful response.\",\"schema\":{\"$ref\":\"#/definitions/authV1Object\"}},\"default\":{\"description\":\"An unexpected error response.\",\"schema\":{\"$ref\":\"#/definitions/runtimeError\"}}},\"summary\":\"Create App Object request\",\"tags\":[\"AppService\"]}},\"/v1/apps/{uuid}/resources\":{\"post\":{\"operationId\":\"AppService_CreateResource\",\"parameters\":[{\"in\":\"path\",\"name\":\"uuid\",\"required\":true,\"type\":\"string\"},{\"in\":\"body\",\"name\":\"body\",\"required\":true,\"schema\":{\"$ref\":\"#/definitions/authV1CreateResourceRequest\"}}],\"responses\":{\"200\":{\"description\":\"A successful response.\",\"schema\":{\"$ref\":\"#/definitions/authV1Resource\"}},\"default\":{\"description\":\"An unexpected error response.\",\"schema\":{\"$ref\":\"#/definitions/runtimeError\"}}},\"summary\":\"Create App Resource object request\",\"tags\":[\"AppService\"]}},\"/v1/apps/{uuid}/routes\":{\"get\":{\"operationId\":\"AppService_ListRoutes\",\"parameters\":[{\"in\":\"path\",\"name\":\"uuid\",\"required\":true,\"type\":\"string\"}],\"responses\":{\"200\":{\"description\":\"A successful response.\",\"schema\":{\"$ref\":\"#/definitions/authV1ListRoutesResponse\"}},\"default\":{\"description\":\"An unexpected error response.\",\"schema\":{\"$ref\":\"#/definitions/runtimeError\"}}},\"summary\":\"List App Routes request\",\"tags\":[\"AppService\"]},\"post\":{\"operationId\":\"AppService_CreateRoute\",\"parameters\":[{\"in\":\"path\",\"name\":\"uuid\",\"required\":true,\"type\":\"string\"},{\"in\":\"body\",\"name\":\"body\",\"required\":true,\"schema\":{\"$ref\":\"#/definitions/authV1CreateRouteRequest\"}}],\"responses\":{\"200\":{\"description\":\"A successful response.\",\"schema\":{\"$ref\":\"#/definitions/authV1Route\"}},\"default\":{\"description\":\"An unexpected error response.\",\"schema\":{\"$ref\":\"#/definitions/runtimeError\"}}},\"summary\":\"Create App Route request\",\"tags\":[\"AppService\"]}},\"/v1/apps/{uuid}/schema\":{\"post\":{\"operationId\":\"AppService_ImportAppSchema\",\"parameters\":[{\"in\":\"path\",\"name\":\"uuid\",\"required\":true,\"type\":\"string\"},{\"in\":\"body\",\"name\":\"body\",\"required\":true,\"schema\":{\"$ref\":\"#/definitions/authV1ImportAppSchemaRequest\"}}],\"responses\":{\"200\":{\"description\":\"A successful response.\",\"schema\":{\"$ref\":\"#/definitions/authV1ImportAppSchemaResponse\"}},\"default\":{\"description\":\"An unexpected error response.\",\"schema\":{\"$ref\":\"#/definitions/runtimeError\"}}},\"summary\":\"Import App Schema request\",\"tags\":[\"AppService\"]}}}"
+const apps_definitions = "{\"authV1App\":{\"properties\":{\"action_aliases\":{\"additionalProperties\":{\"type\":\"string\"},\"title\":\"action_aliases map alternative action names to the rule action, e.g. list to read\",\"type\":\"object\"},\"actions\":{\"additionalProperties\":{\"type\":\"string\"},\"title\":\"actions map the HTTP methods or the full gRPC method names of requests to rule actions,\\ne.g. GET and HEAD to read, routes with an action override them\",\"type\":\"object\"},\"created_at\":{\"format\":\"date-time\",\"type\":\"string\"},\"enable\":{\"type\":\"boolean\"},\"hosts\":{\"items\":{\"type\":\"string\"},\"title\":\"hosts are the forwarded hosts the app serves, its routes apply to them\",\"type\":\"array\"},\"name\":{\"type\":\"string\"},\"updated_at\":{\"format\":\"date-time\",\"type\":\"string\"},\"uuid\":{\"type\":\"string\"}},\"type\":\"object\"},\"authV1CreateAppRequest\":{\"properties\":{\"action_aliases\":{\"additionalProperties\":{\"type\":\"string\"},\"type\":\"object\"},\"actions\":{\"additionalProperties\":{\"type\":\"string\"},\"type\":\"object\"},\"enable\":{\"type\":\"boolean\"},\"hosts\":{\"items\":{\"type\":\"string\"},\"type\":\"array\"},\"name\":{\"type\":\"string\"}},\"type\":\"object\"},\"authV1CreateObjectRequest\":{\"properties\":{\"identifier\":{\"type\":\"string\"},\"uuid\":{\"type\":\"string\"}},\"type\":\"object\"},\"authV1CreateResourceRequest\":{\"properties\":{\"name\":{\"type\":\"string\"},\"uuid\":{\"type\":\"string\"}},\"type\":\"object\"},\"authV1CreateRouteRequest\":{\"properties\":{\"action\":{\"type\":\"string\"},\"method\":{\"type\":\"string\"},\"resource\":{\"type\":\"string\"},\"template\":{\"type\":\"string\"},\"uuid\":{\"type\":\"string\"}},\"type\":\"object\"},\"authV1ImportAppSchemaRequest\":{\"properties\":{\"content\":{\"format\":\"byte\",\"type\":\"string\"},\"dry_run\":{\"type\":\"boolean\"},\"format\":{\"$ref\":\"#/definitions/authV1SchemaFormat\"},\"prune\":{\"title\":\"prune removes the resources and routes of the app which are not in the schema\",\"type\":\"boolean\"},\"uuid\":{\"type\":\"string\"}},\"type\":\"object\"},\"authV1ImportAppSchemaResponse\":{\"properties\":{\"added\":{\"items\":{\"type\":\"string\"},\"title\":\"added and removed are the resources, routes and actions of the app, e.g.\\n\\\"resource orders\\\", \\\"route GET /v1/orders/{object} orders\\\" or \\\"action GET read\\\"\",\"type\":\"array\"},\"applied\":{\"type\":\"boolean\"},\"removed\":{\"items\":{\"type\":\"string\"},\"type\":\"array\"}},\"type\":\"object\"},\"authV1ListAppsResponse\":{\"properties\":{\"apps\":{\"items\":{\"$ref\":\"#/definitions/authV1App\"},\"type\":\"array\"},\"limit\":{\"format\":\"int64\",\"type\":\"string\"},\"offset\":{\"format\":\"int64\",\"type\":\"string\"},\"total_count\":{\"format\":\"int64\",\"type\":\"string\"}},\"type\":\"object\"},\"authV1ListObjectsResponse\":{\"properties\":{\"limit\":{\"format\":\"int64\",\"type\":\"string\"},\"objects\":{\"items\":{\"$ref\":\"#/definitions/authV1Object\"},\"type\":\"array\"},\"offset\":{\"format\":\"int64\",\"type\":\"string\"},\"total_count\":{\"format\":\"int64\",\"type\":\"string\"}},\"type\":\"object\"},\"authV1ListResourcesResponse\":{\"properties\":{\"limit\":{\"format\":\"int64\",\"type\":\"string\"},\"offset\":{\"format\":\"int64\",\"type\":\"string\"},\"resources\":{\"items\":{\"$ref\":\"#/definitions/authV1Resource\"},\"type\":\"array\"},\"total_count\":{\"format\":\"int64\",\"type\":\"string\"}},\"type\":\"object\"},\"authV1ListRoutesResponse\":{\"properties\":{\"routes\":{\"items\":{\"$ref\":\"#/definitions/authV1Route\"},\"type\":\"array\"}},\"type\":\"object\"},\"authV1Object\":{\"properties\":{\"app\":{\"$ref\":\"#/definitions/authV1App\"},\"created_at\":{\"format\":\"date-time\",\"type\":\"string\"},\"identifier\":{\"type\":\"string\"},\"updated_at\":{\"format\":\"date-time\",\"type\":\"string\"},\"uuid\":{\"type\":\"string\"}},\"type\":\"object\"},\"authV1Resource\":{\"properties\":{\"app\":{\"$ref\":\"#/definitions/authV1App\"},\"created_at\":{\"format\":\"date-time\",\"type\":\"string\"},\"name\":{\"type\":\"string\"},\"updated_at\":{\"format\":\"date-time\",\"type\":\"string\"},\"uuid\":{\"type\":\"string\"}},\"type\":\"object\"},\"authV1Route\":{\"properties\":{\"action\":{\"title\":\"action is the rule action, the app action of the request method when empty\",\"type\":\"string\"},\"app\":{\"$ref\":\"#/definitions/authV1App\"},\"created_at\":{\"format\":\"date-time\",\"type\":\"string\"},\"method\":{\"title\":\"method is the HTTP method, * matches every method\",\"type\":\"string\"},\"resource\":{\"type\":\"string\"},\"template\":{\"title\":\"template is the path template, e.g. /orders/{object}, {resource} and {object} capture\\nthe resource and object, other {name} and * segments match any segment and a trailing\\n** matches the rest of the path\",\"type\":\"string\"},\"updated_at\":{\"format\":\"date-time\",\"type\":\"string\"},\"uuid\":{\"type\":\"string\"}},\"title\":\"Route maps the requests to an app matching the method and template to a permission\",\"type\":\"object\"},\"authV1SchemaFormat\":{\"default\":\"OPENAPI\",\"description\":\"- OPENAPI: OPENAPI is an OpenAPI or Swagger JSON document\\n - DESCRIPTOR_SET: DESCRIPTOR_SET is a serialized protobuf FileDescriptorSet\",\"enum\":[\"OPENAPI\",\"DESCRIPTOR_SET\"],\"title\":\"SchemaFormat is the format of an app schema\",\"type\":\"string\"},\"authV1UpdateAppRequest\":{\"properties\":{\"action_aliases\":{\"additionalProperties\":{\"type\":\"string\"},\"type\":\"object\"},\"actions\":{\"additionalProperties\":{\"type\":\"string\"},\"type\":\"object\"},\"enable\":{\"type\":\"boolean\"},\"hosts\":{\"items\":{\"type\":\"string\"},\"type\":\"array\"},\"name\":{\"type\":\"string\"},\"uuid\":{\"type\":\"string\"}},\"type\":\"object\"},\"authV1UpdateObjectRequest\":{\"properties\":{\"app_uuid\":{\"type\":\"string\"},\"identifier\":{\"type\":\"string\"},\"uuid\":{\"type\":\"string\"}},\"type\":\"object\"},\"authV1UpdateResourceRequest\":{\"properties\":{\"app_uuid\":{\"type\":\"string\"},\"name\":{\"type\":\"string\"},\"uuid\":{\"type\":\"string\"}},\"type\":\"object\"},\"protobufAny\":{\"properties\":{\"type_url\":{\"type\":\"string\"},\"value\":{\"format\":\"byte\",\"type\":\"string\"}},\"type\":\"object\"},\"runtimeError\":{\"properties\":{\"code\":{\"format\":\"int32\",\"type\":\"integer\"},\"details\":{\"items\":{\"$ref\":\"#/definitions/protobufAny\"},\"type\":\"array\"},\"error\":{\"type\":\"string\"},\"message\":{\"type\":\"string\"}},\"type\":\"object\"}}"
 
 func init() {
 	var (
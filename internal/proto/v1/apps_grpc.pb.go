@@ -48,6 +48,14 @@ type AppServiceClient interface {
 	UpdateObject(ctx context.Context, in *UpdateObjectRequest, opts ...grpc.CallOption) (*Object, error)
 	// Delete App Object request
 	DeleteObject(ctx context.Context, in *DeleteObjectRequest, opts ...grpc.CallOption) (*empty.Empty, error)
+	// List App Routes request
+	ListRoutes(ctx context.Context, in *ListRoutesRequest, opts ...grpc.CallOption) (*ListRoutesResponse, error)
+	// Create App Route request
+	CreateRoute(ctx context.Context, in *CreateRouteRequest, opts ...grpc.CallOption) (*Route, error)
+	// Delete App Route request
+	DeleteRoute(ctx context.Context, in *DeleteRouteRequest, opts ...grpc.CallOption) (*empty.Empty, error)
+	// Import App Schema request
+	ImportAppSchema(ctx context.Context, in *ImportAppSchemaRequest, opts ...grpc.CallOption) (*ImportAppSchemaResponse, error)
 }
 
 type appServiceClient struct {
@@ -193,6 +201,42 @@ func (c *appServiceClient) DeleteObject(ctx context.Context, in *DeleteObjectReq
 	return out, nil
 }
 
+func (c *appServiceClient) ListRoutes(ctx context.Context, in *ListRoutesRequest, opts ...grpc.CallOption) (*ListRoutesResponse, error) {
+	out := new(ListRoutesResponse)
+	err := c.cc.Invoke(ctx, "/authV1.AppService/ListRoutes", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *appServiceClient) CreateRoute(ctx context.Context, in *CreateRouteRequest, opts ...grpc.CallOption) (*Route, error) {
+	out := new(Route)
+	err := c.cc.Invoke(ctx, "/authV1.AppService/CreateRoute", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *appServiceClient) DeleteRoute(ctx context.Context, in *DeleteRouteRequest, opts ...grpc.CallOption) (*empty.Empty, error) {
+	out := new(empty.Empty)
+	err := c.cc.Invoke(ctx, "/authV1.AppService/DeleteRoute", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *appServiceClient) ImportAppSchema(ctx context.Context, in *ImportAppSchemaRequest, opts ...grpc.CallOption) (*ImportAppSchemaResponse, error) {
+	out := new(ImportAppSchemaResponse)
+	err := c.cc.Invoke(ctx, "/authV1.AppService/ImportAppSchema", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // AppServiceServer is the server API for AppService service.
 // All implementations must embed UnimplementedAppServiceServer
 // for forward compatibility
@@ -227,6 +271,14 @@ type AppServiceServer interface {
 	UpdateObject(context.Context, *UpdateObjectRequest) (*Object, error)
 	// Delete App Object request
 	DeleteObject(context.Context, *DeleteObjectRequest) (*empty.Empty, error)
+	// List App Routes request
+	ListRoutes(context.Context, *ListRoutesRequest) (*ListRoutesResponse, error)
+	// Create App Route request
+	CreateRoute(context.Context, *CreateRouteRequest) (*Route, error)
+	// Delete App Route request
+	DeleteRoute(context.Context, *DeleteRouteRequest) (*empty.Empty, error)
+	// Import App Schema request
+	ImportAppSchema(context.Context, *ImportAppSchemaRequest) (*ImportAppSchemaResponse, error)
 	mustEmbedUnimplementedAppServiceServer()
 }
 
@@ -279,6 +331,18 @@ func (UnimplementedAppServiceServer) UpdateObject(context.Context, *UpdateObject
 func (UnimplementedAppServiceServer) DeleteObject(context.Context, *DeleteObjectRequest) (*empty.Empty, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method DeleteObject not implemented")
 }
+func (UnimplementedAppServiceServer) ListRoutes(context.Context, *ListRoutesRequest) (*ListRoutesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListRoutes not implemented")
+}
+func (UnimplementedAppServiceServer) CreateRoute(context.Context, *CreateRouteRequest) (*Route, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreateRoute not implemented")
+}
+func (UnimplementedAppServiceServer) DeleteRoute(context.Context, *DeleteRouteRequest) (*empty.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DeleteRoute not implemented")
+}
+func (UnimplementedAppServiceServer) ImportAppSchema(context.Context, *ImportAppSchemaRequest) (*ImportAppSchemaResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ImportAppSchema not implemented")
+}
 func (UnimplementedAppServiceServer) mustEmbedUnimplementedAppServiceServer() {}
 
 // UnsafeAppServiceServer may be embedded to opt out of forward compatibility for this service.
@@ -562,6 +626,78 @@ func _AppService_DeleteObject_Handler(srv interface{}, ctx context.Context, dec
 	return interceptor(ctx, in, info, handler)
 }
 
+func _AppService_ListRoutes_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListRoutesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AppServiceServer).ListRoutes(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/authV1.AppService/ListRoutes",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AppServiceServer).ListRoutes(ctx, req.(*ListRoutesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AppService_CreateRoute_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateRouteRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AppServiceServer).CreateRoute(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/authV1.AppService/CreateRoute",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AppServiceServer).CreateRoute(ctx, req.(*CreateRouteRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AppService_DeleteRoute_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeleteRouteRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AppServiceServer).DeleteRoute(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/authV1.AppService/DeleteRoute",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AppServiceServer).DeleteRoute(ctx, req.(*DeleteRouteRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AppService_ImportAppSchema_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ImportAppSchemaRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AppServiceServer).ImportAppSchema(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/authV1.AppService/ImportAppSchema",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AppServiceServer).ImportAppSchema(ctx, req.(*ImportAppSchemaRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 var _AppService_serviceDesc = grpc.ServiceDesc{
 	ServiceName: "authV1.AppService",
 	HandlerType: (*AppServiceServer)(nil),
@@ -626,6 +762,22 @@ var _AppService_serviceDesc = grpc.ServiceDesc{
 			MethodName: "DeleteObject",
 			Handler:    _AppService_DeleteObject_Handler,
 		},
+		{
+			MethodName: "ListRoutes",
+			Handler:    _AppService_ListRoutes_Handler,
+		},
+		{
+			MethodName: "CreateRoute",
+			Handler:    _AppService_CreateRoute_Handler,
+		},
+		{
+			MethodName: "DeleteRoute",
+			Handler:    _AppService_DeleteRoute_Handler,
+		},
+		{
+			MethodName: "ImportAppSchema",
+			Handler:    _AppService_ImportAppSchema_Handler,
+		},
 	},
 	Streams:  []grpc.StreamDesc{},
 	Metadata: "api/proto/v1/apps.proto",
@@ -8,7 +8,7 @@ import (
 )
 
 const audit_logs_paths = "{\"/v1/audit-logs\":{\"get\":{\"operationId\":\"AuditLogService_ListAuditLogs\",\"parameters\":[{\"format\":\"int64\",\"in\":\"query\",\"name\":\"limit\",\"required\":false,\"type\":\"string\"},{\"format\":\"int64\",\"in\":\"query\",\"name\":\"offset\",\"required\":false,\"type\":\"string\"},{\"in\":\"query\",\"name\":\"query\",\"required\":false,\"type\":\"string\"}],\"responses\":{\"200\":{\"description\":\"A successful response.\",\"schema\":{\"$ref\":\"#/definitions/authV1ListAuditLogsResponse\"}},\"default\":{\"description\":\"An unexpected error response.\",\"schema\":{\"$ref\":\"#/definitions/runtimeError\"}}},\"summary\":\"List AuditLogs\",\"tags\":[\"AuditLogService\"]}},\"/v1/audit-logs/{uuid}\":{\"get\":{\"operationId\":\"AuditLogService_GetAuditLog\",\"parameters\":[{\"in\":\"path\",\"name\":\"uuid\",\"required\":true,\"type\":\"string\"}],\"responses\":{\"200\":{\"description\":\"A successful response.\",\"schema\":{\"$ref\":\"#/definitions/authV1AuditLog\"}},\"default\":{\"description\":\"An unexpected error response.\",\"schema\":{\"$ref\":\"#/definitions/runtimeError\"}}},\"summary\":\"Get AuditLog\",\"tags\":[\"AuditLogService\"]}}}"
-const audit_logs_definitions = "{\"authV1AuditLog\":{\"properties\":{\"action\":{\"type\":\"string\"},\"created_at\":{\"format\":\"date-time\",\"type\":\"string\"},\"new_value\":{\"type\":\"string\"},\"object\":{\"type\":\"string\"},\"old_value\":{\"type\":\"string\"},\"updated_at\":{\"format\":\"date-time\",\"type\":\"string\"},\"user\":{\"$ref\":\"#/definitions/authV1User\"},\"uuid\":{\"type\":\"string\"}},\"type\":\"object\"},\"authV1ListAuditLogsResponse\":{\"properties\":{\"audit_logs\":{\"items\":{\"$ref\":\"#/definitions/authV1AuditLog\"},\"type\":\"array\"},\"limit\":{\"format\":\"int64\",\"type\":\"string\"},\"offset\":{\"format\":\"int64\",\"type\":\"string\"},\"total_count\":{\"format\":\"int64\",\"type\":\"string\"}},\"type\":\"object\"},\"authV1User\":{\"properties\":{\"avatar_url\":{\"type\":\"string\"},\"created_at\":{\"format\":\"date-time\",\"type\":\"string\"},\"email\":{\"type\":\"string\"},\"enable\":{\"type\":\"boolean\"},\"firstname\":{\"type\":\"string\"},\"gender\":{\"type\":\"string\"},\"lastname\":{\"type\":\"string\"},\"password\":{\"type\":\"string\"},\"raw_data\":{\"type\":\"string\"},\"roles\":{\"items\":{\"$ref\":\"#/definitions/authV1UserRole\"},\"type\":\"array\"},\"updated_at\":{\"format\":\"date-time\",\"type\":\"string\"},\"username\":{\"type\":\"string\"},\"uuid\":{\"type\":\"string\"}},\"type\":\"object\"},\"authV1UserRole\":{\"properties\":{\"created_at\":{\"format\":\"date-time\",\"type\":\"string\"},\"domain\":{\"type\":\"string\"},\"enable\":{\"type\":\"boolean\"},\"role\":{\"type\":\"string\"},\"updated_at\":{\"format\":\"date-time\",\"type\":\"string\"},\"uuid\":{\"type\":\"string\"}},\"type\":\"object\"},\"protobufAny\":{\"properties\":{\"type_url\":{\"type\":\"string\"},\"value\":{\"format\":\"byte\",\"type\":\"string\"}},\"type\":\"object\"},\"runtimeError\":{\"properties\":{\"code\":{\"format\":\"int32\",\"type\":\"integer\"},\"details\":{\"items\":{\"$ref\":\"#/definitions/protobufAny\"},\"type\":\"array\"},\"error\":{\"type\":\"string\"},\"message\":{\"type\":\"string\"}},\"type\":\"object\"}}"
+const audit_logs_definitions = "{\"authV1AuditLog\":{\"properties\":{\"action\":{\"type\":\"string\"},\"created_at\":{\"format\":\"date-time\",\"type\":\"string\"},\"new_value\":{\"type\":\"string\"},\"object\":{\"type\":\"string\"},\"old_value\":{\"type\":\"string\"},\"updated_at\":{\"format\":\"date-time\",\"type\":\"string\"},\"user\":{\"$ref\":\"#/definitions/authV1User\"},\"uuid\":{\"type\":\"string\"}},\"type\":\"object\"},\"authV1ListAuditLogsResponse\":{\"properties\":{\"audit_logs\":{\"items\":{\"$ref\":\"#/definitions/authV1AuditLog\"},\"type\":\"array\"},\"limit\":{\"format\":\"int64\",\"type\":\"string\"},\"offset\":{\"format\":\"int64\",\"type\":\"string\"},\"total_count\":{\"format\":\"int64\",\"type\":\"string\"}},\"type\":\"object\"},\"authV1User\":{\"properties\":{\"avatar_url\":{\"type\":\"string\"},\"created_at\":{\"format\":\"date-time\",\"type\":\"string\"},\"email\":{\"type\":\"string\"},\"enable\":{\"type\":\"boolean\"},\"firstname\":{\"type\":\"string\"},\"gender\":{\"type\":\"string\"},\"lastname\":{\"type\":\"string\"},\"password\":{\"type\":\"string\"},\"raw_data\":{\"type\":\"string\"},\"roles\":{\"items\":{\"$ref\":\"#/definitions/authV1UserRole\"},\"type\":\"array\"},\"updated_at\":{\"format\":\"date-time\",\"type\":\"string\"},\"username\":{\"type\":\"string\"},\"uuid\":{\"type\":\"string\"}},\"type\":\"object\"},\"authV1UserRole\":{\"properties\":{\"created_at\":{\"format\":\"date-time\",\"type\":\"string\"},\"domain\":{\"type\":\"string\"},\"enable\":{\"type\":\"boolean\"},\"expires_at\":{\"format\":\"date-time\",\"type\":\"string\"},\"role\":{\"type\":\"string\"},\"updated_at\":{\"format\":\"date-time\",\"type\":\"string\"},\"user_uuid\":{\"type\":\"string\"},\"username\":{\"type\":\"string\"},\"uuid\":{\"type\":\"string\"},\"valid_from\":{\"format\":\"date-time\",\"type\":\"string\"}},\"type\":\"object\"},\"protobufAny\":{\"properties\":{\"type_url\":{\"type\":\"string\"},\"value\":{\"format\":\"byte\",\"type\":\"string\"}},\"type\":\"object\"},\"runtimeError\":{\"properties\":{\"code\":{\"format\":\"int32\",\"type\":\"integer\"},\"details\":{\"items\":{\"$ref\":\"#/definitions/protobufAny\"},\"type\":\"array\"},\"error\":{\"type\":\"string\"},\"message\":{\"type\":\"string\"}},\"type\":\"object\"}}"
 
 func init() {
 	var (
@@ -7,8 +7,8 @@ import (
 	"github.com/golang-tire/pkg/grpcgw"
 )
 
-const auth_paths = "{\"/v1/auth/login\":{\"post\":{\"operationId\":\"AuthService_Login\",\"parameters\":[{\"in\":\"body\",\"name\":\"body\",\"required\":true,\"schema\":{\"$ref\":\"#/definitions/authV1LoginRequest\"}}],\"responses\":{\"200\":{\"description\":\"A successful response.\",\"schema\":{\"$ref\":\"#/definitions/authV1LoginResponse\"}},\"default\":{\"description\":\"An unexpected error response.\",\"schema\":{\"$ref\":\"#/definitions/runtimeError\"}}},\"summary\":\"Login login user\",\"tags\":[\"AuthService\"]}},\"/v1/auth/logout\":{\"post\":{\"operationId\":\"AuthService_Logout\",\"parameters\":[{\"in\":\"body\",\"name\":\"body\",\"required\":true,\"schema\":{\"$ref\":\"#/definitions/authV1LogoutRequest\"}}],\"responses\":{\"200\":{\"description\":\"A successful response.\",\"schema\":{\"$ref\":\"#/definitions/authV1LogoutResponse\"}},\"default\":{\"description\":\"An unexpected error response.\",\"schema\":{\"$ref\":\"#/definitions/runtimeError\"}}},\"summary\":\"Logout will close user session\",\"tags\":[\"AuthService\"]}},\"/v1/auth/register\":{\"post\":{\"operationId\":\"AuthService_Register\",\"parameters\":[{\"in\":\"body\",\"name\":\"body\",\"required\":true,\"schema\":{\"$ref\":\"#/definitions/authV1RegisterRequest\"}}],\"responses\":{\"200\":{\"description\":\"A successful response.\",\"schema\":{\"$ref\":\"#/definitions/authV1RegisterResponse\"}},\"default\":{\"description\":\"An unexpected error response.\",\"schema\":{\"$ref\":\"#/definitions/runtimeError\"}}},\"summary\":\"Login login user\",\"tags\":[\"AuthService\"]}},\"/v1/auth/token/refresh\":{\"post\":{\"operationId\":\"AuthService_RefreshToken\",\"parameters\":[{\"in\":\"body\",\"name\":\"body\",\"required\":true,\"schema\":{\"$ref\":\"#/definitions/authV1RefreshTokenRequest\"}}],\"responses\":{\"200\":{\"description\":\"A successful response.\",\"schema\":{\"$ref\":\"#/definitions/authV1RefreshTokenResponse\"}},\"default\":{\"description\":\"An unexpected error response.\",\"schema\":{\"$ref\":\"#/definitions/runtimeError\"}}},\"summary\":\"RefreshToken will check and return new token\",\"tags\":[\"AuthService\"]}},\"/v1/auth/token/verify\":{\"post\":{\"operationId\":\"AuthService_VerifyToken\",\"parameters\":[{\"in\":\"body\",\"name\":\"body\",\"required\":true,\"schema\":{\"$ref\":\"#/definitions/authV1VerifyTokenRequest\"}}],\"responses\":{\"200\":{\"description\":\"A successful response.\",\"schema\":{\"$ref\":\"#/definitions/authV1VerifyTokenResponse\"}},\"default\":{\"description\":\"An unexpected error response.\",\"schema\":{\"$ref\":\"#/definitions/runtimeError\"}}},\"summary\":\"VerifyToken will verify and return token\",\"tags\":[\"AuthService\"]}},\"/v1/auth/validate\":{\"get\":{\"operationId\":\"AuthService_Validate\",\"responses\":{\"200\":{\"description\":\"A successful response.\",\"schema\":{\"properties\":{}}},\"default\":{\"description\":\"An unexpected error response.\",\"schema\":{\"$ref\":\"#/definitions/runtimeError\"}}},\"summary\":\"Validate will check token and return user data in response header\",\"tags\":[\"AuthService\"]}}}"
-const auth_definitions = "{\"authV1LoginRequest\":{\"properties\":{\"password\":{\"type\":\"string\"},\"username\":{\"type\":\"string\"}},\"type\":\"object\"},\"authV1LoginResponse\":{\"properties\":{\"access_token\":{\"type\":\"string\"},\"refresh_token\":{\"type\":\"string\"}},\"type\":\"object\"},\"authV1LogoutRequest\":{\"type\":\"object\"},\"authV1LogoutResponse\":{\"properties\":{\"redirect_to\":{\"type\":\"string\"}},\"type\":\"object\"},\"authV1RefreshTokenRequest\":{\"properties\":{\"refresh_token\":{\"type\":\"string\"}},\"type\":\"object\"},\"authV1RefreshTokenResponse\":{\"properties\":{\"access_token\":{\"type\":\"string\"},\"refresh_token\":{\"type\":\"string\"}},\"type\":\"object\"},\"authV1RegisterRequest\":{\"properties\":{\"avatar_url\":{\"type\":\"string\"},\"email\":{\"type\":\"string\"},\"firstname\":{\"type\":\"string\"},\"gender\":{\"type\":\"string\"},\"lastname\":{\"type\":\"string\"},\"password\":{\"type\":\"string\"},\"raw_data\":{\"type\":\"string\"},\"username\":{\"type\":\"string\"}},\"type\":\"object\"},\"authV1RegisterResponse\":{\"properties\":{\"avatar_url\":{\"type\":\"string\"},\"email\":{\"type\":\"string\"},\"firstname\":{\"type\":\"string\"},\"gender\":{\"type\":\"string\"},\"lastname\":{\"type\":\"string\"},\"password\":{\"type\":\"string\"},\"raw_data\":{\"type\":\"string\"},\"username\":{\"type\":\"string\"},\"uuid\":{\"type\":\"string\"}},\"type\":\"object\"},\"authV1VerifyTokenRequest\":{\"properties\":{\"access_token\":{\"type\":\"string\"}},\"type\":\"object\"},\"authV1VerifyTokenResponse\":{\"properties\":{\"access_token\":{\"type\":\"string\"}},\"type\":\"object\"},\"protobufAny\":{\"properties\":{\"type_url\":{\"type\":\"string\"},\"value\":{\"format\":\"byte\",\"type\":\"string\"}},\"type\":\"object\"},\"runtimeError\":{\"properties\":{\"code\":{\"format\":\"int32\",\"type\":\"integer\"},\"details\":{\"items\":{\"$ref\":\"#/definitions/protobufAny\"},\"type\":\"array\"},\"error\":{\"type\":\"string\"},\"message\":{\"type\":\"string\"}},\"type\":\"object\"}}"
+const auth_paths = "{\"/v1/auth/check\":{\"post\":{\"operationId\":\"AuthService_CheckPermission\",\"parameters\":[{\"in\":\"body\",\"name\":\"body\",\"required\":true,\"schema\":{\"$ref\":\"#/definitions/authV1CheckPermissionRequest\"}}],\"responses\":{\"200\":{\"description\":\"A successful response.\",\"schema\":{\"$ref\":\"#/definitions/authV1CheckPermissionResponse\"}},\"default\":{\"description\":\"An unexpected error response.\",\"schema\":{\"$ref\":\"#/definitions/runtimeError\"}}},\"summary\":\"CheckPermission will check if subject can do the action on resource and object\",\"tags\":[\"AuthService\"]}},\"/v1/auth/check/batch\":{\"post\":{\"operationId\":\"AuthService_BatchCheck\",\"parameters\":[{\"in\":\"body\",\"name\":\"body\",\"required\":true,\"schema\":{\"$ref\":\"#/definitions/authV1BatchCheckRequest\"}}],\"responses\":{\"200\":{\"description\":\"A successful response.\",\"schema\":{\"$ref\":\"#/definitions/authV1BatchCheckResponse\"}},\"default\":{\"description\":\"An unexpected error response.\",\"schema\":{\"$ref\":\"#/definitions/runtimeError\"}}},\"summary\":\"BatchCheck will run many permission checks in one call\",\"tags\":[\"AuthService\"]}},\"/v1/auth/explain\":{\"post\":{\"operationId\":\"AuthService_ExplainDecision\",\"parameters\":[{\"in\":\"body\",\"name\":\"body\",\"required\":true,\"schema\":{\"$ref\":\"#/definitions/authV1ExplainDecisionRequest\"}}],\"responses\":{\"200\":{\"description\":\"A successful response.\",\"schema\":{\"$ref\":\"#/definitions/authV1ExplainDecisionResponse\"}},\"default\":{\"description\":\"An unexpected error response.\",\"schema\":{\"$ref\":\"#/definitions/runtimeError\"}}},\"summary\":\"ExplainDecision will explain how a request is authorized\",\"tags\":[\"AuthService\"]}},\"/v1/auth/login\":{\"post\":{\"operationId\":\"AuthService_Login\",\"parameters\":[{\"in\":\"body\",\"name\":\"body\",\"required\":true,\"schema\":{\"$ref\":\"#/definitions/authV1LoginRequest\"}}],\"responses\":{\"200\":{\"description\":\"A successful response.\",\"schema\":{\"$ref\":\"#/definitions/authV1LoginResponse\"}},\"default\":{\"description\":\"An unexpected error response.\",\"schema\":{\"$ref\":\"#/definitions/runtimeError\"}}},\"summary\":\"Login login user\",\"tags\":[\"AuthService\"]}},\"/v1/auth/logout\":{\"post\":{\"operationId\":\"AuthService_Logout\",\"parameters\":[{\"in\":\"body\",\"name\":\"body\",\"required\":true,\"schema\":{\"$ref\":\"#/definitions/authV1LogoutRequest\"}}],\"responses\":{\"200\":{\"description\":\"A successful response.\",\"schema\":{\"$ref\":\"#/definitions/authV1LogoutResponse\"}},\"default\":{\"description\":\"An unexpected error response.\",\"schema\":{\"$ref\":\"#/definitions/runtimeError\"}}},\"summary\":\"Logout will close user session\",\"tags\":[\"AuthService\"]}},\"/v1/auth/permissions\":{\"get\":{\"operationId\":\"AuthService_GetEffectivePermissions\",\"parameters\":[{\"in\":\"query\",\"name\":\"subject\",\"required\":false,\"type\":\"string\"},{\"in\":\"query\",\"name\":\"domain\",\"required\":false,\"type\":\"string\"},{\"in\":\"query\",\"name\":\"app\",\"required\":false,\"type\":\"string\"},{\"format\":\"int64\",\"in\":\"query\",\"name\":\"limit\",\"required\":false,\"type\":\"string\"},{\"format\":\"int64\",\"in\":\"query\",\"name\":\"offset\",\"required\":false,\"type\":\"string\"}],\"responses\":{\"200\":{\"description\":\"A successful response.\",\"schema\":{\"$ref\":\"#/definitions/authV1GetEffectivePermissionsResponse\"}},\"default\":{\"description\":\"An unexpected error response.\",\"schema\":{\"$ref\":\"#/definitions/runtimeError\"}}},\"summary\":\"GetEffectivePermissions will list every permission of subject after role expansion\",\"tags\":[\"AuthService\"]}},\"/v1/auth/register\":{\"post\":{\"operationId\":\"AuthService_Register\",\"parameters\":[{\"in\":\"body\",\"name\":\"body\",\"required\":true,\"schema\":{\"$ref\":\"#/definitions/authV1RegisterRequest\"}}],\"responses\":{\"200\":{\"description\":\"A successful response.\",\"schema\":{\"$ref\":\"#/definitions/authV1RegisterResponse\"}},\"default\":{\"description\":\"An unexpected error response.\",\"schema\":{\"$ref\":\"#/definitions/runtimeError\"}}},\"summary\":\"Login login user\",\"tags\":[\"AuthService\"]}},\"/v1/auth/subjects\":{\"get\":{\"operationId\":\"AuthService_ListAuthorizedSubjects\",\"parameters\":[{\"in\":\"query\",\"name\":\"domain\",\"required\":false,\"type\":\"string\"},{\"in\":\"query\",\"name\":\"resource\",\"required\":false,\"type\":\"string\"},{\"in\":\"query\",\"name\":\"action\",\"required\":false,\"type\":\"string\"},{\"in\":\"query\",\"name\":\"object\",\"required\":false,\"type\":\"string\"},{\"in\":\"query\",\"name\":\"app\",\"required\":false,\"type\":\"string\"},{\"format\":\"int64\",\"in\":\"query\",\"name\":\"limit\",\"required\":false,\"type\":\"string\"},{\"format\":\"int64\",\"in\":\"query\",\"name\":\"offset\",\"required\":false,\"type\":\"string\"}],\"responses\":{\"200\":{\"description\":\"A successful response.\",\"schema\":{\"$ref\":\"#/definitions/authV1ListAuthorizedSubjectsResponse\"}},\"default\":{\"description\":\"An unexpected error response.\",\"schema\":{\"$ref\":\"#/definitions/runtimeError\"}}},\"summary\":\"ListAuthorizedSubjects will list every user who can do the action on resource\",\"tags\":[\"AuthService\"]}},\"/v1/auth/token/refresh\":{\"post\":{\"operationId\":\"AuthService_RefreshToken\",\"parameters\":[{\"in\":\"body\",\"name\":\"body\",\"required\":true,\"schema\":{\"$ref\":\"#/definitions/authV1RefreshTokenRequest\"}}],\"responses\":{\"200\":{\"description\":\"A successful response.\",\"schema\":{\"$ref\":\"#/definitions/authV1RefreshTokenResponse\"}},\"default\":{\"description\":\"An unexpected error response.\",\"schema\":{\"$ref\":\"#/definitions/runtimeError\"}}},\"summary\":\"RefreshToken will check and return new token\",\"tags\":[\"AuthService\"]}},\"/v1/auth/token/verify\":{\"post\":{\"operationId\":\"AuthService_VerifyToken\",\"parameters\":[{\"in\":\"body\",\"name\":\"body\",\"required\":true,\"schema\":{\"$ref\":\"#/definitions/authV1VerifyTokenRequest\"}}],\"responses\":{\"200\":{\"description\":\"A successful response.\",\"schema\":{\"$ref\":\"#/definitions/authV1VerifyTokenResponse\"}},\"default\":{\"description\":\"An unexpected error response.\",\"schema\":{\"$ref\":\"#/definitions/runtimeError\"}}},\"summary\":\"VerifyToken will verify and return token\",\"tags\":[\"AuthService\"]}},\"/v1/auth/validate\":{\"get\":{\"operationId\":\"AuthService_Validate\",\"responses\":{\"200\":{\"description\":\"A successful response.\",\"schema\":{\"properties\":{}}},\"default\":{\"description\":\"An unexpected error response.\",\"schema\":{\"$ref\":\"#/definitions/runtimeError\"}}},\"summary\":\"Validate will check token and return user data in response header\",\"tags\":[\"AuthService\"]}}}"
+const auth_definitions = "{\"authV1BatchCheckRequest\":{\"properties\":{\"checks\":{\"items\":{\"$ref\":\"#/definitions/authV1CheckPermissionRequest\"},\"type\":\"array\"}},\"type\":\"object\"},\"authV1BatchCheckResponse\":{\"properties\":{\"results\":{\"items\":{\"$ref\":\"#/definitions/authV1CheckPermissionResponse\"},\"type\":\"array\"}},\"type\":\"object\"},\"authV1CheckPermissionRequest\":{\"properties\":{\"action\":{\"type\":\"string\"},\"attributes\":{\"additionalProperties\":{\"type\":\"string\"},\"type\":\"object\"},\"client_ip\":{\"type\":\"string\"},\"domain\":{\"type\":\"string\"},\"object\":{\"type\":\"string\"},\"resource\":{\"type\":\"string\"},\"subject\":{\"type\":\"string\"}},\"type\":\"object\"},\"authV1CheckPermissionResponse\":{\"properties\":{\"action\":{\"type\":\"string\"},\"allowed\":{\"type\":\"boolean\"},\"domain\":{\"type\":\"string\"},\"object\":{\"type\":\"string\"},\"resource\":{\"type\":\"string\"},\"subject\":{\"type\":\"string\"}},\"type\":\"object\"},\"authV1ExplainDecisionRequest\":{\"properties\":{\"domain\":{\"type\":\"string\"},\"host\":{\"type\":\"string\"},\"method\":{\"type\":\"string\"},\"subject\":{\"type\":\"string\"},\"uri\":{\"type\":\"string\"}},\"type\":\"object\"},\"authV1ExplainDecisionResponse\":{\"properties\":{\"action\":{\"type\":\"string\"},\"allowed\":{\"type\":\"boolean\"},\"decisive_rule\":{\"$ref\":\"#/definitions/authV1PolicyRule\"},\"domain\":{\"type\":\"string\"},\"matched_rules\":{\"items\":{\"$ref\":\"#/definitions/authV1PolicyRule\"},\"type\":\"array\"},\"object\":{\"type\":\"string\"},\"relation_allowed\":{\"title\":\"the relation tuples of the object allow the request, they are checked when the policy does not\",\"type\":\"boolean\"},\"resource\":{\"type\":\"string\"},\"roles\":{\"items\":{\"type\":\"string\"},\"type\":\"array\"},\"route_pattern\":{\"type\":\"string\"},\"subject\":{\"type\":\"string\"}},\"type\":\"object\"},\"authV1GetEffectivePermissionsResponse\":{\"properties\":{\"limit\":{\"format\":\"int64\",\"type\":\"string\"},\"offset\":{\"format\":\"int64\",\"type\":\"string\"},\"permissions\":{\"items\":{\"$ref\":\"#/definitions/authV1PolicyRule\"},\"type\":\"array\"},\"total_count\":{\"format\":\"int64\",\"type\":\"string\"}},\"type\":\"object\"},\"authV1ListAuthorizedSubjectsResponse\":{\"properties\":{\"limit\":{\"format\":\"int64\",\"type\":\"string\"},\"offset\":{\"format\":\"int64\",\"type\":\"string\"},\"subjects\":{\"items\":{\"type\":\"string\"},\"type\":\"array\"},\"total_count\":{\"format\":\"int64\",\"type\":\"string\"}},\"type\":\"object\"},\"authV1LoginRequest\":{\"properties\":{\"password\":{\"type\":\"string\"},\"username\":{\"type\":\"string\"}},\"type\":\"object\"},\"authV1LoginResponse\":{\"properties\":{\"access_token\":{\"type\":\"string\"},\"refresh_token\":{\"type\":\"string\"}},\"type\":\"object\"},\"authV1LogoutRequest\":{\"type\":\"object\"},\"authV1LogoutResponse\":{\"properties\":{\"redirect_to\":{\"type\":\"string\"}},\"type\":\"object\"},\"authV1PolicyRule\":{\"properties\":{\"action\":{\"type\":\"string\"},\"condition\":{\"type\":\"string\"},\"domain\":{\"type\":\"string\"},\"effect\":{\"type\":\"string\"},\"object\":{\"type\":\"string\"},\"resource\":{\"type\":\"string\"},\"subject\":{\"type\":\"string\"}},\"type\":\"object\"},\"authV1RefreshTokenRequest\":{\"properties\":{\"refresh_token\":{\"type\":\"string\"}},\"type\":\"object\"},\"authV1RefreshTokenResponse\":{\"properties\":{\"access_token\":{\"type\":\"string\"},\"refresh_token\":{\"type\":\"string\"}},\"type\":\"object\"},\"authV1RegisterRequest\":{\"properties\":{\"avatar_url\":{\"type\":\"string\"},\"email\":{\"type\":\"string\"},\"firstname\":{\"type\":\"string\"},\"gender\":{\"type\":\"string\"},\"lastname\":{\"type\":\"string\"},\"password\":{\"type\":\"string\"},\"raw_data\":{\"type\":\"string\"},\"username\":{\"type\":\"string\"}},\"type\":\"object\"},\"authV1RegisterResponse\":{\"properties\":{\"avatar_url\":{\"type\":\"string\"},\"email\":{\"type\":\"string\"},\"firstname\":{\"type\":\"string\"},\"gender\":{\"type\":\"string\"},\"lastname\":{\"type\":\"string\"},\"password\":{\"type\":\"string\"},\"raw_data\":{\"type\":\"string\"},\"username\":{\"type\":\"string\"},\"uuid\":{\"type\":\"string\"}},\"type\":\"object\"},\"authV1VerifyTokenRequest\":{\"properties\":{\"access_token\":{\"type\":\"string\"}},\"type\":\"object\"},\"authV1VerifyTokenResponse\":{\"properties\":{\"access_token\":{\"type\":\"string\"}},\"type\":\"object\"},\"protobufAny\":{\"properties\":{\"type_url\":{\"type\":\"string\"},\"value\":{\"format\":\"byte\",\"type\":\"string\"}},\"type\":\"object\"},\"runtimeError\":{\"properties\":{\"code\":{\"format\":\"int32\",\"type\":\"integer\"},\"details\":{\"items\":{\"$ref\":\"#/definitions/protobufAny\"},\"type\":\"array\"},\"error\":{\"type\":\"string\"},\"message\":{\"type\":\"string\"}},\"type\":\"object\"}}"
 
 func init() {
 	var (
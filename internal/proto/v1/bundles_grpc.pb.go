@@ -0,0 +1,366 @@
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+
+package auth
+
+import (
+	context "context"
+	empty "github.com/golang/protobuf/ptypes/empty"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+const _ = grpc.SupportPackageIsVersion7
+
+// BundleServiceClient is the client API for BundleService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+type BundleServiceClient interface {
+	// List permission bundles
+	ListBundles(ctx context.Context, in *ListBundlesRequest, opts ...grpc.CallOption) (*ListBundlesResponse, error)
+	// Get permission bundle
+	GetBundle(ctx context.Context, in *GetBundleRequest, opts ...grpc.CallOption) (*PermissionBundle, error)
+	// Create permission bundle object request
+	CreateBundle(ctx context.Context, in *CreateBundleRequest, opts ...grpc.CallOption) (*PermissionBundle, error)
+	// UpdateBundle updates the bundle and the rules of every role it is attached to
+	UpdateBundle(ctx context.Context, in *UpdateBundleRequest, opts ...grpc.CallOption) (*PermissionBundle, error)
+	// DeleteBundle deletes a bundle which is not attached to any role
+	DeleteBundle(ctx context.Context, in *DeleteBundleRequest, opts ...grpc.CallOption) (*empty.Empty, error)
+	// ListRoleBundles lists the bundles attached to a role
+	ListRoleBundles(ctx context.Context, in *ListRoleBundlesRequest, opts ...grpc.CallOption) (*ListRoleBundlesResponse, error)
+	// AttachBundle attaches a bundle to a role in a domain
+	AttachBundle(ctx context.Context, in *AttachBundleRequest, opts ...grpc.CallOption) (*RoleBundle, error)
+	// DetachBundle detaches a bundle from a role and removes the rules it generated
+	DetachBundle(ctx context.Context, in *DetachBundleRequest, opts ...grpc.CallOption) (*empty.Empty, error)
+}
+
+type bundleServiceClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewBundleServiceClient(cc grpc.ClientConnInterface) BundleServiceClient {
+	return &bundleServiceClient{cc}
+}
+
+func (c *bundleServiceClient) ListBundles(ctx context.Context, in *ListBundlesRequest, opts ...grpc.CallOption) (*ListBundlesResponse, error) {
+	out := new(ListBundlesResponse)
+	err := c.cc.Invoke(ctx, "/authV1.BundleService/ListBundles", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *bundleServiceClient) GetBundle(ctx context.Context, in *GetBundleRequest, opts ...grpc.CallOption) (*PermissionBundle, error) {
+	out := new(PermissionBundle)
+	err := c.cc.Invoke(ctx, "/authV1.BundleService/GetBundle", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *bundleServiceClient) CreateBundle(ctx context.Context, in *CreateBundleRequest, opts ...grpc.CallOption) (*PermissionBundle, error) {
+	out := new(PermissionBundle)
+	err := c.cc.Invoke(ctx, "/authV1.BundleService/CreateBundle", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *bundleServiceClient) UpdateBundle(ctx context.Context, in *UpdateBundleRequest, opts ...grpc.CallOption) (*PermissionBundle, error) {
+	out := new(PermissionBundle)
+	err := c.cc.Invoke(ctx, "/authV1.BundleService/UpdateBundle", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *bundleServiceClient) DeleteBundle(ctx context.Context, in *DeleteBundleRequest, opts ...grpc.CallOption) (*empty.Empty, error) {
+	out := new(empty.Empty)
+	err := c.cc.Invoke(ctx, "/authV1.BundleService/DeleteBundle", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *bundleServiceClient) ListRoleBundles(ctx context.Context, in *ListRoleBundlesRequest, opts ...grpc.CallOption) (*ListRoleBundlesResponse, error) {
+	out := new(ListRoleBundlesResponse)
+	err := c.cc.Invoke(ctx, "/authV1.BundleService/ListRoleBundles", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *bundleServiceClient) AttachBundle(ctx context.Context, in *AttachBundleRequest, opts ...grpc.CallOption) (*RoleBundle, error) {
+	out := new(RoleBundle)
+	err := c.cc.Invoke(ctx, "/authV1.BundleService/AttachBundle", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *bundleServiceClient) DetachBundle(ctx context.Context, in *DetachBundleRequest, opts ...grpc.CallOption) (*empty.Empty, error) {
+	out := new(empty.Empty)
+	err := c.cc.Invoke(ctx, "/authV1.BundleService/DetachBundle", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// BundleServiceServer is the server API for BundleService service.
+// All implementations must embed UnimplementedBundleServiceServer
+// for forward compatibility
+type BundleServiceServer interface {
+	// List permission bundles
+	ListBundles(context.Context, *ListBundlesRequest) (*ListBundlesResponse, error)
+	// Get permission bundle
+	GetBundle(context.Context, *GetBundleRequest) (*PermissionBundle, error)
+	// Create permission bundle object request
+	CreateBundle(context.Context, *CreateBundleRequest) (*PermissionBundle, error)
+	// UpdateBundle updates the bundle and the rules of every role it is attached to
+	UpdateBundle(context.Context, *UpdateBundleRequest) (*PermissionBundle, error)
+	// DeleteBundle deletes a bundle which is not attached to any role
+	DeleteBundle(context.Context, *DeleteBundleRequest) (*empty.Empty, error)
+	// ListRoleBundles lists the bundles attached to a role
+	ListRoleBundles(context.Context, *ListRoleBundlesRequest) (*ListRoleBundlesResponse, error)
+	// AttachBundle attaches a bundle to a role in a domain
+	AttachBundle(context.Context, *AttachBundleRequest) (*RoleBundle, error)
+	// DetachBundle detaches a bundle from a role and removes the rules it generated
+	DetachBundle(context.Context, *DetachBundleRequest) (*empty.Empty, error)
+	mustEmbedUnimplementedBundleServiceServer()
+}
+
+// UnimplementedBundleServiceServer must be embedded to have forward compatible implementations.
+type UnimplementedBundleServiceServer struct {
+}
+
+func (UnimplementedBundleServiceServer) ListBundles(context.Context, *ListBundlesRequest) (*ListBundlesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListBundles not implemented")
+}
+func (UnimplementedBundleServiceServer) GetBundle(context.Context, *GetBundleRequest) (*PermissionBundle, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetBundle not implemented")
+}
+func (UnimplementedBundleServiceServer) CreateBundle(context.Context, *CreateBundleRequest) (*PermissionBundle, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreateBundle not implemented")
+}
+func (UnimplementedBundleServiceServer) UpdateBundle(context.Context, *UpdateBundleRequest) (*PermissionBundle, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UpdateBundle not implemented")
+}
+func (UnimplementedBundleServiceServer) DeleteBundle(context.Context, *DeleteBundleRequest) (*empty.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DeleteBundle not implemented")
+}
+func (UnimplementedBundleServiceServer) ListRoleBundles(context.Context, *ListRoleBundlesRequest) (*ListRoleBundlesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListRoleBundles not implemented")
+}
+func (UnimplementedBundleServiceServer) AttachBundle(context.Context, *AttachBundleRequest) (*RoleBundle, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method AttachBundle not implemented")
+}
+func (UnimplementedBundleServiceServer) DetachBundle(context.Context, *DetachBundleRequest) (*empty.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DetachBundle not implemented")
+}
+func (UnimplementedBundleServiceServer) mustEmbedUnimplementedBundleServiceServer() {}
+
+// UnsafeBundleServiceServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to BundleServiceServer will
+// result in compilation errors.
+type UnsafeBundleServiceServer interface {
+	mustEmbedUnimplementedBundleServiceServer()
+}
+
+func RegisterBundleServiceServer(s *grpc.Server, srv BundleServiceServer) {
+	s.RegisterService(&_BundleService_serviceDesc, srv)
+}
+
+func _BundleService_ListBundles_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListBundlesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BundleServiceServer).ListBundles(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/authV1.BundleService/ListBundles",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BundleServiceServer).ListBundles(ctx, req.(*ListBundlesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _BundleService_GetBundle_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetBundleRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BundleServiceServer).GetBundle(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/authV1.BundleService/GetBundle",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BundleServiceServer).GetBundle(ctx, req.(*GetBundleRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _BundleService_CreateBundle_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateBundleRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BundleServiceServer).CreateBundle(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/authV1.BundleService/CreateBundle",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BundleServiceServer).CreateBundle(ctx, req.(*CreateBundleRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _BundleService_UpdateBundle_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UpdateBundleRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BundleServiceServer).UpdateBundle(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/authV1.BundleService/UpdateBundle",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BundleServiceServer).UpdateBundle(ctx, req.(*UpdateBundleRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _BundleService_DeleteBundle_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeleteBundleRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BundleServiceServer).DeleteBundle(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/authV1.BundleService/DeleteBundle",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BundleServiceServer).DeleteBundle(ctx, req.(*DeleteBundleRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _BundleService_ListRoleBundles_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListRoleBundlesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BundleServiceServer).ListRoleBundles(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/authV1.BundleService/ListRoleBundles",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BundleServiceServer).ListRoleBundles(ctx, req.(*ListRoleBundlesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _BundleService_AttachBundle_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AttachBundleRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BundleServiceServer).AttachBundle(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/authV1.BundleService/AttachBundle",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BundleServiceServer).AttachBundle(ctx, req.(*AttachBundleRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _BundleService_DetachBundle_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DetachBundleRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BundleServiceServer).DetachBundle(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/authV1.BundleService/DetachBundle",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BundleServiceServer).DetachBundle(ctx, req.(*DetachBundleRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+var _BundleService_serviceDesc = grpc.ServiceDesc{
+	ServiceName: "authV1.BundleService",
+	HandlerType: (*BundleServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "ListBundles",
+			Handler:    _BundleService_ListBundles_Handler,
+		},
+		{
+			MethodName: "GetBundle",
+			Handler:    _BundleService_GetBundle_Handler,
+		},
+		{
+			MethodName: "CreateBundle",
+			Handler:    _BundleService_CreateBundle_Handler,
+		},
+		{
+			MethodName: "UpdateBundle",
+			Handler:    _BundleService_UpdateBundle_Handler,
+		},
+		{
+			MethodName: "DeleteBundle",
+			Handler:    _BundleService_DeleteBundle_Handler,
+		},
+		{
+			MethodName: "ListRoleBundles",
+			Handler:    _BundleService_ListRoleBundles_Handler,
+		},
+		{
+			MethodName: "AttachBundle",
+			Handler:    _BundleService_AttachBundle_Handler,
+		},
+		{
+			MethodName: "DetachBundle",
+			Handler:    _BundleService_DetachBundle_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "api/proto/v1/bundles.proto",
+}
@@ -7,8 +7,8 @@ import (
 	"github.com/golang-tire/pkg/grpcgw"
 )
 
-const domains_paths = "{\"/v1/domains\":{\"get\":{\"operationId\":\"DomainService_ListDomains\",\"parameters\":[{\"format\":\"int64\",\"in\":\"query\",\"name\":\"limit\",\"required\":false,\"type\":\"string\"},{\"format\":\"int64\",\"in\":\"query\",\"name\":\"offset\",\"required\":false,\"type\":\"string\"},{\"in\":\"query\",\"name\":\"query\",\"required\":false,\"type\":\"string\"}],\"responses\":{\"200\":{\"description\":\"A successful response.\",\"schema\":{\"$ref\":\"#/definitions/authV1ListDomainsResponse\"}},\"default\":{\"description\":\"An unexpected error response.\",\"schema\":{\"$ref\":\"#/definitions/runtimeError\"}}},\"summary\":\"List Domains\",\"tags\":[\"DomainService\"]},\"post\":{\"operationId\":\"DomainService_CreateDomain\",\"parameters\":[{\"in\":\"body\",\"name\":\"body\",\"required\":true,\"schema\":{\"$ref\":\"#/definitions/authV1CreateDomainRequest\"}}],\"responses\":{\"200\":{\"description\":\"A successful response.\",\"schema\":{\"$ref\":\"#/definitions/authV1Domain\"}},\"default\":{\"description\":\"An unexpected error response.\",\"schema\":{\"$ref\":\"#/definitions/runtimeError\"}}},\"summary\":\"Create Domain object request\",\"tags\":[\"DomainService\"]}},\"/v1/domains/{uuid}\":{\"delete\":{\"operationId\":\"DomainService_DeleteDomain\",\"parameters\":[{\"in\":\"path\",\"name\":\"uuid\",\"required\":true,\"type\":\"string\"}],\"responses\":{\"200\":{\"description\":\"A successful response.\",\"schema\":{\"properties\":{}}},\"default\":{\"description\":\"An unexpected error response.\",\"schema\":{\"$ref\":\"#/definitions/runtimeError\"}}},\"summary\":\"Delete Domain object request\",\"tags\":[\"DomainService\"]},\"get\":{\"operationId\":\"DomainService_GetDomain\",\"parameters\":[{\"in\":\"path\",\"name\":\"uuid\",\"required\":true,\"type\":\"string\"}],\"responses\":{\"200\":{\"description\":\"A successful response.\",\"schema\":{\"$ref\":\"#/definitions/authV1Domain\"}},\"default\":{\"description\":\"An unexpected error response.\",\"schema\":{\"$ref\":\"#/definitions/runtimeError\"}}},\"summary\":\"Get Domain\",\"tags\":[\"DomainService\"]},\"put\":{\"operationId\":\"DomainService_UpdateDomain\",\"parameters\":[{\"in\":\"path\",\"name\":\"uuid\",\"required\":true,\"type\":\"string\"},{\"in\":\"body\",\"name\":\"body\",\"required\":true,\"schema\":{\"$ref\":\"#/definitions/authV1UpdateDomainRequest\"}}],\"responses\":{\"200\":{\"description\":\"A successful response.\",\"schema\":{\"$ref\":\"#/definitions/authV1Domain\"}},\"default\":{\"description\":\"An unexpected error response.\",\"schema\":{\"$ref\":\"#/definitions/runtimeError\"}}},\"summary\":\"Update Domain object request\",\"tags\":[\"DomainService\"]}}}"
-const domains_definitions = "{\"authV1CreateDomainRequest\":{\"properties\":{\"enable\":{\"type\":\"boolean\"},\"name\":{\"type\":\"string\"}},\"type\":\"object\"},\"authV1Domain\":{\"properties\":{\"created_at\":{\"format\":\"date-time\",\"type\":\"string\"},\"enable\":{\"type\":\"boolean\"},\"name\":{\"type\":\"string\"},\"updated_at\":{\"format\":\"date-time\",\"type\":\"string\"},\"uuid\":{\"type\":\"string\"}},\"type\":\"object\"},\"authV1ListDomainsResponse\":{\"properties\":{\"domains\":{\"items\":{\"$ref\":\"#/definitions/authV1Domain\"},\"type\":\"array\"},\"limit\":{\"format\":\"int64\",\"type\":\"string\"},\"offset\":{\"format\":\"int64\",\"type\":\"string\"},\"total_count\":{\"format\":\"int64\",\"type\":\"string\"}},\"type\":\"object\"},\"authV1UpdateDomainRequest\":{\"properties\":{\"enable\":{\"type\":\"boolean\"},\"name\":{\"type\":\"string\"},\"uuid\":{\"type\":\"string\"}},\"type\":\"object\"},\"protobufAny\":{\"properties\":{\"type_url\":{\"type\":\"string\"},\"value\":{\"format\":\"byte\",\"type\":\"string\"}},\"type\":\"object\"},\"runtimeError\":{\"properties\":{\"code\":{\"format\":\"int32\",\"type\":\"integer\"},\"details\":{\"items\":{\"$ref\":\"#/definitions/protobufAny\"},\"type\":\"array\"},\"error\":{\"type\":\"string\"},\"message\":{\"type\":\"string\"}},\"type\":\"object\"}}"
+const domains_paths = "{\"/v1/domains\":{\"get\":{\"operationId\":\"DomainService_ListDomains\",\"parameters\":[{\"format\":\"int64\",\"in\":\"query\",\"name\":\"limit\",\"required\":false,\"type\":\"string\"},{\"format\":\"int64\",\"in\":\"query\",\"name\":\"offset\",\"required\":false,\"type\":\"string\"},{\"in\":\"query\",\"name\":\"query\",\"required\":false,\"type\":\"string\"},{\"in\":\"query\",\"name\":\"subtree_uuid\",\"required\":false,\"type\":\"string\"}],\"responses\":{\"200\":{\"description\":\"A successful response.\",\"schema\":{\"$ref\":\"#/definitions/authV1ListDomainsResponse\"}},\"default\":{\"description\":\"An unexpected error response.\",\"schema\":{\"$ref\":\"#/definitions/runtimeError\"}}},\"summary\":\"List Domains\",\"tags\":[\"DomainService\"]},\"post\":{\"operationId\":\"DomainService_CreateDomain\",\"parameters\":[{\"in\":\"body\",\"name\":\"body\",\"required\":true,\"schema\":{\"$ref\":\"#/definitions/authV1CreateDomainRequest\"}}],\"responses\":{\"200\":{\"description\":\"A successful response.\",\"schema\":{\"$ref\":\"#/definitions/authV1Domain\"}},\"default\":{\"description\":\"An unexpected error response.\",\"schema\":{\"$ref\":\"#/definitions/runtimeError\"}}},\"summary\":\"Create Domain object request\",\"tags\":[\"DomainService\"]}},\"/v1/domains/{uuid}\":{\"delete\":{\"operationId\":\"DomainService_DeleteDomain\",\"parameters\":[{\"in\":\"path\",\"name\":\"uuid\",\"required\":true,\"type\":\"string\"}],\"responses\":{\"200\":{\"description\":\"A successful response.\",\"schema\":{\"properties\":{}}},\"default\":{\"description\":\"An unexpected error response.\",\"schema\":{\"$ref\":\"#/definitions/runtimeError\"}}},\"summary\":\"Delete Domain object request\",\"tags\":[\"DomainService\"]},\"get\":{\"operationId\":\"DomainService_GetDomain\",\"parameters\":[{\"in\":\"path\",\"name\":\"uuid\",\"required\":true,\"type\":\"string\"}],\"responses\":{\"200\":{\"description\":\"A successful response.\",\"schema\":{\"$ref\":\"#/definitions/authV1Domain\"}},\"default\":{\"description\":\"An unexpected error response.\",\"schema\":{\"$ref\":\"#/definitions/runtimeError\"}}},\"summary\":\"Get Domain\",\"tags\":[\"DomainService\"]},\"put\":{\"operationId\":\"DomainService_UpdateDomain\",\"parameters\":[{\"in\":\"path\",\"name\":\"uuid\",\"required\":true,\"type\":\"string\"},{\"in\":\"body\",\"name\":\"body\",\"required\":true,\"schema\":{\"$ref\":\"#/definitions/authV1UpdateDomainRequest\"}}],\"responses\":{\"200\":{\"description\":\"A successful response.\",\"schema\":{\"$ref\":\"#/definitions/authV1Domain\"}},\"default\":{\"description\":\"An unexpected error response.\",\"schema\":{\"$ref\":\"#/definitions/runtimeError\"}}},\"summary\":\"Update Domain object request\",\"tags\":[\"DomainService\"]}},\"/v1/domains/{uuid}/settings\":{\"delete\":{\"operationId\":\"DomainService_DeleteDomainSettings\",\"parameters\":[{\"in\":\"path\",\"name\":\"uuid\",\"required\":true,\"type\":\"string\"}],\"responses\":{\"200\":{\"description\":\"A successful response.\",\"schema\":{\"properties\":{}}},\"default\":{\"description\":\"An unexpected error response.\",\"schema\":{\"$ref\":\"#/definitions/runtimeError\"}}},\"summary\":\"Delete the authentication settings of a domain, it uses the global config again\",\"tags\":[\"DomainService\"]},\"get\":{\"operationId\":\"DomainService_GetDomainSettings\",\"parameters\":[{\"in\":\"path\",\"name\":\"uuid\",\"required\":true,\"type\":\"string\"}],\"responses\":{\"200\":{\"description\":\"A successful response.\",\"schema\":{\"$ref\":\"#/definitions/authV1DomainSettings\"}},\"default\":{\"description\":\"An unexpected error response.\",\"schema\":{\"$ref\":\"#/definitions/runtimeError\"}}},\"summary\":\"Get the authentication settings of a domain\",\"tags\":[\"DomainService\"]},\"post\":{\"operationId\":\"DomainService_CreateDomainSettings\",\"parameters\":[{\"in\":\"path\",\"name\":\"uuid\",\"required\":true,\"type\":\"string\"},{\"in\":\"body\",\"name\":\"body\",\"required\":true,\"schema\":{\"$ref\":\"#/definitions/authV1CreateDomainSettingsRequest\"}}],\"responses\":{\"200\":{\"description\":\"A successful response.\",\"schema\":{\"$ref\":\"#/definitions/authV1DomainSettings\"}},\"default\":{\"description\":\"An unexpected error response.\",\"schema\":{\"$ref\":\"#/definitions/runtimeError\"}}},\"summary\":\"Create the authentication settings of a domain\",\"tags\":[\"DomainService\"]},\"put\":{\"operationId\":\"DomainService_UpdateDomainSettings\",\"parameters\":[{\"in\":\"path\",\"name\":\"uuid\",\"required\":true,\"type\":\"string\"},{\"in\":\"body\",\"name\":\"body\",\"required\":true,\"schema\":{\"$ref\":\"#/definitions/authV1UpdateDomainSettingsRequest\"}}],\"responses\":{\"200\":{\"description\":\"A successful response.\",\"schema\":{\"$ref\":\"#/definitions/authV1DomainSettings\"}},\"default\":{\"description\":\"An unexpected error response.\",\"schema\":{\"$ref\":\"#/definitions/runtimeError\"}}},\"summary\":\"Update the authentication settings of a domain\",\"tags\":[\"DomainService\"]}}}"
+const domains_definitions = "{\"authV1CreateDomainRequest\":{\"properties\":{\"enable\":{\"type\":\"boolean\"},\"hosts\":{\"items\":{\"type\":\"string\"},\"type\":\"array\"},\"name\":{\"type\":\"string\"},\"parent_uuid\":{\"type\":\"string\"}},\"type\":\"object\"},\"authV1CreateDomainSettingsRequest\":{\"properties\":{\"access_token_life\":{\"format\":\"int32\",\"type\":\"integer\"},\"default_role_uuid\":{\"type\":\"string\"},\"email_domains\":{\"items\":{\"type\":\"string\"},\"type\":\"array\"},\"login_methods\":{\"items\":{\"type\":\"string\"},\"type\":\"array\"},\"mfa_required\":{\"type\":\"boolean\"},\"refresh_token_life\":{\"format\":\"int32\",\"type\":\"integer\"},\"self_registration\":{\"type\":\"boolean\"},\"uuid\":{\"type\":\"string\"}},\"type\":\"object\"},\"authV1Domain\":{\"properties\":{\"created_at\":{\"format\":\"date-time\",\"type\":\"string\"},\"enable\":{\"type\":\"boolean\"},\"hosts\":{\"items\":{\"type\":\"string\"},\"title\":\"hosts are the aliases and wildcard patterns, e.g. *.tenant.example.com, of the domain\",\"type\":\"array\"},\"name\":{\"type\":\"string\"},\"parent\":{\"type\":\"string\"},\"parent_uuid\":{\"type\":\"string\"},\"updated_at\":{\"format\":\"date-time\",\"type\":\"string\"},\"uuid\":{\"type\":\"string\"}},\"type\":\"object\"},\"authV1DomainSettings\":{\"description\":\"DomainSettings are the authentication settings of a domain, domains without settings use\\nthe global auth config.\",\"properties\":{\"access_token_life\":{\"format\":\"int32\",\"title\":\"access_token_life is in minutes and refresh_token_life in hours, zero uses the global config\",\"type\":\"integer\"},\"created_at\":{\"format\":\"date-time\",\"type\":\"string\"},\"default_role\":{\"title\":\"default_role is assigned in the domain to the registered users\",\"type\":\"string\"},\"domain\":{\"type\":\"string\"},\"email_domains\":{\"items\":{\"type\":\"string\"},\"title\":\"email_domains limit the emails of the registered users, empty allows every email\",\"type\":\"array\"},\"login_methods\":{\"items\":{\"type\":\"string\"},\"type\":\"array\"},\"mfa_required\":{\"type\":\"boolean\"},\"refresh_token_life\":{\"format\":\"int32\",\"type\":\"integer\"},\"self_registration\":{\"title\":\"self_registration opens Register to everyone\",\"type\":\"boolean\"},\"updated_at\":{\"format\":\"date-time\",\"type\":\"string\"},\"uuid\":{\"type\":\"string\"}},\"type\":\"object\"},\"authV1ListDomainsResponse\":{\"properties\":{\"domains\":{\"items\":{\"$ref\":\"#/definitions/authV1Domain\"},\"type\":\"array\"},\"limit\":{\"format\":\"int64\",\"type\":\"string\"},\"offset\":{\"format\":\"int64\",\"type\":\"string\"},\"total_count\":{\"format\":\"int64\",\"type\":\"string\"}},\"type\":\"object\"},\"authV1UpdateDomainRequest\":{\"properties\":{\"enable\":{\"type\":\"boolean\"},\"hosts\":{\"items\":{\"type\":\"string\"},\"type\":\"array\"},\"name\":{\"type\":\"string\"},\"parent_uuid\":{\"type\":\"string\"},\"uuid\":{\"type\":\"string\"}},\"type\":\"object\"},\"authV1UpdateDomainSettingsRequest\":{\"properties\":{\"access_token_life\":{\"format\":\"int32\",\"type\":\"integer\"},\"default_role_uuid\":{\"type\":\"string\"},\"email_domains\":{\"items\":{\"type\":\"string\"},\"type\":\"array\"},\"login_methods\":{\"items\":{\"type\":\"string\"},\"type\":\"array\"},\"mfa_required\":{\"type\":\"boolean\"},\"refresh_token_life\":{\"format\":\"int32\",\"type\":\"integer\"},\"self_registration\":{\"type\":\"boolean\"},\"uuid\":{\"type\":\"string\"}},\"type\":\"object\"},\"protobufAny\":{\"properties\":{\"type_url\":{\"type\":\"string\"},\"value\":{\"format\":\"byte\",\"type\":\"string\"}},\"type\":\"object\"},\"runtimeError\":{\"properties\":{\"code\":{\"format\":\"int32\",\"type\":\"integer\"},\"details\":{\"items\":{\"$ref\":\"#/definitions/protobufAny\"},\"type\":\"array\"},\"error\":{\"type\":\"string\"},\"message\":{\"type\":\"string\"}},\"type\":\"object\"}}"
 
 func init() {
 	var (
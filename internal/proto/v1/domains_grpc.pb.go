@@ -28,6 +28,14 @@ type DomainServiceClient interface {
 	UpdateDomain(ctx context.Context, in *UpdateDomainRequest, opts ...grpc.CallOption) (*Domain, error)
 	// Delete Domain object request
 	DeleteDomain(ctx context.Context, in *DeleteDomainRequest, opts ...grpc.CallOption) (*empty.Empty, error)
+	// Get the authentication settings of a domain
+	GetDomainSettings(ctx context.Context, in *GetDomainSettingsRequest, opts ...grpc.CallOption) (*DomainSettings, error)
+	// Create the authentication settings of a domain
+	CreateDomainSettings(ctx context.Context, in *CreateDomainSettingsRequest, opts ...grpc.CallOption) (*DomainSettings, error)
+	// Update the authentication settings of a domain
+	UpdateDomainSettings(ctx context.Context, in *UpdateDomainSettingsRequest, opts ...grpc.CallOption) (*DomainSettings, error)
+	// Delete the authentication settings of a domain, it uses the global config again
+	DeleteDomainSettings(ctx context.Context, in *DeleteDomainSettingsRequest, opts ...grpc.CallOption) (*empty.Empty, error)
 }
 
 type domainServiceClient struct {
@@ -83,6 +91,42 @@ func (c *domainServiceClient) DeleteDomain(ctx context.Context, in *DeleteDomain
 	return out, nil
 }
 
+func (c *domainServiceClient) GetDomainSettings(ctx context.Context, in *GetDomainSettingsRequest, opts ...grpc.CallOption) (*DomainSettings, error) {
+	out := new(DomainSettings)
+	err := c.cc.Invoke(ctx, "/authV1.DomainService/GetDomainSettings", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *domainServiceClient) CreateDomainSettings(ctx context.Context, in *CreateDomainSettingsRequest, opts ...grpc.CallOption) (*DomainSettings, error) {
+	out := new(DomainSettings)
+	err := c.cc.Invoke(ctx, "/authV1.DomainService/CreateDomainSettings", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *domainServiceClient) UpdateDomainSettings(ctx context.Context, in *UpdateDomainSettingsRequest, opts ...grpc.CallOption) (*DomainSettings, error) {
+	out := new(DomainSettings)
+	err := c.cc.Invoke(ctx, "/authV1.DomainService/UpdateDomainSettings", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *domainServiceClient) DeleteDomainSettings(ctx context.Context, in *DeleteDomainSettingsRequest, opts ...grpc.CallOption) (*empty.Empty, error) {
+	out := new(empty.Empty)
+	err := c.cc.Invoke(ctx, "/authV1.DomainService/DeleteDomainSettings", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // DomainServiceServer is the server API for DomainService service.
 // All implementations must embed UnimplementedDomainServiceServer
 // for forward compatibility
@@ -97,6 +141,14 @@ type DomainServiceServer interface {
 	UpdateDomain(context.Context, *UpdateDomainRequest) (*Domain, error)
 	// Delete Domain object request
 	DeleteDomain(context.Context, *DeleteDomainRequest) (*empty.Empty, error)
+	// Get the authentication settings of a domain
+	GetDomainSettings(context.Context, *GetDomainSettingsRequest) (*DomainSettings, error)
+	// Create the authentication settings of a domain
+	CreateDomainSettings(context.Context, *CreateDomainSettingsRequest) (*DomainSettings, error)
+	// Update the authentication settings of a domain
+	UpdateDomainSettings(context.Context, *UpdateDomainSettingsRequest) (*DomainSettings, error)
+	// Delete the authentication settings of a domain, it uses the global config again
+	DeleteDomainSettings(context.Context, *DeleteDomainSettingsRequest) (*empty.Empty, error)
 	mustEmbedUnimplementedDomainServiceServer()
 }
 
@@ -119,6 +171,18 @@ func (UnimplementedDomainServiceServer) UpdateDomain(context.Context, *UpdateDom
 func (UnimplementedDomainServiceServer) DeleteDomain(context.Context, *DeleteDomainRequest) (*empty.Empty, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method DeleteDomain not implemented")
 }
+func (UnimplementedDomainServiceServer) GetDomainSettings(context.Context, *GetDomainSettingsRequest) (*DomainSettings, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetDomainSettings not implemented")
+}
+func (UnimplementedDomainServiceServer) CreateDomainSettings(context.Context, *CreateDomainSettingsRequest) (*DomainSettings, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreateDomainSettings not implemented")
+}
+func (UnimplementedDomainServiceServer) UpdateDomainSettings(context.Context, *UpdateDomainSettingsRequest) (*DomainSettings, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UpdateDomainSettings not implemented")
+}
+func (UnimplementedDomainServiceServer) DeleteDomainSettings(context.Context, *DeleteDomainSettingsRequest) (*empty.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DeleteDomainSettings not implemented")
+}
 func (UnimplementedDomainServiceServer) mustEmbedUnimplementedDomainServiceServer() {}
 
 // UnsafeDomainServiceServer may be embedded to opt out of forward compatibility for this service.
@@ -222,6 +286,78 @@ func _DomainService_DeleteDomain_Handler(srv interface{}, ctx context.Context, d
 	return interceptor(ctx, in, info, handler)
 }
 
+func _DomainService_GetDomainSettings_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetDomainSettingsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DomainServiceServer).GetDomainSettings(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/authV1.DomainService/GetDomainSettings",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DomainServiceServer).GetDomainSettings(ctx, req.(*GetDomainSettingsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _DomainService_CreateDomainSettings_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateDomainSettingsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DomainServiceServer).CreateDomainSettings(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/authV1.DomainService/CreateDomainSettings",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DomainServiceServer).CreateDomainSettings(ctx, req.(*CreateDomainSettingsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _DomainService_UpdateDomainSettings_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UpdateDomainSettingsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DomainServiceServer).UpdateDomainSettings(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/authV1.DomainService/UpdateDomainSettings",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DomainServiceServer).UpdateDomainSettings(ctx, req.(*UpdateDomainSettingsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _DomainService_DeleteDomainSettings_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeleteDomainSettingsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DomainServiceServer).DeleteDomainSettings(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/authV1.DomainService/DeleteDomainSettings",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DomainServiceServer).DeleteDomainSettings(ctx, req.(*DeleteDomainSettingsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 var _DomainService_serviceDesc = grpc.ServiceDesc{
 	ServiceName: "authV1.DomainService",
 	HandlerType: (*DomainServiceServer)(nil),
@@ -246,6 +382,22 @@ var _DomainService_serviceDesc = grpc.ServiceDesc{
 			MethodName: "DeleteDomain",
 			Handler:    _DomainService_DeleteDomain_Handler,
 		},
+		{
+			MethodName: "GetDomainSettings",
+			Handler:    _DomainService_GetDomainSettings_Handler,
+		},
+		{
+			MethodName: "CreateDomainSettings",
+			Handler:    _DomainService_CreateDomainSettings_Handler,
+		},
+		{
+			MethodName: "UpdateDomainSettings",
+			Handler:    _DomainService_UpdateDomainSettings_Handler,
+		},
+		{
+			MethodName: "DeleteDomainSettings",
+			Handler:    _DomainService_DeleteDomainSettings_Handler,
+		},
 	},
 	Streams:  []grpc.StreamDesc{},
 	Metadata: "api/proto/v1/domains.proto",
@@ -0,0 +1,230 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.25.0
+// 	protoc        v3.8.0
+// source: api/proto/v1/options.proto
+
+package auth
+
+import (
+	proto "github.com/golang/protobuf/proto"
+	descriptor "github.com/golang/protobuf/protoc-gen-go/descriptor"
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	reflect "reflect"
+	sync "sync"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+// This is a compile-time assertion that a sufficiently up-to-date version
+// of the legacy proto package is being used.
+const _ = proto.ProtoPackageIsVersion4
+
+// MethodAccess is how the auth middleware guards a method
+type MethodAccess int32
+
+const (
+	// AUTHORIZED methods need a user with the permission of the method
+	MethodAccess_AUTHORIZED MethodAccess = 0
+	// PUBLIC methods can be called without a token
+	MethodAccess_PUBLIC MethodAccess = 1
+	// AUTHENTICATED methods need a user but no permission
+	MethodAccess_AUTHENTICATED MethodAccess = 2
+)
+
+// Enum value maps for MethodAccess.
+var (
+	MethodAccess_name = map[int32]string{
+		0: "AUTHORIZED",
+		1: "PUBLIC",
+		2: "AUTHENTICATED",
+	}
+	MethodAccess_value = map[string]int32{
+		"AUTHORIZED":    0,
+		"PUBLIC":        1,
+		"AUTHENTICATED": 2,
+	}
+)
+
+func (x MethodAccess) Enum() *MethodAccess {
+	p := new(MethodAccess)
+	*p = x
+	return p
+}
+
+func (x MethodAccess) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (MethodAccess) Descriptor() protoreflect.EnumDescriptor {
+	return file_api_proto_v1_options_proto_enumTypes[0].Descriptor()
+}
+
+func (MethodAccess) Type() protoreflect.EnumType {
+	return &file_api_proto_v1_options_proto_enumTypes[0]
+}
+
+func (x MethodAccess) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use MethodAccess.Descriptor instead.
+func (MethodAccess) EnumDescriptor() ([]byte, []int) {
+	return file_api_proto_v1_options_proto_rawDescGZIP(), []int{0}
+}
+
+var file_api_proto_v1_options_proto_extTypes = []protoimpl.ExtensionInfo{
+	{
+		ExtendedType:  (*descriptor.ServiceOptions)(nil),
+		ExtensionType: (*string)(nil),
+		Field:         51000,
+		Name:          "authV1.resource",
+		Tag:           "bytes,51000,opt,name=resource",
+		Filename:      "api/proto/v1/options.proto",
+	},
+	{
+		ExtendedType:  (*descriptor.MethodOptions)(nil),
+		ExtensionType: (*MethodAccess)(nil),
+		Field:         51001,
+		Name:          "authV1.access",
+		Tag:           "varint,51001,opt,name=access,enum=authV1.MethodAccess",
+		Filename:      "api/proto/v1/options.proto",
+	},
+	{
+		ExtendedType:  (*descriptor.MethodOptions)(nil),
+		ExtensionType: (*string)(nil),
+		Field:         51002,
+		Name:          "authV1.method_resource",
+		Tag:           "bytes,51002,opt,name=method_resource",
+		Filename:      "api/proto/v1/options.proto",
+	},
+	{
+		ExtendedType:  (*descriptor.MethodOptions)(nil),
+		ExtensionType: (*string)(nil),
+		Field:         51003,
+		Name:          "authV1.action",
+		Tag:           "bytes,51003,opt,name=action",
+		Filename:      "api/proto/v1/options.proto",
+	},
+}
+
+// Extension fields to descriptor.ServiceOptions.
+var (
+	// resource is the resource of the authorized methods of the service, the kebab cased
+	// plural service name by default, e.g. audit-logs for AuditLogService
+	//
+	// optional string resource = 51000;
+	E_Resource = &file_api_proto_v1_options_proto_extTypes[0]
+)
+
+// Extension fields to descriptor.MethodOptions.
+var (
+	// access overrides the default access of the method
+	//
+	// optional authV1.MethodAccess access = 51001;
+	E_Access = &file_api_proto_v1_options_proto_extTypes[1]
+	// method_resource overrides the resource of the service
+	//
+	// optional string method_resource = 51002;
+	E_MethodResource = &file_api_proto_v1_options_proto_extTypes[2]
+	// action overrides the action of the method, the leading verb of its name by default
+	//
+	// optional string action = 51003;
+	E_Action = &file_api_proto_v1_options_proto_extTypes[3]
+)
+
+var File_api_proto_v1_options_proto protoreflect.FileDescriptor
+
+var file_api_proto_v1_options_proto_rawDesc = []byte{
+	0x0a, 0x1a, 0x61, 0x70, 0x69, 0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2f, 0x76, 0x31, 0x2f, 0x6f,
+	0x70, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x12, 0x06, 0x61, 0x75,
+	0x74, 0x68, 0x56, 0x31, 0x1a, 0x20, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2f, 0x70, 0x72, 0x6f,
+	0x74, 0x6f, 0x62, 0x75, 0x66, 0x2f, 0x64, 0x65, 0x73, 0x63, 0x72, 0x69, 0x70, 0x74, 0x6f, 0x72,
+	0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2a, 0x3d, 0x0a, 0x0c, 0x4d, 0x65, 0x74, 0x68, 0x6f, 0x64,
+	0x41, 0x63, 0x63, 0x65, 0x73, 0x73, 0x12, 0x0e, 0x0a, 0x0a, 0x41, 0x55, 0x54, 0x48, 0x4f, 0x52,
+	0x49, 0x5a, 0x45, 0x44, 0x10, 0x00, 0x12, 0x0a, 0x0a, 0x06, 0x50, 0x55, 0x42, 0x4c, 0x49, 0x43,
+	0x10, 0x01, 0x12, 0x11, 0x0a, 0x0d, 0x41, 0x55, 0x54, 0x48, 0x45, 0x4e, 0x54, 0x49, 0x43, 0x41,
+	0x54, 0x45, 0x44, 0x10, 0x02, 0x3a, 0x3d, 0x0a, 0x08, 0x72, 0x65, 0x73, 0x6f, 0x75, 0x72, 0x63,
+	0x65, 0x12, 0x1f, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f,
+	0x62, 0x75, 0x66, 0x2e, 0x53, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x4f, 0x70, 0x74, 0x69, 0x6f,
+	0x6e, 0x73, 0x18, 0xb8, 0x8e, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x72, 0x65, 0x73, 0x6f,
+	0x75, 0x72, 0x63, 0x65, 0x3a, 0x4e, 0x0a, 0x06, 0x61, 0x63, 0x63, 0x65, 0x73, 0x73, 0x12, 0x1e,
+	0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66,
+	0x2e, 0x4d, 0x65, 0x74, 0x68, 0x6f, 0x64, 0x4f, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x18, 0xb9,
+	0x8e, 0x03, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x14, 0x2e, 0x61, 0x75, 0x74, 0x68, 0x56, 0x31, 0x2e,
+	0x4d, 0x65, 0x74, 0x68, 0x6f, 0x64, 0x41, 0x63, 0x63, 0x65, 0x73, 0x73, 0x52, 0x06, 0x61, 0x63,
+	0x63, 0x65, 0x73, 0x73, 0x3a, 0x49, 0x0a, 0x0f, 0x6d, 0x65, 0x74, 0x68, 0x6f, 0x64, 0x5f, 0x72,
+	0x65, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x12, 0x1e, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65,
+	0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x4d, 0x65, 0x74, 0x68, 0x6f, 0x64,
+	0x4f, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x18, 0xba, 0x8e, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x0e, 0x6d, 0x65, 0x74, 0x68, 0x6f, 0x64, 0x52, 0x65, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x3a,
+	0x38, 0x0a, 0x06, 0x61, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x1e, 0x2e, 0x67, 0x6f, 0x6f, 0x67,
+	0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x4d, 0x65, 0x74, 0x68,
+	0x6f, 0x64, 0x4f, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x18, 0xbb, 0x8e, 0x03, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x06, 0x61, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x42, 0x18, 0x5a, 0x16, 0x69, 0x6e, 0x74,
+	0x65, 0x72, 0x6e, 0x61, 0x6c, 0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2f, 0x76, 0x31, 0x3b, 0x61,
+	0x75, 0x74, 0x68, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
+}
+
+var (
+	file_api_proto_v1_options_proto_rawDescOnce sync.Once
+	file_api_proto_v1_options_proto_rawDescData = file_api_proto_v1_options_proto_rawDesc
+)
+
+func file_api_proto_v1_options_proto_rawDescGZIP() []byte {
+	file_api_proto_v1_options_proto_rawDescOnce.Do(func() {
+		file_api_proto_v1_options_proto_rawDescData = protoimpl.X.CompressGZIP(file_api_proto_v1_options_proto_rawDescData)
+	})
+	return file_api_proto_v1_options_proto_rawDescData
+}
+
+var file_api_proto_v1_options_proto_enumTypes = make([]protoimpl.EnumInfo, 1)
+var file_api_proto_v1_options_proto_goTypes = []interface{}{
+	(MethodAccess)(0),                 // 0: authV1.MethodAccess
+	(*descriptor.ServiceOptions)(nil), // 1: google.protobuf.ServiceOptions
+	(*descriptor.MethodOptions)(nil),  // 2: google.protobuf.MethodOptions
+}
+var file_api_proto_v1_options_proto_depIdxs = []int32{
+	1, // 0: authV1.resource:extendee -> google.protobuf.ServiceOptions
+	2, // 1: authV1.access:extendee -> google.protobuf.MethodOptions
+	2, // 2: authV1.method_resource:extendee -> google.protobuf.MethodOptions
+	2, // 3: authV1.action:extendee -> google.protobuf.MethodOptions
+	0, // 4: authV1.access:type_name -> authV1.MethodAccess
+	5, // [5:5] is the sub-list for method output_type
+	5, // [5:5] is the sub-list for method input_type
+	4, // [4:5] is the sub-list for extension type_name
+	0, // [0:4] is the sub-list for extension extendee
+	0, // [0:0] is the sub-list for field type_name
+}
+
+func init() { file_api_proto_v1_options_proto_init() }
+func file_api_proto_v1_options_proto_init() {
+	if File_api_proto_v1_options_proto != nil {
+		return
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: file_api_proto_v1_options_proto_rawDesc,
+			NumEnums:      1,
+			NumMessages:   0,
+			NumExtensions: 4,
+			NumServices:   0,
+		},
+		GoTypes:           file_api_proto_v1_options_proto_goTypes,
+		DependencyIndexes: file_api_proto_v1_options_proto_depIdxs,
+		EnumInfos:         file_api_proto_v1_options_proto_enumTypes,
+		ExtensionInfos:    file_api_proto_v1_options_proto_extTypes,
+	}.Build()
+	File_api_proto_v1_options_proto = out.File
+	file_api_proto_v1_options_proto_rawDesc = nil
+	file_api_proto_v1_options_proto_goTypes = nil
+	file_api_proto_v1_options_proto_depIdxs = nil
+}
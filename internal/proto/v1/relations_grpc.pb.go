@@ -0,0 +1,404 @@
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+
+package auth
+
+import (
+	context "context"
+	empty "github.com/golang/protobuf/ptypes/empty"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+const _ = grpc.SupportPackageIsVersion7
+
+// RelationServiceClient is the client API for RelationService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+type RelationServiceClient interface {
+	// WriteRelationTuples stores the tuples, tuples that already exist are kept
+	WriteRelationTuples(ctx context.Context, in *WriteRelationTuplesRequest, opts ...grpc.CallOption) (*WriteRelationTuplesResponse, error)
+	// DeleteRelationTuples removes the tuples
+	DeleteRelationTuples(ctx context.Context, in *DeleteRelationTuplesRequest, opts ...grpc.CallOption) (*DeleteRelationTuplesResponse, error)
+	// List RelationTuples
+	ListRelationTuples(ctx context.Context, in *ListRelationTuplesRequest, opts ...grpc.CallOption) (*ListRelationTuplesResponse, error)
+	// CheckRelation reports whether subject has relation to object
+	CheckRelation(ctx context.Context, in *CheckRelationRequest, opts ...grpc.CallOption) (*CheckRelationResponse, error)
+	// ExpandRelation returns the tree of subjects that have relation to object
+	ExpandRelation(ctx context.Context, in *ExpandRelationRequest, opts ...grpc.CallOption) (*ExpandRelationResponse, error)
+	// ListRelationObjects returns the objects of a namespace subject has relation to
+	ListRelationObjects(ctx context.Context, in *ListRelationObjectsRequest, opts ...grpc.CallOption) (*ListRelationObjectsResponse, error)
+	// List RelationDefinitions
+	ListRelationDefinitions(ctx context.Context, in *ListRelationDefinitionsRequest, opts ...grpc.CallOption) (*ListRelationDefinitionsResponse, error)
+	// Create RelationDefinition
+	CreateRelationDefinition(ctx context.Context, in *CreateRelationDefinitionRequest, opts ...grpc.CallOption) (*RelationDefinition, error)
+	// Delete RelationDefinition
+	DeleteRelationDefinition(ctx context.Context, in *DeleteRelationDefinitionRequest, opts ...grpc.CallOption) (*empty.Empty, error)
+}
+
+type relationServiceClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewRelationServiceClient(cc grpc.ClientConnInterface) RelationServiceClient {
+	return &relationServiceClient{cc}
+}
+
+func (c *relationServiceClient) WriteRelationTuples(ctx context.Context, in *WriteRelationTuplesRequest, opts ...grpc.CallOption) (*WriteRelationTuplesResponse, error) {
+	out := new(WriteRelationTuplesResponse)
+	err := c.cc.Invoke(ctx, "/authV1.RelationService/WriteRelationTuples", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *relationServiceClient) DeleteRelationTuples(ctx context.Context, in *DeleteRelationTuplesRequest, opts ...grpc.CallOption) (*DeleteRelationTuplesResponse, error) {
+	out := new(DeleteRelationTuplesResponse)
+	err := c.cc.Invoke(ctx, "/authV1.RelationService/DeleteRelationTuples", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *relationServiceClient) ListRelationTuples(ctx context.Context, in *ListRelationTuplesRequest, opts ...grpc.CallOption) (*ListRelationTuplesResponse, error) {
+	out := new(ListRelationTuplesResponse)
+	err := c.cc.Invoke(ctx, "/authV1.RelationService/ListRelationTuples", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *relationServiceClient) CheckRelation(ctx context.Context, in *CheckRelationRequest, opts ...grpc.CallOption) (*CheckRelationResponse, error) {
+	out := new(CheckRelationResponse)
+	err := c.cc.Invoke(ctx, "/authV1.RelationService/CheckRelation", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *relationServiceClient) ExpandRelation(ctx context.Context, in *ExpandRelationRequest, opts ...grpc.CallOption) (*ExpandRelationResponse, error) {
+	out := new(ExpandRelationResponse)
+	err := c.cc.Invoke(ctx, "/authV1.RelationService/ExpandRelation", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *relationServiceClient) ListRelationObjects(ctx context.Context, in *ListRelationObjectsRequest, opts ...grpc.CallOption) (*ListRelationObjectsResponse, error) {
+	out := new(ListRelationObjectsResponse)
+	err := c.cc.Invoke(ctx, "/authV1.RelationService/ListRelationObjects", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *relationServiceClient) ListRelationDefinitions(ctx context.Context, in *ListRelationDefinitionsRequest, opts ...grpc.CallOption) (*ListRelationDefinitionsResponse, error) {
+	out := new(ListRelationDefinitionsResponse)
+	err := c.cc.Invoke(ctx, "/authV1.RelationService/ListRelationDefinitions", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *relationServiceClient) CreateRelationDefinition(ctx context.Context, in *CreateRelationDefinitionRequest, opts ...grpc.CallOption) (*RelationDefinition, error) {
+	out := new(RelationDefinition)
+	err := c.cc.Invoke(ctx, "/authV1.RelationService/CreateRelationDefinition", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *relationServiceClient) DeleteRelationDefinition(ctx context.Context, in *DeleteRelationDefinitionRequest, opts ...grpc.CallOption) (*empty.Empty, error) {
+	out := new(empty.Empty)
+	err := c.cc.Invoke(ctx, "/authV1.RelationService/DeleteRelationDefinition", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// RelationServiceServer is the server API for RelationService service.
+// All implementations must embed UnimplementedRelationServiceServer
+// for forward compatibility
+type RelationServiceServer interface {
+	// WriteRelationTuples stores the tuples, tuples that already exist are kept
+	WriteRelationTuples(context.Context, *WriteRelationTuplesRequest) (*WriteRelationTuplesResponse, error)
+	// DeleteRelationTuples removes the tuples
+	DeleteRelationTuples(context.Context, *DeleteRelationTuplesRequest) (*DeleteRelationTuplesResponse, error)
+	// List RelationTuples
+	ListRelationTuples(context.Context, *ListRelationTuplesRequest) (*ListRelationTuplesResponse, error)
+	// CheckRelation reports whether subject has relation to object
+	CheckRelation(context.Context, *CheckRelationRequest) (*CheckRelationResponse, error)
+	// ExpandRelation returns the tree of subjects that have relation to object
+	ExpandRelation(context.Context, *ExpandRelationRequest) (*ExpandRelationResponse, error)
+	// ListRelationObjects returns the objects of a namespace subject has relation to
+	ListRelationObjects(context.Context, *ListRelationObjectsRequest) (*ListRelationObjectsResponse, error)
+	// List RelationDefinitions
+	ListRelationDefinitions(context.Context, *ListRelationDefinitionsRequest) (*ListRelationDefinitionsResponse, error)
+	// Create RelationDefinition
+	CreateRelationDefinition(context.Context, *CreateRelationDefinitionRequest) (*RelationDefinition, error)
+	// Delete RelationDefinition
+	DeleteRelationDefinition(context.Context, *DeleteRelationDefinitionRequest) (*empty.Empty, error)
+	mustEmbedUnimplementedRelationServiceServer()
+}
+
+// UnimplementedRelationServiceServer must be embedded to have forward compatible implementations.
+type UnimplementedRelationServiceServer struct {
+}
+
+func (UnimplementedRelationServiceServer) WriteRelationTuples(context.Context, *WriteRelationTuplesRequest) (*WriteRelationTuplesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method WriteRelationTuples not implemented")
+}
+func (UnimplementedRelationServiceServer) DeleteRelationTuples(context.Context, *DeleteRelationTuplesRequest) (*DeleteRelationTuplesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DeleteRelationTuples not implemented")
+}
+func (UnimplementedRelationServiceServer) ListRelationTuples(context.Context, *ListRelationTuplesRequest) (*ListRelationTuplesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListRelationTuples not implemented")
+}
+func (UnimplementedRelationServiceServer) CheckRelation(context.Context, *CheckRelationRequest) (*CheckRelationResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CheckRelation not implemented")
+}
+func (UnimplementedRelationServiceServer) ExpandRelation(context.Context, *ExpandRelationRequest) (*ExpandRelationResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ExpandRelation not implemented")
+}
+func (UnimplementedRelationServiceServer) ListRelationObjects(context.Context, *ListRelationObjectsRequest) (*ListRelationObjectsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListRelationObjects not implemented")
+}
+func (UnimplementedRelationServiceServer) ListRelationDefinitions(context.Context, *ListRelationDefinitionsRequest) (*ListRelationDefinitionsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListRelationDefinitions not implemented")
+}
+func (UnimplementedRelationServiceServer) CreateRelationDefinition(context.Context, *CreateRelationDefinitionRequest) (*RelationDefinition, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreateRelationDefinition not implemented")
+}
+func (UnimplementedRelationServiceServer) DeleteRelationDefinition(context.Context, *DeleteRelationDefinitionRequest) (*empty.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DeleteRelationDefinition not implemented")
+}
+func (UnimplementedRelationServiceServer) mustEmbedUnimplementedRelationServiceServer() {}
+
+// UnsafeRelationServiceServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to RelationServiceServer will
+// result in compilation errors.
+type UnsafeRelationServiceServer interface {
+	mustEmbedUnimplementedRelationServiceServer()
+}
+
+func RegisterRelationServiceServer(s *grpc.Server, srv RelationServiceServer) {
+	s.RegisterService(&_RelationService_serviceDesc, srv)
+}
+
+func _RelationService_WriteRelationTuples_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(WriteRelationTuplesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RelationServiceServer).WriteRelationTuples(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/authV1.RelationService/WriteRelationTuples",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RelationServiceServer).WriteRelationTuples(ctx, req.(*WriteRelationTuplesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _RelationService_DeleteRelationTuples_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeleteRelationTuplesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RelationServiceServer).DeleteRelationTuples(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/authV1.RelationService/DeleteRelationTuples",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RelationServiceServer).DeleteRelationTuples(ctx, req.(*DeleteRelationTuplesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _RelationService_ListRelationTuples_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListRelationTuplesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RelationServiceServer).ListRelationTuples(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/authV1.RelationService/ListRelationTuples",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RelationServiceServer).ListRelationTuples(ctx, req.(*ListRelationTuplesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _RelationService_CheckRelation_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CheckRelationRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RelationServiceServer).CheckRelation(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/authV1.RelationService/CheckRelation",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RelationServiceServer).CheckRelation(ctx, req.(*CheckRelationRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _RelationService_ExpandRelation_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ExpandRelationRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RelationServiceServer).ExpandRelation(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/authV1.RelationService/ExpandRelation",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RelationServiceServer).ExpandRelation(ctx, req.(*ExpandRelationRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _RelationService_ListRelationObjects_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListRelationObjectsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RelationServiceServer).ListRelationObjects(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/authV1.RelationService/ListRelationObjects",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RelationServiceServer).ListRelationObjects(ctx, req.(*ListRelationObjectsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _RelationService_ListRelationDefinitions_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListRelationDefinitionsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RelationServiceServer).ListRelationDefinitions(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/authV1.RelationService/ListRelationDefinitions",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RelationServiceServer).ListRelationDefinitions(ctx, req.(*ListRelationDefinitionsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _RelationService_CreateRelationDefinition_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateRelationDefinitionRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RelationServiceServer).CreateRelationDefinition(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/authV1.RelationService/CreateRelationDefinition",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RelationServiceServer).CreateRelationDefinition(ctx, req.(*CreateRelationDefinitionRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _RelationService_DeleteRelationDefinition_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeleteRelationDefinitionRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RelationServiceServer).DeleteRelationDefinition(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/authV1.RelationService/DeleteRelationDefinition",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RelationServiceServer).DeleteRelationDefinition(ctx, req.(*DeleteRelationDefinitionRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+var _RelationService_serviceDesc = grpc.ServiceDesc{
+	ServiceName: "authV1.RelationService",
+	HandlerType: (*RelationServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "WriteRelationTuples",
+			Handler:    _RelationService_WriteRelationTuples_Handler,
+		},
+		{
+			MethodName: "DeleteRelationTuples",
+			Handler:    _RelationService_DeleteRelationTuples_Handler,
+		},
+		{
+			MethodName: "ListRelationTuples",
+			Handler:    _RelationService_ListRelationTuples_Handler,
+		},
+		{
+			MethodName: "CheckRelation",
+			Handler:    _RelationService_CheckRelation_Handler,
+		},
+		{
+			MethodName: "ExpandRelation",
+			Handler:    _RelationService_ExpandRelation_Handler,
+		},
+		{
+			MethodName: "ListRelationObjects",
+			Handler:    _RelationService_ListRelationObjects_Handler,
+		},
+		{
+			MethodName: "ListRelationDefinitions",
+			Handler:    _RelationService_ListRelationDefinitions_Handler,
+		},
+		{
+			MethodName: "CreateRelationDefinition",
+			Handler:    _RelationService_CreateRelationDefinition_Handler,
+		},
+		{
+			MethodName: "DeleteRelationDefinition",
+			Handler:    _RelationService_DeleteRelationDefinition_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "api/proto/v1/relations.proto",
+}
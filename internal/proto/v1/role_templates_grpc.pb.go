@@ -0,0 +1,290 @@
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+
+package auth
+
+import (
+	context "context"
+	empty "github.com/golang/protobuf/ptypes/empty"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+const _ = grpc.SupportPackageIsVersion7
+
+// RoleTemplateServiceClient is the client API for RoleTemplateService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+type RoleTemplateServiceClient interface {
+	// List role templates
+	ListRoleTemplates(ctx context.Context, in *ListRoleTemplatesRequest, opts ...grpc.CallOption) (*ListRoleTemplatesResponse, error)
+	// Get role template
+	GetRoleTemplate(ctx context.Context, in *GetRoleTemplateRequest, opts ...grpc.CallOption) (*RoleTemplate, error)
+	// Create role template object request
+	CreateRoleTemplate(ctx context.Context, in *CreateRoleTemplateRequest, opts ...grpc.CallOption) (*RoleTemplate, error)
+	// Update role template object request, roles created from the template keep their bundles
+	UpdateRoleTemplate(ctx context.Context, in *UpdateRoleTemplateRequest, opts ...grpc.CallOption) (*RoleTemplate, error)
+	// Delete role template object request
+	DeleteRoleTemplate(ctx context.Context, in *DeleteRoleTemplateRequest, opts ...grpc.CallOption) (*empty.Empty, error)
+	// ApplyRoleTemplate creates a role with the bundles of the template attached in a domain
+	ApplyRoleTemplate(ctx context.Context, in *ApplyRoleTemplateRequest, opts ...grpc.CallOption) (*ApplyRoleTemplateResponse, error)
+}
+
+type roleTemplateServiceClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewRoleTemplateServiceClient(cc grpc.ClientConnInterface) RoleTemplateServiceClient {
+	return &roleTemplateServiceClient{cc}
+}
+
+func (c *roleTemplateServiceClient) ListRoleTemplates(ctx context.Context, in *ListRoleTemplatesRequest, opts ...grpc.CallOption) (*ListRoleTemplatesResponse, error) {
+	out := new(ListRoleTemplatesResponse)
+	err := c.cc.Invoke(ctx, "/authV1.RoleTemplateService/ListRoleTemplates", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *roleTemplateServiceClient) GetRoleTemplate(ctx context.Context, in *GetRoleTemplateRequest, opts ...grpc.CallOption) (*RoleTemplate, error) {
+	out := new(RoleTemplate)
+	err := c.cc.Invoke(ctx, "/authV1.RoleTemplateService/GetRoleTemplate", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *roleTemplateServiceClient) CreateRoleTemplate(ctx context.Context, in *CreateRoleTemplateRequest, opts ...grpc.CallOption) (*RoleTemplate, error) {
+	out := new(RoleTemplate)
+	err := c.cc.Invoke(ctx, "/authV1.RoleTemplateService/CreateRoleTemplate", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *roleTemplateServiceClient) UpdateRoleTemplate(ctx context.Context, in *UpdateRoleTemplateRequest, opts ...grpc.CallOption) (*RoleTemplate, error) {
+	out := new(RoleTemplate)
+	err := c.cc.Invoke(ctx, "/authV1.RoleTemplateService/UpdateRoleTemplate", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *roleTemplateServiceClient) DeleteRoleTemplate(ctx context.Context, in *DeleteRoleTemplateRequest, opts ...grpc.CallOption) (*empty.Empty, error) {
+	out := new(empty.Empty)
+	err := c.cc.Invoke(ctx, "/authV1.RoleTemplateService/DeleteRoleTemplate", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *roleTemplateServiceClient) ApplyRoleTemplate(ctx context.Context, in *ApplyRoleTemplateRequest, opts ...grpc.CallOption) (*ApplyRoleTemplateResponse, error) {
+	out := new(ApplyRoleTemplateResponse)
+	err := c.cc.Invoke(ctx, "/authV1.RoleTemplateService/ApplyRoleTemplate", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// RoleTemplateServiceServer is the server API for RoleTemplateService service.
+// All implementations must embed UnimplementedRoleTemplateServiceServer
+// for forward compatibility
+type RoleTemplateServiceServer interface {
+	// List role templates
+	ListRoleTemplates(context.Context, *ListRoleTemplatesRequest) (*ListRoleTemplatesResponse, error)
+	// Get role template
+	GetRoleTemplate(context.Context, *GetRoleTemplateRequest) (*RoleTemplate, error)
+	// Create role template object request
+	CreateRoleTemplate(context.Context, *CreateRoleTemplateRequest) (*RoleTemplate, error)
+	// Update role template object request, roles created from the template keep their bundles
+	UpdateRoleTemplate(context.Context, *UpdateRoleTemplateRequest) (*RoleTemplate, error)
+	// Delete role template object request
+	DeleteRoleTemplate(context.Context, *DeleteRoleTemplateRequest) (*empty.Empty, error)
+	// ApplyRoleTemplate creates a role with the bundles of the template attached in a domain
+	ApplyRoleTemplate(context.Context, *ApplyRoleTemplateRequest) (*ApplyRoleTemplateResponse, error)
+	mustEmbedUnimplementedRoleTemplateServiceServer()
+}
+
+// UnimplementedRoleTemplateServiceServer must be embedded to have forward compatible implementations.
+type UnimplementedRoleTemplateServiceServer struct {
+}
+
+func (UnimplementedRoleTemplateServiceServer) ListRoleTemplates(context.Context, *ListRoleTemplatesRequest) (*ListRoleTemplatesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListRoleTemplates not implemented")
+}
+func (UnimplementedRoleTemplateServiceServer) GetRoleTemplate(context.Context, *GetRoleTemplateRequest) (*RoleTemplate, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetRoleTemplate not implemented")
+}
+func (UnimplementedRoleTemplateServiceServer) CreateRoleTemplate(context.Context, *CreateRoleTemplateRequest) (*RoleTemplate, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreateRoleTemplate not implemented")
+}
+func (UnimplementedRoleTemplateServiceServer) UpdateRoleTemplate(context.Context, *UpdateRoleTemplateRequest) (*RoleTemplate, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UpdateRoleTemplate not implemented")
+}
+func (UnimplementedRoleTemplateServiceServer) DeleteRoleTemplate(context.Context, *DeleteRoleTemplateRequest) (*empty.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DeleteRoleTemplate not implemented")
+}
+func (UnimplementedRoleTemplateServiceServer) ApplyRoleTemplate(context.Context, *ApplyRoleTemplateRequest) (*ApplyRoleTemplateResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ApplyRoleTemplate not implemented")
+}
+func (UnimplementedRoleTemplateServiceServer) mustEmbedUnimplementedRoleTemplateServiceServer() {}
+
+// UnsafeRoleTemplateServiceServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to RoleTemplateServiceServer will
+// result in compilation errors.
+type UnsafeRoleTemplateServiceServer interface {
+	mustEmbedUnimplementedRoleTemplateServiceServer()
+}
+
+func RegisterRoleTemplateServiceServer(s *grpc.Server, srv RoleTemplateServiceServer) {
+	s.RegisterService(&_RoleTemplateService_serviceDesc, srv)
+}
+
+func _RoleTemplateService_ListRoleTemplates_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListRoleTemplatesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RoleTemplateServiceServer).ListRoleTemplates(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/authV1.RoleTemplateService/ListRoleTemplates",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RoleTemplateServiceServer).ListRoleTemplates(ctx, req.(*ListRoleTemplatesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _RoleTemplateService_GetRoleTemplate_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetRoleTemplateRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RoleTemplateServiceServer).GetRoleTemplate(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/authV1.RoleTemplateService/GetRoleTemplate",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RoleTemplateServiceServer).GetRoleTemplate(ctx, req.(*GetRoleTemplateRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _RoleTemplateService_CreateRoleTemplate_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateRoleTemplateRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RoleTemplateServiceServer).CreateRoleTemplate(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/authV1.RoleTemplateService/CreateRoleTemplate",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RoleTemplateServiceServer).CreateRoleTemplate(ctx, req.(*CreateRoleTemplateRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _RoleTemplateService_UpdateRoleTemplate_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UpdateRoleTemplateRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RoleTemplateServiceServer).UpdateRoleTemplate(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/authV1.RoleTemplateService/UpdateRoleTemplate",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RoleTemplateServiceServer).UpdateRoleTemplate(ctx, req.(*UpdateRoleTemplateRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _RoleTemplateService_DeleteRoleTemplate_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeleteRoleTemplateRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RoleTemplateServiceServer).DeleteRoleTemplate(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/authV1.RoleTemplateService/DeleteRoleTemplate",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RoleTemplateServiceServer).DeleteRoleTemplate(ctx, req.(*DeleteRoleTemplateRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _RoleTemplateService_ApplyRoleTemplate_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ApplyRoleTemplateRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RoleTemplateServiceServer).ApplyRoleTemplate(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/authV1.RoleTemplateService/ApplyRoleTemplate",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RoleTemplateServiceServer).ApplyRoleTemplate(ctx, req.(*ApplyRoleTemplateRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+var _RoleTemplateService_serviceDesc = grpc.ServiceDesc{
+	ServiceName: "authV1.RoleTemplateService",
+	HandlerType: (*RoleTemplateServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "ListRoleTemplates",
+			Handler:    _RoleTemplateService_ListRoleTemplates_Handler,
+		},
+		{
+			MethodName: "GetRoleTemplate",
+			Handler:    _RoleTemplateService_GetRoleTemplate_Handler,
+		},
+		{
+			MethodName: "CreateRoleTemplate",
+			Handler:    _RoleTemplateService_CreateRoleTemplate_Handler,
+		},
+		{
+			MethodName: "UpdateRoleTemplate",
+			Handler:    _RoleTemplateService_UpdateRoleTemplate_Handler,
+		},
+		{
+			MethodName: "DeleteRoleTemplate",
+			Handler:    _RoleTemplateService_DeleteRoleTemplate_Handler,
+		},
+		{
+			MethodName: "ApplyRoleTemplate",
+			Handler:    _RoleTemplateService_ApplyRoleTemplate_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "api/proto/v1/role_templates.proto",
+}
@@ -7,8 +7,8 @@ import (
 	"github.com/golang-tire/pkg/grpcgw"
 )
 
-const rules_paths = "{\"/v1/rules\":{\"get\":{\"operationId\":\"RuleService_ListRules\",\"parameters\":[{\"format\":\"int64\",\"in\":\"query\",\"name\":\"limit\",\"required\":false,\"type\":\"string\"},{\"format\":\"int64\",\"in\":\"query\",\"name\":\"offset\",\"required\":false,\"type\":\"string\"},{\"in\":\"query\",\"name\":\"query\",\"required\":false,\"type\":\"string\"}],\"responses\":{\"200\":{\"description\":\"A successful response.\",\"schema\":{\"$ref\":\"#/definitions/authV1ListRulesResponse\"}},\"default\":{\"description\":\"An unexpected error response.\",\"schema\":{\"$ref\":\"#/definitions/runtimeError\"}}},\"summary\":\"List Rules\",\"tags\":[\"RuleService\"]},\"post\":{\"operationId\":\"RuleService_CreateRule\",\"parameters\":[{\"in\":\"body\",\"name\":\"body\",\"required\":true,\"schema\":{\"$ref\":\"#/definitions/authV1CreateRuleRequest\"}}],\"responses\":{\"200\":{\"description\":\"A successful response.\",\"schema\":{\"$ref\":\"#/definitions/authV1Rule\"}},\"default\":{\"description\":\"An unexpected error response.\",\"schema\":{\"$ref\":\"#/definitions/runtimeError\"}}},\"summary\":\"Create Rule object request\",\"tags\":[\"RuleService\"]}},\"/v1/rules/{uuid}\":{\"delete\":{\"operationId\":\"RuleService_DeleteRule\",\"parameters\":[{\"in\":\"path\",\"name\":\"uuid\",\"required\":true,\"type\":\"string\"}],\"responses\":{\"200\":{\"description\":\"A successful response.\",\"schema\":{\"properties\":{}}},\"default\":{\"description\":\"An unexpected error response.\",\"schema\":{\"$ref\":\"#/definitions/runtimeError\"}}},\"summary\":\"Delete Rule object request\",\"tags\":[\"RuleService\"]},\"get\":{\"operationId\":\"RuleService_GetRule\",\"parameters\":[{\"in\":\"path\",\"name\":\"uuid\",\"required\":true,\"type\":\"string\"}],\"responses\":{\"200\":{\"description\":\"A successful response.\",\"schema\":{\"$ref\":\"#/definitions/authV1Rule\"}},\"default\":{\"description\":\"An unexpected error response.\",\"schema\":{\"$ref\":\"#/definitions/runtimeError\"}}},\"summary\":\"Get Rule\",\"tags\":[\"RuleService\"]},\"put\":{\"operationId\":\"RuleService_UpdateRule\",\"parameters\":[{\"in\":\"path\",\"name\":\"uuid\",\"required\":true,\"type\":\"string\"},{\"in\":\"body\",\"name\":\"body\",\"required\":true,\"schema\":{\"$ref\":\"#/definitions/authV1UpdateRuleRequest\"}}],\"responses\":{\"200\":{\"description\":\"A successful response.\",\"schema\":{\"$ref\":\"#/definitions/authV1Rule\"}},\"default\":{\"description\":\"An unexpected error response.\",\"schema\":{\"$ref\":\"#/definitions/runtimeError\"}}},\"summary\":\"Update Rule object request\",\"tags\":[\"RuleService\"]}}}"
-const rules_definitions = "{\"authV1CreateRuleRequest\":{\"properties\":{\"action\":{\"type\":\"string\"},\"domain\":{\"type\":\"string\"},\"effect\":{\"$ref\":\"#/definitions/authV1Effect\"},\"object\":{\"type\":\"string\"},\"resource\":{\"type\":\"string\"},\"role\":{\"type\":\"string\"}},\"type\":\"object\"},\"authV1Effect\":{\"default\":\"DENY\",\"enum\":[\"DENY\",\"ALLOW\"],\"type\":\"string\"},\"authV1ListRulesResponse\":{\"properties\":{\"limit\":{\"format\":\"int64\",\"type\":\"string\"},\"offset\":{\"format\":\"int64\",\"type\":\"string\"},\"rules\":{\"items\":{\"$ref\":\"#/definitions/authV1Rule\"},\"type\":\"array\"},\"total_count\":{\"format\":\"int64\",\"type\":\"string\"}},\"type\":\"object\"},\"authV1Rule\":{\"properties\":{\"action\":{\"type\":\"string\"},\"created_at\":{\"format\":\"date-time\",\"type\":\"string\"},\"domain\":{\"type\":\"string\"},\"effect\":{\"$ref\":\"#/definitions/authV1Effect\"},\"object\":{\"type\":\"string\"},\"resource\":{\"type\":\"string\"},\"role\":{\"type\":\"string\"},\"updated_at\":{\"format\":\"date-time\",\"type\":\"string\"},\"uuid\":{\"type\":\"string\"}},\"type\":\"object\"},\"authV1UpdateRuleRequest\":{\"properties\":{\"action\":{\"type\":\"string\"},\"domain\":{\"type\":\"string\"},\"effect\":{\"$ref\":\"#/definitions/authV1Effect\"},\"object\":{\"type\":\"string\"},\"resource\":{\"type\":\"string\"},\"role\":{\"type\":\"string\"},\"uuid\":{\"type\":\"string\"}},\"type\":\"object\"},\"protobufAny\":{\"properties\":{\"type_url\":{\"type\":\"string\"},\"value\":{\"format\":\"byte\",\"type\":\"string\"}},\"type\":\"object\"},\"runtimeError\":{\"properties\":{\"code\":{\"format\":\"int32\",\"type\":\"integer\"},\"details\":{\"items\":{\"$ref\":\"#/definitions/protobufAny\"},\"type\":\"array\"},\"error\":{\"type\":\"string\"},\"message\":{\"type\":\"string\"}},\"type\":\"object\"}}"
+const rules_paths = "{\"/v1/rules\":{\"get\":{\"operationId\":\"RuleService_ListRules\",\"parameters\":[{\"format\":\"int64\",\"in\":\"query\",\"name\":\"limit\",\"required\":false,\"type\":\"string\"},{\"format\":\"int64\",\"in\":\"query\",\"name\":\"offset\",\"required\":false,\"type\":\"string\"},{\"in\":\"query\",\"name\":\"query\",\"required\":false,\"type\":\"string\"},{\"description\":\"app filters the rules referencing the resources or objects of the named app.\",\"in\":\"query\",\"name\":\"app\",\"required\":false,\"type\":\"string\"}],\"responses\":{\"200\":{\"description\":\"A successful response.\",\"schema\":{\"$ref\":\"#/definitions/authV1ListRulesResponse\"}},\"default\":{\"description\":\"An unexpected error response.\",\"schema\":{\"$ref\":\"#/definitions/runtimeError\"}}},\"summary\":\"List Rules\",\"tags\":[\"RuleService\"]},\"post\":{\"operationId\":\"RuleService_CreateRule\",\"parameters\":[{\"in\":\"body\",\"name\":\"body\",\"required\":true,\"schema\":{\"$ref\":\"#/definitions/authV1CreateRuleRequest\"}}],\"responses\":{\"200\":{\"description\":\"A successful response.\",\"schema\":{\"$ref\":\"#/definitions/authV1Rule\"}},\"default\":{\"description\":\"An unexpected error response.\",\"schema\":{\"$ref\":\"#/definitions/runtimeError\"}}},\"summary\":\"Create Rule object request\",\"tags\":[\"RuleService\"]}},\"/v1/rules/export\":{\"get\":{\"operationId\":\"RuleService_ExportPolicy\",\"parameters\":[{\"default\":\"CSV\",\"enum\":[\"CSV\",\"YAML\"],\"in\":\"query\",\"name\":\"format\",\"required\":false,\"type\":\"string\"}],\"responses\":{\"200\":{\"description\":\"A successful response.\",\"schema\":{\"$ref\":\"#/definitions/authV1ExportPolicyResponse\"}},\"default\":{\"description\":\"An unexpected error response.\",\"schema\":{\"$ref\":\"#/definitions/runtimeError\"}}},\"summary\":\"Export Policy as casbin csv or yaml\",\"tags\":[\"RuleService\"]}},\"/v1/rules/import\":{\"post\":{\"operationId\":\"RuleService_ImportPolicy\",\"parameters\":[{\"in\":\"body\",\"name\":\"body\",\"required\":true,\"schema\":{\"$ref\":\"#/definitions/authV1ImportPolicyRequest\"}}],\"responses\":{\"200\":{\"description\":\"A successful response.\",\"schema\":{\"$ref\":\"#/definitions/authV1ImportPolicyResponse\"}},\"default\":{\"description\":\"An unexpected error response.\",\"schema\":{\"$ref\":\"#/definitions/runtimeError\"}}},\"summary\":\"Import Policy from casbin csv or yaml\",\"tags\":[\"RuleService\"]}},\"/v1/rules/lint\":{\"get\":{\"operationId\":\"RuleService_LintRules\",\"parameters\":[{\"default\":\"INFO\",\"enum\":[\"INFO\",\"WARNING\",\"ERROR\"],\"in\":\"query\",\"name\":\"min_severity\",\"required\":false,\"type\":\"string\"}],\"responses\":{\"200\":{\"description\":\"A successful response.\",\"schema\":{\"$ref\":\"#/definitions/authV1LintRulesResponse\"}},\"default\":{\"description\":\"An unexpected error response.\",\"schema\":{\"$ref\":\"#/definitions/runtimeError\"}}},\"summary\":\"Lint Rules and report conflicting, shadowed and orphaned rules\",\"tags\":[\"RuleService\"]}},\"/v1/rules/promote\":{\"post\":{\"operationId\":\"RuleService_PromoteRules\",\"parameters\":[{\"in\":\"body\",\"name\":\"body\",\"required\":true,\"schema\":{\"$ref\":\"#/definitions/authV1PromoteRulesRequest\"}}],\"responses\":{\"200\":{\"description\":\"A successful response.\",\"schema\":{\"$ref\":\"#/definitions/authV1PromoteRulesResponse\"}},\"default\":{\"description\":\"An unexpected error response.\",\"schema\":{\"$ref\":\"#/definitions/runtimeError\"}}},\"summary\":\"Promote shadow Rules to live rules\",\"tags\":[\"RuleService\"]}},\"/v1/rules/revisions\":{\"get\":{\"operationId\":\"RuleService_ListPolicyRevisions\",\"parameters\":[{\"format\":\"int64\",\"in\":\"query\",\"name\":\"limit\",\"required\":false,\"type\":\"string\"},{\"format\":\"int64\",\"in\":\"query\",\"name\":\"offset\",\"required\":false,\"type\":\"string\"}],\"responses\":{\"200\":{\"description\":\"A successful response.\",\"schema\":{\"$ref\":\"#/definitions/authV1ListPolicyRevisionsResponse\"}},\"default\":{\"description\":\"An unexpected error response.\",\"schema\":{\"$ref\":\"#/definitions/runtimeError\"}}},\"summary\":\"List Policy Revisions\",\"tags\":[\"RuleService\"]}},\"/v1/rules/revisions/diff\":{\"get\":{\"operationId\":\"RuleService_DiffPolicyRevisions\",\"parameters\":[{\"format\":\"int64\",\"in\":\"query\",\"name\":\"from\",\"required\":false,\"type\":\"string\"},{\"format\":\"int64\",\"in\":\"query\",\"name\":\"to\",\"required\":false,\"type\":\"string\"}],\"responses\":{\"200\":{\"description\":\"A successful response.\",\"schema\":{\"$ref\":\"#/definitions/authV1DiffPolicyRevisionsResponse\"}},\"default\":{\"description\":\"An unexpected error response.\",\"schema\":{\"$ref\":\"#/definitions/runtimeError\"}}},\"summary\":\"Diff two Policy Revisions\",\"tags\":[\"RuleService\"]}},\"/v1/rules/revisions/{number}/rollback\":{\"post\":{\"operationId\":\"RuleService_RollbackPolicy\",\"parameters\":[{\"format\":\"int64\",\"in\":\"path\",\"name\":\"number\",\"required\":true,\"type\":\"string\"},{\"in\":\"body\",\"name\":\"body\",\"required\":true,\"schema\":{\"$ref\":\"#/definitions/authV1RollbackPolicyRequest\"}}],\"responses\":{\"200\":{\"description\":\"A successful response.\",\"schema\":{\"$ref\":\"#/definitions/authV1PolicyRevision\"}},\"default\":{\"description\":\"An unexpected error response.\",\"schema\":{\"$ref\":\"#/definitions/runtimeError\"}}},\"summary\":\"Rollback Policy to a previous revision\",\"tags\":[\"RuleService\"]}},\"/v1/rules/{uuid}\":{\"delete\":{\"operationId\":\"RuleService_DeleteRule\",\"parameters\":[{\"in\":\"path\",\"name\":\"uuid\",\"required\":true,\"type\":\"string\"}],\"responses\":{\"200\":{\"description\":\"A successful response.\",\"schema\":{\"properties\":{}}},\"default\":{\"description\":\"An unexpected error response.\",\"schema\":{\"$ref\":\"#/definitions/runtimeError\"}}},\"summary\":\"Delete Rule object request\",\"tags\":[\"RuleService\"]},\"get\":{\"operationId\":\"RuleService_GetRule\",\"parameters\":[{\"in\":\"path\",\"name\":\"uuid\",\"required\":true,\"type\":\"string\"}],\"responses\":{\"200\":{\"description\":\"A successful response.\",\"schema\":{\"$ref\":\"#/definitions/authV1Rule\"}},\"default\":{\"description\":\"An unexpected error response.\",\"schema\":{\"$ref\":\"#/definitions/runtimeError\"}}},\"summary\":\"Get Rule\",\"tags\":[\"RuleService\"]},\"put\":{\"operationId\":\"RuleService_UpdateRule\",\"parameters\":[{\"in\":\"path\",\"name\":\"uuid\",\"required\":true,\"type\":\"string\"},{\"in\":\"body\",\"name\":\"body\",\"required\":true,\"schema\":{\"$ref\":\"#/definitions/authV1UpdateRuleRequest\"}}],\"responses\":{\"200\":{\"description\":\"A successful response.\",\"schema\":{\"$ref\":\"#/definitions/authV1Rule\"}},\"default\":{\"description\":\"An unexpected error response.\",\"schema\":{\"$ref\":\"#/definitions/runtimeError\"}}},\"summary\":\"Update Rule object request\",\"tags\":[\"RuleService\"]}}}"
+const rules_definitions = "{\"authV1CreateRuleRequest\":{\"properties\":{\"action\":{\"type\":\"string\"},\"condition\":{\"type\":\"string\"},\"domain\":{\"type\":\"string\"},\"effect\":{\"$ref\":\"#/definitions/authV1Effect\"},\"object\":{\"type\":\"string\"},\"object_uuid\":{\"type\":\"string\"},\"resource\":{\"type\":\"string\"},\"resource_uuid\":{\"type\":\"string\"},\"role\":{\"type\":\"string\"},\"shadow\":{\"type\":\"boolean\"}},\"type\":\"object\"},\"authV1DiffPolicyRevisionsResponse\":{\"properties\":{\"added\":{\"items\":{\"type\":\"string\"},\"type\":\"array\"},\"removed\":{\"items\":{\"type\":\"string\"},\"type\":\"array\"}},\"type\":\"object\"},\"authV1Effect\":{\"default\":\"DENY\",\"enum\":[\"DENY\",\"ALLOW\"],\"type\":\"string\"},\"authV1ExportPolicyResponse\":{\"properties\":{\"content\":{\"type\":\"string\"},\"format\":{\"$ref\":\"#/definitions/authV1PolicyFormat\"}},\"type\":\"object\"},\"authV1ImportPolicyRequest\":{\"properties\":{\"content\":{\"type\":\"string\"},\"dry_run\":{\"type\":\"boolean\"},\"format\":{\"$ref\":\"#/definitions/authV1PolicyFormat\"},\"prune\":{\"type\":\"boolean\"}},\"type\":\"object\"},\"authV1ImportPolicyResponse\":{\"properties\":{\"added\":{\"items\":{\"type\":\"string\"},\"type\":\"array\"},\"applied\":{\"type\":\"boolean\"},\"created_domains\":{\"items\":{\"type\":\"string\"},\"type\":\"array\"},\"created_roles\":{\"items\":{\"type\":\"string\"},\"type\":\"array\"},\"removed\":{\"items\":{\"type\":\"string\"},\"type\":\"array\"}},\"type\":\"object\"},\"authV1LintFinding\":{\"properties\":{\"code\":{\"type\":\"string\"},\"message\":{\"type\":\"string\"},\"rules\":{\"items\":{\"type\":\"string\"},\"type\":\"array\"},\"severity\":{\"$ref\":\"#/definitions/authV1LintSeverity\"}},\"type\":\"object\"},\"authV1LintRulesResponse\":{\"properties\":{\"findings\":{\"items\":{\"$ref\":\"#/definitions/authV1LintFinding\"},\"type\":\"array\"}},\"type\":\"object\"},\"authV1LintSeverity\":{\"default\":\"INFO\",\"enum\":[\"INFO\",\"WARNING\",\"ERROR\"],\"type\":\"string\"},\"authV1ListPolicyRevisionsResponse\":{\"properties\":{\"limit\":{\"format\":\"int64\",\"type\":\"string\"},\"offset\":{\"format\":\"int64\",\"type\":\"string\"},\"revisions\":{\"items\":{\"$ref\":\"#/definitions/authV1PolicyRevision\"},\"type\":\"array\"},\"total_count\":{\"format\":\"int64\",\"type\":\"string\"}},\"type\":\"object\"},\"authV1ListRulesResponse\":{\"properties\":{\"limit\":{\"format\":\"int64\",\"type\":\"string\"},\"offset\":{\"format\":\"int64\",\"type\":\"string\"},\"rules\":{\"items\":{\"$ref\":\"#/definitions/authV1Rule\"},\"type\":\"array\"},\"total_count\":{\"format\":\"int64\",\"type\":\"string\"}},\"type\":\"object\"},\"authV1PolicyFormat\":{\"default\":\"CSV\",\"enum\":[\"CSV\",\"YAML\"],\"type\":\"string\"},\"authV1PolicyRevision\":{\"properties\":{\"created_at\":{\"format\":\"date-time\",\"type\":\"string\"},\"number\":{\"format\":\"int64\",\"type\":\"string\"},\"reason\":{\"type\":\"string\"},\"uuid\":{\"type\":\"string\"}},\"type\":\"object\"},\"authV1PromoteRulesRequest\":{\"properties\":{\"uuids\":{\"items\":{\"type\":\"string\"},\"type\":\"array\"}},\"type\":\"object\"},\"authV1PromoteRulesResponse\":{\"properties\":{\"rules\":{\"items\":{\"$ref\":\"#/definitions/authV1Rule\"},\"type\":\"array\"}},\"type\":\"object\"},\"authV1RollbackPolicyRequest\":{\"properties\":{\"number\":{\"format\":\"int64\",\"type\":\"string\"}},\"type\":\"object\"},\"authV1Rule\":{\"properties\":{\"action\":{\"type\":\"string\"},\"app\":{\"title\":\"app is the name of the app of the referenced resource or object\",\"type\":\"string\"},\"bundle\":{\"title\":\"bundle is the name of the permission bundle the rule is generated from, such rules\\nchange with the bundle and cannot be edited on their own\",\"type\":\"string\"},\"condition\":{\"type\":\"string\"},\"created_at\":{\"format\":\"date-time\",\"type\":\"string\"},\"domain\":{\"type\":\"string\"},\"effect\":{\"$ref\":\"#/definitions/authV1Effect\"},\"object\":{\"type\":\"string\"},\"object_uuid\":{\"type\":\"string\"},\"resource\":{\"type\":\"string\"},\"resource_uuid\":{\"title\":\"resource_uuid and object_uuid are the app resource and object the rule references,\\nthe resource and object of the rule follow their names\",\"type\":\"string\"},\"role\":{\"type\":\"string\"},\"shadow\":{\"type\":\"boolean\"},\"updated_at\":{\"format\":\"date-time\",\"type\":\"string\"},\"uuid\":{\"type\":\"string\"}},\"type\":\"object\"},\"authV1UpdateRuleRequest\":{\"properties\":{\"action\":{\"type\":\"string\"},\"condition\":{\"type\":\"string\"},\"domain\":{\"type\":\"string\"},\"effect\":{\"$ref\":\"#/definitions/authV1Effect\"},\"object\":{\"type\":\"string\"},\"object_uuid\":{\"type\":\"string\"},\"resource\":{\"type\":\"string\"},\"resource_uuid\":{\"type\":\"string\"},\"role\":{\"type\":\"string\"},\"shadow\":{\"type\":\"boolean\"},\"uuid\":{\"type\":\"string\"}},\"type\":\"object\"},\"protobufAny\":{\"properties\":{\"type_url\":{\"type\":\"string\"},\"value\":{\"format\":\"byte\",\"type\":\"string\"}},\"type\":\"object\"},\"runtimeError\":{\"properties\":{\"code\":{\"format\":\"int32\",\"type\":\"integer\"},\"details\":{\"items\":{\"$ref\":\"#/definitions/protobufAny\"},\"type\":\"array\"},\"error\":{\"type\":\"string\"},\"message\":{\"type\":\"string\"}},\"type\":\"object\"}}"
 
 func init() {
 	var (
@@ -28,6 +28,20 @@ type RuleServiceClient interface {
 	UpdateRule(ctx context.Context, in *UpdateRuleRequest, opts ...grpc.CallOption) (*Rule, error)
 	// Delete Rule object request
 	DeleteRule(ctx context.Context, in *DeleteRuleRequest, opts ...grpc.CallOption) (*empty.Empty, error)
+	// Lint Rules and report conflicting, shadowed and orphaned rules
+	LintRules(ctx context.Context, in *LintRulesRequest, opts ...grpc.CallOption) (*LintRulesResponse, error)
+	// Export Policy as casbin csv or yaml
+	ExportPolicy(ctx context.Context, in *ExportPolicyRequest, opts ...grpc.CallOption) (*ExportPolicyResponse, error)
+	// Import Policy from casbin csv or yaml
+	ImportPolicy(ctx context.Context, in *ImportPolicyRequest, opts ...grpc.CallOption) (*ImportPolicyResponse, error)
+	// List Policy Revisions
+	ListPolicyRevisions(ctx context.Context, in *ListPolicyRevisionsRequest, opts ...grpc.CallOption) (*ListPolicyRevisionsResponse, error)
+	// Diff two Policy Revisions
+	DiffPolicyRevisions(ctx context.Context, in *DiffPolicyRevisionsRequest, opts ...grpc.CallOption) (*DiffPolicyRevisionsResponse, error)
+	// Rollback Policy to a previous revision
+	RollbackPolicy(ctx context.Context, in *RollbackPolicyRequest, opts ...grpc.CallOption) (*PolicyRevision, error)
+	// Promote shadow Rules to live rules
+	PromoteRules(ctx context.Context, in *PromoteRulesRequest, opts ...grpc.CallOption) (*PromoteRulesResponse, error)
 }
 
 type ruleServiceClient struct {
@@ -83,6 +97,69 @@ func (c *ruleServiceClient) DeleteRule(ctx context.Context, in *DeleteRuleReques
 	return out, nil
 }
 
+func (c *ruleServiceClient) LintRules(ctx context.Context, in *LintRulesRequest, opts ...grpc.CallOption) (*LintRulesResponse, error) {
+	out := new(LintRulesResponse)
+	err := c.cc.Invoke(ctx, "/authV1.RuleService/LintRules", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *ruleServiceClient) ExportPolicy(ctx context.Context, in *ExportPolicyRequest, opts ...grpc.CallOption) (*ExportPolicyResponse, error) {
+	out := new(ExportPolicyResponse)
+	err := c.cc.Invoke(ctx, "/authV1.RuleService/ExportPolicy", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *ruleServiceClient) ImportPolicy(ctx context.Context, in *ImportPolicyRequest, opts ...grpc.CallOption) (*ImportPolicyResponse, error) {
+	out := new(ImportPolicyResponse)
+	err := c.cc.Invoke(ctx, "/authV1.RuleService/ImportPolicy", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *ruleServiceClient) ListPolicyRevisions(ctx context.Context, in *ListPolicyRevisionsRequest, opts ...grpc.CallOption) (*ListPolicyRevisionsResponse, error) {
+	out := new(ListPolicyRevisionsResponse)
+	err := c.cc.Invoke(ctx, "/authV1.RuleService/ListPolicyRevisions", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *ruleServiceClient) DiffPolicyRevisions(ctx context.Context, in *DiffPolicyRevisionsRequest, opts ...grpc.CallOption) (*DiffPolicyRevisionsResponse, error) {
+	out := new(DiffPolicyRevisionsResponse)
+	err := c.cc.Invoke(ctx, "/authV1.RuleService/DiffPolicyRevisions", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *ruleServiceClient) RollbackPolicy(ctx context.Context, in *RollbackPolicyRequest, opts ...grpc.CallOption) (*PolicyRevision, error) {
+	out := new(PolicyRevision)
+	err := c.cc.Invoke(ctx, "/authV1.RuleService/RollbackPolicy", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *ruleServiceClient) PromoteRules(ctx context.Context, in *PromoteRulesRequest, opts ...grpc.CallOption) (*PromoteRulesResponse, error) {
+	out := new(PromoteRulesResponse)
+	err := c.cc.Invoke(ctx, "/authV1.RuleService/PromoteRules", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // RuleServiceServer is the server API for RuleService service.
 // All implementations must embed UnimplementedRuleServiceServer
 // for forward compatibility
@@ -97,6 +174,20 @@ type RuleServiceServer interface {
 	UpdateRule(context.Context, *UpdateRuleRequest) (*Rule, error)
 	// Delete Rule object request
 	DeleteRule(context.Context, *DeleteRuleRequest) (*empty.Empty, error)
+	// Lint Rules and report conflicting, shadowed and orphaned rules
+	LintRules(context.Context, *LintRulesRequest) (*LintRulesResponse, error)
+	// Export Policy as casbin csv or yaml
+	ExportPolicy(context.Context, *ExportPolicyRequest) (*ExportPolicyResponse, error)
+	// Import Policy from casbin csv or yaml
+	ImportPolicy(context.Context, *ImportPolicyRequest) (*ImportPolicyResponse, error)
+	// List Policy Revisions
+	ListPolicyRevisions(context.Context, *ListPolicyRevisionsRequest) (*ListPolicyRevisionsResponse, error)
+	// Diff two Policy Revisions
+	DiffPolicyRevisions(context.Context, *DiffPolicyRevisionsRequest) (*DiffPolicyRevisionsResponse, error)
+	// Rollback Policy to a previous revision
+	RollbackPolicy(context.Context, *RollbackPolicyRequest) (*PolicyRevision, error)
+	// Promote shadow Rules to live rules
+	PromoteRules(context.Context, *PromoteRulesRequest) (*PromoteRulesResponse, error)
 	mustEmbedUnimplementedRuleServiceServer()
 }
 
@@ -119,6 +210,27 @@ func (UnimplementedRuleServiceServer) UpdateRule(context.Context, *UpdateRuleReq
 func (UnimplementedRuleServiceServer) DeleteRule(context.Context, *DeleteRuleRequest) (*empty.Empty, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method DeleteRule not implemented")
 }
+func (UnimplementedRuleServiceServer) LintRules(context.Context, *LintRulesRequest) (*LintRulesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method LintRules not implemented")
+}
+func (UnimplementedRuleServiceServer) ExportPolicy(context.Context, *ExportPolicyRequest) (*ExportPolicyResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ExportPolicy not implemented")
+}
+func (UnimplementedRuleServiceServer) ImportPolicy(context.Context, *ImportPolicyRequest) (*ImportPolicyResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ImportPolicy not implemented")
+}
+func (UnimplementedRuleServiceServer) ListPolicyRevisions(context.Context, *ListPolicyRevisionsRequest) (*ListPolicyRevisionsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListPolicyRevisions not implemented")
+}
+func (UnimplementedRuleServiceServer) DiffPolicyRevisions(context.Context, *DiffPolicyRevisionsRequest) (*DiffPolicyRevisionsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DiffPolicyRevisions not implemented")
+}
+func (UnimplementedRuleServiceServer) RollbackPolicy(context.Context, *RollbackPolicyRequest) (*PolicyRevision, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RollbackPolicy not implemented")
+}
+func (UnimplementedRuleServiceServer) PromoteRules(context.Context, *PromoteRulesRequest) (*PromoteRulesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method PromoteRules not implemented")
+}
 func (UnimplementedRuleServiceServer) mustEmbedUnimplementedRuleServiceServer() {}
 
 // UnsafeRuleServiceServer may be embedded to opt out of forward compatibility for this service.
@@ -222,6 +334,132 @@ func _RuleService_DeleteRule_Handler(srv interface{}, ctx context.Context, dec f
 	return interceptor(ctx, in, info, handler)
 }
 
+func _RuleService_LintRules_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(LintRulesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RuleServiceServer).LintRules(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/authV1.RuleService/LintRules",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RuleServiceServer).LintRules(ctx, req.(*LintRulesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _RuleService_ExportPolicy_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ExportPolicyRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RuleServiceServer).ExportPolicy(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/authV1.RuleService/ExportPolicy",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RuleServiceServer).ExportPolicy(ctx, req.(*ExportPolicyRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _RuleService_ImportPolicy_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ImportPolicyRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RuleServiceServer).ImportPolicy(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/authV1.RuleService/ImportPolicy",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RuleServiceServer).ImportPolicy(ctx, req.(*ImportPolicyRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _RuleService_ListPolicyRevisions_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListPolicyRevisionsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RuleServiceServer).ListPolicyRevisions(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/authV1.RuleService/ListPolicyRevisions",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RuleServiceServer).ListPolicyRevisions(ctx, req.(*ListPolicyRevisionsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _RuleService_DiffPolicyRevisions_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DiffPolicyRevisionsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RuleServiceServer).DiffPolicyRevisions(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/authV1.RuleService/DiffPolicyRevisions",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RuleServiceServer).DiffPolicyRevisions(ctx, req.(*DiffPolicyRevisionsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _RuleService_RollbackPolicy_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RollbackPolicyRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RuleServiceServer).RollbackPolicy(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/authV1.RuleService/RollbackPolicy",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RuleServiceServer).RollbackPolicy(ctx, req.(*RollbackPolicyRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _RuleService_PromoteRules_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(PromoteRulesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RuleServiceServer).PromoteRules(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/authV1.RuleService/PromoteRules",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RuleServiceServer).PromoteRules(ctx, req.(*PromoteRulesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 var _RuleService_serviceDesc = grpc.ServiceDesc{
 	ServiceName: "authV1.RuleService",
 	HandlerType: (*RuleServiceServer)(nil),
@@ -246,6 +484,34 @@ var _RuleService_serviceDesc = grpc.ServiceDesc{
 			MethodName: "DeleteRule",
 			Handler:    _RuleService_DeleteRule_Handler,
 		},
+		{
+			MethodName: "LintRules",
+			Handler:    _RuleService_LintRules_Handler,
+		},
+		{
+			MethodName: "ExportPolicy",
+			Handler:    _RuleService_ExportPolicy_Handler,
+		},
+		{
+			MethodName: "ImportPolicy",
+			Handler:    _RuleService_ImportPolicy_Handler,
+		},
+		{
+			MethodName: "ListPolicyRevisions",
+			Handler:    _RuleService_ListPolicyRevisions_Handler,
+		},
+		{
+			MethodName: "DiffPolicyRevisions",
+			Handler:    _RuleService_DiffPolicyRevisions_Handler,
+		},
+		{
+			MethodName: "RollbackPolicy",
+			Handler:    _RuleService_RollbackPolicy_Handler,
+		},
+		{
+			MethodName: "PromoteRules",
+			Handler:    _RuleService_PromoteRules_Handler,
+		},
 	},
 	Streams:  []grpc.StreamDesc{},
 	Metadata: "api/proto/v1/rules.proto",
@@ -0,0 +1,99 @@
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+
+package auth
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+const _ = grpc.SupportPackageIsVersion7
+
+// ShadowDecisionServiceClient is the client API for ShadowDecisionService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+type ShadowDecisionServiceClient interface {
+	// List ShadowDecisions
+	ListShadowDecisions(ctx context.Context, in *ListShadowDecisionsRequest, opts ...grpc.CallOption) (*ListShadowDecisionsResponse, error)
+}
+
+type shadowDecisionServiceClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewShadowDecisionServiceClient(cc grpc.ClientConnInterface) ShadowDecisionServiceClient {
+	return &shadowDecisionServiceClient{cc}
+}
+
+func (c *shadowDecisionServiceClient) ListShadowDecisions(ctx context.Context, in *ListShadowDecisionsRequest, opts ...grpc.CallOption) (*ListShadowDecisionsResponse, error) {
+	out := new(ListShadowDecisionsResponse)
+	err := c.cc.Invoke(ctx, "/authV1.ShadowDecisionService/ListShadowDecisions", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// ShadowDecisionServiceServer is the server API for ShadowDecisionService service.
+// All implementations must embed UnimplementedShadowDecisionServiceServer
+// for forward compatibility
+type ShadowDecisionServiceServer interface {
+	// List ShadowDecisions
+	ListShadowDecisions(context.Context, *ListShadowDecisionsRequest) (*ListShadowDecisionsResponse, error)
+	mustEmbedUnimplementedShadowDecisionServiceServer()
+}
+
+// UnimplementedShadowDecisionServiceServer must be embedded to have forward compatible implementations.
+type UnimplementedShadowDecisionServiceServer struct {
+}
+
+func (UnimplementedShadowDecisionServiceServer) ListShadowDecisions(context.Context, *ListShadowDecisionsRequest) (*ListShadowDecisionsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListShadowDecisions not implemented")
+}
+func (UnimplementedShadowDecisionServiceServer) mustEmbedUnimplementedShadowDecisionServiceServer() {}
+
+// UnsafeShadowDecisionServiceServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to ShadowDecisionServiceServer will
+// result in compilation errors.
+type UnsafeShadowDecisionServiceServer interface {
+	mustEmbedUnimplementedShadowDecisionServiceServer()
+}
+
+func RegisterShadowDecisionServiceServer(s *grpc.Server, srv ShadowDecisionServiceServer) {
+	s.RegisterService(&_ShadowDecisionService_serviceDesc, srv)
+}
+
+func _ShadowDecisionService_ListShadowDecisions_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListShadowDecisionsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ShadowDecisionServiceServer).ListShadowDecisions(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/authV1.ShadowDecisionService/ListShadowDecisions",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ShadowDecisionServiceServer).ListShadowDecisions(ctx, req.(*ListShadowDecisionsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+var _ShadowDecisionService_serviceDesc = grpc.ServiceDesc{
+	ServiceName: "authV1.ShadowDecisionService",
+	HandlerType: (*ShadowDecisionServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "ListShadowDecisions",
+			Handler:    _ShadowDecisionService_ListShadowDecisions_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "api/proto/v1/shadow_decisions.proto",
+}
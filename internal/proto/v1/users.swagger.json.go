@@ -7,8 +7,8 @@ import (
 	"github.com/golang-tire/pkg/grpcgw"
 )
 
-const users_paths = "{\"/v1/users\":{\"get\":{\"operationId\":\"UserService_ListUsers\",\"parameters\":[{\"format\":\"int64\",\"in\":\"query\",\"name\":\"limit\",\"required\":false,\"type\":\"string\"},{\"format\":\"int64\",\"in\":\"query\",\"name\":\"offset\",\"required\":false,\"type\":\"string\"},{\"in\":\"query\",\"name\":\"query\",\"required\":false,\"type\":\"string\"}],\"responses\":{\"200\":{\"description\":\"A successful response.\",\"schema\":{\"$ref\":\"#/definitions/authV1ListUsersResponse\"}},\"default\":{\"description\":\"An unexpected error response.\",\"schema\":{\"$ref\":\"#/definitions/runtimeError\"}}},\"summary\":\"List Users\",\"tags\":[\"UserService\"]},\"post\":{\"operationId\":\"UserService_CreateUser\",\"parameters\":[{\"in\":\"body\",\"name\":\"body\",\"required\":true,\"schema\":{\"$ref\":\"#/definitions/authV1CreateUserRequest\"}}],\"responses\":{\"200\":{\"description\":\"A successful response.\",\"schema\":{\"$ref\":\"#/definitions/authV1User\"}},\"default\":{\"description\":\"An unexpected error response.\",\"schema\":{\"$ref\":\"#/definitions/runtimeError\"}}},\"summary\":\"Create User object request\",\"tags\":[\"UserService\"]}},\"/v1/users/{uuid}\":{\"delete\":{\"operationId\":\"UserService_DeleteUser\",\"parameters\":[{\"in\":\"path\",\"name\":\"uuid\",\"required\":true,\"type\":\"string\"}],\"responses\":{\"200\":{\"description\":\"A successful response.\",\"schema\":{\"properties\":{}}},\"default\":{\"description\":\"An unexpected error response.\",\"schema\":{\"$ref\":\"#/definitions/runtimeError\"}}},\"summary\":\"Delete User object request\",\"tags\":[\"UserService\"]},\"get\":{\"operationId\":\"UserService_GetUser\",\"parameters\":[{\"in\":\"path\",\"name\":\"uuid\",\"required\":true,\"type\":\"string\"}],\"responses\":{\"200\":{\"description\":\"A successful response.\",\"schema\":{\"$ref\":\"#/definitions/authV1User\"}},\"default\":{\"description\":\"An unexpected error response.\",\"schema\":{\"$ref\":\"#/definitions/runtimeError\"}}},\"summary\":\"Get User\",\"tags\":[\"UserService\"]},\"put\":{\"operationId\":\"UserService_UpdateUser\",\"parameters\":[{\"in\":\"path\",\"name\":\"uuid\",\"required\":true,\"type\":\"string\"},{\"in\":\"body\",\"name\":\"body\",\"required\":true,\"schema\":{\"$ref\":\"#/definitions/authV1UpdateUserRequest\"}}],\"responses\":{\"200\":{\"description\":\"A successful response.\",\"schema\":{\"$ref\":\"#/definitions/authV1User\"}},\"default\":{\"description\":\"An unexpected error response.\",\"schema\":{\"$ref\":\"#/definitions/runtimeError\"}}},\"summary\":\"Update User object request\",\"tags\":[\"UserService\"]}},\"/v1/users/{uuid}/roles\":{\"post\":{\"operationId\":\"UserService_AddUserRole\",\"parameters\":[{\"in\":\"path\",\"name\":\"uuid\",\"required\":true,\"type\":\"string\"},{\"in\":\"body\",\"name\":\"body\",\"required\":true,\"schema\":{\"$ref\":\"#/definitions/authV1AddUserRoleRequest\"}}],\"responses\":{\"200\":{\"description\":\"A successful response.\",\"schema\":{\"$ref\":\"#/definitions/authV1User\"}},\"default\":{\"description\":\"An unexpected error response.\",\"schema\":{\"$ref\":\"#/definitions/runtimeError\"}}},\"summary\":\"AddUserRole assign a role to a user\",\"tags\":[\"UserService\"]}},\"/v1/users/{uuid}/roles/{user_role_uuid}\":{\"delete\":{\"operationId\":\"UserService_DeleteUserRole\",\"parameters\":[{\"in\":\"path\",\"name\":\"uuid\",\"required\":true,\"type\":\"string\"},{\"in\":\"path\",\"name\":\"user_role_uuid\",\"required\":true,\"type\":\"string\"}],\"responses\":{\"200\":{\"description\":\"A successful response.\",\"schema\":{\"properties\":{}}},\"default\":{\"description\":\"An unexpected error response.\",\"schema\":{\"$ref\":\"#/definitions/runtimeError\"}}},\"summary\":\"DeleteUserRole remove a user role\",\"tags\":[\"UserService\"]},\"put\":{\"operationId\":\"UserService_UpdateUserRole\",\"parameters\":[{\"in\":\"path\",\"name\":\"uuid\",\"required\":true,\"type\":\"string\"},{\"in\":\"path\",\"name\":\"user_role_uuid\",\"required\":true,\"type\":\"string\"},{\"in\":\"body\",\"name\":\"body\",\"required\":true,\"schema\":{\"$ref\":\"#/definitions/authV1UpdateUserRoleRequest\"}}],\"responses\":{\"200\":{\"description\":\"A successful response.\",\"schema\":{\"$ref\":\"#/definitions/authV1User\"}},\"default\":{\"description\":\"An unexpected error response.\",\"schema\":{\"$ref\":\"#/definitions/runtimeError\"}}},\"summary\":\"UpdateUserRole update a user role\",\"tags\":[\"UserService\"]}}}"
-const users_definitions = "{\"authV1AddUserRoleRequest\":{\"properties\":{\"domain_uuid\":{\"type\":\"string\"},\"enable\":{\"type\":\"boolean\"},\"role_uuid\":{\"type\":\"string\"},\"uuid\":{\"type\":\"string\"}},\"type\":\"object\"},\"authV1CreateUserRequest\":{\"properties\":{\"avatar_url\":{\"type\":\"string\"},\"email\":{\"type\":\"string\"},\"enable\":{\"type\":\"boolean\"},\"firstname\":{\"type\":\"string\"},\"gender\":{\"type\":\"string\"},\"lastname\":{\"type\":\"string\"},\"password\":{\"type\":\"string\"},\"raw_data\":{\"type\":\"string\"},\"username\":{\"type\":\"string\"}},\"type\":\"object\"},\"authV1ListUsersResponse\":{\"properties\":{\"limit\":{\"format\":\"int64\",\"type\":\"string\"},\"offset\":{\"format\":\"int64\",\"type\":\"string\"},\"total_count\":{\"format\":\"int64\",\"type\":\"string\"},\"users\":{\"items\":{\"$ref\":\"#/definitions/authV1User\"},\"type\":\"array\"}},\"type\":\"object\"},\"authV1UpdateUserRequest\":{\"properties\":{\"avatar_url\":{\"type\":\"string\"},\"email\":{\"type\":\"string\"},\"enable\":{\"type\":\"boolean\"},\"firstname\":{\"type\":\"string\"},\"gender\":{\"type\":\"string\"},\"lastname\":{\"type\":\"string\"},\"password\":{\"type\":\"string\"},\"raw_data\":{\"type\":\"string\"},\"username\":{\"type\":\"string\"},\"uuid\":{\"type\":\"string\"}},\"type\":\"object\"},\"authV1UpdateUserRoleRequest\":{\"properties\":{\"domain_uuid\":{\"type\":\"string\"},\"enable\":{\"type\":\"boolean\"},\"role_uuid\":{\"type\":\"string\"},\"user_role_uuid\":{\"type\":\"string\"},\"uuid\":{\"type\":\"string\"}},\"type\":\"object\"},\"authV1User\":{\"properties\":{\"avatar_url\":{\"type\":\"string\"},\"created_at\":{\"format\":\"date-time\",\"type\":\"string\"},\"email\":{\"type\":\"string\"},\"enable\":{\"type\":\"boolean\"},\"firstname\":{\"type\":\"string\"},\"gender\":{\"type\":\"string\"},\"lastname\":{\"type\":\"string\"},\"password\":{\"type\":\"string\"},\"raw_data\":{\"type\":\"string\"},\"roles\":{\"items\":{\"$ref\":\"#/definitions/authV1UserRole\"},\"type\":\"array\"},\"updated_at\":{\"format\":\"date-time\",\"type\":\"string\"},\"username\":{\"type\":\"string\"},\"uuid\":{\"type\":\"string\"}},\"type\":\"object\"},\"authV1UserRole\":{\"properties\":{\"created_at\":{\"format\":\"date-time\",\"type\":\"string\"},\"domain\":{\"type\":\"string\"},\"enable\":{\"type\":\"boolean\"},\"role\":{\"type\":\"string\"},\"updated_at\":{\"format\":\"date-time\",\"type\":\"string\"},\"uuid\":{\"type\":\"string\"}},\"type\":\"object\"},\"protobufAny\":{\"properties\":{\"type_url\":{\"type\":\"string\"},\"value\":{\"format\":\"byte\",\"type\":\"string\"}},\"type\":\"object\"},\"runtimeError\":{\"properties\":{\"code\":{\"format\":\"int32\",\"type\":\"integer\"},\"details\":{\"items\":{\"$ref\":\"#/definitions/protobufAny\"},\"type\":\"array\"},\"error\":{\"type\":\"string\"},\"message\":{\"type\":\"string\"}},\"type\":\"object\"}}"
+const users_paths = "{\"/v1/users\":{\"get\":{\"operationId\":\"UserService_ListUsers\",\"parameters\":[{\"format\":\"int64\",\"in\":\"query\",\"name\":\"limit\",\"required\":false,\"type\":\"string\"},{\"format\":\"int64\",\"in\":\"query\",\"name\":\"offset\",\"required\":false,\"type\":\"string\"},{\"in\":\"query\",\"name\":\"query\",\"required\":false,\"type\":\"string\"}],\"responses\":{\"200\":{\"description\":\"A successful response.\",\"schema\":{\"$ref\":\"#/definitions/authV1ListUsersResponse\"}},\"default\":{\"description\":\"An unexpected error response.\",\"schema\":{\"$ref\":\"#/definitions/runtimeError\"}}},\"summary\":\"List Users\",\"tags\":[\"UserService\"]},\"post\":{\"operationId\":\"UserService_CreateUser\",\"parameters\":[{\"in\":\"body\",\"name\":\"body\",\"required\":true,\"schema\":{\"$ref\":\"#/definitions/authV1CreateUserRequest\"}}],\"responses\":{\"200\":{\"description\":\"A successful response.\",\"schema\":{\"$ref\":\"#/definitions/authV1User\"}},\"default\":{\"description\":\"An unexpected error response.\",\"schema\":{\"$ref\":\"#/definitions/runtimeError\"}}},\"summary\":\"Create User object request\",\"tags\":[\"UserService\"]}},\"/v1/users/roles/expiring\":{\"get\":{\"operationId\":\"UserService_ListExpiringUserRoles\",\"parameters\":[{\"format\":\"int64\",\"in\":\"query\",\"name\":\"limit\",\"required\":false,\"type\":\"string\"},{\"format\":\"int64\",\"in\":\"query\",\"name\":\"offset\",\"required\":false,\"type\":\"string\"},{\"description\":\"within is a duration like 72h, it defaults to users.expiringWithin.\",\"in\":\"query\",\"name\":\"within\",\"required\":false,\"type\":\"string\"}],\"responses\":{\"200\":{\"description\":\"A successful response.\",\"schema\":{\"$ref\":\"#/definitions/authV1ListExpiringUserRolesResponse\"}},\"default\":{\"description\":\"An unexpected error response.\",\"schema\":{\"$ref\":\"#/definitions/runtimeError\"}}},\"summary\":\"ListExpiringUserRoles list the user roles that expire soon\",\"tags\":[\"UserService\"]}},\"/v1/users/{uuid}\":{\"delete\":{\"operationId\":\"UserService_DeleteUser\",\"parameters\":[{\"in\":\"path\",\"name\":\"uuid\",\"required\":true,\"type\":\"string\"}],\"responses\":{\"200\":{\"description\":\"A successful response.\",\"schema\":{\"properties\":{}}},\"default\":{\"description\":\"An unexpected error response.\",\"schema\":{\"$ref\":\"#/definitions/runtimeError\"}}},\"summary\":\"Delete User object request\",\"tags\":[\"UserService\"]},\"get\":{\"operationId\":\"UserService_GetUser\",\"parameters\":[{\"in\":\"path\",\"name\":\"uuid\",\"required\":true,\"type\":\"string\"}],\"responses\":{\"200\":{\"description\":\"A successful response.\",\"schema\":{\"$ref\":\"#/definitions/authV1User\"}},\"default\":{\"description\":\"An unexpected error response.\",\"schema\":{\"$ref\":\"#/definitions/runtimeError\"}}},\"summary\":\"Get User\",\"tags\":[\"UserService\"]},\"put\":{\"operationId\":\"UserService_UpdateUser\",\"parameters\":[{\"in\":\"path\",\"name\":\"uuid\",\"required\":true,\"type\":\"string\"},{\"in\":\"body\",\"name\":\"body\",\"required\":true,\"schema\":{\"$ref\":\"#/definitions/authV1UpdateUserRequest\"}}],\"responses\":{\"200\":{\"description\":\"A successful response.\",\"schema\":{\"$ref\":\"#/definitions/authV1User\"}},\"default\":{\"description\":\"An unexpected error response.\",\"schema\":{\"$ref\":\"#/definitions/runtimeError\"}}},\"summary\":\"Update User object request\",\"tags\":[\"UserService\"]}},\"/v1/users/{uuid}/roles\":{\"post\":{\"operationId\":\"UserService_AddUserRole\",\"parameters\":[{\"in\":\"path\",\"name\":\"uuid\",\"required\":true,\"type\":\"string\"},{\"in\":\"body\",\"name\":\"body\",\"required\":true,\"schema\":{\"$ref\":\"#/definitions/authV1AddUserRoleRequest\"}}],\"responses\":{\"200\":{\"description\":\"A successful response.\",\"schema\":{\"$ref\":\"#/definitions/authV1User\"}},\"default\":{\"description\":\"An unexpected error response.\",\"schema\":{\"$ref\":\"#/definitions/runtimeError\"}}},\"summary\":\"AddUserRole assign a role to a user\",\"tags\":[\"UserService\"]}},\"/v1/users/{uuid}/roles/{user_role_uuid}\":{\"delete\":{\"operationId\":\"UserService_DeleteUserRole\",\"parameters\":[{\"in\":\"path\",\"name\":\"uuid\",\"required\":true,\"type\":\"string\"},{\"in\":\"path\",\"name\":\"user_role_uuid\",\"required\":true,\"type\":\"string\"}],\"responses\":{\"200\":{\"description\":\"A successful response.\",\"schema\":{\"properties\":{}}},\"default\":{\"description\":\"An unexpected error response.\",\"schema\":{\"$ref\":\"#/definitions/runtimeError\"}}},\"summary\":\"DeleteUserRole remove a user role\",\"tags\":[\"UserService\"]},\"put\":{\"operationId\":\"UserService_UpdateUserRole\",\"parameters\":[{\"in\":\"path\",\"name\":\"uuid\",\"required\":true,\"type\":\"string\"},{\"in\":\"path\",\"name\":\"user_role_uuid\",\"required\":true,\"type\":\"string\"},{\"in\":\"body\",\"name\":\"body\",\"required\":true,\"schema\":{\"$ref\":\"#/definitions/authV1UpdateUserRoleRequest\"}}],\"responses\":{\"200\":{\"description\":\"A successful response.\",\"schema\":{\"$ref\":\"#/definitions/authV1User\"}},\"default\":{\"description\":\"An unexpected error response.\",\"schema\":{\"$ref\":\"#/definitions/runtimeError\"}}},\"summary\":\"UpdateUserRole update a user role\",\"tags\":[\"UserService\"]}}}"
+const users_definitions = "{\"authV1AddUserRoleRequest\":{\"properties\":{\"domain_uuid\":{\"type\":\"string\"},\"enable\":{\"type\":\"boolean\"},\"expires_at\":{\"format\":\"date-time\",\"type\":\"string\"},\"role_uuid\":{\"type\":\"string\"},\"uuid\":{\"type\":\"string\"},\"valid_from\":{\"format\":\"date-time\",\"type\":\"string\"}},\"type\":\"object\"},\"authV1CreateUserRequest\":{\"properties\":{\"avatar_url\":{\"type\":\"string\"},\"email\":{\"type\":\"string\"},\"enable\":{\"type\":\"boolean\"},\"firstname\":{\"type\":\"string\"},\"gender\":{\"type\":\"string\"},\"lastname\":{\"type\":\"string\"},\"password\":{\"type\":\"string\"},\"raw_data\":{\"type\":\"string\"},\"username\":{\"type\":\"string\"}},\"type\":\"object\"},\"authV1ListExpiringUserRolesResponse\":{\"properties\":{\"limit\":{\"format\":\"int64\",\"type\":\"string\"},\"offset\":{\"format\":\"int64\",\"type\":\"string\"},\"total_count\":{\"format\":\"int64\",\"type\":\"string\"},\"user_roles\":{\"items\":{\"$ref\":\"#/definitions/authV1UserRole\"},\"type\":\"array\"}},\"type\":\"object\"},\"authV1ListUsersResponse\":{\"properties\":{\"limit\":{\"format\":\"int64\",\"type\":\"string\"},\"offset\":{\"format\":\"int64\",\"type\":\"string\"},\"total_count\":{\"format\":\"int64\",\"type\":\"string\"},\"users\":{\"items\":{\"$ref\":\"#/definitions/authV1User\"},\"type\":\"array\"}},\"type\":\"object\"},\"authV1UpdateUserRequest\":{\"properties\":{\"avatar_url\":{\"type\":\"string\"},\"email\":{\"type\":\"string\"},\"enable\":{\"type\":\"boolean\"},\"firstname\":{\"type\":\"string\"},\"gender\":{\"type\":\"string\"},\"lastname\":{\"type\":\"string\"},\"password\":{\"type\":\"string\"},\"raw_data\":{\"type\":\"string\"},\"username\":{\"type\":\"string\"},\"uuid\":{\"type\":\"string\"}},\"type\":\"object\"},\"authV1UpdateUserRoleRequest\":{\"properties\":{\"domain_uuid\":{\"type\":\"string\"},\"enable\":{\"type\":\"boolean\"},\"expires_at\":{\"format\":\"date-time\",\"type\":\"string\"},\"role_uuid\":{\"type\":\"string\"},\"user_role_uuid\":{\"type\":\"string\"},\"uuid\":{\"type\":\"string\"},\"valid_from\":{\"format\":\"date-time\",\"type\":\"string\"}},\"type\":\"object\"},\"authV1User\":{\"properties\":{\"avatar_url\":{\"type\":\"string\"},\"created_at\":{\"format\":\"date-time\",\"type\":\"string\"},\"email\":{\"type\":\"string\"},\"enable\":{\"type\":\"boolean\"},\"firstname\":{\"type\":\"string\"},\"gender\":{\"type\":\"string\"},\"lastname\":{\"type\":\"string\"},\"password\":{\"type\":\"string\"},\"raw_data\":{\"type\":\"string\"},\"roles\":{\"items\":{\"$ref\":\"#/definitions/authV1UserRole\"},\"type\":\"array\"},\"updated_at\":{\"format\":\"date-time\",\"type\":\"string\"},\"username\":{\"type\":\"string\"},\"uuid\":{\"type\":\"string\"}},\"type\":\"object\"},\"authV1UserRole\":{\"properties\":{\"created_at\":{\"format\":\"date-time\",\"type\":\"string\"},\"domain\":{\"type\":\"string\"},\"enable\":{\"type\":\"boolean\"},\"expires_at\":{\"format\":\"date-time\",\"type\":\"string\"},\"role\":{\"type\":\"string\"},\"updated_at\":{\"format\":\"date-time\",\"type\":\"string\"},\"user_uuid\":{\"type\":\"string\"},\"username\":{\"type\":\"string\"},\"uuid\":{\"type\":\"string\"},\"valid_from\":{\"format\":\"date-time\",\"type\":\"string\"}},\"type\":\"object\"},\"protobufAny\":{\"properties\":{\"type_url\":{\"type\":\"string\"},\"value\":{\"format\":\"byte\",\"type\":\"string\"}},\"type\":\"object\"},\"runtimeError\":{\"properties\":{\"code\":{\"format\":\"int32\",\"type\":\"integer\"},\"details\":{\"items\":{\"$ref\":\"#/definitions/protobufAny\"},\"type\":\"array\"},\"error\":{\"type\":\"string\"},\"message\":{\"type\":\"string\"}},\"type\":\"object\"}}"
 
 func init() {
 	var (
@@ -34,6 +34,8 @@ type UserServiceClient interface {
 	UpdateUserRole(ctx context.Context, in *UpdateUserRoleRequest, opts ...grpc.CallOption) (*User, error)
 	// DeleteUserRole remove a user role
 	DeleteUserRole(ctx context.Context, in *DeleteUserRoleRequest, opts ...grpc.CallOption) (*empty.Empty, error)
+	// ListExpiringUserRoles list the user roles that expire soon
+	ListExpiringUserRoles(ctx context.Context, in *ListExpiringUserRolesRequest, opts ...grpc.CallOption) (*ListExpiringUserRolesResponse, error)
 }
 
 type userServiceClient struct {
@@ -116,6 +118,15 @@ func (c *userServiceClient) DeleteUserRole(ctx context.Context, in *DeleteUserRo
 	return out, nil
 }
 
+func (c *userServiceClient) ListExpiringUserRoles(ctx context.Context, in *ListExpiringUserRolesRequest, opts ...grpc.CallOption) (*ListExpiringUserRolesResponse, error) {
+	out := new(ListExpiringUserRolesResponse)
+	err := c.cc.Invoke(ctx, "/authV1.UserService/ListExpiringUserRoles", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // UserServiceServer is the server API for UserService service.
 // All implementations must embed UnimplementedUserServiceServer
 // for forward compatibility
@@ -136,6 +147,8 @@ type UserServiceServer interface {
 	UpdateUserRole(context.Context, *UpdateUserRoleRequest) (*User, error)
 	// DeleteUserRole remove a user role
 	DeleteUserRole(context.Context, *DeleteUserRoleRequest) (*empty.Empty, error)
+	// ListExpiringUserRoles list the user roles that expire soon
+	ListExpiringUserRoles(context.Context, *ListExpiringUserRolesRequest) (*ListExpiringUserRolesResponse, error)
 	mustEmbedUnimplementedUserServiceServer()
 }
 
@@ -167,6 +180,9 @@ func (UnimplementedUserServiceServer) UpdateUserRole(context.Context, *UpdateUse
 func (UnimplementedUserServiceServer) DeleteUserRole(context.Context, *DeleteUserRoleRequest) (*empty.Empty, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method DeleteUserRole not implemented")
 }
+func (UnimplementedUserServiceServer) ListExpiringUserRoles(context.Context, *ListExpiringUserRolesRequest) (*ListExpiringUserRolesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListExpiringUserRoles not implemented")
+}
 func (UnimplementedUserServiceServer) mustEmbedUnimplementedUserServiceServer() {}
 
 // UnsafeUserServiceServer may be embedded to opt out of forward compatibility for this service.
@@ -324,6 +340,24 @@ func _UserService_DeleteUserRole_Handler(srv interface{}, ctx context.Context, d
 	return interceptor(ctx, in, info, handler)
 }
 
+func _UserService_ListExpiringUserRoles_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListExpiringUserRolesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(UserServiceServer).ListExpiringUserRoles(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/authV1.UserService/ListExpiringUserRoles",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(UserServiceServer).ListExpiringUserRoles(ctx, req.(*ListExpiringUserRolesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 var _UserService_serviceDesc = grpc.ServiceDesc{
 	ServiceName: "authV1.UserService",
 	HandlerType: (*UserServiceServer)(nil),
@@ -360,6 +394,10 @@ var _UserService_serviceDesc = grpc.ServiceDesc{
 			MethodName: "DeleteUserRole",
 			Handler:    _UserService_DeleteUserRole_Handler,
 		},
+		{
+			MethodName: "ListExpiringUserRoles",
+			Handler:    _UserService_ListExpiringUserRoles_Handler,
+		},
 	},
 	Streams:  []grpc.StreamDesc{},
 	Metadata: "api/proto/v1/users.proto",
@@ -0,0 +1,114 @@
+package relations
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/golang-tire/auth/internal/pkg/helpers"
+	auth "github.com/golang-tire/auth/internal/proto/v1"
+	"github.com/golang-tire/pkg/grpcgw"
+	"github.com/golang/protobuf/ptypes/empty"
+	"github.com/grpc-ecosystem/grpc-gateway/v2/runtime"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+type API interface {
+	grpcgw.Controller
+}
+
+type api struct {
+	service Service
+	auth.RelationServiceServer
+}
+
+func (a api) InitRest(ctx context.Context, conn *grpc.ClientConn, mux *runtime.ServeMux, httpMux *http.ServeMux) {
+	cl := auth.NewRelationServiceClient(conn)
+	_ = auth.RegisterRelationServiceHandlerClient(ctx, mux, cl)
+}
+
+func (a api) InitGrpc(ctx context.Context, server *grpc.Server) {
+	auth.RegisterRelationServiceServer(server, a)
+}
+
+func (a api) WriteRelationTuples(ctx context.Context, request *auth.WriteRelationTuplesRequest) (*auth.WriteRelationTuplesResponse, error) {
+	res, err := a.service.Write(ctx, request)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+	return res, err
+}
+
+func (a api) DeleteRelationTuples(ctx context.Context, request *auth.DeleteRelationTuplesRequest) (*auth.DeleteRelationTuplesResponse, error) {
+	res, err := a.service.Delete(ctx, request)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+	return res, err
+}
+
+func (a api) ListRelationTuples(ctx context.Context, request *auth.ListRelationTuplesRequest) (*auth.ListRelationTuplesResponse, error) {
+	offset, limit := helpers.GetOffsetAndLimit(request.Offset, request.Limit)
+	res, err := a.service.Query(ctx, request, offset, limit)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+	return res, err
+}
+
+func (a api) CheckRelation(ctx context.Context, request *auth.CheckRelationRequest) (*auth.CheckRelationResponse, error) {
+	res, err := a.service.Check(ctx, request)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+	return res, err
+}
+
+func (a api) ExpandRelation(ctx context.Context, request *auth.ExpandRelationRequest) (*auth.ExpandRelationResponse, error) {
+	res, err := a.service.Expand(ctx, request)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+	return res, err
+}
+
+func (a api) ListRelationObjects(ctx context.Context, request *auth.ListRelationObjectsRequest) (*auth.ListRelationObjectsResponse, error) {
+	offset, limit := helpers.GetOffsetAndLimit(request.Offset, request.Limit)
+	res, err := a.service.ListObjects(ctx, request, offset, limit)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+	return res, err
+}
+
+func (a api) ListRelationDefinitions(ctx context.Context, request *auth.ListRelationDefinitionsRequest) (*auth.ListRelationDefinitionsResponse, error) {
+	offset, limit := helpers.GetOffsetAndLimit(request.Offset, request.Limit)
+	res, err := a.service.QueryDefinitions(ctx, request.App, offset, limit)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+	return res, err
+}
+
+func (a api) CreateRelationDefinition(ctx context.Context, request *auth.CreateRelationDefinitionRequest) (*auth.RelationDefinition, error) {
+	res, err := a.service.CreateDefinition(ctx, request)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+	return res, err
+}
+
+func (a api) DeleteRelationDefinition(ctx context.Context, request *auth.DeleteRelationDefinitionRequest) (*empty.Empty, error) {
+	_, err := a.service.DeleteDefinition(ctx, request.Uuid)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+	return &empty.Empty{}, nil
+}
+
+func New(srv Service) API {
+	s := api{service: srv}
+	grpcgw.RegisterController(s)
+	return s
+}
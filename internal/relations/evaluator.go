@@ -0,0 +1,304 @@
+package relations
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/golang-tire/auth/internal/entity"
+	auth "github.com/golang-tire/auth/internal/proto/v1"
+)
+
+// evaluator computes relations from the stored tuples and the relation rewrites.
+type evaluator struct {
+	ctx         context.Context
+	repo        Repository
+	definitions map[string][]entity.RelationDefinition
+	// visiting holds the object relations on the current path, they cut cycles
+	visiting map[string]bool
+}
+
+func (s service) newEvaluator(ctx context.Context) *evaluator {
+	return &evaluator{
+		ctx:         ctx,
+		repo:        s.repo,
+		definitions: map[string][]entity.RelationDefinition{},
+		visiting:    map[string]bool{},
+	}
+}
+
+// rewrites returns the included relations of the namespace relation.
+func (e *evaluator) rewrites(namespace, relation string) ([]string, error) {
+	definitions, ok := e.definitions[namespace]
+	if !ok {
+		var err error
+		definitions, err = e.repo.Definitions(e.ctx, namespace)
+		if err != nil {
+			return nil, err
+		}
+		e.definitions[namespace] = definitions
+	}
+	for _, d := range definitions {
+		if d.Relation == relation {
+			return d.RewriteList(), nil
+		}
+	}
+	return nil, nil
+}
+
+// enter marks the object relation as visited, it returns false when it is already on the path.
+func (e *evaluator) enter(namespace, id, relation string, depth int) (bool, error) {
+	if depth > maxDepth.Int() {
+		return false, fmt.Errorf("relation of %s:%s exceeds the max depth of %d", namespace, id, maxDepth.Int())
+	}
+	key := namespace + ":" + id + "#" + relation
+	if e.visiting[key] {
+		return false, nil
+	}
+	e.visiting[key] = true
+	return true, nil
+}
+
+func (e *evaluator) leave(namespace, id, relation string) {
+	delete(e.visiting, namespace+":"+id+"#"+relation)
+}
+
+// check reports whether subject has relation to namespace:id.
+func (e *evaluator) check(namespace, id, relation, subject string, depth int) (bool, error) {
+	ok, err := e.enter(namespace, id, relation, depth)
+	if err != nil || !ok {
+		return false, err
+	}
+	defer e.leave(namespace, id, relation)
+
+	tuples, err := e.repo.Tuples(e.ctx, namespace, id, relation)
+	if err != nil {
+		return false, err
+	}
+	for _, t := range tuples {
+		if t.Subject == subject {
+			return true, nil
+		}
+	}
+	for _, t := range tuples {
+		subjectNamespace, subjectID, subjectRelation, err := parseSubject(t.Subject)
+		if err != nil || subjectRelation == "" {
+			continue
+		}
+		if ok, err := e.check(subjectNamespace, subjectID, subjectRelation, subject, depth+1); err != nil || ok {
+			return ok, err
+		}
+	}
+
+	rewrites, err := e.rewrites(namespace, relation)
+	if err != nil {
+		return false, err
+	}
+	for _, rewrite := range rewrites {
+		tupleRelation, target, ok := splitRewrite(rewrite)
+		if !ok {
+			if ok, err := e.check(namespace, id, rewrite, subject, depth+1); err != nil || ok {
+				return ok, err
+			}
+			continue
+		}
+
+		related, err := e.relatedObjects(namespace, id, tupleRelation)
+		if err != nil {
+			return false, err
+		}
+		for _, object := range related {
+			if ok, err := e.check(object[0], object[1], target, subject, depth+1); err != nil || ok {
+				return ok, err
+			}
+		}
+	}
+	return false, nil
+}
+
+// expand returns the subject tree of relation of namespace:id.
+func (e *evaluator) expand(namespace, id, relation string, depth int) (*auth.RelationTree, error) {
+	tree := &auth.RelationTree{Object: namespace + ":" + id, Relation: relation}
+	ok, err := e.enter(namespace, id, relation, depth)
+	if err != nil || !ok {
+		return tree, err
+	}
+	defer e.leave(namespace, id, relation)
+
+	tuples, err := e.repo.Tuples(e.ctx, namespace, id, relation)
+	if err != nil {
+		return nil, err
+	}
+	for _, t := range tuples {
+		subjectNamespace, subjectID, subjectRelation, err := parseSubject(t.Subject)
+		if err != nil || subjectRelation == "" {
+			tree.Subjects = append(tree.Subjects, t.Subject)
+			continue
+		}
+		child, err := e.expand(subjectNamespace, subjectID, subjectRelation, depth+1)
+		if err != nil {
+			return nil, err
+		}
+		tree.Children = append(tree.Children, child)
+	}
+
+	rewrites, err := e.rewrites(namespace, relation)
+	if err != nil {
+		return nil, err
+	}
+	for _, rewrite := range rewrites {
+		tupleRelation, target, ok := splitRewrite(rewrite)
+		if !ok {
+			child, err := e.expand(namespace, id, rewrite, depth+1)
+			if err != nil {
+				return nil, err
+			}
+			tree.Children = append(tree.Children, child)
+			continue
+		}
+
+		related, err := e.relatedObjects(namespace, id, tupleRelation)
+		if err != nil {
+			return nil, err
+		}
+		for _, object := range related {
+			child, err := e.expand(object[0], object[1], target, depth+1)
+			if err != nil {
+				return nil, err
+			}
+			tree.Children = append(tree.Children, child)
+		}
+	}
+	return tree, nil
+}
+
+// node is a relation of an object.
+type node struct {
+	namespace, id, relation string
+}
+
+// arrow is a tuple_relation->relation rewrite of the relation of a namespace.
+type arrow struct {
+	namespace, tupleRelation, relation string
+}
+
+// objects returns the sorted ids of the objects of namespace subject has relation to. It is
+// check in reverse, it starts at the tuples of the subject and follows the subject sets,
+// rewrites and tuple rewrites to the relations they grant, so it only reads the tuples of
+// the subjects it reaches.
+func (e *evaluator) objects(namespace, relation, subject string) ([]string, error) {
+	definitions, err := e.repo.AllDefinitions(e.ctx)
+	if err != nil {
+		return nil, err
+	}
+	// includes maps a relation of a namespace to the relations including it, arrows maps a
+	// relation to the tuple rewrites following it
+	includes := map[string][]string{}
+	arrows := map[string][]arrow{}
+	for _, d := range definitions {
+		for _, rewrite := range d.RewriteList() {
+			tupleRelation, target, ok := splitRewrite(rewrite)
+			if !ok {
+				key := d.Namespace + "#" + rewrite
+				includes[key] = append(includes[key], d.Relation)
+				continue
+			}
+			arrows[target] = append(arrows[target], arrow{d.Namespace, tupleRelation, d.Relation})
+		}
+	}
+
+	found := map[string]bool{}
+	reached := map[node]bool{}
+	var pending []node
+	var reach func(n node)
+	reach = func(n node) {
+		if reached[n] {
+			return
+		}
+		reached[n] = true
+		pending = append(pending, n)
+		if n.namespace == namespace && n.relation == relation {
+			found[n.id] = true
+		}
+		for _, r := range includes[n.namespace+"#"+n.relation] {
+			reach(node{n.namespace, n.id, r})
+		}
+	}
+
+	// sets are the subjects whose tuples grant their relation, objects the objects related
+	// by tuple rewrites with the relations reached for them
+	sets := map[string]bool{subject: true}
+	objects := map[string][]string{}
+	for depth := 0; len(sets) > 0 || len(objects) > 0; depth++ {
+		if depth > maxDepth.Int() {
+			return nil, fmt.Errorf("relations of %s exceed the max depth of %d", subject, maxDepth.Int())
+		}
+		var subjects []string
+		for s := range sets {
+			subjects = append(subjects, s)
+		}
+		for o := range objects {
+			subjects = append(subjects, o)
+		}
+		tuples, err := e.repo.SubjectTuples(e.ctx, subjects)
+		if err != nil {
+			return nil, err
+		}
+		for _, t := range tuples {
+			if sets[t.Subject] {
+				reach(node{t.Namespace, t.ObjectID, t.Relation})
+			}
+			for _, target := range objects[t.Subject] {
+				for _, a := range arrows[target] {
+					if a.namespace == t.Namespace && a.tupleRelation == t.Relation {
+						reach(node{t.Namespace, t.ObjectID, a.relation})
+					}
+				}
+			}
+		}
+
+		sets, objects = map[string]bool{}, map[string][]string{}
+		for _, n := range pending {
+			object := n.namespace + ":" + n.id
+			sets[object+"#"+n.relation] = true
+			if len(arrows[n.relation]) > 0 {
+				objects[object] = append(objects[object], n.relation)
+			}
+		}
+		pending = nil
+	}
+
+	ids := make([]string, 0, len(found))
+	for id := range found {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+	return ids, nil
+}
+
+// relatedObjects returns the namespace and id of the objects that are subjects of the relation.
+func (e *evaluator) relatedObjects(namespace, id, relation string) ([][2]string, error) {
+	tuples, err := e.repo.Tuples(e.ctx, namespace, id, relation)
+	if err != nil {
+		return nil, err
+	}
+	var objects [][2]string
+	for _, t := range tuples {
+		subjectNamespace, subjectID, subjectRelation, err := parseSubject(t.Subject)
+		if err != nil || subjectNamespace == "" || subjectRelation != "" {
+			continue
+		}
+		objects = append(objects, [2]string{subjectNamespace, subjectID})
+	}
+	return objects, nil
+}
+
+// splitRewrite splits a tuple_relation->relation rewrite.
+func splitRewrite(rewrite string) (string, string, bool) {
+	parts := strings.SplitN(rewrite, tupleRewrite, 2)
+	if len(parts) != 2 {
+		return "", "", false
+	}
+	return parts[0], parts[1], true
+}
@@ -0,0 +1,238 @@
+package relations
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+
+	"github.com/golang-tire/auth/internal/pkg/db"
+
+	"github.com/golang-tire/auth/internal/entity"
+)
+
+// Repository encapsulates the logic to access relation tuples and definitions from the data source.
+type Repository interface {
+	// WriteTuples saves the tuples that do not exist yet and returns the new revision.
+	WriteTuples(ctx context.Context, tuples []entity.RelationTuple) (int64, error)
+	// DeleteTuples removes the tuples and returns the new revision.
+	DeleteTuples(ctx context.Context, tuples []entity.RelationTuple) (int64, error)
+	// Tuples returns the tuples of the object relation.
+	Tuples(ctx context.Context, namespace, objectID, relation string) ([]entity.RelationTuple, error)
+	// QueryTuples returns the tuples matching the non empty fields of filter with the given offset and limit.
+	QueryTuples(ctx context.Context, filter entity.RelationTuple, offset, limit int64) ([]entity.RelationTuple, int, error)
+	// SubjectTuples returns the tuples of the subjects.
+	SubjectTuples(ctx context.Context, subjects []string) ([]entity.RelationTuple, error)
+	// LatestRevision returns the revision of the last tuple change, 0 if there is none.
+	LatestRevision(ctx context.Context) (int64, error)
+
+	// GetDefinition returns the relation definition with the specified UUID.
+	GetDefinition(ctx context.Context, uuid string) (entity.RelationDefinition, error)
+	// Definitions returns the relation definitions of namespace.
+	Definitions(ctx context.Context, namespace string) ([]entity.RelationDefinition, error)
+	// AllDefinitions returns the relation definitions of every namespace.
+	AllDefinitions(ctx context.Context) ([]entity.RelationDefinition, error)
+	// QueryDefinitions returns the relation definitions of app with the given offset and limit, an empty app matches all.
+	QueryDefinitions(ctx context.Context, app string, offset, limit int64) ([]entity.RelationDefinition, int, error)
+	// CreateDefinition saves a new relation definition in the storage.
+	CreateDefinition(ctx context.Context, definition entity.RelationDefinition) (string, error)
+	// DeleteDefinition removes the relation definition from the storage.
+	DeleteDefinition(ctx context.Context, definition entity.RelationDefinition) error
+}
+
+// repository persists relation tuples in database
+type repository struct {
+	db *db.DB
+}
+
+// NewRepository creates a new relation repository
+func NewRepository(db *db.DB) Repository {
+	return repository{db}
+}
+
+// WriteTuples saves the missing tuples and a relation change in one transaction.
+func (r repository) WriteTuples(ctx context.Context, tuples []entity.RelationTuple) (int64, error) {
+	var revision int64
+	err := r.db.Transactional(ctx, func(ctx context.Context) error {
+		change, err := r.addChange(ctx, "write", len(tuples))
+		if err != nil {
+			return err
+		}
+		revision = change
+		for _, t := range tuples {
+			var count int64
+			res := r.db.With(ctx).Model(&entity.RelationTuple{}).
+				Where("namespace = ? AND object_id = ? AND relation = ? AND subject = ?", t.Namespace, t.ObjectID, t.Relation, t.Subject).
+				Count(&count)
+			if res.Error != nil {
+				return res.Error
+			}
+			if count > 0 {
+				continue
+			}
+			t.Revision = revision
+			if res := r.db.With(ctx).Create(&t); res.Error != nil {
+				return res.Error
+			}
+		}
+		return nil
+	})
+	return revision, err
+}
+
+// DeleteTuples removes the tuples and saves a relation change in one transaction.
+func (r repository) DeleteTuples(ctx context.Context, tuples []entity.RelationTuple) (int64, error) {
+	var revision int64
+	err := r.db.Transactional(ctx, func(ctx context.Context) error {
+		change, err := r.addChange(ctx, "delete", len(tuples))
+		if err != nil {
+			return err
+		}
+		revision = change
+		for _, t := range tuples {
+			res := r.db.With(ctx).
+				Where("namespace = ? AND object_id = ? AND relation = ? AND subject = ?", t.Namespace, t.ObjectID, t.Relation, t.Subject).
+				Delete(&entity.RelationTuple{})
+			if res.Error != nil {
+				return res.Error
+			}
+		}
+		return nil
+	})
+	return revision, err
+}
+
+func (r repository) addChange(ctx context.Context, operation string, tuples int) (int64, error) {
+	now := time.Now()
+	change := entity.RelationChange{Operation: operation, Tuples: tuples}
+	change.CreatedAt = now
+	change.UpdatedAt = now
+	res := r.db.With(ctx).Create(&change)
+	return int64(change.ID), res.Error
+}
+
+// Tuples returns the tuples of the object relation from the database.
+func (r repository) Tuples(ctx context.Context, namespace, objectID, relation string) ([]entity.RelationTuple, error) {
+	var _tuples []entity.RelationTuple
+	res := r.db.With(ctx).
+		Where("namespace = ? AND object_id = ? AND relation = ?", namespace, objectID, relation).
+		Order("id asc").
+		Find(&_tuples)
+	return _tuples, res.Error
+}
+
+// QueryTuples retrieves the matching tuples with the specified offset and limit from the database.
+func (r repository) QueryTuples(ctx context.Context, filter entity.RelationTuple, offset, limit int64) ([]entity.RelationTuple, int, error) {
+	var _tuples []entity.RelationTuple
+	var count int64
+
+	query := r.db.With(ctx).Model(&entity.RelationTuple{})
+	if filter.Namespace != "" {
+		query = query.Where("namespace = ?", filter.Namespace)
+	}
+	if filter.ObjectID != "" {
+		query = query.Where("object_id = ?", filter.ObjectID)
+	}
+	if filter.Relation != "" {
+		query = query.Where("relation = ?", filter.Relation)
+	}
+	if filter.Subject != "" {
+		query = query.Where("subject = ?", filter.Subject)
+	}
+	if res := query.Count(&count); res.Error != nil {
+		return nil, 0, res.Error
+	}
+
+	res := query.
+		Limit(int(limit)).
+		Offset(int(offset)).
+		Order("id asc").
+		Find(&_tuples)
+	return _tuples, int(count), res.Error
+}
+
+// SubjectTuples returns the tuples of the subjects from the database.
+func (r repository) SubjectTuples(ctx context.Context, subjects []string) ([]entity.RelationTuple, error) {
+	var _tuples []entity.RelationTuple
+	res := r.db.With(ctx).
+		Where("subject IN (?)", subjects).
+		Order("id asc").
+		Find(&_tuples)
+	return _tuples, res.Error
+}
+
+// LatestRevision returns the id of the last relation change in the database.
+func (r repository) LatestRevision(ctx context.Context) (int64, error) {
+	var revision int64
+	res := r.db.With(ctx).Model(&entity.RelationChange{}).Select("COALESCE(MAX(id), 0)").Scan(&revision)
+	return revision, res.Error
+}
+
+// GetDefinition reads the relation definition with the specified UUID from the database.
+func (r repository) GetDefinition(ctx context.Context, uuid string) (entity.RelationDefinition, error) {
+	var definition entity.RelationDefinition
+	res := r.db.With(ctx).Preload("App").Where("uuid = ?", uuid).First(&definition)
+	if res.Error != nil && res.Error == gorm.ErrRecordNotFound {
+		return entity.RelationDefinition{}, fmt.Errorf("relation definition with uuid `%s` not found", uuid)
+	}
+	return definition, res.Error
+}
+
+// Definitions returns the relation definitions of namespace from the database.
+func (r repository) Definitions(ctx context.Context, namespace string) ([]entity.RelationDefinition, error) {
+	var _definitions []entity.RelationDefinition
+	res := r.db.With(ctx).
+		Preload("App").
+		Where("namespace = ?", namespace).
+		Order("id asc").
+		Find(&_definitions)
+	return _definitions, res.Error
+}
+
+// AllDefinitions returns the relation definitions of every namespace from the database.
+func (r repository) AllDefinitions(ctx context.Context) ([]entity.RelationDefinition, error) {
+	var _definitions []entity.RelationDefinition
+	res := r.db.With(ctx).Order("id asc").Find(&_definitions)
+	return _definitions, res.Error
+}
+
+// QueryDefinitions retrieves the relation definitions with the specified offset and limit from the database.
+func (r repository) QueryDefinitions(ctx context.Context, app string, offset, limit int64) ([]entity.RelationDefinition, int, error) {
+	var _definitions []entity.RelationDefinition
+	var count int64
+
+	query := r.db.With(ctx).Model(&entity.RelationDefinition{})
+	if app != "" {
+		subQuery := r.db.With(ctx).Select("id").Where("name = ?", app).Table("apps")
+		query = query.Where("app_id IN (?)", subQuery)
+	}
+	if res := query.Count(&count); res.Error != nil {
+		return nil, 0, res.Error
+	}
+
+	res := query.
+		Limit(int(limit)).
+		Offset(int(offset)).
+		Order("id asc").
+		Preload("App").
+		Find(&_definitions)
+	return _definitions, int(count), res.Error
+}
+
+// CreateDefinition saves a new relation definition record in the database.
+func (r repository) CreateDefinition(ctx context.Context, definition entity.RelationDefinition) (string, error) {
+	now := time.Now()
+	definition.UUID = uuid.New().String()
+	definition.CreatedAt = now
+	definition.UpdatedAt = now
+	res := r.db.With(ctx).Omit("App").Create(&definition)
+	return definition.UUID, res.Error
+}
+
+// DeleteDefinition deletes a relation definition from the database.
+func (r repository) DeleteDefinition(ctx context.Context, definition entity.RelationDefinition) error {
+	res := r.db.With(ctx).Delete(&definition)
+	return res.Error
+}
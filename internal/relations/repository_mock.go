@@ -0,0 +1,146 @@
+package relations
+
+import (
+	"context"
+	"errors"
+
+	"github.com/google/uuid"
+
+	"github.com/golang-tire/auth/internal/entity"
+)
+
+var errNotFound = errors.New("not found")
+
+func NewMockRepository() *mockRepository {
+	return &mockRepository{}
+}
+
+type mockRepository struct {
+	tuples      []entity.RelationTuple
+	definitions []entity.RelationDefinition
+	revision    int64
+}
+
+func sameTuple(a, b entity.RelationTuple) bool {
+	return a.Namespace == b.Namespace && a.ObjectID == b.ObjectID && a.Relation == b.Relation && a.Subject == b.Subject
+}
+
+func (m *mockRepository) WriteTuples(ctx context.Context, tuples []entity.RelationTuple) (int64, error) {
+	m.revision++
+	for _, t := range tuples {
+		exists := false
+		for _, item := range m.tuples {
+			if sameTuple(item, t) {
+				exists = true
+				break
+			}
+		}
+		if !exists {
+			t.Revision = m.revision
+			m.tuples = append(m.tuples, t)
+		}
+	}
+	return m.revision, nil
+}
+
+func (m *mockRepository) DeleteTuples(ctx context.Context, tuples []entity.RelationTuple) (int64, error) {
+	m.revision++
+	for _, t := range tuples {
+		for i, item := range m.tuples {
+			if sameTuple(item, t) {
+				m.tuples = append(m.tuples[:i], m.tuples[i+1:]...)
+				break
+			}
+		}
+	}
+	return m.revision, nil
+}
+
+func (m mockRepository) Tuples(ctx context.Context, namespace, objectID, relation string) ([]entity.RelationTuple, error) {
+	var items []entity.RelationTuple
+	for _, item := range m.tuples {
+		if item.Namespace == namespace && item.ObjectID == objectID && item.Relation == relation {
+			items = append(items, item)
+		}
+	}
+	return items, nil
+}
+
+func (m mockRepository) QueryTuples(ctx context.Context, filter entity.RelationTuple, offset, limit int64) ([]entity.RelationTuple, int, error) {
+	var items []entity.RelationTuple
+	for _, item := range m.tuples {
+		if (filter.Namespace == "" || item.Namespace == filter.Namespace) &&
+			(filter.ObjectID == "" || item.ObjectID == filter.ObjectID) &&
+			(filter.Relation == "" || item.Relation == filter.Relation) &&
+			(filter.Subject == "" || item.Subject == filter.Subject) {
+			items = append(items, item)
+		}
+	}
+	return items, len(items), nil
+}
+
+func (m mockRepository) SubjectTuples(ctx context.Context, subjects []string) ([]entity.RelationTuple, error) {
+	var items []entity.RelationTuple
+	for _, item := range m.tuples {
+		for _, subject := range subjects {
+			if item.Subject == subject {
+				items = append(items, item)
+				break
+			}
+		}
+	}
+	return items, nil
+}
+
+func (m mockRepository) LatestRevision(ctx context.Context) (int64, error) {
+	return m.revision, nil
+}
+
+func (m mockRepository) GetDefinition(ctx context.Context, uuid string) (entity.RelationDefinition, error) {
+	for _, item := range m.definitions {
+		if item.UUID == uuid {
+			return item, nil
+		}
+	}
+	return entity.RelationDefinition{}, errNotFound
+}
+
+func (m mockRepository) Definitions(ctx context.Context, namespace string) ([]entity.RelationDefinition, error) {
+	var items []entity.RelationDefinition
+	for _, item := range m.definitions {
+		if item.Namespace == namespace {
+			items = append(items, item)
+		}
+	}
+	return items, nil
+}
+
+func (m mockRepository) AllDefinitions(ctx context.Context) ([]entity.RelationDefinition, error) {
+	return m.definitions, nil
+}
+
+func (m mockRepository) QueryDefinitions(ctx context.Context, app string, offset, limit int64) ([]entity.RelationDefinition, int, error) {
+	var items []entity.RelationDefinition
+	for _, item := range m.definitions {
+		if app == "" || item.App.Name == app {
+			items = append(items, item)
+		}
+	}
+	return items, len(items), nil
+}
+
+func (m *mockRepository) CreateDefinition(ctx context.Context, definition entity.RelationDefinition) (string, error) {
+	definition.UUID = uuid.New().String()
+	m.definitions = append(m.definitions, definition)
+	return definition.UUID, nil
+}
+
+func (m *mockRepository) DeleteDefinition(ctx context.Context, definition entity.RelationDefinition) error {
+	for i, item := range m.definitions {
+		if item.UUID == definition.UUID {
+			m.definitions = append(m.definitions[:i], m.definitions[i+1:]...)
+			break
+		}
+	}
+	return nil
+}
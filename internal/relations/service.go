@@ -0,0 +1,419 @@
+package relations
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+
+	validation "github.com/go-ozzo/ozzo-validation/v4"
+	"github.com/golang-tire/pkg/config"
+
+	"github.com/golang-tire/auth/internal/apps"
+	"github.com/golang-tire/auth/internal/entity"
+	auth "github.com/golang-tire/auth/internal/proto/v1"
+)
+
+const (
+	maxTuplesPerRequest = 100
+	// tupleRewrite separates the tuple relation from the included relation in rewrites like parent->viewer
+	tupleRewrite = "->"
+)
+
+// maxDepth limits how deep checks and expansions follow subject sets and rewrites
+var maxDepth = config.RegisterInt("relations.maxDepth", 16)
+
+var (
+	name       = regexp.MustCompile(`^[\w.-]+$`)
+	identifier = regexp.MustCompile(`^[\w.@/-]+$`)
+)
+
+// Service encapsulates use case logic for relation tuples.
+type Service interface {
+	Write(ctx context.Context, req *auth.WriteRelationTuplesRequest) (*auth.WriteRelationTuplesResponse, error)
+	Delete(ctx context.Context, req *auth.DeleteRelationTuplesRequest) (*auth.DeleteRelationTuplesResponse, error)
+	Query(ctx context.Context, req *auth.ListRelationTuplesRequest, offset, limit int64) (*auth.ListRelationTuplesResponse, error)
+	Check(ctx context.Context, req *auth.CheckRelationRequest) (*auth.CheckRelationResponse, error)
+	Expand(ctx context.Context, req *auth.ExpandRelationRequest) (*auth.ExpandRelationResponse, error)
+	ListObjects(ctx context.Context, req *auth.ListRelationObjectsRequest, offset, limit int64) (*auth.ListRelationObjectsResponse, error)
+
+	QueryDefinitions(ctx context.Context, app string, offset, limit int64) (*auth.ListRelationDefinitionsResponse, error)
+	CreateDefinition(ctx context.Context, req *auth.CreateRelationDefinitionRequest) (*auth.RelationDefinition, error)
+	DeleteDefinition(ctx context.Context, uuid string) (*auth.RelationDefinition, error)
+
+	// CheckObject checks the relation of subject to the object namespace:objectID, defined is
+	// false when no relation definition covers it.
+	CheckObject(ctx context.Context, namespace, objectID, relation, subject string) (defined bool, allowed bool, err error)
+}
+
+// ValidateTuple validates the RelationTuple fields.
+func ValidateTuple(t *auth.RelationTuple) error {
+	return validation.ValidateStruct(t,
+		validation.Field(&t.Object, validation.Required, validation.Length(0, 256), validation.By(validObject)),
+		validation.Field(&t.Relation, validation.Required, validation.Length(0, 64), validation.Match(name)),
+		validation.Field(&t.Subject, validation.Required, validation.Length(0, 256), validation.By(validSubject)),
+	)
+}
+
+// ValidateCheckRequest validates the CheckRelationRequest fields.
+func ValidateCheckRequest(c *auth.CheckRelationRequest) error {
+	return validation.ValidateStruct(c,
+		validation.Field(&c.Object, validation.Required, validation.By(validObject)),
+		validation.Field(&c.Relation, validation.Required, validation.Match(name)),
+		validation.Field(&c.Subject, validation.Required, validation.By(validSubject)),
+	)
+}
+
+// ValidateCreateDefinitionRequest validates the CreateRelationDefinitionRequest fields.
+func ValidateCreateDefinitionRequest(c *auth.CreateRelationDefinitionRequest) error {
+	return validation.ValidateStruct(c,
+		validation.Field(&c.App, validation.Required, validation.Length(0, 128)),
+		validation.Field(&c.Namespace, validation.Required, validation.Length(0, 64), validation.Match(name)),
+		validation.Field(&c.Relation, validation.Required, validation.Length(0, 64), validation.Match(name)),
+		validation.Field(&c.Rewrites, validation.Each(validation.By(validRewrite))),
+	)
+}
+
+func validObject(value interface{}) error {
+	_, _, err := parseObject(value.(string))
+	return err
+}
+
+func validSubject(value interface{}) error {
+	_, _, _, err := parseSubject(value.(string))
+	return err
+}
+
+func validRewrite(value interface{}) error {
+	rewrite := value.(string)
+	parts := strings.Split(rewrite, tupleRewrite)
+	if len(parts) > 2 {
+		return errors.New("must be a relation or tuple_relation->relation")
+	}
+	for _, p := range parts {
+		if !name.MatchString(p) {
+			return errors.New("must be a relation or tuple_relation->relation")
+		}
+	}
+	return nil
+}
+
+// parseObject splits namespace:id.
+func parseObject(object string) (string, string, error) {
+	parts := strings.SplitN(object, ":", 2)
+	if len(parts) != 2 || !name.MatchString(parts[0]) || !identifier.MatchString(parts[1]) {
+		return "", "", fmt.Errorf("object `%s` must be in namespace:id form", object)
+	}
+	return parts[0], parts[1], nil
+}
+
+// parseSubject splits a subject into the object and relation of a subject set, a plain
+// username has neither.
+func parseSubject(subject string) (string, string, string, error) {
+	object, relation := subject, ""
+	if i := strings.Index(subject, "#"); i >= 0 {
+		object, relation = subject[:i], subject[i+1:]
+		if !name.MatchString(relation) {
+			return "", "", "", fmt.Errorf("subject `%s` has an invalid relation", subject)
+		}
+	}
+	if !strings.Contains(object, ":") {
+		if relation != "" || !identifier.MatchString(object) {
+			return "", "", "", fmt.Errorf("subject `%s` must be a username, namespace:id or namespace:id#relation", subject)
+		}
+		return "", object, "", nil
+	}
+	namespace, id, err := parseObject(object)
+	if err != nil {
+		return "", "", "", err
+	}
+	return namespace, id, relation, nil
+}
+
+func formatToken(revision int64) string {
+	return strconv.FormatInt(revision, 10)
+}
+
+type service struct {
+	repo     Repository
+	appsRepo apps.Repository
+}
+
+// NewService creates a new relation service.
+func NewService(repo Repository, appsRepo apps.Repository) Service {
+	return service{repo, appsRepo}
+}
+
+// toEntities validates the tuples against the relation definitions.
+func (s service) toEntities(ctx context.Context, tuples []*auth.RelationTuple) ([]entity.RelationTuple, error) {
+	if len(tuples) == 0 || len(tuples) > maxTuplesPerRequest {
+		return nil, fmt.Errorf("tuples must have 1 to %d items", maxTuplesPerRequest)
+	}
+
+	var items []entity.RelationTuple
+	for _, t := range tuples {
+		if err := ValidateTuple(t); err != nil {
+			return nil, err
+		}
+		namespace, id, _ := parseObject(t.Object)
+		if _, err := s.definition(ctx, namespace, t.Relation); err != nil {
+			return nil, err
+		}
+		subjectNamespace, _, subjectRelation, _ := parseSubject(t.Subject)
+		if subjectRelation != "" {
+			if _, err := s.definition(ctx, subjectNamespace, subjectRelation); err != nil {
+				return nil, err
+			}
+		}
+		items = append(items, entity.RelationTuple{
+			Namespace: namespace,
+			ObjectID:  id,
+			Relation:  t.Relation,
+			Subject:   t.Subject,
+		})
+	}
+	return items, nil
+}
+
+// definition returns the definition of the namespace relation.
+func (s service) definition(ctx context.Context, namespace, relation string) (entity.RelationDefinition, error) {
+	definitions, err := s.repo.Definitions(ctx, namespace)
+	if err != nil {
+		return entity.RelationDefinition{}, err
+	}
+	for _, d := range definitions {
+		if d.Relation == relation {
+			return d, nil
+		}
+	}
+	return entity.RelationDefinition{}, fmt.Errorf("relation `%s` of namespace `%s` is not defined", relation, namespace)
+}
+
+// revision returns the store revision reads are made at, it fails when token is newer.
+func (s service) revision(ctx context.Context, token string) (int64, error) {
+	latest, err := s.repo.LatestRevision(ctx)
+	if err != nil {
+		return 0, err
+	}
+	if token == "" {
+		return latest, nil
+	}
+	revision, err := strconv.ParseInt(token, 10, 64)
+	if err != nil || revision < 0 {
+		return 0, fmt.Errorf("invalid consistency token `%s`", token)
+	}
+	if revision > latest {
+		return 0, fmt.Errorf("consistency token `%s` is not known yet", token)
+	}
+	return latest, nil
+}
+
+// Write stores the tuples.
+func (s service) Write(ctx context.Context, req *auth.WriteRelationTuplesRequest) (*auth.WriteRelationTuplesResponse, error) {
+	tuples, err := s.toEntities(ctx, req.Tuples)
+	if err != nil {
+		return nil, err
+	}
+	revision, err := s.repo.WriteTuples(ctx, tuples)
+	if err != nil {
+		return nil, err
+	}
+	return &auth.WriteRelationTuplesResponse{ConsistencyToken: formatToken(revision)}, nil
+}
+
+// Delete removes the tuples.
+func (s service) Delete(ctx context.Context, req *auth.DeleteRelationTuplesRequest) (*auth.DeleteRelationTuplesResponse, error) {
+	tuples, err := s.toEntities(ctx, req.Tuples)
+	if err != nil {
+		return nil, err
+	}
+	revision, err := s.repo.DeleteTuples(ctx, tuples)
+	if err != nil {
+		return nil, err
+	}
+	return &auth.DeleteRelationTuplesResponse{ConsistencyToken: formatToken(revision)}, nil
+}
+
+// Query returns the tuples matching the request filters.
+func (s service) Query(ctx context.Context, req *auth.ListRelationTuplesRequest, offset, limit int64) (*auth.ListRelationTuplesResponse, error) {
+	filter := entity.RelationTuple{Relation: req.Relation, Subject: req.Subject}
+	if req.Object != "" {
+		namespace, id, err := parseObject(req.Object)
+		if err != nil {
+			return nil, err
+		}
+		filter.Namespace, filter.ObjectID = namespace, id
+	}
+
+	revision, err := s.repo.LatestRevision(ctx)
+	if err != nil {
+		return nil, err
+	}
+	items, count, err := s.repo.QueryTuples(ctx, filter, offset, limit)
+	if err != nil {
+		return nil, err
+	}
+	return &auth.ListRelationTuplesResponse{
+		RelationTuples:   entity.RelationTupleToProtoList(items),
+		TotalCount:       int64(count),
+		Offset:           offset,
+		Limit:            limit,
+		ConsistencyToken: formatToken(revision),
+	}, nil
+}
+
+// Check reports whether the subject has the relation to the object.
+func (s service) Check(ctx context.Context, req *auth.CheckRelationRequest) (*auth.CheckRelationResponse, error) {
+	if err := ValidateCheckRequest(req); err != nil {
+		return nil, err
+	}
+	revision, err := s.revision(ctx, req.ConsistencyToken)
+	if err != nil {
+		return nil, err
+	}
+	namespace, id, _ := parseObject(req.Object)
+	if _, err := s.definition(ctx, namespace, req.Relation); err != nil {
+		return nil, err
+	}
+
+	allowed, err := s.newEvaluator(ctx).check(namespace, id, req.Relation, req.Subject, 0)
+	if err != nil {
+		return nil, err
+	}
+	return &auth.CheckRelationResponse{Allowed: allowed, ConsistencyToken: formatToken(revision)}, nil
+}
+
+// Expand returns the subject tree of the object relation.
+func (s service) Expand(ctx context.Context, req *auth.ExpandRelationRequest) (*auth.ExpandRelationResponse, error) {
+	namespace, id, err := parseObject(req.Object)
+	if err != nil {
+		return nil, err
+	}
+	revision, err := s.revision(ctx, req.ConsistencyToken)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := s.definition(ctx, namespace, req.Relation); err != nil {
+		return nil, err
+	}
+
+	tree, err := s.newEvaluator(ctx).expand(namespace, id, req.Relation, 0)
+	if err != nil {
+		return nil, err
+	}
+	return &auth.ExpandRelationResponse{Tree: tree, ConsistencyToken: formatToken(revision)}, nil
+}
+
+// ListObjects returns the objects of the namespace the subject has the relation to with the
+// given offset and limit.
+func (s service) ListObjects(ctx context.Context, req *auth.ListRelationObjectsRequest, offset, limit int64) (*auth.ListRelationObjectsResponse, error) {
+	if _, _, _, err := parseSubject(req.Subject); err != nil || req.Subject == "" {
+		return nil, fmt.Errorf("invalid subject `%s`", req.Subject)
+	}
+	revision, err := s.revision(ctx, req.ConsistencyToken)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := s.definition(ctx, req.Namespace, req.Relation); err != nil {
+		return nil, err
+	}
+
+	ids, err := s.newEvaluator(ctx).objects(req.Namespace, req.Relation, req.Subject)
+	if err != nil {
+		return nil, err
+	}
+	res := &auth.ListRelationObjectsResponse{
+		TotalCount:       int64(len(ids)),
+		Offset:           offset,
+		Limit:            limit,
+		ConsistencyToken: formatToken(revision),
+	}
+	for i := offset; i < int64(len(ids)) && i < offset+limit; i++ {
+		res.Objects = append(res.Objects, req.Namespace+":"+ids[i])
+	}
+	return res, nil
+}
+
+// CheckObject checks the relation when the namespace defines it.
+func (s service) CheckObject(ctx context.Context, namespace, objectID, relation, subject string) (bool, bool, error) {
+	if _, err := s.definition(ctx, namespace, relation); err != nil {
+		return false, false, nil
+	}
+	allowed, err := s.newEvaluator(ctx).check(namespace, objectID, relation, subject, 0)
+	return true, allowed, err
+}
+
+// QueryDefinitions returns the relation definitions of the app.
+func (s service) QueryDefinitions(ctx context.Context, app string, offset, limit int64) (*auth.ListRelationDefinitionsResponse, error) {
+	items, count, err := s.repo.QueryDefinitions(ctx, app, offset, limit)
+	if err != nil {
+		return nil, err
+	}
+	return &auth.ListRelationDefinitionsResponse{
+		RelationDefinitions: entity.RelationDefinitionToProtoList(items),
+		TotalCount:          int64(count),
+		Offset:              offset,
+		Limit:               limit,
+	}, nil
+}
+
+// CreateDefinition defines a relation of a namespace, a namespace belongs to a single app.
+func (s service) CreateDefinition(ctx context.Context, req *auth.CreateRelationDefinitionRequest) (*auth.RelationDefinition, error) {
+	if err := ValidateCreateDefinitionRequest(req); err != nil {
+		return nil, err
+	}
+	app, err := s.appsRepo.GetAppByName(ctx, req.App)
+	if err != nil {
+		return nil, fmt.Errorf("app `%s` not found", req.App)
+	}
+
+	definitions, err := s.repo.Definitions(ctx, req.Namespace)
+	if err != nil {
+		return nil, err
+	}
+	for _, d := range definitions {
+		if d.AppID != app.ID {
+			return nil, fmt.Errorf("namespace `%s` belongs to app `%s`", req.Namespace, d.App.Name)
+		}
+		if d.Relation == req.Relation {
+			return nil, fmt.Errorf("relation `%s` of namespace `%s` is already defined", req.Relation, req.Namespace)
+		}
+	}
+
+	id, err := s.repo.CreateDefinition(ctx, entity.RelationDefinition{
+		AppID:     app.ID,
+		App:       app,
+		Namespace: req.Namespace,
+		Relation:  req.Relation,
+		Rewrites:  strings.Join(req.Rewrites, ","),
+	})
+	if err != nil {
+		return nil, err
+	}
+	definition, err := s.repo.GetDefinition(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	return definition.ToProto(), nil
+}
+
+// DeleteDefinition deletes a relation definition that has no tuples.
+func (s service) DeleteDefinition(ctx context.Context, uuid string) (*auth.RelationDefinition, error) {
+	definition, err := s.repo.GetDefinition(ctx, uuid)
+	if err != nil {
+		return nil, err
+	}
+	_, count, err := s.repo.QueryTuples(ctx, entity.RelationTuple{Namespace: definition.Namespace, Relation: definition.Relation}, 0, 1)
+	if err != nil {
+		return nil, err
+	}
+	if count > 0 {
+		return nil, fmt.Errorf("relation `%s` of namespace `%s` still has %d tuples", definition.Relation, definition.Namespace, count)
+	}
+	if err := s.repo.DeleteDefinition(ctx, definition); err != nil {
+		return nil, err
+	}
+	return definition.ToProto(), nil
+}
@@ -0,0 +1,172 @@
+package relations
+
+import (
+	"context"
+	"testing"
+
+	"github.com/golang-tire/auth/internal/apps"
+	"github.com/golang-tire/auth/internal/entity"
+	"github.com/golang-tire/auth/internal/pkg/testutils"
+	auth "github.com/golang-tire/auth/internal/proto/v1"
+	"github.com/stretchr/testify/assert"
+	"gorm.io/gorm"
+)
+
+func TestRelationTuple_Validate(t *testing.T) {
+	tests := []struct {
+		name      string
+		model     *auth.RelationTuple
+		wantError bool
+	}{
+		{"user", &auth.RelationTuple{Object: "doc:42", Relation: "editor", Subject: "alice"}, false},
+		{"object", &auth.RelationTuple{Object: "doc:42", Relation: "parent", Subject: "folder:7"}, false},
+		{"subject set", &auth.RelationTuple{Object: "doc:42", Relation: "viewer", Subject: "group:eng#member"}, false},
+		{"required", &auth.RelationTuple{Object: "doc:42", Relation: "editor"}, true},
+		{"invalid object", &auth.RelationTuple{Object: "doc", Relation: "editor", Subject: "alice"}, true},
+		{"invalid subject", &auth.RelationTuple{Object: "doc:42", Relation: "editor", Subject: "alice#member"}, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := ValidateTuple(tt.model)
+			assert.Equal(t, tt.wantError, err != nil)
+		})
+	}
+}
+
+func Test_service(t *testing.T) {
+	testutils.TestUp()
+	ctx := context.Background()
+
+	appsRepo := apps.NewMockRepository()
+	_, _ = appsRepo.CreateApp(ctx, entity.App{Model: gorm.Model{ID: 1}, Name: "docs", Enable: true})
+	_, _ = appsRepo.CreateApp(ctx, entity.App{Model: gorm.Model{ID: 2}, Name: "other", Enable: true})
+	s := NewService(NewMockRepository(), appsRepo)
+
+	for _, d := range []*auth.CreateRelationDefinitionRequest{
+		{App: "docs", Namespace: "folder", Relation: "viewer"},
+		{App: "docs", Namespace: "group", Relation: "member"},
+		{App: "docs", Namespace: "doc", Relation: "parent"},
+		{App: "docs", Namespace: "doc", Relation: "editor"},
+		{App: "docs", Namespace: "doc", Relation: "viewer", Rewrites: []string{"editor", "parent->viewer"}},
+	} {
+		_, err := s.CreateDefinition(ctx, d)
+		assert.Nil(t, err)
+	}
+	_, err := s.CreateDefinition(ctx, &auth.CreateRelationDefinitionRequest{App: "other", Namespace: "doc", Relation: "owner"})
+	assert.NotNil(t, err)
+	_, err = s.CreateDefinition(ctx, &auth.CreateRelationDefinitionRequest{App: "docs", Namespace: "doc", Relation: "owner", Rewrites: []string{"a->b->c"}})
+	assert.NotNil(t, err)
+
+	// tuples need a defined relation
+	_, err = s.Write(ctx, &auth.WriteRelationTuplesRequest{Tuples: []*auth.RelationTuple{{Object: "doc:42", Relation: "owner", Subject: "alice"}}})
+	assert.NotNil(t, err)
+
+	res, err := s.Write(ctx, &auth.WriteRelationTuplesRequest{Tuples: []*auth.RelationTuple{
+		{Object: "doc:42", Relation: "editor", Subject: "alice"},
+		{Object: "doc:42", Relation: "parent", Subject: "folder:7"},
+		{Object: "folder:7", Relation: "viewer", Subject: "group:eng#member"},
+		{Object: "group:eng", Relation: "member", Subject: "bob"},
+		{Object: "doc:43", Relation: "editor", Subject: "carol"},
+	}})
+	assert.Nil(t, err)
+	token := res.ConsistencyToken
+
+	check := func(object, relation, subject string) bool {
+		res, err := s.Check(ctx, &auth.CheckRelationRequest{Object: object, Relation: relation, Subject: subject, ConsistencyToken: token})
+		assert.Nil(t, err)
+		return res.Allowed
+	}
+	assert.True(t, check("doc:42", "editor", "alice"))
+	assert.True(t, check("doc:42", "viewer", "alice"))
+	assert.True(t, check("doc:42", "viewer", "bob"))
+	assert.False(t, check("doc:42", "editor", "bob"))
+	assert.False(t, check("doc:42", "viewer", "carol"))
+
+	_, err = s.Check(ctx, &auth.CheckRelationRequest{Object: "doc:42", Relation: "viewer", Subject: "bob", ConsistencyToken: "100"})
+	assert.NotNil(t, err)
+
+	objects, err := s.ListObjects(ctx, &auth.ListRelationObjectsRequest{Namespace: "doc", Relation: "viewer", Subject: "bob"}, 0, 10)
+	assert.Nil(t, err)
+	assert.Equal(t, []string{"doc:42"}, objects.Objects)
+
+	expand, err := s.Expand(ctx, &auth.ExpandRelationRequest{Object: "doc:42", Relation: "viewer"})
+	assert.Nil(t, err)
+	assert.Len(t, expand.Tree.Children, 2)
+	assert.Equal(t, []string{"alice"}, expand.Tree.Children[0].Subjects)
+
+	defined, allowed, err := s.CheckObject(ctx, "doc", "42", "viewer", "bob")
+	assert.Nil(t, err)
+	assert.True(t, defined)
+	assert.True(t, allowed)
+	defined, _, _ = s.CheckObject(ctx, "doc", "42", "GET", "bob")
+	assert.False(t, defined)
+
+	// removing the membership revokes the inherited relation
+	del, err := s.Delete(ctx, &auth.DeleteRelationTuplesRequest{Tuples: []*auth.RelationTuple{{Object: "group:eng", Relation: "member", Subject: "bob"}}})
+	assert.Nil(t, err)
+	assert.NotEqual(t, token, del.ConsistencyToken)
+	token = del.ConsistencyToken
+	assert.False(t, check("doc:42", "viewer", "bob"))
+
+	// definitions with tuples can not be deleted
+	definitions, _ := s.QueryDefinitions(ctx, "docs", 0, 10)
+	assert.Equal(t, int64(5), definitions.TotalCount)
+	_, err = s.DeleteDefinition(ctx, definitions.RelationDefinitions[3].Uuid)
+	assert.NotNil(t, err)
+	_, err = s.DeleteDefinition(ctx, definitions.RelationDefinitions[1].Uuid)
+	assert.Nil(t, err)
+}
+
+func Test_service_ListObjects(t *testing.T) {
+	testutils.TestUp()
+	ctx := context.Background()
+
+	appsRepo := apps.NewMockRepository()
+	_, _ = appsRepo.CreateApp(ctx, entity.App{Model: gorm.Model{ID: 1}, Name: "docs", Enable: true})
+	s := NewService(NewMockRepository(), appsRepo)
+	for _, d := range []*auth.CreateRelationDefinitionRequest{
+		{App: "docs", Namespace: "folder", Relation: "viewer"},
+		{App: "docs", Namespace: "group", Relation: "member"},
+		{App: "docs", Namespace: "doc", Relation: "parent"},
+		{App: "docs", Namespace: "doc", Relation: "editor"},
+		{App: "docs", Namespace: "doc", Relation: "viewer", Rewrites: []string{"editor", "parent->viewer"}},
+	} {
+		_, err := s.CreateDefinition(ctx, d)
+		assert.Nil(t, err)
+	}
+
+	_, err := s.Write(ctx, &auth.WriteRelationTuplesRequest{Tuples: []*auth.RelationTuple{
+		{Object: "doc:1", Relation: "editor", Subject: "alice"},
+		{Object: "doc:2", Relation: "viewer", Subject: "group:eng#member"},
+		{Object: "doc:3", Relation: "parent", Subject: "folder:7"},
+		{Object: "doc:4", Relation: "parent", Subject: "folder:7"},
+		{Object: "doc:5", Relation: "editor", Subject: "carol"},
+		{Object: "folder:7", Relation: "viewer", Subject: "group:all#member"},
+		{Object: "group:all", Relation: "member", Subject: "group:eng#member"},
+		{Object: "group:eng", Relation: "member", Subject: "group:all#member"},
+		{Object: "group:eng", Relation: "member", Subject: "bob"},
+		{Object: "group:eng", Relation: "member", Subject: "alice"},
+	}})
+	assert.Nil(t, err)
+
+	// the listed objects are the ones check allows, nested and cyclic groups included
+	for _, subject := range []string{"alice", "bob", "carol", "dave", "group:eng#member"} {
+		var want []string
+		for _, id := range []string{"1", "2", "3", "4", "5"} {
+			res, err := s.Check(ctx, &auth.CheckRelationRequest{Object: "doc:" + id, Relation: "viewer", Subject: subject})
+			assert.Nil(t, err)
+			if res.Allowed {
+				want = append(want, "doc:"+id)
+			}
+		}
+		res, err := s.ListObjects(ctx, &auth.ListRelationObjectsRequest{Namespace: "doc", Relation: "viewer", Subject: subject}, 0, 10)
+		assert.Nil(t, err)
+		assert.Equal(t, want, res.Objects, subject)
+		assert.Equal(t, int64(len(want)), res.TotalCount, subject)
+	}
+
+	res, err := s.ListObjects(ctx, &auth.ListRelationObjectsRequest{Namespace: "doc", Relation: "viewer", Subject: "alice"}, 1, 2)
+	assert.Nil(t, err)
+	assert.Equal(t, []string{"doc:2", "doc:3"}, res.Objects)
+	assert.Equal(t, int64(4), res.TotalCount)
+}
@@ -0,0 +1,88 @@
+package role_templates
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/golang-tire/auth/internal/pkg/helpers"
+	auth "github.com/golang-tire/auth/internal/proto/v1"
+	"github.com/golang-tire/pkg/grpcgw"
+	"github.com/golang/protobuf/ptypes/empty"
+	"github.com/grpc-ecosystem/grpc-gateway/v2/runtime"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+type API interface {
+	grpcgw.Controller
+}
+
+type api struct {
+	service Service
+	auth.RoleTemplateServiceServer
+}
+
+func (a api) InitRest(ctx context.Context, conn *grpc.ClientConn, mux *runtime.ServeMux, httpMux *http.ServeMux) {
+	cl := auth.NewRoleTemplateServiceClient(conn)
+	_ = auth.RegisterRoleTemplateServiceHandlerClient(ctx, mux, cl)
+}
+
+func (a api) InitGrpc(ctx context.Context, server *grpc.Server) {
+	auth.RegisterRoleTemplateServiceServer(server, a)
+}
+
+func (a api) ListRoleTemplates(ctx context.Context, request *auth.ListRoleTemplatesRequest) (*auth.ListRoleTemplatesResponse, error) {
+	offset, limit := helpers.GetOffsetAndLimit(request.Offset, request.Limit)
+	res, err := a.service.Query(ctx, request.Query, offset, limit)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+	return res, err
+}
+
+func (a api) GetRoleTemplate(ctx context.Context, request *auth.GetRoleTemplateRequest) (*auth.RoleTemplate, error) {
+	res, err := a.service.Get(ctx, request.Uuid)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+	return res, err
+}
+
+func (a api) CreateRoleTemplate(ctx context.Context, request *auth.CreateRoleTemplateRequest) (*auth.RoleTemplate, error) {
+	res, err := a.service.Create(ctx, request)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+	return res, err
+}
+
+func (a api) UpdateRoleTemplate(ctx context.Context, request *auth.UpdateRoleTemplateRequest) (*auth.RoleTemplate, error) {
+	res, err := a.service.Update(ctx, request)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+	return res, err
+}
+
+func (a api) DeleteRoleTemplate(ctx context.Context, request *auth.DeleteRoleTemplateRequest) (*empty.Empty, error) {
+	_, err := a.service.Delete(ctx, request.Uuid)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+	return &empty.Empty{}, nil
+}
+
+func (a api) ApplyRoleTemplate(ctx context.Context, request *auth.ApplyRoleTemplateRequest) (*auth.ApplyRoleTemplateResponse, error) {
+	res, err := a.service.Apply(ctx, request)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+	return res, err
+}
+
+func New(srv Service) API {
+	s := api{service: srv}
+	grpcgw.RegisterController(s)
+	return s
+}
@@ -0,0 +1,147 @@
+package role_templates
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+
+	"github.com/golang-tire/auth/internal/pkg/db"
+
+	"github.com/golang-tire/auth/internal/entity"
+)
+
+// Repository encapsulates the logic to access role templates from the data source.
+type Repository interface {
+	// Get returns the role template with the specified UUID.
+	Get(ctx context.Context, uuid string) (entity.RoleTemplate, error)
+	// GetByName returns the role template with the specified name.
+	GetByName(ctx context.Context, name string) (entity.RoleTemplate, error)
+	// Count returns the number of role templates.
+	Count(ctx context.Context) (int64, error)
+	// Query returns the list of role templates with the given offset and limit.
+	Query(ctx context.Context, query string, offset, limit int64) ([]entity.RoleTemplate, int, error)
+	// Create saves a new role template with its bundles in the storage.
+	Create(ctx context.Context, template entity.RoleTemplate) (string, error)
+	// Update updates the role template with given UUID and replaces its bundles in the storage.
+	Update(ctx context.Context, template entity.RoleTemplate) error
+	// Delete removes the role template from the storage.
+	Delete(ctx context.Context, template entity.RoleTemplate) error
+	// Transactional runs f in a transaction, repository calls using the given context join it.
+	Transactional(ctx context.Context, f func(ctx context.Context) error) error
+}
+
+// repository persists role templates in database
+type repository struct {
+	db *db.DB
+}
+
+// NewRepository creates a new role template repository
+func NewRepository(db *db.DB) Repository {
+	return repository{db}
+}
+
+// Get reads the role template with the specified UUID from the database.
+func (r repository) Get(ctx context.Context, uuid string) (entity.RoleTemplate, error) {
+	var template entity.RoleTemplate
+	res := r.db.With(ctx).Preload("Bundles.Bundle.Permissions").Where("uuid = ?", uuid).First(&template)
+	if res.Error != nil && res.Error == gorm.ErrRecordNotFound {
+		return entity.RoleTemplate{}, fmt.Errorf("role template with uuid `%s` not found", uuid)
+	}
+	return template, res.Error
+}
+
+// GetByName reads the role template with the specified name from the database.
+func (r repository) GetByName(ctx context.Context, name string) (entity.RoleTemplate, error) {
+	var template entity.RoleTemplate
+	res := r.db.With(ctx).Preload("Bundles.Bundle").Where("name = ?", name).First(&template)
+	if res.Error != nil && res.Error == gorm.ErrRecordNotFound {
+		return entity.RoleTemplate{}, fmt.Errorf("role template with name `%s` not found", name)
+	}
+	return template, res.Error
+}
+
+// Create saves a new role template record with its bundles in the database.
+// It returns the UUID of the newly inserted role template record.
+func (r repository) Create(ctx context.Context, template entity.RoleTemplate) (string, error) {
+	now := time.Now()
+	template.UUID = uuid.New().String()
+	template.CreatedAt = now
+	template.UpdatedAt = now
+	err := r.db.Transactional(ctx, func(ctx context.Context) error {
+		if res := r.db.With(ctx).Omit("Bundles").Create(&template); res.Error != nil {
+			return res.Error
+		}
+		return r.addBundles(ctx, template)
+	})
+	return template.UUID, err
+}
+
+// Update saves the changes to a role template and replaces its bundles in the database.
+func (r repository) Update(ctx context.Context, template entity.RoleTemplate) error {
+	return r.db.Transactional(ctx, func(ctx context.Context) error {
+		if res := r.db.With(ctx).Where("template_id = ?", template.ID).Delete(&entity.RoleTemplateBundle{}); res.Error != nil {
+			return res.Error
+		}
+		if err := r.addBundles(ctx, template); err != nil {
+			return err
+		}
+		return r.db.With(ctx).Omit("Bundles").Save(&template).Error
+	})
+}
+
+func (r repository) addBundles(ctx context.Context, template entity.RoleTemplate) error {
+	for _, b := range template.Bundles {
+		item := entity.RoleTemplateBundle{TemplateID: template.ID, BundleID: b.BundleID}
+		if res := r.db.With(ctx).Create(&item); res.Error != nil {
+			return res.Error
+		}
+	}
+	return nil
+}
+
+// Delete deletes a role template with its bundle entries from the database.
+func (r repository) Delete(ctx context.Context, template entity.RoleTemplate) error {
+	return r.db.Transactional(ctx, func(ctx context.Context) error {
+		if res := r.db.With(ctx).Where("template_id = ?", template.ID).Delete(&entity.RoleTemplateBundle{}); res.Error != nil {
+			return res.Error
+		}
+		return r.db.With(ctx).Omit("Bundles").Delete(&template).Error
+	})
+}
+
+// Count returns the number of the role template records in the database.
+func (r repository) Count(ctx context.Context) (int64, error) {
+	var count int64
+	res := r.db.With(ctx).Model(&entity.RoleTemplate{}).Count(&count)
+	return count, res.Error
+}
+
+// Query retrieves the role template records with the specified offset and limit from the database.
+func (r repository) Query(ctx context.Context, query string, offset, limit int64) ([]entity.RoleTemplate, int, error) {
+	var _templates []entity.RoleTemplate
+	var count int64
+
+	res := r.db.With(ctx).Model(&entity.RoleTemplate{})
+	if len(query) >= 1 {
+		res = res.Where("name LIKE ?", "%"+query+"%")
+	}
+	if err := res.Count(&count).Error; err != nil {
+		return nil, 0, err
+	}
+
+	res = res.
+		Limit(int(limit)).
+		Offset(int(offset)).
+		Order("id asc").
+		Preload("Bundles.Bundle").
+		Find(&_templates)
+	return _templates, int(count), res.Error
+}
+
+// Transactional runs f in a database transaction.
+func (r repository) Transactional(ctx context.Context, f func(ctx context.Context) error) error {
+	return r.db.Transactional(ctx, f)
+}
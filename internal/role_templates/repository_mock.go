@@ -0,0 +1,87 @@
+package role_templates
+
+import (
+	"context"
+	"errors"
+
+	"github.com/google/uuid"
+
+	"github.com/golang-tire/auth/internal/entity"
+)
+
+var errCRUD = errors.New("error crud")
+var errNotFound = errors.New("not found")
+
+func NewMockRepository() *mockRepository {
+	return &mockRepository{}
+}
+
+type mockRepository struct {
+	items  []entity.RoleTemplate
+	lastID uint
+}
+
+func (m mockRepository) Get(ctx context.Context, uuid string) (entity.RoleTemplate, error) {
+	for _, item := range m.items {
+		if item.UUID == uuid {
+			return item, nil
+		}
+	}
+	return entity.RoleTemplate{}, errNotFound
+}
+
+func (m mockRepository) GetByName(ctx context.Context, name string) (entity.RoleTemplate, error) {
+	for _, item := range m.items {
+		if item.Name == name {
+			return item, nil
+		}
+	}
+	return entity.RoleTemplate{}, errNotFound
+}
+
+func (m mockRepository) Count(ctx context.Context) (int64, error) {
+	return int64(len(m.items)), nil
+}
+
+func (m mockRepository) Query(ctx context.Context, query string, offset, limit int64) ([]entity.RoleTemplate, int, error) {
+	return m.items, len(m.items), nil
+}
+
+func (m *mockRepository) Create(ctx context.Context, template entity.RoleTemplate) (string, error) {
+	template.UUID = uuid.New().String()
+	if template.Name == "error" {
+		return template.UUID, errCRUD
+	}
+	m.lastID++
+	template.ID = m.lastID
+	m.items = append(m.items, template)
+	return template.UUID, nil
+}
+
+func (m *mockRepository) Update(ctx context.Context, template entity.RoleTemplate) error {
+	if template.Name == "error" {
+		return errCRUD
+	}
+	for i, item := range m.items {
+		if item.UUID == template.UUID {
+			m.items[i] = template
+			break
+		}
+	}
+	return nil
+}
+
+func (m *mockRepository) Delete(ctx context.Context, template entity.RoleTemplate) error {
+	for i, item := range m.items {
+		if item.UUID == template.UUID {
+			m.items[i] = m.items[len(m.items)-1]
+			m.items = m.items[:len(m.items)-1]
+			break
+		}
+	}
+	return nil
+}
+
+func (m mockRepository) Transactional(ctx context.Context, f func(ctx context.Context) error) error {
+	return f(ctx)
+}
@@ -0,0 +1,240 @@
+package role_templates
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	validation "github.com/go-ozzo/ozzo-validation/v4"
+	"github.com/go-ozzo/ozzo-validation/v4/is"
+
+	"github.com/golang-tire/auth/internal/bundles"
+	"github.com/golang-tire/auth/internal/domains"
+	"github.com/golang-tire/auth/internal/entity"
+	"github.com/golang-tire/auth/internal/pkg/pubsub"
+	"github.com/golang-tire/auth/internal/pkg/scope"
+	auth "github.com/golang-tire/auth/internal/proto/v1"
+	"github.com/golang-tire/auth/internal/roles"
+)
+
+// Service encapsulates use case logic for role templates.
+type Service interface {
+	Get(ctx context.Context, uuid string) (*auth.RoleTemplate, error)
+	Query(ctx context.Context, query string, offset, limit int64) (*auth.ListRoleTemplatesResponse, error)
+	Count(ctx context.Context) (int64, error)
+	Create(ctx context.Context, req *auth.CreateRoleTemplateRequest) (*auth.RoleTemplate, error)
+	Update(ctx context.Context, req *auth.UpdateRoleTemplateRequest) (*auth.RoleTemplate, error)
+	Delete(ctx context.Context, uuid string) (*auth.RoleTemplate, error)
+	// Apply creates a role with the bundles of the template attached in a domain.
+	Apply(ctx context.Context, req *auth.ApplyRoleTemplateRequest) (*auth.ApplyRoleTemplateResponse, error)
+}
+
+// ValidateCreateRequest validates the CreateRoleTemplateRequest fields.
+func ValidateCreateRequest(c *auth.CreateRoleTemplateRequest) error {
+	return validation.ValidateStruct(c,
+		validation.Field(&c.Name, validation.Required, validation.Length(0, 128)),
+		validation.Field(&c.Description, validation.Length(0, 512)),
+		validation.Field(&c.BundleUuids, validation.Required, validation.Each(is.UUID)),
+	)
+}
+
+// ValidateUpdateRequest validates the UpdateRoleTemplateRequest fields.
+func ValidateUpdateRequest(u *auth.UpdateRoleTemplateRequest) error {
+	return validation.ValidateStruct(u,
+		validation.Field(&u.Name, validation.Required, validation.Length(0, 128)),
+		validation.Field(&u.Description, validation.Length(0, 512)),
+		validation.Field(&u.BundleUuids, validation.Required, validation.Each(is.UUID)),
+	)
+}
+
+// ValidateApplyRequest validates the ApplyRoleTemplateRequest fields.
+func ValidateApplyRequest(a *auth.ApplyRoleTemplateRequest) error {
+	return validation.ValidateStruct(a,
+		validation.Field(&a.Uuid, validation.Required, is.UUID),
+		validation.Field(&a.Role, validation.Required, validation.Length(0, 128)),
+		validation.Field(&a.DomainUuid, validation.Required, is.UUID),
+	)
+}
+
+type service struct {
+	repo           Repository
+	rolesRepo      roles.Repository
+	domainsRepo    domains.Repository
+	bundlesRepo    bundles.Repository
+	bundlesService bundles.Service
+}
+
+// NewService creates a new role template service.
+func NewService(repo Repository, rolesRepo roles.Repository, domainsRepo domains.Repository,
+	bundlesRepo bundles.Repository, bundlesService bundles.Service) Service {
+	return service{repo, rolesRepo, domainsRepo, bundlesRepo, bundlesService}
+}
+
+// Get returns the role template with the specified UUID.
+func (s service) Get(ctx context.Context, uuid string) (*auth.RoleTemplate, error) {
+	template, err := s.repo.Get(ctx, uuid)
+	if err != nil {
+		return nil, err
+	}
+	return template.ToProto(), nil
+}
+
+// Create creates a new role template.
+func (s service) Create(ctx context.Context, req *auth.CreateRoleTemplateRequest) (*auth.RoleTemplate, error) {
+	if err := ValidateCreateRequest(req); err != nil {
+		return nil, err
+	}
+	if err := scope.FromContext(ctx).CheckGlobal("create role templates"); err != nil {
+		return nil, err
+	}
+	if _, err := s.repo.GetByName(ctx, req.Name); err == nil {
+		return nil, fmt.Errorf("role template `%s` already exists", req.Name)
+	}
+	items, err := s.templateBundles(ctx, req.BundleUuids)
+	if err != nil {
+		return nil, err
+	}
+	id, err := s.repo.Create(ctx, entity.RoleTemplate{
+		Name:        req.Name,
+		Description: req.Description,
+		Bundles:     items,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return s.Get(ctx, id)
+}
+
+// Update updates the role template with the specified UUID, the roles created from it
+// keep the bundles they were created with.
+func (s service) Update(ctx context.Context, req *auth.UpdateRoleTemplateRequest) (*auth.RoleTemplate, error) {
+	if err := ValidateUpdateRequest(req); err != nil {
+		return nil, err
+	}
+	if err := scope.FromContext(ctx).CheckGlobal("update role templates"); err != nil {
+		return nil, err
+	}
+
+	template, err := s.repo.Get(ctx, req.Uuid)
+	if err != nil {
+		return nil, err
+	}
+	if other, err := s.repo.GetByName(ctx, req.Name); err == nil && other.UUID != template.UUID {
+		return nil, fmt.Errorf("role template `%s` already exists", req.Name)
+	}
+	items, err := s.templateBundles(ctx, req.BundleUuids)
+	if err != nil {
+		return nil, err
+	}
+	template.Name = req.Name
+	template.Description = req.Description
+	template.Bundles = items
+	template.UpdatedAt = time.Now()
+	if err := s.repo.Update(ctx, template); err != nil {
+		return nil, err
+	}
+	return s.Get(ctx, template.UUID)
+}
+
+// Delete deletes the role template with the specified UUID, the roles created from it stay.
+func (s service) Delete(ctx context.Context, uuid string) (*auth.RoleTemplate, error) {
+	if err := scope.FromContext(ctx).CheckGlobal("delete role templates"); err != nil {
+		return nil, err
+	}
+	template, err := s.repo.Get(ctx, uuid)
+	if err != nil {
+		return nil, err
+	}
+	if err = s.repo.Delete(ctx, template); err != nil {
+		return nil, err
+	}
+	return template.ToProto(), nil
+}
+
+// Count returns the number of role templates.
+func (s service) Count(ctx context.Context) (int64, error) {
+	return s.repo.Count(ctx)
+}
+
+// Query returns the role templates with the specified offset and limit.
+func (s service) Query(ctx context.Context, query string, offset, limit int64) (*auth.ListRoleTemplatesResponse, error) {
+	items, count, err := s.repo.Query(ctx, query, offset, limit)
+	if err != nil {
+		return nil, err
+	}
+	return &auth.ListRoleTemplatesResponse{
+		RoleTemplates: entity.RoleTemplateToProtoList(items),
+		TotalCount:    int64(count),
+		Offset:        offset,
+		Limit:         limit,
+	}, nil
+}
+
+// Apply creates a role with the title of the request and attaches the bundles of the
+// template to it in the domain, the role and its rules are created together or not at all.
+func (s service) Apply(ctx context.Context, req *auth.ApplyRoleTemplateRequest) (*auth.ApplyRoleTemplateResponse, error) {
+	if err := ValidateApplyRequest(req); err != nil {
+		return nil, err
+	}
+	template, err := s.repo.Get(ctx, req.Uuid)
+	if err != nil {
+		return nil, err
+	}
+	domain, err := s.domainsRepo.Get(ctx, req.DomainUuid)
+	if err != nil {
+		return nil, err
+	}
+	if err := scope.FromContext(ctx).Check(domain.Name); err != nil {
+		return nil, err
+	}
+	// rules and role assignments refer to roles by title
+	if _, err := s.rolesRepo.GetByTitle(ctx, req.Role); err == nil {
+		return nil, fmt.Errorf("role `%s` already exists", req.Role)
+	}
+
+	var items []entity.PermissionBundle
+	for _, b := range template.Bundles {
+		items = append(items, b.Bundle)
+	}
+
+	var role entity.Role
+	var attached []entity.RoleBundle
+	err = s.repo.Transactional(ctx, func(ctx context.Context) error {
+		id, err := s.rolesRepo.Create(ctx, entity.Role{Title: req.Role, Enable: true})
+		if err != nil {
+			return err
+		}
+		role, err = s.rolesRepo.Get(ctx, id)
+		if err != nil {
+			return err
+		}
+		attached, err = s.bundlesService.AttachBundles(ctx, role, domain, items)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	pubsub.Notify(pubsub.RuleChange, "apply role template "+template.UUID)
+	return &auth.ApplyRoleTemplateResponse{
+		Role:        role.ToProto(),
+		RoleBundles: entity.RoleBundleToProtoList(attached),
+	}, nil
+}
+
+// templateBundles returns the bundle entries of a template, every bundle once.
+func (s service) templateBundles(ctx context.Context, uuids []string) ([]entity.RoleTemplateBundle, error) {
+	var items []entity.RoleTemplateBundle
+	seen := map[string]bool{}
+	for _, id := range uuids {
+		if seen[id] {
+			continue
+		}
+		seen[id] = true
+		bundle, err := s.bundlesRepo.Get(ctx, id)
+		if err != nil {
+			return nil, err
+		}
+		items = append(items, entity.RoleTemplateBundle{BundleID: bundle.ID, Bundle: bundle})
+	}
+	return items, nil
+}
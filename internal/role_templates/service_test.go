@@ -0,0 +1,126 @@
+package role_templates
+
+import (
+	"context"
+	"testing"
+
+	"github.com/golang-tire/auth/internal/apps"
+	"github.com/golang-tire/auth/internal/bundles"
+	"github.com/golang-tire/auth/internal/domains"
+	"github.com/golang-tire/auth/internal/entity"
+	"github.com/golang-tire/auth/internal/groups"
+	"github.com/golang-tire/auth/internal/pkg/scope"
+	auth "github.com/golang-tire/auth/internal/proto/v1"
+	"github.com/golang-tire/auth/internal/roles"
+	"github.com/golang-tire/auth/internal/rules"
+	"github.com/golang-tire/auth/internal/users"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCreateRoleTemplateRequest_Validate(t *testing.T) {
+	id := "4e5c3a52-3b4f-4c38-9d61-4a4e1b9f2a10"
+	tests := []struct {
+		name      string
+		model     *auth.CreateRoleTemplateRequest
+		wantError bool
+	}{
+		{"success", &auth.CreateRoleTemplateRequest{Name: "editor", BundleUuids: []string{id}}, false},
+		{"required", &auth.CreateRoleTemplateRequest{Name: "", BundleUuids: []string{id}}, true},
+		{"no bundles", &auth.CreateRoleTemplateRequest{Name: "editor"}, true},
+		{"invalid uuid", &auth.CreateRoleTemplateRequest{Name: "editor", BundleUuids: []string{"articles-read"}}, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := ValidateCreateRequest(tt.model)
+			assert.Equal(t, tt.wantError, err != nil)
+		})
+	}
+}
+
+// createBundle creates a bundle of the actions on articles and returns its UUID.
+func createBundle(t *testing.T, s bundles.Service, name string, actions ...string) string {
+	var permissions []*auth.BundlePermission
+	for _, action := range actions {
+		permissions = append(permissions, &auth.BundlePermission{Resource: "articles", Action: action, Object: "*", Effect: auth.Effect_ALLOW})
+	}
+	bundle, err := s.Create(context.Background(), &auth.CreateBundleRequest{Name: name, Permissions: permissions})
+	assert.Nil(t, err)
+	return bundle.Uuid
+}
+
+func Test_service_CRUD(t *testing.T) {
+	rulesRepo := rules.NewMockRepository()
+	rolesRepo := roles.NewMockRepository()
+	domainsRepo := domains.NewMockRepository()
+	bundlesRepo := bundles.NewMockRepository()
+	rulesSrv := rules.NewService(rulesRepo, domainsRepo, domains.NewService(domainsRepo, rolesRepo), rolesRepo, users.NewMockRepository(), apps.NewMockRepository(), groups.NewMockRepository())
+	bundlesSrv := bundles.NewService(bundlesRepo, rolesRepo, domainsRepo, rulesSrv)
+	s := NewService(NewMockRepository(), rolesRepo, domainsRepo, bundlesRepo, bundlesSrv)
+	ctx := context.Background()
+	readUUID := createBundle(t, bundlesSrv, "articles-read", "get", "list")
+	writeUUID := createBundle(t, bundlesSrv, "articles-write", "create", "update")
+
+	template, err := s.Create(ctx, &auth.CreateRoleTemplateRequest{Name: "reader", BundleUuids: []string{readUUID, readUUID}})
+	assert.Nil(t, err)
+	assert.NotEmpty(t, template.Uuid)
+	assert.Len(t, template.Bundles, 1)
+
+	_, err = s.Create(ctx, &auth.CreateRoleTemplateRequest{Name: "reader", BundleUuids: []string{readUUID}})
+	assert.NotNil(t, err)
+	_, err = s.Create(ctx, &auth.CreateRoleTemplateRequest{Name: "writer", BundleUuids: []string{"4e5c3a52-3b4f-4c38-9d61-4a4e1b9f2a10"}})
+	assert.NotNil(t, err)
+	_, err = s.Create(scope.NewContext(ctx, scope.Domains("foo.bar")), &auth.CreateRoleTemplateRequest{Name: "writer", BundleUuids: []string{readUUID}})
+	assert.NotNil(t, err)
+	count, _ := s.Count(ctx)
+	assert.Equal(t, int64(1), count)
+
+	template, err = s.Update(ctx, &auth.UpdateRoleTemplateRequest{Uuid: template.Uuid, Name: "editor", Description: "edit articles", BundleUuids: []string{readUUID, writeUUID}})
+	assert.Nil(t, err)
+	assert.Equal(t, "editor", template.Name)
+	assert.Len(t, template.Bundles, 2)
+
+	_, err = s.Delete(ctx, template.Uuid)
+	assert.Nil(t, err)
+	_, err = s.Get(ctx, template.Uuid)
+	assert.NotNil(t, err)
+}
+
+func Test_service_Apply(t *testing.T) {
+	rulesRepo := rules.NewMockRepository()
+	rolesRepo := roles.NewMockRepository()
+	domainsRepo := domains.NewMockRepository()
+	bundlesRepo := bundles.NewMockRepository()
+	rulesSrv := rules.NewService(rulesRepo, domainsRepo, domains.NewService(domainsRepo, rolesRepo), rolesRepo, users.NewMockRepository(), apps.NewMockRepository(), groups.NewMockRepository())
+	bundlesSrv := bundles.NewService(bundlesRepo, rolesRepo, domainsRepo, rulesSrv)
+	s := NewService(NewMockRepository(), rolesRepo, domainsRepo, bundlesRepo, bundlesSrv)
+	ctx := context.Background()
+	domainUUID, _ := domainsRepo.Create(ctx, entity.Domain{Name: "foo.bar", Enable: true})
+	otherUUID, _ := domainsRepo.Create(ctx, entity.Domain{Name: "other.com", Enable: true})
+	readUUID := createBundle(t, bundlesSrv, "articles-read", "get", "list")
+	writeUUID := createBundle(t, bundlesSrv, "articles-write", "create")
+
+	template, err := s.Create(ctx, &auth.CreateRoleTemplateRequest{Name: "editor", BundleUuids: []string{readUUID, writeUUID}})
+	assert.Nil(t, err)
+
+	// the role gets a rule for every permission of the template bundles
+	res, err := s.Apply(ctx, &auth.ApplyRoleTemplateRequest{Uuid: template.Uuid, Role: "foo-editor", DomainUuid: domainUUID})
+	assert.Nil(t, err)
+	assert.Equal(t, "foo-editor", res.Role.Title)
+	assert.Len(t, res.RoleBundles, 2)
+	ruleList, _, _ := rulesRepo.Query(ctx, "", 0, 10)
+	assert.Len(t, ruleList, 3)
+	for _, rule := range ruleList {
+		assert.Equal(t, "foo-editor", rule.Role.Title)
+		assert.Equal(t, "foo.bar", rule.Domain.Name)
+	}
+
+	_, err = s.Apply(ctx, &auth.ApplyRoleTemplateRequest{Uuid: template.Uuid, Role: "foo-editor", DomainUuid: domainUUID})
+	assert.NotNil(t, err)
+
+	// domain admins apply templates in their domains only
+	adminCtx := scope.NewContext(ctx, scope.Domains("foo.bar"))
+	_, err = s.Apply(adminCtx, &auth.ApplyRoleTemplateRequest{Uuid: template.Uuid, Role: "other-editor", DomainUuid: otherUUID})
+	assert.NotNil(t, err)
+	_, err = rolesRepo.GetByTitle(ctx, "other-editor")
+	assert.NotNil(t, err)
+}
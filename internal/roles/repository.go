@@ -8,6 +8,7 @@ import (
 	"gorm.io/gorm"
 
 	"github.com/golang-tire/auth/internal/pkg/db"
+	"github.com/golang-tire/auth/internal/pkg/scope"
 
 	"github.com/google/uuid"
 
@@ -30,6 +31,8 @@ type Repository interface {
 	Update(ctx context.Context, role entity.Role) error
 	// Delete removes the role with given UUID from the storage.
 	Delete(ctx context.Context, role entity.Role) error
+	// HasGlobalRules reports whether the role has rules that apply in every domain.
+	HasGlobalRules(ctx context.Context, role entity.Role) (bool, error)
 }
 
 // repository persists roles in database
@@ -85,6 +88,16 @@ func (r repository) Delete(ctx context.Context, role entity.Role) error {
 	return res.Error
 }
 
+// HasGlobalRules reports whether the role has rules without a domain or in the global domain.
+func (r repository) HasGlobalRules(ctx context.Context, role entity.Role) (bool, error) {
+	var count int64
+	res := r.db.With(ctx).Model(&entity.Rule{}).
+		Joins("left join domains on domains.id = rules.domain_id").
+		Where("rules.role_id = ? and (rules.domain_id = 0 or domains.name = ?)", role.ID, scope.GlobalDomain).
+		Count(&count)
+	return count > 0, res.Error
+}
+
 // Count returns the number of the role records in the database.
 func (r repository) Count(ctx context.Context) (int64, error) {
 	var count int64
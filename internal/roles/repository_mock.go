@@ -17,7 +17,8 @@ func NewMockRepository() *mockRepository {
 }
 
 type mockRepository struct {
-	items []entity.Role
+	items  []entity.Role
+	lastID uint
 }
 
 func (m mockRepository) GetByTitle(ctx context.Context, title string) (entity.Role, error) {
@@ -52,6 +53,8 @@ func (m *mockRepository) Create(ctx context.Context, role entity.Role) (string,
 	if role.Title == "error" {
 		return Uuid, errCRUD
 	}
+	m.lastID++
+	role.ID = m.lastID
 	m.items = append(m.items, role)
 	return Uuid, nil
 }
@@ -79,3 +82,7 @@ func (m *mockRepository) Delete(ctx context.Context, role entity.Role) error {
 	}
 	return nil
 }
+
+func (m mockRepository) HasGlobalRules(ctx context.Context, role entity.Role) (bool, error) {
+	return false, nil
+}
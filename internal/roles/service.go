@@ -5,7 +5,10 @@ import (
 	"time"
 
 	validation "github.com/go-ozzo/ozzo-validation/v4"
+
 	"github.com/golang-tire/auth/internal/entity"
+	"github.com/golang-tire/auth/internal/pkg/pubsub"
+	"github.com/golang-tire/auth/internal/pkg/scope"
 	auth "github.com/golang-tire/auth/internal/proto/v1"
 )
 
@@ -77,6 +80,8 @@ func (s service) Update(ctx context.Context, req *auth.UpdateRoleRequest) (*auth
 		return nil, err
 	}
 
+	// rules and role assignments refer to roles by title
+	changed := role.Title != req.Title || role.Enable != req.Enable
 	now := time.Now()
 	role.Title = req.Title
 	role.Enable = req.Enable
@@ -84,6 +89,9 @@ func (s service) Update(ctx context.Context, req *auth.UpdateRoleRequest) (*auth
 	if err := s.repo.Update(ctx, role); err != nil {
 		return nil, err
 	}
+	if changed {
+		pubsub.Notify(pubsub.RuleChange, "update role "+role.UUID)
+	}
 	return role.ToProto(), nil
 }
 
@@ -117,3 +125,23 @@ func (s service) Query(ctx context.Context, query string, offset, limit int64) (
 		Limit:      limit,
 	}, nil
 }
+
+// CheckGrant keeps domain administrators from granting the platform admin role or roles
+// with rules that apply in every domain.
+func CheckGrant(ctx context.Context, repo Repository, role entity.Role) error {
+	sc := scope.FromContext(ctx)
+	if sc.IsGlobal() {
+		return nil
+	}
+	if role.Title == scope.PlatformAdminRole {
+		return sc.CheckGlobal("grant the `" + role.Title + "` role")
+	}
+	global, err := repo.HasGlobalRules(ctx, role)
+	if err != nil {
+		return err
+	}
+	if global {
+		return sc.CheckGlobal("grant the `" + role.Title + "` role, it has global rules")
+	}
+	return nil
+}
@@ -34,7 +34,7 @@ func (a api) InitGrpc(ctx context.Context, server *grpc.Server) {
 
 func (a api) ListRules(ctx context.Context, request *auth.ListRulesRequest) (*auth.ListRulesResponse, error) {
 	offset, limit := helpers.GetOffsetAndLimit(request.Offset, request.Limit)
-	res, err := a.service.Query(ctx, request.Query, offset, limit)
+	res, err := a.service.Query(ctx, request.Query, request.App, offset, limit)
 	if err != nil {
 		return nil, status.Error(codes.InvalidArgument, err.Error())
 	}
@@ -73,6 +73,63 @@ func (a api) DeleteRule(ctx context.Context, request *auth.DeleteRuleRequest) (*
 	return &empty.Empty{}, err
 }
 
+func (a api) LintRules(ctx context.Context, request *auth.LintRulesRequest) (*auth.LintRulesResponse, error) {
+	res, err := a.service.Lint(ctx, request.MinSeverity)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+	return res, err
+}
+
+func (a api) ExportPolicy(ctx context.Context, request *auth.ExportPolicyRequest) (*auth.ExportPolicyResponse, error) {
+	res, err := a.service.ExportPolicy(ctx, request.Format)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+	return res, err
+}
+
+func (a api) ImportPolicy(ctx context.Context, request *auth.ImportPolicyRequest) (*auth.ImportPolicyResponse, error) {
+	res, err := a.service.ImportPolicy(ctx, request)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+	return res, err
+}
+
+func (a api) ListPolicyRevisions(ctx context.Context, request *auth.ListPolicyRevisionsRequest) (*auth.ListPolicyRevisionsResponse, error) {
+	offset, limit := helpers.GetOffsetAndLimit(request.Offset, request.Limit)
+	res, err := a.service.QueryRevisions(ctx, offset, limit)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+	return res, err
+}
+
+func (a api) DiffPolicyRevisions(ctx context.Context, request *auth.DiffPolicyRevisionsRequest) (*auth.DiffPolicyRevisionsResponse, error) {
+	res, err := a.service.DiffRevisions(ctx, request.From, request.To)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+	return res, err
+}
+
+func (a api) RollbackPolicy(ctx context.Context, request *auth.RollbackPolicyRequest) (*auth.PolicyRevision, error) {
+	res, err := a.service.Rollback(ctx, request.Number)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+	return res, err
+}
+
+func (a api) PromoteRules(ctx context.Context, request *auth.PromoteRulesRequest) (*auth.PromoteRulesResponse, error) {
+	res, err := a.service.Promote(ctx, request.Uuids)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+	return res, err
+}
+
 func New(srv Service) API {
 	s := api{service: srv}
 	grpcgw.RegisterController(s)
@@ -0,0 +1,76 @@
+package rules
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/golang-tire/auth/internal/entity"
+)
+
+// checkEditable returns an error for rules generated from a bundle, they change with the
+// bundle and go away when it is detached.
+func checkEditable(rule entity.Rule) error {
+	if rule.RoleBundleID == nil {
+		return nil
+	}
+	name := "a bundle"
+	if rule.RoleBundle != nil {
+		name = fmt.Sprintf("bundle `%s`", rule.RoleBundle.Bundle.Name)
+	}
+	return fmt.Errorf("rule is generated from %s, change the bundle instead", name)
+}
+
+// SyncBundleRules replaces the rules generated from the bundle attachments with a rule for
+// every permission of their bundles and records a revision. The caller publishes the
+// policy change once the transaction is committed.
+func (s service) SyncBundleRules(ctx context.Context, roleBundles []entity.RoleBundle, reason string) error {
+	for _, rb := range roleBundles {
+		if err := s.removeBundleRules(ctx, rb); err != nil {
+			return err
+		}
+		id := rb.ID
+		for _, p := range rb.Bundle.Permissions {
+			rule := entity.Rule{
+				Role:         rb.Role,
+				RoleID:       rb.RoleID,
+				Domain:       rb.Domain,
+				DomainID:     rb.DomainID,
+				Resource:     p.Resource,
+				Action:       p.Action,
+				Object:       p.Object,
+				Effect:       p.Effect,
+				Condition:    p.Condition,
+				RoleBundleID: &id,
+			}
+			if err := s.checkConflicts(ctx, rule); err != nil {
+				return fmt.Errorf("bundle `%s` for role `%s`: %v", rb.Bundle.Name, rb.Role.Title, err)
+			}
+			if _, err := s.repo.Create(ctx, rule); err != nil {
+				return err
+			}
+		}
+	}
+	return s.RecordRevision(ctx, reason)
+}
+
+// RemoveBundleRules removes the rules generated from the bundle attachment and records a
+// revision. The caller publishes the policy change once the transaction is committed.
+func (s service) RemoveBundleRules(ctx context.Context, roleBundle entity.RoleBundle, reason string) error {
+	if err := s.removeBundleRules(ctx, roleBundle); err != nil {
+		return err
+	}
+	return s.RecordRevision(ctx, reason)
+}
+
+func (s service) removeBundleRules(ctx context.Context, roleBundle entity.RoleBundle) error {
+	items, err := s.repo.BundleRules(ctx, roleBundle)
+	if err != nil {
+		return err
+	}
+	for _, rule := range items {
+		if err := s.repo.Delete(ctx, rule); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,252 @@
+package rules
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/casbin/casbin/v2/util"
+	"github.com/golang-tire/auth/internal/entity"
+	"github.com/golang-tire/auth/internal/pkg/scope"
+	auth "github.com/golang-tire/auth/internal/proto/v1"
+	"github.com/golang-tire/pkg/config"
+)
+
+const (
+	lintDuplicate       = "duplicate"
+	lintConflict        = "conflict"
+	lintShadowed        = "shadowed"
+	lintOrphanRole      = "orphan_role"
+	lintUnknownResource = "unknown_resource"
+)
+
+var (
+	// blockConflicts rejects rule changes that introduce a definite conflict
+	blockConflicts = config.RegisterBool("rules.blockConflicts", false)
+	// bootstrapPolicy is the bootstrap policy of the enforcer, its role links make roles held
+	bootstrapPolicy = config.RegisterStringSlice("rbac.bootstrapPolicy", nil)
+)
+
+// Lint analyses all rules and returns the findings with at least the given severity.
+func (s service) Lint(ctx context.Context, minSeverity auth.LintSeverity) (*auth.LintRulesResponse, error) {
+	if err := scope.FromContext(ctx).CheckGlobal("lint the policy"); err != nil {
+		return nil, err
+	}
+
+	items, err := s.repo.All(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	heldRoles, err := s.heldRoles(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	resources, err := s.appsRepo.AllResources(ctx)
+	if err != nil {
+		return nil, err
+	}
+	var resourceNames []string
+	for _, r := range resources {
+		resourceNames = append(resourceNames, r.Name)
+	}
+
+	res := &auth.LintRulesResponse{}
+	for _, f := range LintRules(items, heldRoles, resourceNames) {
+		if f.Severity >= minSeverity {
+			res.Findings = append(res.Findings, f)
+		}
+	}
+	return res, nil
+}
+
+// heldRoles returns the titles of the roles somebody holds, through a user role, the role
+// binding of a group with members or a role link of the bootstrap policy.
+func (s service) heldRoles(ctx context.Context) (map[string]bool, error) {
+	held := map[string]bool{}
+	userRoles, err := s.usersRepo.AllUserRole(ctx)
+	if err != nil {
+		return nil, err
+	}
+	for _, ur := range userRoles {
+		held[ur.Role.Title] = true
+	}
+	groupRoles, err := s.groupsRepo.AllGroupRoles(ctx)
+	if err != nil {
+		return nil, err
+	}
+	for _, gr := range groupRoles {
+		if len(gr.Group.Members) > 0 {
+			held[gr.Role.Title] = true
+		}
+	}
+
+	// roles inherit the roles they are linked to, links of users hold the role themselves
+	links := bootstrapRoleLinks()
+	var queue []string
+	for subject, roles := range links {
+		if _, err := s.rolesRepo.GetByTitle(ctx, subject); err != nil {
+			queue = append(queue, roles...)
+		}
+	}
+	for role := range held {
+		queue = append(queue, links[role]...)
+	}
+	for len(queue) > 0 {
+		role := queue[0]
+		queue = queue[1:]
+		if !held[role] {
+			held[role] = true
+			queue = append(queue, links[role]...)
+		}
+	}
+	return held, nil
+}
+
+// bootstrapRoleLinks returns the roles the g lines of the bootstrap policy link every
+// subject to.
+func bootstrapRoleLinks() map[string][]string {
+	links := map[string][]string{}
+	for _, text := range bootstrapPolicy.Slice() {
+		tokens := strings.Split(text, ",")
+		if len(tokens) < 3 || strings.TrimSpace(tokens[0]) != "g" {
+			continue
+		}
+		subject := strings.TrimSpace(tokens[1])
+		links[subject] = append(links[subject], strings.TrimSpace(tokens[2]))
+	}
+	return links
+}
+
+// checkConflicts returns an error if rule is in definite conflict with an existing rule.
+func (s service) checkConflicts(ctx context.Context, rule entity.Rule) error {
+	if !blockConflicts.Bool() || rule.Shadow {
+		return nil
+	}
+
+	items, err := s.repo.All(ctx)
+	if err != nil {
+		return err
+	}
+	for _, item := range items {
+		if item.UUID == rule.UUID || item.Shadow {
+			continue
+		}
+		if sameTarget(item, rule) && !strings.EqualFold(item.Effect, rule.Effect) {
+			return fmt.Errorf("rule conflicts with rule `%s`", item.UUID)
+		}
+	}
+	return nil
+}
+
+// LintRules reports duplicate, conflicting and shadowed rules, rules of roles nobody
+// holds and rules naming resources that are not registered in any app.
+func LintRules(items []entity.Rule, heldRoles map[string]bool, resources []string) []*auth.LintFinding {
+	var findings []*auth.LintFinding
+
+	for i, a := range items {
+		for _, b := range items[i+1:] {
+			if !sameTarget(a, b) {
+				continue
+			}
+			if strings.EqualFold(a.Effect, b.Effect) {
+				findings = append(findings, &auth.LintFinding{
+					Severity: auth.LintSeverity_WARNING,
+					Code:     lintDuplicate,
+					Message:  fmt.Sprintf("rule %s is a duplicate", describeRule(b)),
+					Rules:    []string{a.UUID, b.UUID},
+				})
+			} else {
+				findings = append(findings, &auth.LintFinding{
+					Severity: auth.LintSeverity_ERROR,
+					Code:     lintConflict,
+					Message:  fmt.Sprintf("rule %s is both allowed and denied", describeRule(a)),
+					Rules:    []string{a.UUID, b.UUID},
+				})
+			}
+		}
+	}
+
+	for _, allow := range items {
+		if !isAllow(allow) {
+			continue
+		}
+		for _, deny := range items {
+			if isAllow(deny) || sameTarget(allow, deny) || !covers(deny, allow) {
+				continue
+			}
+			findings = append(findings, &auth.LintFinding{
+				Severity: auth.LintSeverity_WARNING,
+				Code:     lintShadowed,
+				Message:  fmt.Sprintf("allow rule %s is cancelled by deny rule %s", describeRule(allow), describeRule(deny)),
+				Rules:    []string{allow.UUID, deny.UUID},
+			})
+		}
+	}
+
+	for _, r := range items {
+		if !heldRoles[r.Role.Title] {
+			findings = append(findings, &auth.LintFinding{
+				Severity: auth.LintSeverity_INFO,
+				Code:     lintOrphanRole,
+				Message:  fmt.Sprintf("role `%s` of rule %s is not held by any user", r.Role.Title, describeRule(r)),
+				Rules:    []string{r.UUID},
+			})
+		}
+		if r.Resource != "*" && !matchesAny(resources, r.Resource) {
+			findings = append(findings, &auth.LintFinding{
+				Severity: auth.LintSeverity_WARNING,
+				Code:     lintUnknownResource,
+				Message:  fmt.Sprintf("resource `%s` of rule %s does not exist in any app", r.Resource, describeRule(r)),
+				Rules:    []string{r.UUID},
+			})
+		}
+	}
+	return findings
+}
+
+func sameTarget(a, b entity.Rule) bool {
+	return a.Role.Title == b.Role.Title &&
+		a.Domain.Name == b.Domain.Name &&
+		a.Resource == b.Resource &&
+		a.Action == b.Action &&
+		a.Object == b.Object &&
+		a.Condition == b.Condition
+}
+
+// covers reports whether every request matched by inner is matched by outer as well.
+// A conditional outer rule may not apply, so it covers nothing.
+func covers(outer, inner entity.Rule) bool {
+	return outer.Condition == "" &&
+		outer.Role.Title == inner.Role.Title &&
+		outer.Domain.Name == inner.Domain.Name &&
+		globMatch(inner.Resource, outer.Resource) &&
+		globMatch(inner.Action, outer.Action) &&
+		globMatch(inner.Object, outer.Object)
+}
+
+func isAllow(r entity.Rule) bool {
+	return strings.EqualFold(r.Effect, auth.Effect_ALLOW.String())
+}
+
+func matchesAny(names []string, pattern string) bool {
+	for _, name := range names {
+		if globMatch(name, pattern) {
+			return true
+		}
+	}
+	return false
+}
+
+func globMatch(key, pattern string) bool {
+	ok, err := util.GlobMatch(key, pattern)
+	return err == nil && ok
+}
+
+func describeRule(r entity.Rule) string {
+	if r.Condition != "" {
+		return fmt.Sprintf("(%s, %s, %s, %s, %s, %s, %s)", r.Role.Title, r.Domain.Name, r.Resource, r.Action, r.Object, r.Effect, r.Condition)
+	}
+	return fmt.Sprintf("(%s, %s, %s, %s, %s, %s)", r.Role.Title, r.Domain.Name, r.Resource, r.Action, r.Object, r.Effect)
+}
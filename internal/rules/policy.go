@@ -0,0 +1,559 @@
+package rules
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/golang-tire/auth/internal/entity"
+	"github.com/golang-tire/auth/internal/pkg/conditions"
+	"github.com/golang-tire/auth/internal/pkg/pubsub"
+	"github.com/golang-tire/auth/internal/pkg/scope"
+	auth "github.com/golang-tire/auth/internal/proto/v1"
+	"gopkg.in/yaml.v2"
+)
+
+// anyDomain is the domain name used in policy files for rules and user roles of all domains,
+// they are stored without a domain
+const anyDomain = scope.GlobalDomain
+
+// PolicyDocument is the structured form of a policy file.
+type PolicyDocument struct {
+	Rules     []PolicyRule     `yaml:"rules"`
+	UserRoles []PolicyUserRole `yaml:"userRoles"`
+}
+
+// PolicyRule is a rule line of a policy file.
+type PolicyRule struct {
+	Role     string `yaml:"role"`
+	Domain   string `yaml:"domain"`
+	Resource string `yaml:"resource"`
+	Action   string `yaml:"action"`
+	Object   string `yaml:"object"`
+	Effect   string `yaml:"effect"`
+	// Condition is the optional rule condition, in csv files it is the rest of the line
+	Condition string `yaml:"condition,omitempty"`
+	// Shadow rules are evaluated and logged but not enforced
+	Shadow bool `yaml:"shadow,omitempty"`
+	// AppResource and AppObject are the UUIDs of the app resource and object the rule references
+	AppResource string `yaml:"appResource,omitempty"`
+	AppObject   string `yaml:"appObject,omitempty"`
+	// Bundle is the UUID of the bundle attachment the rule is generated from
+	Bundle string `yaml:"bundle,omitempty"`
+}
+
+// PolicyUserRole is a user role line of a policy file.
+type PolicyUserRole struct {
+	User     string `yaml:"user"`
+	Role     string `yaml:"role"`
+	Domain   string `yaml:"domain"`
+	Disabled bool   `yaml:"disabled,omitempty"`
+	// ValidFrom and ExpiresAt bound the assignment in time, empty means unbounded
+	ValidFrom *time.Time `yaml:"validFrom,omitempty"`
+	ExpiresAt *time.Time `yaml:"expiresAt,omitempty"`
+}
+
+func (p PolicyRule) line() string {
+	fields := []string{"p", p.Role, p.Domain, p.Resource, p.Action, p.Object, p.Effect}
+	if p.Condition != "" {
+		fields = append(fields, p.Condition)
+	}
+	return strings.Join(fields, ", ")
+}
+
+func (p PolicyUserRole) line() string {
+	return strings.Join([]string{"g", p.User, p.Role, p.Domain}, ", ")
+}
+
+// attributes returns the rule settings csv lines can not hold.
+func (p PolicyRule) attributes() []string {
+	var attrs []string
+	if p.Shadow {
+		attrs = append(attrs, "shadow")
+	}
+	if p.AppResource != "" {
+		attrs = append(attrs, "appResource="+p.AppResource)
+	}
+	if p.AppObject != "" {
+		attrs = append(attrs, "appObject="+p.AppObject)
+	}
+	if p.Bundle != "" {
+		attrs = append(attrs, "bundle="+p.Bundle)
+	}
+	return attrs
+}
+
+// attributes returns the user role settings csv lines can not hold.
+func (p PolicyUserRole) attributes() []string {
+	var attrs []string
+	if p.Disabled {
+		attrs = append(attrs, "disabled")
+	}
+	if p.ValidFrom != nil {
+		attrs = append(attrs, "validFrom="+p.ValidFrom.UTC().Format(time.RFC3339))
+	}
+	if p.ExpiresAt != nil {
+		attrs = append(attrs, "expiresAt="+p.ExpiresAt.UTC().Format(time.RFC3339))
+	}
+	return attrs
+}
+
+// withAttributes appends the attributes to the policy line, the result identifies the
+// stored row the line stands for.
+func withAttributes(line string, attrs []string) string {
+	if len(attrs) == 0 {
+		return line
+	}
+	return line + " # " + strings.Join(attrs, ", ")
+}
+
+func (p PolicyRule) key() string {
+	return withAttributes(p.line(), p.attributes())
+}
+
+func (p PolicyUserRole) key() string {
+	return withAttributes(p.line(), p.attributes())
+}
+
+func (p *PolicyRule) normalize() error {
+	if p.Domain == "" {
+		p.Domain = anyDomain
+	}
+	p.Effect = strings.ToLower(p.Effect)
+	p.Condition = strings.TrimSpace(p.Condition)
+	if p.Role == "" || p.Resource == "" || p.Action == "" || p.Object == "" {
+		return fmt.Errorf("rule `%s` has empty fields", p.line())
+	}
+	if p.Effect != "allow" && p.Effect != "deny" {
+		return fmt.Errorf("rule `%s` has invalid effect `%s`", p.line(), p.Effect)
+	}
+	if err := conditions.Validate(p.Condition); err != nil {
+		return fmt.Errorf("rule `%s` has %v", p.line(), err)
+	}
+	return nil
+}
+
+func (p *PolicyUserRole) normalize() error {
+	if p.Domain == "" {
+		p.Domain = anyDomain
+	}
+	if p.User == "" || p.Role == "" {
+		return fmt.Errorf("user role `%s` has empty fields", p.line())
+	}
+	if p.ValidFrom != nil && p.ExpiresAt != nil && !p.ExpiresAt.After(*p.ValidFrom) {
+		return fmt.Errorf("user role `%s` expires before it is valid", p.line())
+	}
+	return nil
+}
+
+// ParsePolicy parses a casbin csv or yaml policy file.
+func ParsePolicy(format auth.PolicyFormat, content string) (*PolicyDocument, error) {
+	doc := &PolicyDocument{}
+	switch format {
+	case auth.PolicyFormat_CSV:
+		scanner := bufio.NewScanner(strings.NewReader(content))
+		for scanner.Scan() {
+			text := strings.TrimSpace(scanner.Text())
+			if text == "" || strings.HasPrefix(text, "#") {
+				continue
+			}
+
+			fields := strings.Split(text, ",")
+			for i := range fields {
+				fields[i] = strings.TrimSpace(fields[i])
+			}
+
+			switch {
+			case fields[0] == "p" && len(fields) >= 7:
+				doc.Rules = append(doc.Rules, PolicyRule{
+					Role:      fields[1],
+					Domain:    fields[2],
+					Resource:  fields[3],
+					Action:    fields[4],
+					Object:    fields[5],
+					Effect:    fields[6],
+					Condition: strings.Join(fields[7:], ", "),
+				})
+			case fields[0] == "g" && (len(fields) == 3 || len(fields) == 4):
+				ur := PolicyUserRole{User: fields[1], Role: fields[2]}
+				if len(fields) == 4 {
+					ur.Domain = fields[3]
+				}
+				doc.UserRoles = append(doc.UserRoles, ur)
+			default:
+				return nil, fmt.Errorf("invalid policy line `%s`", text)
+			}
+		}
+		if err := scanner.Err(); err != nil {
+			return nil, err
+		}
+	case auth.PolicyFormat_YAML:
+		if err := yaml.UnmarshalStrict([]byte(content), doc); err != nil {
+			return nil, err
+		}
+	default:
+		return nil, fmt.Errorf("unsupported policy format `%s`", format)
+	}
+
+	for i := range doc.Rules {
+		if err := doc.Rules[i].normalize(); err != nil {
+			return nil, err
+		}
+	}
+	for i := range doc.UserRoles {
+		if err := doc.UserRoles[i].normalize(); err != nil {
+			return nil, err
+		}
+	}
+	return doc, nil
+}
+
+// FormatPolicy encodes the policy document as casbin csv or yaml. Only yaml holds the shadow,
+// reference and bundle settings of rules and the state and validity of user roles, csv
+// fails for lines that have them.
+func FormatPolicy(format auth.PolicyFormat, doc *PolicyDocument) (string, error) {
+	switch format {
+	case auth.PolicyFormat_CSV:
+		var b strings.Builder
+		for _, r := range doc.Rules {
+			if len(r.attributes()) > 0 {
+				return "", errNotCSV(r.key())
+			}
+			b.WriteString(r.line() + "\n")
+		}
+		if len(doc.Rules) > 0 && len(doc.UserRoles) > 0 {
+			b.WriteString("\n")
+		}
+		for _, ur := range doc.UserRoles {
+			if len(ur.attributes()) > 0 {
+				return "", errNotCSV(ur.key())
+			}
+			b.WriteString(ur.line() + "\n")
+		}
+		return b.String(), nil
+	case auth.PolicyFormat_YAML:
+		out, err := yaml.Marshal(doc)
+		if err != nil {
+			return "", err
+		}
+		return string(out), nil
+	default:
+		return "", fmt.Errorf("unsupported policy format `%s`", format)
+	}
+}
+
+func errNotCSV(key string) error {
+	return fmt.Errorf("`%s` can not be represented in csv, use yaml", key)
+}
+
+// currentPolicy holds the stored rules and user roles keyed by their policy line and attributes.
+type currentPolicy struct {
+	doc       *PolicyDocument
+	rules     map[string]entity.Rule
+	userRoles map[string]entity.UserRole
+}
+
+func (s service) currentPolicy(ctx context.Context) (*currentPolicy, error) {
+	items, err := s.repo.All(ctx)
+	if err != nil {
+		return nil, err
+	}
+	userRoles, err := s.usersRepo.UserRoles(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	cur := &currentPolicy{
+		doc:       &PolicyDocument{},
+		rules:     map[string]entity.Rule{},
+		userRoles: map[string]entity.UserRole{},
+	}
+	for _, r := range items {
+		p := PolicyRule{
+			Role:      r.Role.Title,
+			Domain:    r.Domain.Name,
+			Resource:  r.Resource,
+			Action:    r.Action,
+			Object:    r.Object,
+			Effect:    r.Effect,
+			Condition: r.Condition,
+			Shadow:    r.Shadow,
+		}
+		if r.AppResource != nil {
+			p.AppResource = r.AppResource.UUID
+		}
+		if r.AppObject != nil {
+			p.AppObject = r.AppObject.UUID
+		}
+		if r.RoleBundle != nil {
+			p.Bundle = r.RoleBundle.UUID
+		}
+		_ = p.normalize()
+		cur.doc.Rules = append(cur.doc.Rules, p)
+		cur.rules[p.key()] = r
+	}
+	for _, ur := range userRoles {
+		p := PolicyUserRole{
+			User:      ur.User.Username,
+			Role:      ur.Role.Title,
+			Domain:    ur.Domain.Name,
+			Disabled:  !ur.Enable,
+			ValidFrom: utc(ur.ValidFrom),
+			ExpiresAt: utc(ur.ExpiresAt),
+		}
+		_ = p.normalize()
+		cur.doc.UserRoles = append(cur.doc.UserRoles, p)
+		cur.userRoles[p.key()] = ur
+	}
+	return cur, nil
+}
+
+func utc(t *time.Time) *time.Time {
+	if t == nil {
+		return nil
+	}
+	u := t.UTC()
+	return &u
+}
+
+// ExportPolicy returns the stored rules and user roles as a policy file.
+func (s service) ExportPolicy(ctx context.Context, format auth.PolicyFormat) (*auth.ExportPolicyResponse, error) {
+	if err := scope.FromContext(ctx).CheckGlobal("export the policy"); err != nil {
+		return nil, err
+	}
+
+	cur, err := s.currentPolicy(ctx)
+	if err != nil {
+		return nil, err
+	}
+	content, err := FormatPolicy(format, cur.doc)
+	if err != nil {
+		return nil, err
+	}
+	return &auth.ExportPolicyResponse{Format: format, Content: content}, nil
+}
+
+// ImportPolicy compares a policy file with the stored policy and, unless dry run is set,
+// applies the difference in one transaction. Missing roles and domains are created and,
+// with prune, stored lines that are not in the file are removed. Csv files can not prune
+// the lines only yaml can represent.
+func (s service) ImportPolicy(ctx context.Context, req *auth.ImportPolicyRequest) (*auth.ImportPolicyResponse, error) {
+	if err := scope.FromContext(ctx).CheckGlobal("import the policy"); err != nil {
+		return nil, err
+	}
+	return s.importPolicy(ctx, req, "import policy")
+}
+
+// importPolicy applies the policy file and records the result as a revision with the given reason.
+func (s service) importPolicy(ctx context.Context, req *auth.ImportPolicyRequest, reason string) (*auth.ImportPolicyResponse, error) {
+	doc, err := ParsePolicy(req.Format, req.Content)
+	if err != nil {
+		return nil, err
+	}
+	cur, err := s.currentPolicy(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	res := &auth.ImportPolicyResponse{}
+	desired := map[string]bool{}
+	var addRules []PolicyRule
+	var addUserRoles []PolicyUserRole
+	missingRoles := map[string]bool{}
+	missingDomains := map[string]bool{}
+
+	checkRefs := func(role, domain string) {
+		if _, err := s.rolesRepo.GetByTitle(ctx, role); err != nil && !missingRoles[role] {
+			missingRoles[role] = true
+			res.CreatedRoles = append(res.CreatedRoles, role)
+		}
+		if domain == anyDomain {
+			return
+		}
+		if _, err := s.domainsRepo.GetByName(ctx, domain); err != nil && !missingDomains[domain] {
+			missingDomains[domain] = true
+			res.CreatedDomains = append(res.CreatedDomains, domain)
+		}
+	}
+
+	for _, r := range doc.Rules {
+		key := r.key()
+		if desired[key] {
+			continue
+		}
+		desired[key] = true
+		if _, ok := cur.rules[key]; ok {
+			continue
+		}
+		checkRefs(r.Role, r.Domain)
+		if err := s.checkRuleRefs(ctx, r); err != nil {
+			return nil, err
+		}
+		addRules = append(addRules, r)
+		res.Added = append(res.Added, key)
+	}
+
+	users := map[string]entity.User{}
+	for _, ur := range doc.UserRoles {
+		key := ur.key()
+		if desired[key] {
+			continue
+		}
+		desired[key] = true
+		if _, ok := cur.userRoles[key]; ok {
+			continue
+		}
+		if _, ok := users[ur.User]; !ok {
+			user, err := s.usersRepo.FindOne(ctx, "username = ?", ur.User)
+			if err != nil {
+				return nil, fmt.Errorf("user `%s` not found", ur.User)
+			}
+			users[ur.User] = user
+		}
+		checkRefs(ur.Role, ur.Domain)
+		addUserRoles = append(addUserRoles, ur)
+		res.Added = append(res.Added, key)
+	}
+
+	var removeRules []entity.Rule
+	var removeUserRoles []entity.UserRole
+	if req.Prune {
+		csv := req.Format == auth.PolicyFormat_CSV
+		for _, r := range cur.doc.Rules {
+			if key := r.key(); !desired[key] {
+				if csv && len(r.attributes()) > 0 {
+					return nil, fmt.Errorf("can not prune %v", errNotCSV(key))
+				}
+				removeRules = append(removeRules, cur.rules[key])
+				res.Removed = append(res.Removed, key)
+			}
+		}
+		for _, ur := range cur.doc.UserRoles {
+			if key := ur.key(); !desired[key] {
+				if csv && len(ur.attributes()) > 0 {
+					return nil, fmt.Errorf("can not prune %v", errNotCSV(key))
+				}
+				removeUserRoles = append(removeUserRoles, cur.userRoles[key])
+				res.Removed = append(res.Removed, key)
+			}
+		}
+	}
+
+	if req.DryRun {
+		return res, nil
+	}
+
+	err = s.repo.Transactional(ctx, func(ctx context.Context) error {
+		for _, title := range res.CreatedRoles {
+			if _, err := s.rolesRepo.Create(ctx, entity.Role{Title: title, Enable: true}); err != nil {
+				return err
+			}
+		}
+		// the domains service validates the domains and drops the cached hosts and tree
+		for _, name := range res.CreatedDomains {
+			if _, err := s.domainsSrv.Create(ctx, &auth.CreateDomainRequest{Name: name, Enable: true}); err != nil {
+				return err
+			}
+		}
+
+		for _, r := range addRules {
+			role, domain, err := s.roleAndDomain(ctx, r.Role, r.Domain)
+			if err != nil {
+				return err
+			}
+			rule := entity.Rule{
+				Role:      role,
+				Domain:    domain,
+				Resource:  r.Resource,
+				Action:    r.Action,
+				Object:    r.Object,
+				Effect:    strings.ToUpper(r.Effect),
+				Condition: r.Condition,
+				Shadow:    r.Shadow,
+			}
+			if err := s.setReferences(ctx, &rule, r.AppResource, r.AppObject); err != nil {
+				return err
+			}
+			if r.Bundle != "" {
+				roleBundle, err := s.repo.GetRoleBundle(ctx, r.Bundle)
+				if err != nil {
+					return err
+				}
+				rule.RoleBundleID = &roleBundle.ID
+			}
+			if _, err := s.repo.Create(ctx, rule); err != nil {
+				return err
+			}
+		}
+		for _, ur := range addUserRoles {
+			role, domain, err := s.roleAndDomain(ctx, ur.Role, ur.Domain)
+			if err != nil {
+				return err
+			}
+			if _, err := s.usersRepo.AddUserRole(ctx, entity.UserRole{
+				User:      users[ur.User],
+				Role:      role,
+				Domain:    domain,
+				Enable:    !ur.Disabled,
+				ValidFrom: ur.ValidFrom,
+				ExpiresAt: ur.ExpiresAt,
+			}); err != nil {
+				return err
+			}
+		}
+
+		for _, r := range removeRules {
+			if err := s.repo.Delete(ctx, r); err != nil {
+				return err
+			}
+		}
+		for _, ur := range removeUserRoles {
+			if err := s.usersRepo.DeleteUserRole(ctx, ur); err != nil {
+				return err
+			}
+		}
+		return s.RecordRevision(ctx, reason)
+	})
+	if err != nil {
+		return nil, err
+	}
+	pubsub.Notify(pubsub.RuleChange, reason)
+
+	res.Applied = true
+	return res, nil
+}
+
+// checkRuleRefs returns an error if the app resource, app object or bundle attachment the
+// rule references does not exist.
+func (s service) checkRuleRefs(ctx context.Context, r PolicyRule) error {
+	if r.AppResource != "" {
+		if _, err := s.appsRepo.GetResource(ctx, r.AppResource); err != nil {
+			return err
+		}
+	}
+	if r.AppObject != "" {
+		if _, err := s.appsRepo.GetObject(ctx, r.AppObject); err != nil {
+			return err
+		}
+	}
+	if r.Bundle != "" {
+		if _, err := s.repo.GetRoleBundle(ctx, r.Bundle); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (s service) roleAndDomain(ctx context.Context, title, name string) (entity.Role, entity.Domain, error) {
+	role, err := s.rolesRepo.GetByTitle(ctx, title)
+	if err != nil {
+		return entity.Role{}, entity.Domain{}, err
+	}
+	domain, err := s.domain(ctx, name)
+	if err != nil {
+		return entity.Role{}, entity.Domain{}, err
+	}
+	return role, domain, nil
+}
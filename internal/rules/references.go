@@ -0,0 +1,129 @@
+package rules
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/golang-tire/auth/internal/entity"
+	"github.com/golang-tire/auth/internal/pkg/pubsub"
+)
+
+// setReferences points the rule to the app resource and object with the given UUIDs, the
+// resource and object of the rule take their names. Empty UUIDs drop the references.
+func (s service) setReferences(ctx context.Context, rule *entity.Rule, resourceUUID, objectUUID string) error {
+	rule.AppResourceID, rule.AppResource = nil, nil
+	rule.AppObjectID, rule.AppObject = nil, nil
+
+	if resourceUUID != "" {
+		resource, err := s.appsRepo.GetResource(ctx, resourceUUID)
+		if err != nil {
+			return err
+		}
+		if rule.Resource != "" && rule.Resource != resource.Name {
+			return fmt.Errorf("resource `%s` does not match the referenced resource `%s`", rule.Resource, resource.Name)
+		}
+		rule.Resource = resource.Name
+		rule.AppResourceID, rule.AppResource = &resource.ID, &resource
+	}
+
+	if objectUUID != "" {
+		object, err := s.appsRepo.GetObject(ctx, objectUUID)
+		if err != nil {
+			return err
+		}
+		if rule.Object != "" && rule.Object != object.Identifier {
+			return fmt.Errorf("object `%s` does not match the referenced object `%s`", rule.Object, object.Identifier)
+		}
+		rule.Object = object.Identifier
+		rule.AppObjectID, rule.AppObject = &object.ID, &object
+	}
+
+	if rule.AppResource != nil && rule.AppObject != nil && rule.AppResource.App.UUID != rule.AppObject.App.UUID {
+		return fmt.Errorf("resource `%s` and object `%s` belong to different apps", rule.Resource, rule.Object)
+	}
+	return nil
+}
+
+// RenameResource updates the resource of the rules referencing the app resource to its name.
+func (s service) RenameResource(ctx context.Context, resource entity.Resource) error {
+	items, err := s.repo.ResourceRules(ctx, resource)
+	if err != nil {
+		return err
+	}
+	return s.updateReferences(ctx, items, "rename resource "+resource.UUID, func(rule *entity.Rule) {
+		rule.Resource = resource.Name
+	})
+}
+
+// RenameObject updates the object of the rules referencing the app object to its identifier.
+func (s service) RenameObject(ctx context.Context, object entity.Object) error {
+	items, err := s.repo.ObjectRules(ctx, object)
+	if err != nil {
+		return err
+	}
+	return s.updateReferences(ctx, items, "rename object "+object.UUID, func(rule *entity.Rule) {
+		rule.Object = object.Identifier
+	})
+}
+
+// ReleaseResource deletes the rules referencing the app resource when cascade is set,
+// otherwise it fails while any rule references the resource.
+func (s service) ReleaseResource(ctx context.Context, resource entity.Resource, cascade bool) error {
+	items, err := s.repo.ResourceRules(ctx, resource)
+	if err != nil {
+		return err
+	}
+	if len(items) > 0 && !cascade {
+		return fmt.Errorf("resource `%s` is used by %d rules", resource.Name, len(items))
+	}
+	return s.deleteReferences(ctx, items, "delete resource "+resource.UUID)
+}
+
+// ReleaseObject deletes the rules referencing the app object when cascade is set,
+// otherwise it fails while any rule references the object.
+func (s service) ReleaseObject(ctx context.Context, object entity.Object, cascade bool) error {
+	items, err := s.repo.ObjectRules(ctx, object)
+	if err != nil {
+		return err
+	}
+	if len(items) > 0 && !cascade {
+		return fmt.Errorf("object `%s` is used by %d rules", object.Identifier, len(items))
+	}
+	return s.deleteReferences(ctx, items, "delete object "+object.UUID)
+}
+
+func (s service) updateReferences(ctx context.Context, items []entity.Rule, reason string, update func(rule *entity.Rule)) error {
+	if len(items) == 0 {
+		return nil
+	}
+	return s.repo.Transactional(ctx, func(ctx context.Context) error {
+		for _, rule := range items {
+			update(&rule)
+			rule.UpdatedAt = time.Now()
+			if err := s.repo.Update(ctx, rule); err != nil {
+				return err
+			}
+		}
+		return s.RecordRevision(ctx, reason)
+	})
+}
+
+func (s service) deleteReferences(ctx context.Context, items []entity.Rule, reason string) error {
+	if len(items) == 0 {
+		return nil
+	}
+	err := s.repo.Transactional(ctx, func(ctx context.Context) error {
+		for _, rule := range items {
+			if err := s.repo.Delete(ctx, rule); err != nil {
+				return err
+			}
+		}
+		return s.RecordRevision(ctx, reason)
+	})
+	if err != nil {
+		return err
+	}
+	pubsub.Notify(pubsub.RuleChange, reason)
+	return nil
+}
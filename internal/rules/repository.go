@@ -22,6 +22,19 @@ type Repository interface {
 	Count(ctx context.Context) (int64, error)
 	// Query returns the list of rules with the given offset and limit.
 	Query(ctx context.Context, query string, offset, limit int64) ([]entity.Rule, int, error)
+	// QueryInDomains returns the list of rules of the named domains with the given offset and limit.
+	QueryInDomains(ctx context.Context, query string, domains []string, offset, limit int64) ([]entity.Rule, int, error)
+	// QueryInApp returns the list of rules referencing the resources or objects of the app with
+	// the given offset and limit, only the rules of the named domains unless domains is nil.
+	QueryInApp(ctx context.Context, query string, app entity.App, domains []string, offset, limit int64) ([]entity.Rule, int, error)
+	// ResourceRules returns the rules referencing the app resource.
+	ResourceRules(ctx context.Context, resource entity.Resource) ([]entity.Rule, error)
+	// ObjectRules returns the rules referencing the app object.
+	ObjectRules(ctx context.Context, object entity.Object) ([]entity.Rule, error)
+	// BundleRules returns the rules generated from the bundle attachment.
+	BundleRules(ctx context.Context, roleBundle entity.RoleBundle) ([]entity.Rule, error)
+	// GetRoleBundle returns the bundle attachment with the specified UUID.
+	GetRoleBundle(ctx context.Context, uuid string) (entity.RoleBundle, error)
 	// Create saves a new rule in the storage.
 	Create(ctx context.Context, rule entity.Rule) (string, error)
 	// Update updates the rule with given UUID in the storage.
@@ -30,6 +43,16 @@ type Repository interface {
 	Delete(ctx context.Context, rule entity.Rule) error
 	// All retrieves all rules records from the database.
 	All(ctx context.Context) ([]entity.Rule, error)
+	// CreateRevision saves a new policy revision with the next revision number.
+	CreateRevision(ctx context.Context, revision entity.PolicyRevision) (entity.PolicyRevision, error)
+	// LatestRevision returns the policy revision with the highest number.
+	LatestRevision(ctx context.Context) (entity.PolicyRevision, error)
+	// GetRevision returns the policy revision with the specified number.
+	GetRevision(ctx context.Context, number int64) (entity.PolicyRevision, error)
+	// QueryRevisions returns the list of policy revisions with the given offset and limit.
+	QueryRevisions(ctx context.Context, offset, limit int64) ([]entity.PolicyRevision, int, error)
+	// Transactional runs f in a transaction, repository calls using the given context join it.
+	Transactional(ctx context.Context, f func(ctx context.Context) error) error
 }
 
 // repository persists rules in database
@@ -48,6 +71,9 @@ func (r repository) Get(ctx context.Context, uuid string) (entity.Rule, error) {
 	res := r.db.With(ctx).
 		Preload("Domain").
 		Preload("Role").
+		Preload("AppResource.App").
+		Preload("AppObject.App").
+		Preload("RoleBundle.Bundle").
 		Where("rules.uuid = ?", uuid).First(&rule)
 	if res.Error != nil && res.Error == gorm.ErrRecordNotFound {
 		return entity.Rule{}, fmt.Errorf("rule with uuid `%s` not found", uuid)
@@ -55,6 +81,16 @@ func (r repository) Get(ctx context.Context, uuid string) (entity.Rule, error) {
 	return rule, res.Error
 }
 
+// GetRoleBundle reads the bundle attachment with the specified UUID from the database.
+func (r repository) GetRoleBundle(ctx context.Context, uuid string) (entity.RoleBundle, error) {
+	var roleBundle entity.RoleBundle
+	res := r.db.With(ctx).Preload("Bundle").Where("uuid = ?", uuid).First(&roleBundle)
+	if res.Error != nil && res.Error == gorm.ErrRecordNotFound {
+		return entity.RoleBundle{}, fmt.Errorf("role bundle with uuid `%s` not found", uuid)
+	}
+	return roleBundle, res.Error
+}
+
 // Create saves a new rule record in the database.
 // It returns the UUID of the newly inserted rule record.
 func (r repository) Create(ctx context.Context, rule entity.Rule) (string, error) {
@@ -93,7 +129,10 @@ func (r repository) Query(ctx context.Context, query string, offset, limit int64
 		Offset(int(offset)).
 		Order("rules.id asc").
 		Preload("Domain").
-		Preload("Role")
+		Preload("Role").
+		Preload("AppResource.App").
+		Preload("AppObject.App").
+		Preload("RoleBundle.Bundle")
 
 	if len(query) >= 1 {
 		subQuery := r.db.With(ctx).Select("id").Where("title LIKE ?", "%"+query+"%").Table("roles")
@@ -109,6 +148,111 @@ func (r repository) Query(ctx context.Context, query string, offset, limit int64
 	return _rule, int(count), res.Error
 }
 
+// QueryInDomains retrieves the rule records of the named domains with the specified offset and limit from the database.
+func (r repository) QueryInDomains(ctx context.Context, query string, domains []string, offset, limit int64) ([]entity.Rule, int, error) {
+	var _rule []entity.Rule
+	var count int64
+
+	domainQuery := r.db.With(ctx).Select("id").Where("name IN (?)", domains).Table("domains")
+	res := r.db.With(ctx).Model(&entity.Rule{}).Where("domain_id IN (?)", domainQuery)
+	if len(query) >= 1 {
+		subQuery := r.db.With(ctx).Select("id").Where("title LIKE ?", "%"+query+"%").Table("roles")
+		res = res.Where("role_id IN (?)", subQuery)
+	}
+	if err := res.Count(&count).Error; err != nil {
+		return nil, 0, err
+	}
+
+	res = res.
+		Limit(int(limit)).
+		Offset(int(offset)).
+		Order("rules.id asc").
+		Preload("Domain").
+		Preload("Role").
+		Preload("AppResource.App").
+		Preload("AppObject.App").
+		Preload("RoleBundle.Bundle").
+		Find(&_rule)
+	return _rule, int(count), res.Error
+}
+
+// QueryInApp retrieves the rule records referencing the resources or objects of the app with
+// the specified offset and limit from the database.
+func (r repository) QueryInApp(ctx context.Context, query string, app entity.App, domains []string, offset, limit int64) ([]entity.Rule, int, error) {
+	var _rule []entity.Rule
+	var count int64
+
+	resourceQuery := r.db.With(ctx).Select("id").Where("app_id = ?", app.ID).Table("resources")
+	objectQuery := r.db.With(ctx).Select("id").Where("app_id = ?", app.ID).Table("objects")
+	res := r.db.With(ctx).Model(&entity.Rule{}).
+		Where("app_resource_id IN (?) OR app_object_id IN (?)", resourceQuery, objectQuery)
+	if domains != nil {
+		domainQuery := r.db.With(ctx).Select("id").Where("name IN (?)", domains).Table("domains")
+		res = res.Where("domain_id IN (?)", domainQuery)
+	}
+	if len(query) >= 1 {
+		subQuery := r.db.With(ctx).Select("id").Where("title LIKE ?", "%"+query+"%").Table("roles")
+		res = res.Where("role_id IN (?)", subQuery)
+	}
+	if err := res.Count(&count).Error; err != nil {
+		return nil, 0, err
+	}
+
+	res = res.
+		Limit(int(limit)).
+		Offset(int(offset)).
+		Order("rules.id asc").
+		Preload("Domain").
+		Preload("Role").
+		Preload("AppResource.App").
+		Preload("AppObject.App").
+		Preload("RoleBundle.Bundle").
+		Find(&_rule)
+	return _rule, int(count), res.Error
+}
+
+// ResourceRules retrieves the rule records referencing the resource from the database.
+func (r repository) ResourceRules(ctx context.Context, resource entity.Resource) ([]entity.Rule, error) {
+	var _rule []entity.Rule
+	res := r.db.With(ctx).
+		Where("app_resource_id = ?", resource.ID).
+		Order("rules.id asc").
+		Preload("Domain").
+		Preload("Role").
+		Preload("AppResource.App").
+		Preload("AppObject.App").
+		Preload("RoleBundle.Bundle").
+		Find(&_rule)
+	return _rule, res.Error
+}
+
+// ObjectRules retrieves the rule records referencing the object from the database.
+func (r repository) ObjectRules(ctx context.Context, object entity.Object) ([]entity.Rule, error) {
+	var _rule []entity.Rule
+	res := r.db.With(ctx).
+		Where("app_object_id = ?", object.ID).
+		Order("rules.id asc").
+		Preload("Domain").
+		Preload("Role").
+		Preload("AppResource.App").
+		Preload("AppObject.App").
+		Preload("RoleBundle.Bundle").
+		Find(&_rule)
+	return _rule, res.Error
+}
+
+// BundleRules retrieves the rule records generated from the bundle attachment from the database.
+func (r repository) BundleRules(ctx context.Context, roleBundle entity.RoleBundle) ([]entity.Rule, error) {
+	var _rule []entity.Rule
+	res := r.db.With(ctx).
+		Where("role_bundle_id = ?", roleBundle.ID).
+		Order("rules.id asc").
+		Preload("Domain").
+		Preload("Role").
+		Find(&_rule)
+	return _rule, res.Error
+}
+
 // All retrieves all rules records from the database.
 func (r repository) All(ctx context.Context) ([]entity.Rule, error) {
 	var _rule []entity.Rule
@@ -116,7 +260,67 @@ func (r repository) All(ctx context.Context) ([]entity.Rule, error) {
 		Order("rules.id asc").
 		Preload("Domain").
 		Preload("Role").
+		Preload("AppResource.App").
+		Preload("AppObject.App").
+		Preload("RoleBundle.Bundle").
 		Find(&_rule)
 
 	return _rule, res.Error
 }
+
+// CreateRevision saves a new policy revision record in the database.
+// The revision gets the number after the latest revision.
+func (r repository) CreateRevision(ctx context.Context, revision entity.PolicyRevision) (entity.PolicyRevision, error) {
+	var number int64
+	res := r.db.With(ctx).Model(&entity.PolicyRevision{}).Select("COALESCE(MAX(number), 0)").Scan(&number)
+	if res.Error != nil {
+		return revision, res.Error
+	}
+
+	now := time.Now()
+	revision.UUID = uuid.New().String()
+	revision.Number = number + 1
+	revision.CreatedAt = now
+	revision.UpdatedAt = now
+	res = r.db.With(ctx).Create(&revision)
+	return revision, res.Error
+}
+
+// LatestRevision reads the policy revision with the highest number from the database.
+func (r repository) LatestRevision(ctx context.Context) (entity.PolicyRevision, error) {
+	var revision entity.PolicyRevision
+	res := r.db.With(ctx).Order("number desc").First(&revision)
+	return revision, res.Error
+}
+
+// GetRevision reads the policy revision with the specified number from the database.
+func (r repository) GetRevision(ctx context.Context, number int64) (entity.PolicyRevision, error) {
+	var revision entity.PolicyRevision
+	res := r.db.With(ctx).Where("number = ?", number).First(&revision)
+	if res.Error != nil && res.Error == gorm.ErrRecordNotFound {
+		return entity.PolicyRevision{}, fmt.Errorf("policy revision `%d` not found", number)
+	}
+	return revision, res.Error
+}
+
+// QueryRevisions retrieves the policy revision records with the specified offset and limit from the database.
+func (r repository) QueryRevisions(ctx context.Context, offset, limit int64) ([]entity.PolicyRevision, int, error) {
+	var _revisions []entity.PolicyRevision
+	res := r.db.With(ctx).
+		Select("id", "uuid", "number", "reason", "created_at", "updated_at").
+		Limit(int(limit)).
+		Offset(int(offset)).
+		Order("number desc").
+		Find(&_revisions)
+
+	var count int64
+	if err := r.db.With(ctx).Model(&entity.PolicyRevision{}).Count(&count).Error; err != nil {
+		return nil, 0, err
+	}
+	return _revisions, int(count), res.Error
+}
+
+// Transactional runs f in a database transaction.
+func (r repository) Transactional(ctx context.Context, f func(ctx context.Context) error) error {
+	return r.db.Transactional(ctx, f)
+}
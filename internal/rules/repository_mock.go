@@ -19,7 +19,9 @@ func NewMockRepository() *MockRepository {
 
 // MockRepository rules mock repository
 type MockRepository struct {
-	items []entity.Rule
+	items       []entity.Rule
+	revisions   []entity.PolicyRevision
+	roleBundles []entity.RoleBundle
 }
 
 func (m MockRepository) Get(ctx context.Context, id string) (entity.Rule, error) {
@@ -39,6 +41,80 @@ func (m MockRepository) Query(ctx context.Context, query string, offset, limit i
 	return m.items, len(m.items), nil
 }
 
+func (m MockRepository) QueryInDomains(ctx context.Context, query string, domains []string, offset, limit int64) ([]entity.Rule, int, error) {
+	var items []entity.Rule
+	for _, item := range m.items {
+		for _, domain := range domains {
+			if item.Domain.Name == domain {
+				items = append(items, item)
+				break
+			}
+		}
+	}
+	return items, len(items), nil
+}
+
+func (m MockRepository) QueryInApp(ctx context.Context, query string, app entity.App, domains []string, offset, limit int64) ([]entity.Rule, int, error) {
+	var items []entity.Rule
+	for _, item := range m.items {
+		inApp := (item.AppResource != nil && item.AppResource.App.UUID == app.UUID) ||
+			(item.AppObject != nil && item.AppObject.App.UUID == app.UUID)
+		if !inApp {
+			continue
+		}
+		if domains != nil {
+			found := false
+			for _, domain := range domains {
+				found = found || item.Domain.Name == domain
+			}
+			if !found {
+				continue
+			}
+		}
+		items = append(items, item)
+	}
+	return items, len(items), nil
+}
+
+func (m MockRepository) ResourceRules(ctx context.Context, resource entity.Resource) ([]entity.Rule, error) {
+	var items []entity.Rule
+	for _, item := range m.items {
+		if item.AppResource != nil && item.AppResource.UUID == resource.UUID {
+			items = append(items, item)
+		}
+	}
+	return items, nil
+}
+
+func (m MockRepository) ObjectRules(ctx context.Context, object entity.Object) ([]entity.Rule, error) {
+	var items []entity.Rule
+	for _, item := range m.items {
+		if item.AppObject != nil && item.AppObject.UUID == object.UUID {
+			items = append(items, item)
+		}
+	}
+	return items, nil
+}
+
+func (m MockRepository) BundleRules(ctx context.Context, roleBundle entity.RoleBundle) ([]entity.Rule, error) {
+	var items []entity.Rule
+	for _, item := range m.items {
+		if item.RoleBundleID != nil && *item.RoleBundleID == roleBundle.ID {
+			items = append(items, item)
+		}
+	}
+	return items, nil
+}
+
+func (m MockRepository) GetRoleBundle(ctx context.Context, uuid string) (entity.RoleBundle, error) {
+	for _, item := range m.roleBundles {
+		if item.UUID == uuid {
+			return item, nil
+		}
+	}
+	return entity.RoleBundle{}, gorm.ErrRecordNotFound
+}
+
 func (m *MockRepository) Create(ctx context.Context, rule entity.Rule) (string, error) {
 	UUID := uuid.New().String()
 	rule.UUID = UUID
@@ -74,5 +150,43 @@ func (m *MockRepository) Delete(ctx context.Context, rule entity.Rule) error {
 }
 
 func (m *MockRepository) All(ctx context.Context) ([]entity.Rule, error) {
+	for i, item := range m.items {
+		for j, rb := range m.roleBundles {
+			if item.RoleBundleID != nil && *item.RoleBundleID == rb.ID {
+				m.items[i].RoleBundle = &m.roleBundles[j]
+			}
+		}
+	}
 	return m.items, nil
 }
+
+func (m MockRepository) Transactional(ctx context.Context, f func(ctx context.Context) error) error {
+	return f(ctx)
+}
+
+func (m *MockRepository) CreateRevision(ctx context.Context, revision entity.PolicyRevision) (entity.PolicyRevision, error) {
+	revision.UUID = uuid.New().String()
+	revision.Number = int64(len(m.revisions)) + 1
+	m.revisions = append(m.revisions, revision)
+	return revision, nil
+}
+
+func (m MockRepository) LatestRevision(ctx context.Context) (entity.PolicyRevision, error) {
+	if len(m.revisions) == 0 {
+		return entity.PolicyRevision{}, gorm.ErrRecordNotFound
+	}
+	return m.revisions[len(m.revisions)-1], nil
+}
+
+func (m MockRepository) GetRevision(ctx context.Context, number int64) (entity.PolicyRevision, error) {
+	for _, item := range m.revisions {
+		if item.Number == number {
+			return item, nil
+		}
+	}
+	return entity.PolicyRevision{}, gorm.ErrRecordNotFound
+}
+
+func (m MockRepository) QueryRevisions(ctx context.Context, offset, limit int64) ([]entity.PolicyRevision, int, error) {
+	return m.revisions, len(m.revisions), nil
+}
@@ -0,0 +1,168 @@
+package rules
+
+import (
+	"context"
+	"errors"
+	"strconv"
+
+	"gorm.io/gorm"
+
+	"github.com/golang-tire/auth/internal/entity"
+	"github.com/golang-tire/auth/internal/pkg/scope"
+	auth "github.com/golang-tire/auth/internal/proto/v1"
+	"github.com/golang-tire/pkg/log"
+)
+
+// RecordRevision stores the current rules and user roles as a new policy revision,
+// nothing is stored if the policy did not change since the latest revision.
+func (s service) RecordRevision(ctx context.Context, reason string) error {
+	cur, err := s.currentPolicy(ctx)
+	if err != nil {
+		return err
+	}
+	snapshot, err := FormatPolicy(auth.PolicyFormat_YAML, cur.doc)
+	if err != nil {
+		return err
+	}
+
+	latest, err := s.repo.LatestRevision(ctx)
+	if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+		return err
+	}
+	if err == nil && latest.Format == auth.PolicyFormat_YAML && latest.Snapshot == snapshot {
+		return nil
+	}
+
+	_, err = s.repo.CreateRevision(ctx, entity.PolicyRevision{
+		Reason:   reason,
+		Snapshot: snapshot,
+		Format:   auth.PolicyFormat_YAML,
+	})
+	return err
+}
+
+// recordRevision records a revision after a single change, failures are only logged
+// because the change itself is already stored.
+func (s service) recordRevision(ctx context.Context, reason string) {
+	if err := s.RecordRevision(ctx, reason); err != nil {
+		log.Error("record policy revision failed", log.Err(err))
+	}
+}
+
+// QueryRevisions returns the policy revisions with the specified offset and limit.
+func (s service) QueryRevisions(ctx context.Context, offset, limit int64) (*auth.ListPolicyRevisionsResponse, error) {
+	if err := scope.FromContext(ctx).CheckGlobal("list policy revisions"); err != nil {
+		return nil, err
+	}
+
+	items, count, err := s.repo.QueryRevisions(ctx, offset, limit)
+	if err != nil {
+		return nil, err
+	}
+	return &auth.ListPolicyRevisionsResponse{
+		Revisions:  entity.PolicyRevisionToProtoList(items),
+		TotalCount: int64(count),
+		Offset:     offset,
+		Limit:      limit,
+	}, nil
+}
+
+// DiffRevisions returns the policy lines added and removed from revision from to revision to.
+// A zero to compares with the latest revision.
+func (s service) DiffRevisions(ctx context.Context, from, to int64) (*auth.DiffPolicyRevisionsResponse, error) {
+	if err := scope.FromContext(ctx).CheckGlobal("diff policy revisions"); err != nil {
+		return nil, err
+	}
+
+	fromRev, err := s.repo.GetRevision(ctx, from)
+	if err != nil {
+		return nil, err
+	}
+
+	var toRev entity.PolicyRevision
+	if to == 0 {
+		toRev, err = s.repo.LatestRevision(ctx)
+	} else {
+		toRev, err = s.repo.GetRevision(ctx, to)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	fromLines, err := snapshotLines(fromRev)
+	if err != nil {
+		return nil, err
+	}
+	toLines, err := snapshotLines(toRev)
+	if err != nil {
+		return nil, err
+	}
+
+	res := &auth.DiffPolicyRevisionsResponse{}
+	for _, line := range toLines {
+		if !contains(fromLines, line) {
+			res.Added = append(res.Added, line)
+		}
+	}
+	for _, line := range fromLines {
+		if !contains(toLines, line) {
+			res.Removed = append(res.Removed, line)
+		}
+	}
+	return res, nil
+}
+
+// Rollback restores the rules and user roles of the given revision along with their shadow,
+// reference, bundle and validity settings. Older csv revisions can not restore those, the
+// rollback fails if it would remove rows that have them. The rollback is stored as a new
+// revision and announced to the enforcers on the rule-change topic.
+func (s service) Rollback(ctx context.Context, number int64) (*auth.PolicyRevision, error) {
+	if err := scope.FromContext(ctx).CheckGlobal("roll back the policy"); err != nil {
+		return nil, err
+	}
+
+	revision, err := s.repo.GetRevision(ctx, number)
+	if err != nil {
+		return nil, err
+	}
+
+	_, err = s.importPolicy(ctx, &auth.ImportPolicyRequest{
+		Format:  revision.Format,
+		Content: revision.Snapshot,
+		Prune:   true,
+	}, "rollback to revision "+strconv.FormatInt(number, 10))
+	if err != nil {
+		return nil, err
+	}
+
+	latest, err := s.repo.LatestRevision(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return latest.ToProto(), nil
+}
+
+func snapshotLines(revision entity.PolicyRevision) ([]string, error) {
+	doc, err := ParsePolicy(revision.Format, revision.Snapshot)
+	if err != nil {
+		return nil, err
+	}
+
+	var lines []string
+	for _, r := range doc.Rules {
+		lines = append(lines, r.key())
+	}
+	for _, ur := range doc.UserRoles {
+		lines = append(lines, ur.key())
+	}
+	return lines, nil
+}
+
+func contains(items []string, item string) bool {
+	for _, i := range items {
+		if i == item {
+			return true
+		}
+	}
+	return false
+}
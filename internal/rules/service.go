@@ -2,13 +2,20 @@ package rules
 
 import (
 	"context"
+	"fmt"
 	"time"
 
+	"github.com/golang-tire/auth/internal/apps"
 	"github.com/golang-tire/auth/internal/roles"
+	"github.com/golang-tire/auth/internal/users"
 
 	"github.com/golang-tire/auth/internal/domains"
+	"github.com/golang-tire/auth/internal/groups"
+	"github.com/golang-tire/auth/internal/pkg/conditions"
+	"github.com/golang-tire/auth/internal/pkg/scope"
 
 	validation "github.com/go-ozzo/ozzo-validation/v4"
+	"github.com/go-ozzo/ozzo-validation/v4/is"
 	"github.com/golang-tire/auth/internal/entity"
 	auth "github.com/golang-tire/auth/internal/proto/v1"
 )
@@ -16,23 +23,44 @@ import (
 // Service encapsulates use case logic for rules.
 type Service interface {
 	Get(ctx context.Context, uuid string) (*auth.Rule, error)
-	Query(ctx context.Context, query string, offset, limit int64) (*auth.ListRulesResponse, error)
+	Query(ctx context.Context, query, app string, offset, limit int64) (*auth.ListRulesResponse, error)
 	Count(ctx context.Context) (int64, error)
 	Create(ctx context.Context, input *auth.CreateRuleRequest) (*auth.Rule, error)
 	Update(ctx context.Context, input *auth.UpdateRuleRequest) (*auth.Rule, error)
 	Delete(ctx context.Context, uuid string) (*auth.Rule, error)
 	All(ctx context.Context) ([]entity.Rule, error)
+	Lint(ctx context.Context, minSeverity auth.LintSeverity) (*auth.LintRulesResponse, error)
+	ExportPolicy(ctx context.Context, format auth.PolicyFormat) (*auth.ExportPolicyResponse, error)
+	ImportPolicy(ctx context.Context, req *auth.ImportPolicyRequest) (*auth.ImportPolicyResponse, error)
+	RecordRevision(ctx context.Context, reason string) error
+	QueryRevisions(ctx context.Context, offset, limit int64) (*auth.ListPolicyRevisionsResponse, error)
+	DiffRevisions(ctx context.Context, from, to int64) (*auth.DiffPolicyRevisionsResponse, error)
+	Rollback(ctx context.Context, number int64) (*auth.PolicyRevision, error)
+	Promote(ctx context.Context, uuids []string) (*auth.PromoteRulesResponse, error)
+	RenameResource(ctx context.Context, resource entity.Resource) error
+	RenameObject(ctx context.Context, object entity.Object) error
+	ReleaseResource(ctx context.Context, resource entity.Resource, cascade bool) error
+	ReleaseObject(ctx context.Context, object entity.Object, cascade bool) error
+	// SyncBundleRules replaces the rules generated from the bundle attachments with the
+	// permissions of their bundles, it joins the transaction of the context.
+	SyncBundleRules(ctx context.Context, roleBundles []entity.RoleBundle, reason string) error
+	// RemoveBundleRules removes the rules generated from the bundle attachment, it joins
+	// the transaction of the context.
+	RemoveBundleRules(ctx context.Context, roleBundle entity.RoleBundle, reason string) error
 }
 
 // ValidateCreateRequest validates the CreateRuleRequest fields.
 func ValidateCreateRequest(c *auth.CreateRuleRequest) error {
 	return validation.ValidateStruct(c,
 		validation.Field(&c.Role, validation.Required, validation.Length(0, 128)),
-		validation.Field(&c.Object, validation.Required, validation.Length(0, 128)),
+		validation.Field(&c.Object, validation.When(c.ObjectUuid == "", validation.Required), validation.Length(0, 128)),
 		validation.Field(&c.Domain, validation.Required, validation.Length(0, 128)),
-		validation.Field(&c.Resource, validation.Required, validation.Length(0, 128)),
+		validation.Field(&c.Resource, validation.When(c.ResourceUuid == "", validation.Required), validation.Length(0, 128)),
 		validation.Field(&c.Action, validation.Required, validation.Length(0, 128)),
 		validation.Field(&c.Effect, validation.Required),
+		validation.Field(&c.Condition, validation.Length(0, 1024), validation.By(validateCondition)),
+		validation.Field(&c.ResourceUuid, is.UUID),
+		validation.Field(&c.ObjectUuid, is.UUID),
 	)
 }
 
@@ -40,23 +68,36 @@ func ValidateCreateRequest(c *auth.CreateRuleRequest) error {
 func ValidateUpdateRequest(u *auth.UpdateRuleRequest) error {
 	return validation.ValidateStruct(u,
 		validation.Field(&u.Role, validation.Required, validation.Length(0, 128)),
-		validation.Field(&u.Object, validation.Required, validation.Length(0, 128)),
+		validation.Field(&u.Object, validation.When(u.ObjectUuid == "", validation.Required), validation.Length(0, 128)),
 		validation.Field(&u.Domain, validation.Required, validation.Length(0, 128)),
-		validation.Field(&u.Resource, validation.Required, validation.Length(0, 128)),
+		validation.Field(&u.Resource, validation.When(u.ResourceUuid == "", validation.Required), validation.Length(0, 128)),
 		validation.Field(&u.Action, validation.Required, validation.Length(0, 128)),
 		validation.Field(&u.Effect, validation.Required),
+		validation.Field(&u.Condition, validation.Length(0, 1024), validation.By(validateCondition)),
+		validation.Field(&u.ResourceUuid, is.UUID),
+		validation.Field(&u.ObjectUuid, is.UUID),
 	)
 }
 
+func validateCondition(value interface{}) error {
+	cond, _ := value.(string)
+	return conditions.Validate(cond)
+}
+
 type service struct {
 	repo        Repository
 	domainsRepo domains.Repository
+	domainsSrv  domains.Service
 	rolesRepo   roles.Repository
+	usersRepo   users.Repository
+	appsRepo    apps.Repository
+	groupsRepo  groups.Repository
 }
 
 // NewService creates a new rule service.
-func NewService(repo Repository, domainsRepo domains.Repository, rolesRepo roles.Repository) Service {
-	return service{repo, domainsRepo, rolesRepo}
+func NewService(repo Repository, domainsRepo domains.Repository, domainsSrv domains.Service,
+	rolesRepo roles.Repository, usersRepo users.Repository, appsRepo apps.Repository, groupsRepo groups.Repository) Service {
+	return service{repo, domainsRepo, domainsSrv, rolesRepo, usersRepo, appsRepo, groupsRepo}
 }
 
 // Get returns the rule with the specified the rule UUID.
@@ -65,16 +106,39 @@ func (s service) Get(ctx context.Context, UUID string) (*auth.Rule, error) {
 	if err != nil {
 		return nil, err
 	}
+	if err := scope.FromContext(ctx).Check(ruleDomain(rule)); err != nil {
+		return nil, err
+	}
 	return rule.ToProto(), nil
 }
 
+// ruleDomain returns the domain name of the rule, rules without one apply to every domain.
+func ruleDomain(rule entity.Rule) string {
+	if rule.Domain.Name == "" {
+		return scope.GlobalDomain
+	}
+	return rule.Domain.Name
+}
+
+// domain returns the domain with the specified name, the global domain has no record and
+// its rules get the zero domain.
+func (s service) domain(ctx context.Context, name string) (entity.Domain, error) {
+	if name == scope.GlobalDomain {
+		return entity.Domain{}, nil
+	}
+	return s.domainsRepo.GetByName(ctx, name)
+}
+
 // Create creates a new rule.
 func (s service) Create(ctx context.Context, req *auth.CreateRuleRequest) (*auth.Rule, error) {
 	if err := ValidateCreateRequest(req); err != nil {
 		return nil, err
 	}
+	if err := scope.FromContext(ctx).Check(req.Domain); err != nil {
+		return nil, err
+	}
 
-	domain, err := s.domainsRepo.GetByName(ctx, req.Domain)
+	domain, err := s.domain(ctx, req.Domain)
 	if err != nil {
 		return nil, err
 	}
@@ -84,17 +148,28 @@ func (s service) Create(ctx context.Context, req *auth.CreateRuleRequest) (*auth
 		return nil, err
 	}
 
-	id, err := s.repo.Create(ctx, entity.Rule{
-		Role:     role,
-		Domain:   domain,
-		Object:   req.Object,
-		Action:   req.Action,
-		Resource: req.Resource,
-		Effect:   req.Effect.String(),
-	})
+	rule := entity.Rule{
+		Role:      role,
+		Domain:    domain,
+		Object:    req.Object,
+		Action:    req.Action,
+		Resource:  req.Resource,
+		Effect:    req.Effect.String(),
+		Shadow:    req.Shadow,
+		Condition: req.Condition,
+	}
+	if err := s.setReferences(ctx, &rule, req.ResourceUuid, req.ObjectUuid); err != nil {
+		return nil, err
+	}
+	if err := s.checkConflicts(ctx, rule); err != nil {
+		return nil, err
+	}
+
+	id, err := s.repo.Create(ctx, rule)
 	if err != nil {
 		return nil, err
 	}
+	s.recordRevision(ctx, "create rule "+id)
 	return s.Get(ctx, id)
 }
 
@@ -108,8 +183,18 @@ func (s service) Update(ctx context.Context, req *auth.UpdateRuleRequest) (*auth
 	if err != nil {
 		return nil, err
 	}
+	if err := checkEditable(rule); err != nil {
+		return nil, err
+	}
+	sc := scope.FromContext(ctx)
+	if err := sc.Check(ruleDomain(rule)); err != nil {
+		return nil, err
+	}
+	if err := sc.Check(req.Domain); err != nil {
+		return nil, err
+	}
 
-	domain, err := s.domainsRepo.GetByName(ctx, req.Domain)
+	domain, err := s.domain(ctx, req.Domain)
 	if err != nil {
 		return nil, err
 	}
@@ -122,15 +207,26 @@ func (s service) Update(ctx context.Context, req *auth.UpdateRuleRequest) (*auth
 	now := time.Now()
 	rule.Role = role
 	rule.Domain = domain
+	rule.DomainID = domain.ID
 	rule.Object = req.Object
 	rule.Action = req.Action
 	rule.Resource = req.Resource
 	rule.Effect = req.Effect.String()
+	rule.Shadow = req.Shadow
+	rule.Condition = req.Condition
 	rule.UpdatedAt = now
+	if err := s.setReferences(ctx, &rule, req.ResourceUuid, req.ObjectUuid); err != nil {
+		return nil, err
+	}
+
+	if err := s.checkConflicts(ctx, rule); err != nil {
+		return nil, err
+	}
 
 	if err := s.repo.Update(ctx, rule); err != nil {
 		return nil, err
 	}
+	s.recordRevision(ctx, "update rule "+rule.UUID)
 	return rule.ToProto(), nil
 }
 
@@ -140,20 +236,103 @@ func (s service) Delete(ctx context.Context, UUID string) (*auth.Rule, error) {
 	if err != nil {
 		return nil, err
 	}
+	if err := checkEditable(rule); err != nil {
+		return nil, err
+	}
+	if err := scope.FromContext(ctx).Check(ruleDomain(rule)); err != nil {
+		return nil, err
+	}
 	if err = s.repo.Delete(ctx, rule); err != nil {
 		return nil, err
 	}
+	s.recordRevision(ctx, "delete rule "+rule.UUID)
 	return rule.ToProto(), nil
 }
 
+// Promote turns the shadow rules with the given UUIDs into live rules,
+// all shadow rules are promoted if no UUID is given.
+func (s service) Promote(ctx context.Context, uuids []string) (*auth.PromoteRulesResponse, error) {
+	sc := scope.FromContext(ctx)
+	var items []entity.Rule
+	if len(uuids) == 0 {
+		if err := sc.CheckGlobal("promote all shadow rules"); err != nil {
+			return nil, err
+		}
+		all, err := s.repo.All(ctx)
+		if err != nil {
+			return nil, err
+		}
+		for _, rule := range all {
+			if rule.Shadow {
+				items = append(items, rule)
+			}
+		}
+	} else {
+		for _, id := range uuids {
+			rule, err := s.repo.Get(ctx, id)
+			if err != nil {
+				return nil, err
+			}
+			if !rule.Shadow {
+				return nil, fmt.Errorf("rule `%s` is not a shadow rule", id)
+			}
+			if err := sc.Check(ruleDomain(rule)); err != nil {
+				return nil, err
+			}
+			items = append(items, rule)
+		}
+	}
+
+	res := &auth.PromoteRulesResponse{}
+	err := s.repo.Transactional(ctx, func(ctx context.Context) error {
+		for _, rule := range items {
+			rule.Shadow = false
+			rule.UpdatedAt = time.Now()
+			if err := s.repo.Update(ctx, rule); err != nil {
+				return err
+			}
+			res.Rules = append(res.Rules, rule.ToProto())
+		}
+		return s.RecordRevision(ctx, "promote shadow rules")
+	})
+	if err != nil {
+		return nil, err
+	}
+	return res, nil
+}
+
 // Count returns the number of rules.
 func (s service) Count(ctx context.Context) (int64, error) {
 	return s.repo.Count(ctx)
 }
 
-// Query returns the rules with the specified offset and limit.
-func (s service) Query(ctx context.Context, query string, offset, limit int64) (*auth.ListRulesResponse, error) {
-	items, count, err := s.repo.Query(ctx, query, offset, limit)
+// Query returns the rules with the specified offset and limit, only the rules referencing
+// the resources or objects of the named app if app is set. Domain admins only see the rules
+// of the domains they administer.
+func (s service) Query(ctx context.Context, query, app string, offset, limit int64) (*auth.ListRulesResponse, error) {
+	var items []entity.Rule
+	var count int
+	var err error
+	sc := scope.FromContext(ctx)
+	switch {
+	case app != "":
+		a, err := s.appsRepo.GetAppByName(ctx, app)
+		if err != nil {
+			return nil, err
+		}
+		var domains []string
+		if !sc.IsGlobal() {
+			domains = sc.DomainList()
+		}
+		items, count, err = s.repo.QueryInApp(ctx, query, a, domains, offset, limit)
+		if err != nil {
+			return nil, err
+		}
+	case sc.IsGlobal():
+		items, count, err = s.repo.Query(ctx, query, offset, limit)
+	default:
+		items, count, err = s.repo.QueryInDomains(ctx, query, sc.DomainList(), offset, limit)
+	}
 	if err != nil {
 		return nil, err
 	}
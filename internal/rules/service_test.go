@@ -2,16 +2,24 @@ package rules
 
 import (
 	"context"
+	"errors"
 	"testing"
+	"time"
 
 	"github.com/golang-tire/auth/internal/pkg/testutils"
 
 	"github.com/golang-tire/auth/internal/entity"
 
+	"github.com/golang-tire/auth/internal/apps"
 	"github.com/golang-tire/auth/internal/domains"
+	"github.com/golang-tire/auth/internal/groups"
+	"github.com/golang-tire/auth/internal/pkg/scope"
 	auth "github.com/golang-tire/auth/internal/proto/v1"
 	"github.com/golang-tire/auth/internal/roles"
+	"github.com/golang-tire/auth/internal/users"
+	"github.com/golang-tire/pkg/config"
 	"github.com/stretchr/testify/assert"
+	"gorm.io/gorm"
 )
 
 func TestCreateRuleRequest_Validate(t *testing.T) {
@@ -41,6 +49,15 @@ func TestCreateRuleRequest_Validate(t *testing.T) {
 			Action: "1234567890123456789012345678901234567890123456789012345678901234567890123456789012345678901234567890123456789012345678901234567890",
 			Effect: auth.Effect_ALLOW,
 		}, true},
+		{"invalid condition", auth.CreateRuleRequest{
+			Role:      "test",
+			Domain:    "test",
+			Object:    "test",
+			Action:    "test",
+			Resource:  "test",
+			Effect:    auth.Effect_ALLOW,
+			Condition: "timeBetween(r.ctx.Time, ",
+		}, true},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -93,7 +110,7 @@ func Test_service_CRUD(t *testing.T) {
 	domainRepo := domains.NewMockRepository()
 	roleRepo := roles.NewMockRepository()
 
-	s := NewService(&MockRepository{}, domainRepo, roleRepo)
+	s := NewService(&MockRepository{}, domainRepo, domains.NewService(domainRepo, roleRepo), roleRepo, users.NewMockRepository(), apps.NewMockRepository(), groups.NewMockRepository())
 	ctx := context.Background()
 
 	// initial count
@@ -230,7 +247,7 @@ func Test_service_CRUD(t *testing.T) {
 	assert.Equal(t, id, rule.Uuid)
 
 	// query
-	_rules, _ := s.Query(ctx, "", 0, 0)
+	_rules, _ := s.Query(ctx, "", "", 0, 0)
 	assert.Equal(t, 2, int(_rules.TotalCount))
 
 	// delete
@@ -244,3 +261,556 @@ func Test_service_CRUD(t *testing.T) {
 
 	testutils.TestDown()
 }
+
+func TestLintRules(t *testing.T) {
+	admin := entity.Role{Title: "admin"}
+	guest := entity.Role{Title: "guest"}
+	domain := entity.Domain{Name: "foo.bar"}
+
+	items := []entity.Rule{
+		{UUID: "1", Role: admin, Domain: domain, Resource: "products", Action: "GET", Object: "*", Effect: "ALLOW"},
+		{UUID: "2", Role: admin, Domain: domain, Resource: "products", Action: "GET", Object: "*", Effect: "ALLOW"},
+		{UUID: "3", Role: admin, Domain: domain, Resource: "cars", Action: "GET", Object: "*", Effect: "ALLOW"},
+		{UUID: "4", Role: admin, Domain: domain, Resource: "cars", Action: "GET", Object: "*", Effect: "DENY"},
+		{UUID: "5", Role: admin, Domain: domain, Resource: "orders", Action: "POST", Object: "1", Effect: "ALLOW"},
+		{UUID: "6", Role: admin, Domain: domain, Resource: "orders", Action: "*", Object: "*", Effect: "DENY"},
+		{UUID: "7", Role: guest, Domain: domain, Resource: "unknown", Action: "GET", Object: "*", Effect: "ALLOW"},
+	}
+	heldRoles := map[string]bool{"admin": true}
+	resources := []string{"products", "cars", "orders"}
+
+	codes := map[string][]string{}
+	for _, f := range LintRules(items, heldRoles, resources) {
+		codes[f.Code] = append(codes[f.Code], f.Rules...)
+	}
+
+	assert.Equal(t, []string{"1", "2"}, codes[lintDuplicate])
+	assert.Equal(t, []string{"3", "4"}, codes[lintConflict])
+	assert.Equal(t, []string{"5", "6"}, codes[lintShadowed])
+	assert.Equal(t, []string{"7"}, codes[lintOrphanRole])
+	assert.Equal(t, []string{"7"}, codes[lintUnknownResource])
+}
+
+// stringSlice is a fixed string slice config value.
+type stringSlice []string
+
+func (s stringSlice) Slice() []string {
+	return s
+}
+
+func Test_service_LintHeldRoles(t *testing.T) {
+	defer func(policy config.StringSlice) { bootstrapPolicy = policy }(bootstrapPolicy)
+	bootstrapPolicy = stringSlice{"g, editor, viewer, *", "g, alice, auditor, *"}
+
+	ctx := context.Background()
+	repo := &MockRepository{}
+	domainRepo := domains.NewMockRepository()
+	roleRepo := roles.NewMockRepository()
+	usersRepo := users.NewMockRepository()
+	groupsRepo := groups.NewMockRepository()
+	s := NewService(repo, domainRepo, domains.NewService(domainRepo, roleRepo), roleRepo, usersRepo, apps.NewMockRepository(), groupsRepo)
+
+	role := func(title string) entity.Role {
+		_, err := roleRepo.Create(ctx, entity.Role{Title: title, Enable: true})
+		assert.Nil(t, err)
+		r, _ := roleRepo.GetByTitle(ctx, title)
+		return r
+	}
+	admin, editor, guest := role("admin"), role("editor"), role("guest")
+	role("viewer")
+	role("auditor")
+	_, err := usersRepo.AddUserRole(ctx, entity.UserRole{Role: admin, Enable: true})
+	assert.Nil(t, err)
+
+	// editor is held only through the binding of a group with members, guest through
+	// the binding of an empty group
+	_, err = groupsRepo.Create(ctx, entity.Group{Name: "editors", Enable: true})
+	assert.Nil(t, err)
+	_, err = groupsRepo.Create(ctx, entity.Group{Name: "guests", Enable: true})
+	assert.Nil(t, err)
+	assert.Nil(t, groupsRepo.AddMember(ctx, entity.GroupMember{GroupID: 1, User: entity.User{Username: "bob"}}))
+	_, err = groupsRepo.AddGroupRole(ctx, entity.GroupRole{GroupID: 1, Role: editor, Enable: true})
+	assert.Nil(t, err)
+	_, err = groupsRepo.AddGroupRole(ctx, entity.GroupRole{GroupID: 2, Role: guest, Enable: true})
+	assert.Nil(t, err)
+
+	for _, title := range []string{"admin", "editor", "viewer", "auditor", "guest", "nobody"} {
+		_, err := repo.Create(ctx, entity.Rule{Role: entity.Role{Title: title}, Resource: title, Action: "get", Object: "*", Effect: "ALLOW"})
+		assert.Nil(t, err)
+	}
+
+	res, err := s.Lint(ctx, auth.LintSeverity_INFO)
+	assert.Nil(t, err)
+	var orphans []string
+	for _, f := range res.Findings {
+		if f.Code == lintOrphanRole {
+			orphans = append(orphans, f.Message)
+		}
+	}
+	// viewer is inherited from editor, auditor is linked to a user by the bootstrap policy
+	if assert.Len(t, orphans, 2) {
+		assert.Contains(t, orphans[0], "guest")
+		assert.Contains(t, orphans[1], "nobody")
+	}
+}
+
+func TestParsePolicy(t *testing.T) {
+	csv := `
+p, role:admin, *, users, get, *, allow
+p, role:owner, foo.bar, users, *, *, DENY
+
+g, mohsen, role:admin
+g, mina, role:owner, foo.bar
+`
+	doc, err := ParsePolicy(auth.PolicyFormat_CSV, csv)
+	assert.Nil(t, err)
+	assert.Len(t, doc.Rules, 2)
+	assert.Equal(t, "deny", doc.Rules[1].Effect)
+	assert.Equal(t, "*", doc.UserRoles[0].Domain)
+	assert.Equal(t, "foo.bar", doc.UserRoles[1].Domain)
+
+	out, err := FormatPolicy(auth.PolicyFormat_YAML, doc)
+	assert.Nil(t, err)
+	parsed, err := ParsePolicy(auth.PolicyFormat_YAML, out)
+	assert.Nil(t, err)
+	assert.Equal(t, doc, parsed)
+
+	_, err = ParsePolicy(auth.PolicyFormat_CSV, "p, admin, *, users")
+	assert.NotNil(t, err)
+	_, err = ParsePolicy(auth.PolicyFormat_CSV, "p, admin, *, users, get, *, maybe")
+	assert.NotNil(t, err)
+}
+
+func TestParsePolicy_Condition(t *testing.T) {
+	csv := `p, admin, *, users, get, *, allow, ipIn(r.ctx.IP, "10.0.0.0/8") && timeBetween(r.ctx.Time, "09:00", "17:00")`
+	doc, err := ParsePolicy(auth.PolicyFormat_CSV, csv)
+	assert.Nil(t, err)
+	assert.Equal(t, `ipIn(r.ctx.IP, "10.0.0.0/8") && timeBetween(r.ctx.Time, "09:00", "17:00")`, doc.Rules[0].Condition)
+
+	out, err := FormatPolicy(auth.PolicyFormat_CSV, doc)
+	assert.Nil(t, err)
+	assert.Equal(t, csv+"\n", out)
+
+	_, err = ParsePolicy(auth.PolicyFormat_CSV, `p, admin, *, users, get, *, allow, ipIn(r.ctx.IP`)
+	assert.NotNil(t, err)
+}
+
+func Test_service_ImportPolicy(t *testing.T) {
+	testutils.TestUp()
+
+	ctx := context.Background()
+	usersRepo := users.NewMockRepository()
+	_, err := usersRepo.Create(ctx, entity.User{Username: "mohsen", Email: "mohsen@foo.bar"})
+	assert.Nil(t, err)
+
+	repo := &MockRepository{}
+	domainRepo := domains.NewMockRepository()
+	roleRepo := roles.NewMockRepository()
+	domainsSrv := domains.NewService(domainRepo, roleRepo)
+	s := NewService(repo, domainRepo, domainsSrv, roleRepo, usersRepo, apps.NewMockRepository(), groups.NewMockRepository())
+	req := &auth.ImportPolicyRequest{
+		Format:  auth.PolicyFormat_CSV,
+		Content: "p, admin, foo.bar, users, get, *, allow\ng, mohsen, admin, foo.bar\n",
+		DryRun:  true,
+	}
+
+	// dry run only reports the difference
+	res, err := s.ImportPolicy(ctx, req)
+	assert.Nil(t, err)
+	assert.False(t, res.Applied)
+	assert.Equal(t, []string{"admin"}, res.CreatedRoles)
+	assert.Equal(t, []string{"foo.bar"}, res.CreatedDomains)
+	assert.Len(t, res.Added, 2)
+	count, _ := s.Count(ctx)
+	assert.Equal(t, int64(0), count)
+
+	_, err = domainsSrv.ResolveHost(ctx, "foo.bar")
+	assert.True(t, errors.Is(err, domains.ErrUnknownHost))
+
+	// apply
+	req.DryRun = false
+	res, err = s.ImportPolicy(ctx, req)
+	assert.Nil(t, err)
+	assert.True(t, res.Applied)
+	count, _ = s.Count(ctx)
+	assert.Equal(t, int64(1), count)
+	// the created domains are resolved without waiting for another domain change
+	domain, err := domainsSrv.ResolveHost(ctx, "foo.bar")
+	assert.Nil(t, err)
+	assert.Equal(t, "foo.bar", domain.Name)
+
+	// unknown users are rejected
+	req.Content = "g, nobody, admin\n"
+	_, err = s.ImportPolicy(ctx, req)
+	assert.NotNil(t, err)
+
+	// global lines are stored without a domain
+	req.Content = "p, admin, *, users, get, *, allow\ng, mohsen, admin\n"
+	res, err = s.ImportPolicy(ctx, req)
+	assert.Nil(t, err)
+	assert.Empty(t, res.CreatedDomains)
+	assert.Len(t, res.Added, 2)
+	items, err := repo.All(ctx)
+	assert.Nil(t, err)
+	assert.Equal(t, uint(0), items[len(items)-1].DomainID)
+	assert.Equal(t, "*", ruleDomain(items[len(items)-1]))
+	export, err := s.ExportPolicy(ctx, auth.PolicyFormat_CSV)
+	assert.Nil(t, err)
+	assert.Contains(t, export.Content, "p, admin, *, users, get, *, allow\n")
+	assert.Contains(t, export.Content, "g, mohsen, admin, *\n")
+}
+
+func Test_service_ExportPolicy(t *testing.T) {
+	testutils.TestUp()
+
+	ctx := context.Background()
+	usersRepo := users.NewMockRepository()
+	_, err := usersRepo.Create(ctx, entity.User{Username: "mohsen", Email: "mohsen@foo.bar"})
+	assert.Nil(t, err)
+	domainRepo := domains.NewMockRepository()
+	roleRepo := roles.NewMockRepository()
+	s := NewService(&MockRepository{}, domainRepo, domains.NewService(domainRepo, roleRepo), roleRepo, usersRepo, apps.NewMockRepository(), groups.NewMockRepository())
+
+	content := `rules:
+- role: admin
+  domain: foo.bar
+  resource: users
+  action: get
+  object: '*'
+  effect: allow
+- role: admin
+  domain: foo.bar
+  resource: users
+  action: delete
+  object: '*'
+  effect: allow
+  shadow: true
+userRoles:
+- user: mohsen
+  role: admin
+  domain: foo.bar
+  disabled: true
+  validFrom: 2030-01-01T00:00:00Z
+  expiresAt: 2030-02-01T00:00:00Z
+`
+	res, err := s.ImportPolicy(ctx, &auth.ImportPolicyRequest{Format: auth.PolicyFormat_YAML, Content: content})
+	assert.Nil(t, err)
+	assert.Equal(t, []string{
+		"p, admin, foo.bar, users, get, *, allow",
+		"p, admin, foo.bar, users, delete, *, allow # shadow",
+		"g, mohsen, admin, foo.bar # disabled, validFrom=2030-01-01T00:00:00Z, expiresAt=2030-02-01T00:00:00Z",
+	}, res.Added)
+
+	// yaml exports round trip
+	export, err := s.ExportPolicy(ctx, auth.PolicyFormat_YAML)
+	assert.Nil(t, err)
+	assert.Equal(t, content, export.Content)
+	res, err = s.ImportPolicy(ctx, &auth.ImportPolicyRequest{Format: auth.PolicyFormat_YAML, Content: export.Content, Prune: true, DryRun: true})
+	assert.Nil(t, err)
+	assert.Empty(t, res.Added)
+	assert.Empty(t, res.Removed)
+
+	// csv can not hold the shadow rule and the user role settings
+	_, err = s.ExportPolicy(ctx, auth.PolicyFormat_CSV)
+	assert.NotNil(t, err)
+	_, err = s.ImportPolicy(ctx, &auth.ImportPolicyRequest{
+		Format:  auth.PolicyFormat_CSV,
+		Content: "p, admin, foo.bar, users, get, *, allow\n",
+		Prune:   true,
+		DryRun:  true,
+	})
+	assert.NotNil(t, err)
+}
+
+func Test_service_Revisions(t *testing.T) {
+	testutils.TestUp()
+
+	ctx := context.Background()
+	domainRepo := domains.NewMockRepository()
+	roleRepo := roles.NewMockRepository()
+	s := NewService(&MockRepository{}, domainRepo, domains.NewService(domainRepo, roleRepo), roleRepo, users.NewMockRepository(), apps.NewMockRepository(), groups.NewMockRepository())
+
+	apply := func(content string) {
+		_, err := s.ImportPolicy(ctx, &auth.ImportPolicyRequest{Format: auth.PolicyFormat_CSV, Content: content, Prune: true})
+		assert.Nil(t, err)
+	}
+	apply("p, admin, foo.bar, users, get, *, allow\n")
+	apply("p, admin, foo.bar, users, get, *, allow\np, admin, foo.bar, cars, get, *, allow\n")
+
+	// unchanged policy does not create a revision
+	assert.Nil(t, s.RecordRevision(ctx, "noop"))
+	revisions, err := s.QueryRevisions(ctx, 0, 10)
+	assert.Nil(t, err)
+	assert.Equal(t, int64(2), revisions.TotalCount)
+
+	diff, err := s.DiffRevisions(ctx, 1, 2)
+	assert.Nil(t, err)
+	assert.Equal(t, []string{"p, admin, foo.bar, cars, get, *, allow"}, diff.Added)
+	assert.Empty(t, diff.Removed)
+
+	_, err = s.DiffRevisions(ctx, 1, 5)
+	assert.NotNil(t, err)
+
+	revision, err := s.Rollback(ctx, 1)
+	assert.Nil(t, err)
+	assert.Equal(t, int64(3), revision.Number)
+	count, _ := s.Count(ctx)
+	assert.Equal(t, int64(1), count)
+}
+
+func Test_service_RollbackSettings(t *testing.T) {
+	testutils.TestUp()
+
+	ctx := context.Background()
+	usersRepo := users.NewMockRepository()
+	domainRepo := domains.NewMockRepository()
+	roleRepo := roles.NewMockRepository()
+	appsRepo := apps.NewMockRepository()
+	_, _ = usersRepo.Create(ctx, entity.User{Username: "mohsen", Email: "mohsen@foo.bar"})
+	_, _ = domainRepo.Create(ctx, entity.Domain{Name: "foo.bar", Enable: true})
+	_, _ = roleRepo.Create(ctx, entity.Role{Title: "admin", Enable: true})
+	shopID, _ := appsRepo.CreateApp(ctx, entity.App{Name: "shop"})
+	shop, _ := appsRepo.GetApp(ctx, shopID)
+	ordersID, _ := appsRepo.CreateResource(ctx, shop, entity.Resource{Name: "orders"})
+	mohsen, _ := usersRepo.FindOne(ctx, "username = ?", "mohsen")
+	role, _ := roleRepo.GetByTitle(ctx, "admin")
+	domain, _ := domainRepo.GetByName(ctx, "foo.bar")
+
+	repo := &MockRepository{}
+	s := NewService(repo, domainRepo, domains.NewService(domainRepo, roleRepo), roleRepo, usersRepo, appsRepo, groups.NewMockRepository())
+
+	// a bundle rule, a shadow rule, a rule referencing an app resource and an expiring user role
+	repo.roleBundles = []entity.RoleBundle{{
+		Model:  gorm.Model{ID: 1},
+		UUID:   "0b8a2f6e-5d4c-4b1a-9e3f-7c6d5e4f3a21",
+		Role:   role,
+		Domain: domain,
+		Bundle: entity.PermissionBundle{Name: "billing", Permissions: []entity.BundlePermission{
+			{Resource: "invoices", Action: "read", Object: "*", Effect: "ALLOW"},
+		}},
+	}}
+	assert.Nil(t, s.SyncBundleRules(ctx, repo.roleBundles, "attach billing"))
+	_, err := s.Create(ctx, &auth.CreateRuleRequest{
+		Role: "admin", Domain: "foo.bar", Resource: "products", Object: "*", Action: "delete", Effect: auth.Effect_ALLOW, Shadow: true,
+	})
+	assert.Nil(t, err)
+	_, err = s.Create(ctx, &auth.CreateRuleRequest{
+		Role: "admin", Domain: "foo.bar", ResourceUuid: ordersID, Object: "*", Action: "read", Effect: auth.Effect_ALLOW,
+	})
+	assert.Nil(t, err)
+	validFrom := time.Date(2030, 1, 1, 0, 0, 0, 0, time.UTC)
+	expiresAt := validFrom.Add(30 * 24 * time.Hour)
+	_, err = usersRepo.AddUserRole(ctx, entity.UserRole{
+		User: mohsen, Role: role, Domain: domain, ValidFrom: &validFrom, ExpiresAt: &expiresAt,
+	})
+	assert.Nil(t, err)
+	assert.Nil(t, s.RecordRevision(ctx, "add expiring role"))
+	saved, err := repo.LatestRevision(ctx)
+	assert.Nil(t, err)
+
+	_, err = s.ImportPolicy(ctx, &auth.ImportPolicyRequest{Format: auth.PolicyFormat_YAML, Content: "{}", Prune: true})
+	assert.Nil(t, err)
+	count, _ := s.Count(ctx)
+	assert.Equal(t, int64(0), count)
+	userRoles, _ := usersRepo.UserRoles(ctx)
+	assert.Empty(t, userRoles)
+
+	// the rollback restores the rows with their settings
+	_, err = s.Rollback(ctx, saved.Number)
+	assert.Nil(t, err)
+	items, err := repo.All(ctx)
+	assert.Nil(t, err)
+	assert.Len(t, items, 3)
+	for _, rule := range items {
+		switch rule.Resource {
+		case "invoices":
+			assert.Equal(t, uint(1), *rule.RoleBundleID)
+		case "products":
+			assert.True(t, rule.Shadow)
+		case "orders":
+			assert.Equal(t, ordersID, rule.AppResource.UUID)
+		default:
+			t.Errorf("unexpected rule %v", rule.Resource)
+		}
+	}
+	userRoles, err = usersRepo.UserRoles(ctx)
+	assert.Nil(t, err)
+	assert.Len(t, userRoles, 1)
+	assert.False(t, userRoles[0].Enable)
+	assert.True(t, validFrom.Equal(*userRoles[0].ValidFrom))
+	assert.True(t, expiresAt.Equal(*userRoles[0].ExpiresAt))
+
+	diff, err := s.DiffRevisions(ctx, saved.Number, 0)
+	assert.Nil(t, err)
+	assert.Empty(t, diff.Added)
+	assert.Empty(t, diff.Removed)
+}
+
+func Test_service_Promote(t *testing.T) {
+	testutils.TestUp()
+
+	ctx := context.Background()
+	domainRepo := domains.NewMockRepository()
+	roleRepo := roles.NewMockRepository()
+	_, _ = domainRepo.Create(ctx, entity.Domain{Name: "foo.bar", Enable: true})
+	_, _ = roleRepo.Create(ctx, entity.Role{Title: "admin", Enable: true})
+
+	s := NewService(&MockRepository{}, domainRepo, domains.NewService(domainRepo, roleRepo), roleRepo, users.NewMockRepository(), apps.NewMockRepository(), groups.NewMockRepository())
+	rule, err := s.Create(ctx, &auth.CreateRuleRequest{
+		Role:     "admin",
+		Resource: "products",
+		Domain:   "foo.bar",
+		Object:   "*",
+		Action:   "DELETE",
+		Effect:   auth.Effect_ALLOW,
+		Shadow:   true,
+	})
+	assert.Nil(t, err)
+	assert.True(t, rule.Shadow)
+
+	// shadow rules are exported to yaml only
+	_, err = s.ExportPolicy(ctx, auth.PolicyFormat_CSV)
+	assert.NotNil(t, err)
+	export, err := s.ExportPolicy(ctx, auth.PolicyFormat_YAML)
+	assert.Nil(t, err)
+	assert.Contains(t, export.Content, "shadow: true")
+
+	res, err := s.Promote(ctx, nil)
+	assert.Nil(t, err)
+	assert.Len(t, res.Rules, 1)
+	assert.False(t, res.Rules[0].Shadow)
+	export, err = s.ExportPolicy(ctx, auth.PolicyFormat_CSV)
+	assert.Nil(t, err)
+	assert.Equal(t, "p, admin, foo.bar, products, DELETE, *, allow\n", export.Content)
+
+	_, err = s.Promote(ctx, []string{rule.Uuid})
+	assert.NotNil(t, err)
+}
+
+func Test_service_DomainAdmin(t *testing.T) {
+	testutils.TestUp()
+
+	ctx := context.Background()
+	domainRepo := domains.NewMockRepository()
+	roleRepo := roles.NewMockRepository()
+	_, _ = domainRepo.Create(ctx, entity.Domain{Name: "foo.bar", Enable: true})
+	_, _ = domainRepo.Create(ctx, entity.Domain{Name: "bar.baz", Enable: true})
+	_, _ = domainRepo.Create(ctx, entity.Domain{Name: "*", Enable: true})
+	_, _ = roleRepo.Create(ctx, entity.Role{Title: "admin", Enable: true})
+
+	s := NewService(&MockRepository{}, domainRepo, domains.NewService(domainRepo, roleRepo), roleRepo, users.NewMockRepository(), apps.NewMockRepository(), groups.NewMockRepository())
+	other, err := s.Create(ctx, &auth.CreateRuleRequest{
+		Role: "admin", Resource: "products", Domain: "bar.baz", Object: "*", Action: "GET", Effect: auth.Effect_ALLOW,
+	})
+	assert.Nil(t, err)
+
+	adminCtx := scope.NewContext(ctx, scope.Domains("foo.bar"))
+	own, err := s.Create(adminCtx, &auth.CreateRuleRequest{
+		Role: "admin", Resource: "products", Domain: "foo.bar", Object: "*", Action: "GET", Effect: auth.Effect_ALLOW,
+	})
+	assert.Nil(t, err)
+
+	// rules of other domains and global rules are out of reach
+	_, err = s.Create(adminCtx, &auth.CreateRuleRequest{
+		Role: "admin", Resource: "products", Domain: "*", Object: "*", Action: "GET", Effect: auth.Effect_ALLOW,
+	})
+	assert.NotNil(t, err)
+	_, err = s.Get(adminCtx, other.Uuid)
+	assert.NotNil(t, err)
+	_, err = s.Delete(adminCtx, other.Uuid)
+	assert.NotNil(t, err)
+	_, err = s.Update(adminCtx, &auth.UpdateRuleRequest{
+		Uuid: own.Uuid, Role: "admin", Resource: "products", Domain: "bar.baz", Object: "*", Action: "GET", Effect: auth.Effect_ALLOW,
+	})
+	assert.NotNil(t, err)
+
+	res, err := s.Query(adminCtx, "", "", 0, 10)
+	assert.Nil(t, err)
+	assert.Equal(t, int64(1), res.TotalCount)
+	assert.Equal(t, own.Uuid, res.Rules[0].Uuid)
+
+	_, err = s.ExportPolicy(adminCtx, auth.PolicyFormat_CSV)
+	assert.NotNil(t, err)
+	_, err = s.Promote(adminCtx, nil)
+	assert.NotNil(t, err)
+
+	_, err = s.Delete(adminCtx, own.Uuid)
+	assert.Nil(t, err)
+}
+
+func Test_service_References(t *testing.T) {
+	testutils.TestUp()
+
+	ctx := context.Background()
+	domainRepo := domains.NewMockRepository()
+	roleRepo := roles.NewMockRepository()
+	appsRepo := apps.NewMockRepository()
+	_, _ = domainRepo.Create(ctx, entity.Domain{Name: "foo.bar", Enable: true})
+	_, _ = roleRepo.Create(ctx, entity.Role{Title: "admin", Enable: true})
+	shopID, _ := appsRepo.CreateApp(ctx, entity.App{Name: "shop"})
+	shop, _ := appsRepo.GetApp(ctx, shopID)
+	blogID, _ := appsRepo.CreateApp(ctx, entity.App{Name: "blog"})
+	blog, _ := appsRepo.GetApp(ctx, blogID)
+	ordersID, _ := appsRepo.CreateResource(ctx, shop, entity.Resource{Name: "orders"})
+	orderID, _ := appsRepo.CreateObject(ctx, shop, entity.Object{Identifier: "42"})
+	postID, _ := appsRepo.CreateObject(ctx, blog, entity.Object{Identifier: "hello"})
+
+	s := NewService(&MockRepository{}, domainRepo, domains.NewService(domainRepo, roleRepo), roleRepo, users.NewMockRepository(), appsRepo, groups.NewMockRepository())
+	appsSrv := apps.NewService(appsRepo, s)
+
+	rule, err := s.Create(ctx, &auth.CreateRuleRequest{
+		Role: "admin", Domain: "foo.bar", ResourceUuid: ordersID, ObjectUuid: orderID, Action: "read", Effect: auth.Effect_ALLOW,
+	})
+	assert.Nil(t, err)
+	assert.Equal(t, "orders", rule.Resource)
+	assert.Equal(t, "42", rule.Object)
+	assert.Equal(t, "shop", rule.App)
+
+	// references must exist, match the given names and belong to one app
+	_, err = s.Create(ctx, &auth.CreateRuleRequest{
+		Role: "admin", Domain: "foo.bar", ResourceUuid: ordersID, ObjectUuid: postID, Action: "read", Effect: auth.Effect_ALLOW,
+	})
+	assert.NotNil(t, err)
+	_, err = s.Create(ctx, &auth.CreateRuleRequest{
+		Role: "admin", Domain: "foo.bar", Resource: "invoices", ResourceUuid: ordersID, Object: "*", Action: "read", Effect: auth.Effect_ALLOW,
+	})
+	assert.NotNil(t, err)
+	_, err = s.Create(ctx, &auth.CreateRuleRequest{
+		Role: "admin", Domain: "foo.bar", ResourceUuid: postID, Object: "*", Action: "read", Effect: auth.Effect_ALLOW,
+	})
+	assert.NotNil(t, err)
+
+	_, err = s.Create(ctx, &auth.CreateRuleRequest{
+		Role: "admin", Domain: "foo.bar", Resource: "posts", ObjectUuid: postID, Action: "read", Effect: auth.Effect_ALLOW,
+	})
+	assert.Nil(t, err)
+
+	res, err := s.Query(ctx, "", "shop", 0, 10)
+	assert.Nil(t, err)
+	assert.Equal(t, int64(1), res.TotalCount)
+	assert.Equal(t, rule.Uuid, res.Rules[0].Uuid)
+	_, err = s.Query(ctx, "", "none", 0, 10)
+	assert.NotNil(t, err)
+
+	// renames propagate to the rules
+	_, err = appsSrv.UpdateResource(ctx, &auth.UpdateResourceRequest{Uuid: ordersID, AppUuid: shopID, Name: "purchases"})
+	assert.Nil(t, err)
+	_, err = appsSrv.UpdateObject(ctx, &auth.UpdateObjectRequest{Uuid: orderID, AppUuid: shopID, Identifier: "43"})
+	assert.Nil(t, err)
+	rule, err = s.Get(ctx, rule.Uuid)
+	assert.Nil(t, err)
+	assert.Equal(t, "purchases", rule.Resource)
+	assert.Equal(t, "43", rule.Object)
+
+	// rules in use block deletion unless cascaded
+	_, err = appsSrv.DeleteResource(ctx, ordersID, false)
+	assert.NotNil(t, err)
+	_, err = appsSrv.DeleteResource(ctx, ordersID, true)
+	assert.Nil(t, err)
+	_, err = s.Get(ctx, rule.Uuid)
+	assert.NotNil(t, err)
+	_, err = appsSrv.DeleteObject(ctx, orderID, false)
+	assert.Nil(t, err)
+	_, err = appsSrv.DeleteObject(ctx, postID, false)
+	assert.NotNil(t, err)
+}
@@ -0,0 +1,67 @@
+package shadow_decisions
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/golang-tire/auth/internal/pkg/db"
+
+	"github.com/golang-tire/auth/internal/entity"
+)
+
+// Repository encapsulates the logic to access shadow decisions from the data source.
+type Repository interface {
+	// Count returns the number of shadow decisions.
+	Count(ctx context.Context) (int64, error)
+	// Query returns the list of shadow decisions with the given offset and limit.
+	Query(ctx context.Context, query string, offset, limit int64) ([]entity.ShadowDecision, int, error)
+	// Create saves a new shadow decision in the storage.
+	Create(ctx context.Context, decision entity.ShadowDecision) (string, error)
+}
+
+// repository persists shadow decisions in database
+type repository struct {
+	db *db.DB
+}
+
+func (r repository) Create(ctx context.Context, decision entity.ShadowDecision) (string, error) {
+	now := time.Now()
+	decision.UUID = uuid.New().String()
+	decision.CreatedAt = now
+	decision.UpdatedAt = now
+	res := r.db.With(ctx).Create(&decision)
+	return decision.UUID, res.Error
+}
+
+func (r repository) Count(ctx context.Context) (int64, error) {
+	var count int64
+	res := r.db.With(ctx).Model(&entity.ShadowDecision{}).Count(&count)
+	return count, res.Error
+}
+
+func (r repository) Query(ctx context.Context, query string, offset, limit int64) ([]entity.ShadowDecision, int, error) {
+	var _decisions []entity.ShadowDecision
+	res := r.db.With(ctx).
+		Limit(int(limit)).
+		Offset(int(offset)).
+		Order("id desc")
+
+	if len(query) >= 1 {
+		res = res.Where("username LIKE ?", "%"+query+"%").Find(&_decisions)
+	} else {
+		res = res.Find(&_decisions)
+	}
+
+	count, err := r.Count(ctx)
+	if err != nil {
+		return nil, 0, err
+	}
+	return _decisions, int(count), res.Error
+}
+
+// NewRepository creates a new shadow decision repository
+func NewRepository(db *db.DB) Repository {
+	return repository{db}
+}
@@ -0,0 +1,31 @@
+package shadow_decisions
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+
+	"github.com/golang-tire/auth/internal/entity"
+)
+
+func NewMockRepository() *mockRepository {
+	return &mockRepository{}
+}
+
+type mockRepository struct {
+	items []entity.ShadowDecision
+}
+
+func (m *mockRepository) Create(ctx context.Context, decision entity.ShadowDecision) (string, error) {
+	decision.UUID = uuid.New().String()
+	m.items = append(m.items, decision)
+	return decision.UUID, nil
+}
+
+func (m mockRepository) Count(ctx context.Context) (int64, error) {
+	return int64(len(m.items)), nil
+}
+
+func (m mockRepository) Query(ctx context.Context, query string, offset, limit int64) ([]entity.ShadowDecision, int, error) {
+	return m.items, len(m.items), nil
+}
@@ -0,0 +1,48 @@
+package shadow_decisions
+
+import (
+	"context"
+
+	"github.com/golang-tire/auth/internal/entity"
+
+	auth "github.com/golang-tire/auth/internal/proto/v1"
+)
+
+// Service encapsulates use case logic for shadow decisions.
+type Service interface {
+	Query(ctx context.Context, query string, offset, limit int64) (*auth.ListShadowDecisionsResponse, error)
+	Count(ctx context.Context) (int64, error)
+	Create(ctx context.Context, decision entity.ShadowDecision) error
+}
+
+type service struct {
+	repo Repository
+}
+
+// Create records a decision of the shadow policy which differs from the live one.
+func (s service) Create(ctx context.Context, decision entity.ShadowDecision) error {
+	_, err := s.repo.Create(ctx, decision)
+	return err
+}
+
+func (s service) Query(ctx context.Context, query string, offset, limit int64) (*auth.ListShadowDecisionsResponse, error) {
+	items, count, err := s.repo.Query(ctx, query, offset, limit)
+	if err != nil {
+		return nil, err
+	}
+	return &auth.ListShadowDecisionsResponse{
+		ShadowDecisions: entity.ShadowDecisionToProtoList(items),
+		TotalCount:      int64(count),
+		Offset:          offset,
+		Limit:           limit,
+	}, nil
+}
+
+func (s service) Count(ctx context.Context) (int64, error) {
+	return s.repo.Count(ctx)
+}
+
+// NewService creates a new shadow decisions service.
+func NewService(repo Repository) Service {
+	return service{repo}
+}
@@ -0,0 +1,36 @@
+package shadow_decisions
+
+import (
+	"context"
+	"testing"
+
+	"github.com/golang-tire/auth/internal/entity"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_service(t *testing.T) {
+	s := NewService(&mockRepository{})
+	ctx := context.Background()
+
+	count, _ := s.Count(ctx)
+	assert.Equal(t, int64(0), count)
+
+	err := s.Create(ctx, entity.ShadowDecision{
+		Username:       "mohsen",
+		Domain:         "foo.bar",
+		URI:            "/v1/users",
+		Method:         "GET",
+		Resource:       "users",
+		LiveDecision:   true,
+		ShadowDecision: false,
+	})
+	assert.Nil(t, err)
+	count, _ = s.Count(ctx)
+	assert.Equal(t, int64(1), count)
+
+	res, err := s.Query(ctx, "", 0, 10)
+	assert.Nil(t, err)
+	assert.Equal(t, int64(1), res.TotalCount)
+	assert.Equal(t, "mohsen", res.ShadowDecisions[0].Username)
+	assert.False(t, res.ShadowDecisions[0].ShadowDecision)
+}
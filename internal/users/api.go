@@ -98,6 +98,15 @@ func (a api) DeleteUserRole(ctx context.Context, request *auth.DeleteUserRoleReq
 	return &empty.Empty{}, nil
 }
 
+func (a api) ListExpiringUserRoles(ctx context.Context, request *auth.ListExpiringUserRolesRequest) (*auth.ListExpiringUserRolesResponse, error) {
+	offset, limit := helpers.GetOffsetAndLimit(request.Offset, request.Limit)
+	res, err := a.service.ListExpiringUserRoles(ctx, request.Within, offset, limit)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+	return res, nil
+}
+
 func New(srv Service) API {
 	s := api{service: srv}
 	grpcgw.RegisterController(s)
@@ -0,0 +1,103 @@
+package users
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/golang-tire/auth/internal/entity"
+	"github.com/golang-tire/auth/internal/pkg/pubsub"
+	auth "github.com/golang-tire/auth/internal/proto/v1"
+	"github.com/golang-tire/pkg/config"
+	"github.com/golang-tire/pkg/log"
+)
+
+const expireAuditAction = "expire"
+
+// expiryInterval is how often the expiry scheduler runs
+var expiryInterval = config.RegisterString("users.expiryInterval", "1m")
+
+// AuditLogger writes audit log entries, it is implemented by the audit logs service.
+type AuditLogger interface {
+	Create(ctx context.Context, req *auth.CreateAuditLogRequest) (*auth.AuditLog, error)
+}
+
+// ExpiryScheduler revokes expired user roles and reloads the policy when user roles
+// expire or become valid.
+type ExpiryScheduler struct {
+	service   Service
+	auditLogs AuditLogger
+	interval  time.Duration
+	last      time.Time
+}
+
+// NewExpiryScheduler creates a new expiry scheduler, auditLogs may be nil.
+func NewExpiryScheduler(service Service, auditLogs AuditLogger) (*ExpiryScheduler, error) {
+	interval, err := time.ParseDuration(expiryInterval.String())
+	if err != nil {
+		return nil, fmt.Errorf("invalid users.expiryInterval: %v", err)
+	}
+	if interval <= 0 {
+		return nil, fmt.Errorf("users.expiryInterval must be positive")
+	}
+	return &ExpiryScheduler{service: service, auditLogs: auditLogs, interval: interval, last: time.Now()}, nil
+}
+
+// Run runs the scheduler until ctx is done.
+func (e *ExpiryScheduler) Run(ctx context.Context) {
+	go func() {
+		ticker := time.NewTicker(e.interval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case now := <-ticker.C:
+				if err := e.Tick(ctx, now); err != nil {
+					log.Error("expire user roles failed", log.Err(err))
+				}
+			}
+		}
+	}()
+}
+
+// Tick revokes the user roles expired at now and publishes a policy change if any user
+// role expired or became valid since the previous tick.
+func (e *ExpiryScheduler) Tick(ctx context.Context, now time.Time) error {
+	revoked, err := e.service.RevokeExpiredUserRoles(ctx, now)
+	for _, ur := range revoked {
+		e.audit(ctx, ur)
+	}
+	if err != nil {
+		if len(revoked) > 0 {
+			pubsub.Notify(pubsub.UserChange, "revoke expired user roles")
+		}
+		return err
+	}
+
+	activated, err := e.service.ActivatedUserRoles(ctx, e.last, now)
+	if err != nil {
+		return err
+	}
+	e.last = now
+
+	if len(revoked) > 0 || len(activated) > 0 {
+		pubsub.Notify(pubsub.UserChange, fmt.Sprintf("%d user roles expired, %d became valid", len(revoked), len(activated)))
+	}
+	return nil
+}
+
+func (e *ExpiryScheduler) audit(ctx context.Context, ur entity.UserRole) {
+	if e.auditLogs == nil {
+		return
+	}
+	_, err := e.auditLogs.Create(ctx, &auth.CreateAuditLogRequest{
+		UserUuid: ur.User.UUID,
+		Action:   expireAuditAction,
+		Object:   "user_role:" + ur.UUID,
+		OldValue: ur.Role.Title + "@" + ur.Domain.Name,
+	})
+	if err != nil {
+		log.Error("write expire audit log failed", log.String("user_role", ur.UUID), log.Err(err))
+	}
+}
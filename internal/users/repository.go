@@ -22,6 +22,10 @@ type Repository interface {
 	Count(ctx context.Context) (int64, error)
 	// Query returns the list of users with the given offset and limit.
 	Query(ctx context.Context, query string, offset, limit int64) ([]entity.User, int, error)
+	// QueryInDomains returns the list of users having roles in the named domains with the given offset and limit.
+	QueryInDomains(ctx context.Context, query string, domains []string, offset, limit int64) ([]entity.User, int, error)
+	// Usernames returns the sorted names of the users with the given offset and limit.
+	Usernames(ctx context.Context, offset, limit int64) ([]string, error)
 	// Create saves a new user in the storage.
 	Create(ctx context.Context, user entity.User) (string, error)
 	// Update updates the user with given UUID in the storage.
@@ -38,6 +42,16 @@ type Repository interface {
 	DeleteUserRole(ctx context.Context, userRole entity.UserRole) error
 	// GetUserRole reads the user role with the specified ID from the database.
 	AllUserRole(ctx context.Context) ([]entity.UserRole, error)
+	// UserRoles returns every user role, the disabled ones included.
+	UserRoles(ctx context.Context) ([]entity.UserRole, error)
+	// ExpiredUserRoles returns the user roles that expired at or before now.
+	ExpiredUserRoles(ctx context.Context, now time.Time) ([]entity.UserRole, error)
+	// ExpiringUserRoles returns the enabled user roles that expire after now and at or before until.
+	ExpiringUserRoles(ctx context.Context, now, until time.Time, domains []string, offset, limit int64) ([]entity.UserRole, int64, error)
+	// ActivatedUserRoles returns the enabled user roles that became valid after from and at or before to.
+	ActivatedUserRoles(ctx context.Context, from, to time.Time) ([]entity.UserRole, error)
+	// Transactional runs f in a transaction, repository calls using the given context join it.
+	Transactional(ctx context.Context, f func(ctx context.Context) error) error
 	// FindOne returns the one of users with the given condition
 	FindOne(ctx context.Context, condition string, params ...interface{}) (entity.User, error)
 }
@@ -118,6 +132,48 @@ func (r repository) Query(ctx context.Context, query string, offset, limit int64
 	return _users, int(count), res.Error
 }
 
+// QueryInDomains retrieves the records of users having roles in the named domains with the specified offset and limit from the database.
+func (r repository) QueryInDomains(ctx context.Context, query string, domains []string, offset, limit int64) ([]entity.User, int, error) {
+	var _users []entity.User
+	var count int64
+
+	domainQuery := r.db.With(ctx).Select("id").Where("name IN (?)", domains).Table("domains")
+	userQuery := r.db.With(ctx).Select("user_id").Where("domain_id IN (?)", domainQuery).Table("user_roles")
+	res := r.db.With(ctx).Model(&entity.User{}).Where("id IN (?)", userQuery)
+	if len(query) >= 1 {
+		res = res.Where("username LIKE ? OR email LIKE ? OR firstname LIKE ? OR lastname LIKE ?", "%"+query+"%", "%"+query+"%", "%"+query+"%", "%"+query+"%")
+	}
+	if err := res.Count(&count).Error; err != nil {
+		return nil, 0, err
+	}
+
+	res = res.
+		Limit(int(limit)).
+		Offset(int(offset)).
+		Order("users.id asc").
+		Preload("UserRoles.Domain").
+		Preload("UserRoles.Role").
+		Find(&_users)
+	return _users, int(count), res.Error
+}
+
+// Usernames retrieves the user names ordered by name with the specified offset and limit from the database.
+func (r repository) Usernames(ctx context.Context, offset, limit int64) ([]string, error) {
+	var names []string
+	res := r.db.With(ctx).
+		Model(&entity.User{}).
+		Limit(int(limit)).
+		Offset(int(offset)).
+		Order("users.username asc").
+		Pluck("username", &names)
+	return names, res.Error
+}
+
+// Transactional runs f in a database transaction.
+func (r repository) Transactional(ctx context.Context, f func(ctx context.Context) error) error {
+	return r.db.Transactional(ctx, f)
+}
+
 // FindOne returns the one of users with the given condition
 func (r repository) FindOne(ctx context.Context, condition string, params ...interface{}) (entity.User, error) {
 	var user entity.User
@@ -140,7 +196,7 @@ func (r repository) AddUserRole(ctx context.Context, userRole entity.UserRole) (
 // GetUserRole reads the user role with the specified ID from the database.
 func (r repository) GetUserRole(ctx context.Context, uuid string) (entity.UserRole, error) {
 	var userRole entity.UserRole
-	res := r.db.With(ctx).Where("uuid = ?", uuid).First(&userRole)
+	res := r.db.With(ctx).Preload("Domain").Preload("Role").Where("uuid = ?", uuid).First(&userRole)
 	if res.Error != nil && res.Error == gorm.ErrRecordNotFound {
 		return entity.UserRole{}, fmt.Errorf("userRole with uuid `%s` not found", uuid)
 	}
@@ -168,3 +224,68 @@ func (r repository) AllUserRole(ctx context.Context) ([]entity.UserRole, error)
 		Find(&_userRoles)
 	return _userRoles, res.Error
 }
+
+// UserRoles retrieves every user role record, the disabled ones included, from the database.
+func (r repository) UserRoles(ctx context.Context) ([]entity.UserRole, error) {
+	var _userRoles []entity.UserRole
+	res := r.db.With(ctx).
+		Order("user_roles.id asc").
+		Preload("Domain").
+		Preload("Role").
+		Preload("User").
+		Find(&_userRoles)
+	return _userRoles, res.Error
+}
+
+// ExpiredUserRoles returns the user roles that expired at or before now.
+func (r repository) ExpiredUserRoles(ctx context.Context, now time.Time) ([]entity.UserRole, error) {
+	var _userRoles []entity.UserRole
+	res := r.db.With(ctx).
+		Order("user_roles.id asc").
+		Preload("Domain").
+		Preload("Role").
+		Preload("User").
+		Where("user_roles.expires_at <= ?", now).
+		Find(&_userRoles)
+	return _userRoles, res.Error
+}
+
+// ExpiringUserRoles returns the enabled user roles that expire after now and at or before until,
+// a non empty domains limits them to the user roles of the named domains.
+func (r repository) ExpiringUserRoles(ctx context.Context, now, until time.Time, domains []string, offset, limit int64) ([]entity.UserRole, int64, error) {
+	var _userRoles []entity.UserRole
+	var count int64
+	query := r.db.With(ctx).
+		Model(&entity.UserRole{}).
+		Where("user_roles.enable = ? AND user_roles.expires_at > ? AND user_roles.expires_at <= ?", true, now, until)
+	if len(domains) > 0 {
+		domainQuery := r.db.With(ctx).Select("id").Where("name IN (?)", domains).Table("domains")
+		query = query.Where("user_roles.domain_id IN (?)", domainQuery)
+	}
+	if res := query.Count(&count); res.Error != nil {
+		return nil, 0, res.Error
+	}
+
+	res := query.
+		Limit(int(limit)).
+		Offset(int(offset)).
+		Order("user_roles.expires_at asc").
+		Preload("Domain").
+		Preload("Role").
+		Preload("User").
+		Find(&_userRoles)
+	return _userRoles, count, res.Error
+}
+
+// ActivatedUserRoles returns the enabled user roles that became valid after from and at or before to.
+func (r repository) ActivatedUserRoles(ctx context.Context, from, to time.Time) ([]entity.UserRole, error) {
+	var _userRoles []entity.UserRole
+	res := r.db.With(ctx).
+		Order("user_roles.id asc").
+		Preload("Domain").
+		Preload("Role").
+		Preload("User").
+		Where("user_roles.enable = ? AND user_roles.valid_from > ? AND user_roles.valid_from <= ?", true, from, to).
+		Find(&_userRoles)
+	return _userRoles, res.Error
+}
@@ -3,6 +3,8 @@ package users
 import (
 	"context"
 	"errors"
+	"sort"
+	"time"
 
 	"github.com/golang-tire/auth/internal/entity"
 	"github.com/google/uuid"
@@ -16,42 +18,123 @@ func NewMockRepository() *mockRepository {
 }
 
 type mockRepository struct {
-	items []entity.User
+	items     []entity.User
+	userRoles []entity.UserRole
 }
 
 func (m mockRepository) AllUserRole(ctx context.Context) ([]entity.UserRole, error) {
-	panic("implement me")
+	return m.userRoles, nil
+}
+
+func (m mockRepository) UserRoles(ctx context.Context) ([]entity.UserRole, error) {
+	return m.userRoles, nil
 }
 
 func (m mockRepository) FindOne(ctx context.Context, condition string, params ...interface{}) (entity.User, error) {
-	panic("implement me")
+	for _, item := range m.items {
+		if len(params) > 0 && (item.Username == params[0] || item.Email == params[0]) {
+			return item, nil
+		}
+	}
+	return entity.User{}, gorm.ErrRecordNotFound
 }
 
-func (m mockRepository) AddUserRole(ctx context.Context, userRole entity.UserRole) (string, error) {
-	panic("implement me")
+func (m *mockRepository) AddUserRole(ctx context.Context, userRole entity.UserRole) (string, error) {
+	userRole.UUID = uuid.New().String()
+	m.userRoles = append(m.userRoles, userRole)
+	return userRole.UUID, nil
 }
 
 func (m mockRepository) GetUserRole(ctx context.Context, uuid string) (entity.UserRole, error) {
-	panic("implement me")
+	for _, item := range m.userRoles {
+		if item.UUID == uuid {
+			return item, nil
+		}
+	}
+	return entity.UserRole{}, gorm.ErrRecordNotFound
 }
 
-func (m mockRepository) UpdateUserRole(ctx context.Context, userRole entity.UserRole) error {
-	panic("implement me")
+func (m *mockRepository) UpdateUserRole(ctx context.Context, userRole entity.UserRole) error {
+	for i, item := range m.userRoles {
+		if item.UUID == userRole.UUID {
+			m.userRoles[i] = userRole
+			break
+		}
+	}
+	return nil
 }
 
-func (m mockRepository) DeleteUserRole(ctx context.Context, userRole entity.UserRole) error {
-	panic("implement me")
+func (m *mockRepository) DeleteUserRole(ctx context.Context, userRole entity.UserRole) error {
+	for i, item := range m.userRoles {
+		if item.UUID == userRole.UUID {
+			m.userRoles = append(m.userRoles[:i], m.userRoles[i+1:]...)
+			break
+		}
+	}
+	return nil
+}
+
+func (m mockRepository) ExpiredUserRoles(ctx context.Context, now time.Time) ([]entity.UserRole, error) {
+	var items []entity.UserRole
+	for _, item := range m.userRoles {
+		if item.ExpiresAt != nil && !item.ExpiresAt.After(now) {
+			items = append(items, item)
+		}
+	}
+	return items, nil
+}
+
+func (m mockRepository) ExpiringUserRoles(ctx context.Context, now, until time.Time, domains []string, offset, limit int64) ([]entity.UserRole, int64, error) {
+	var items []entity.UserRole
+	for _, item := range m.userRoles {
+		if len(domains) > 0 && !contains(domains, item.Domain.Name) {
+			continue
+		}
+		if item.Enable && item.ExpiresAt != nil && item.ExpiresAt.After(now) && !item.ExpiresAt.After(until) {
+			items = append(items, item)
+		}
+	}
+	return items, int64(len(items)), nil
+}
+
+func (m mockRepository) ActivatedUserRoles(ctx context.Context, from, to time.Time) ([]entity.UserRole, error) {
+	var items []entity.UserRole
+	for _, item := range m.userRoles {
+		if item.Enable && item.ValidFrom != nil && item.ValidFrom.After(from) && !item.ValidFrom.After(to) {
+			items = append(items, item)
+		}
+	}
+	return items, nil
 }
 
 func (m mockRepository) Get(ctx context.Context, id string) (entity.User, error) {
 	for _, item := range m.items {
 		if item.UUID == id {
-			return item, nil
+			return m.withRoles(item), nil
 		}
 	}
 	return entity.User{}, gorm.ErrRecordNotFound
 }
 
+func (m mockRepository) withRoles(user entity.User) entity.User {
+	user.UserRoles = nil
+	for _, ur := range m.userRoles {
+		if ur.User.UUID == user.UUID {
+			user.UserRoles = append(user.UserRoles, ur)
+		}
+	}
+	return user
+}
+
+func contains(items []string, s string) bool {
+	for _, item := range items {
+		if item == s {
+			return true
+		}
+	}
+	return false
+}
+
 func (m mockRepository) Count(ctx context.Context) (int64, error) {
 	return int64(len(m.items)), nil
 }
@@ -60,6 +143,38 @@ func (m mockRepository) Query(ctx context.Context, query string, offset, limit i
 	return m.items, len(m.items), nil
 }
 
+func (m mockRepository) QueryInDomains(ctx context.Context, query string, domains []string, offset, limit int64) ([]entity.User, int, error) {
+	var items []entity.User
+	for _, item := range m.items {
+		for _, ur := range m.withRoles(item).UserRoles {
+			if contains(domains, ur.Domain.Name) {
+				items = append(items, m.withRoles(item))
+				break
+			}
+		}
+	}
+	return items, len(items), nil
+}
+
+func (m mockRepository) Transactional(ctx context.Context, f func(ctx context.Context) error) error {
+	return f(ctx)
+}
+
+func (m mockRepository) Usernames(ctx context.Context, offset, limit int64) ([]string, error) {
+	var names []string
+	for _, item := range m.items {
+		names = append(names, item.Username)
+	}
+	sort.Strings(names)
+	if offset > int64(len(names)) {
+		offset = int64(len(names))
+	}
+	if end := offset + limit; end < int64(len(names)) {
+		names = names[:end]
+	}
+	return names[offset:], nil
+}
+
 func (m *mockRepository) Create(ctx context.Context, user entity.User) (string, error) {
 	Uuid := uuid.New().String()
 	user.UUID = Uuid
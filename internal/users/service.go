@@ -2,16 +2,23 @@ package users
 
 import (
 	"context"
+	"errors"
+	"fmt"
 	"time"
 
 	"github.com/golang-tire/auth/internal/domains"
 
+	"github.com/golang-tire/auth/internal/pkg/pubsub"
 	"github.com/golang-tire/auth/internal/roles"
 
 	validation "github.com/go-ozzo/ozzo-validation/v4"
 	"github.com/go-ozzo/ozzo-validation/v4/is"
 	"github.com/golang-tire/auth/internal/entity"
+	"github.com/golang-tire/auth/internal/pkg/scope"
 	auth "github.com/golang-tire/auth/internal/proto/v1"
+	"github.com/golang-tire/pkg/config"
+	"github.com/golang-tire/pkg/log"
+	"github.com/golang/protobuf/ptypes/timestamp"
 )
 
 // Service encapsulates use case logic for users.
@@ -20,6 +27,8 @@ type Service interface {
 	Query(ctx context.Context, query string, offset, limit int64) (*auth.ListUsersResponse, error)
 	Count(ctx context.Context) (int64, error)
 	Create(ctx context.Context, req *auth.CreateUserRequest) (*auth.User, error)
+	// Register creates the user and assigns it the role in the domain, if a role is given, in one transaction
+	Register(ctx context.Context, req *auth.CreateUserRequest, roleUuid, domainUuid string) (*auth.User, error)
 	Update(ctx context.Context, req *auth.UpdateUserRequest) (*auth.User, error)
 	Delete(ctx context.Context, Uuid string) (*auth.User, error)
 
@@ -30,8 +39,20 @@ type Service interface {
 	UpdateUserRole(ctx context.Context, req *auth.UpdateUserRoleRequest) (*auth.User, error)
 	DeleteUserRole(ctx context.Context, req *auth.DeleteUserRoleRequest) (*auth.User, error)
 	ListUserRoles(ctx context.Context) ([]entity.UserRole, error)
+	// ListUsernames returns the sorted names of the users with the given offset and limit
+	ListUsernames(ctx context.Context, offset, limit int64) ([]string, error)
+
+	// ListExpiringUserRoles returns the user roles that expire within the given duration
+	ListExpiringUserRoles(ctx context.Context, within string, offset, limit int64) (*auth.ListExpiringUserRolesResponse, error)
+	// RevokeExpiredUserRoles deletes the user roles that expired at or before now
+	RevokeExpiredUserRoles(ctx context.Context, now time.Time) ([]entity.UserRole, error)
+	// ActivatedUserRoles returns the user roles that became valid after from and at or before to
+	ActivatedUserRoles(ctx context.Context, from, to time.Time) ([]entity.UserRole, error)
 }
 
+// expiringWithin is the default window of ListExpiringUserRoles
+var expiringWithin = config.RegisterString("users.expiringWithin", "168h")
+
 // ValidateCreateRequest validates the CreateUserRequest fields.
 func ValidateCreateRequest(c *auth.CreateUserRequest) error {
 	return validation.ValidateStruct(c,
@@ -56,6 +77,7 @@ func ValidateAddUserRoleRequest(c *auth.AddUserRoleRequest) error {
 		validation.Field(&c.Uuid, validation.Required, is.UUID),
 		validation.Field(&c.RoleUuid, validation.Required, is.UUID),
 		validation.Field(&c.DomainUuid, validation.Required, is.UUID),
+		validation.Field(&c.ExpiresAt, validation.By(expiresAfter(c.ValidFrom))),
 	)
 }
 
@@ -66,27 +88,103 @@ func ValidateUpdateUserRoleRequest(c *auth.UpdateUserRoleRequest) error {
 		validation.Field(&c.UserRoleUuid, validation.Required, is.UUID),
 		validation.Field(&c.RoleUuid, validation.Required, is.UUID),
 		validation.Field(&c.DomainUuid, validation.Required, is.UUID),
+		validation.Field(&c.ExpiresAt, validation.By(expiresAfter(c.ValidFrom))),
 	)
 }
 
+// expiresAfter checks that the expiry time is after validFrom.
+func expiresAfter(validFrom *timestamp.Timestamp) validation.RuleFunc {
+	return func(value interface{}) error {
+		expiresAt, _ := value.(*timestamp.Timestamp)
+		if expiresAt == nil || validFrom == nil {
+			return nil
+		}
+		if !expiresAt.AsTime().After(validFrom.AsTime()) {
+			return errors.New("must be after valid_from")
+		}
+		return nil
+	}
+}
+
+// timeOrNil converts a timestamp to a time, unset timestamps give nil.
+func timeOrNil(t *timestamp.Timestamp) *time.Time {
+	if t == nil {
+		return nil
+	}
+	v := t.AsTime()
+	return &v
+}
+
+// PolicyRecorder records a policy revision after user roles change.
+type PolicyRecorder interface {
+	RecordRevision(ctx context.Context, reason string) error
+}
+
 type service struct {
 	repo        Repository
 	domainsRepo domains.Repository
 	rolesRepo   roles.Repository
+	recorder    PolicyRecorder
+}
+
+// NewService creates a new user service, recorder may be nil.
+func NewService(repo Repository, domainsRepo domains.Repository, rolesRepo roles.Repository, recorder PolicyRecorder) Service {
+	return service{repo, domainsRepo, rolesRepo, recorder}
+}
+
+// recordRevision records the user role change as a policy revision.
+func (s service) recordRevision(ctx context.Context, reason string) {
+	if s.recorder == nil {
+		return
+	}
+	if err := s.recorder.RecordRevision(ctx, reason); err != nil {
+		log.Error("record policy revision failed", log.Err(err))
+	}
+}
+
+// userRoleDomain returns the domain name of the user role, user roles without one apply to
+// every domain.
+func userRoleDomain(ur entity.UserRole) string {
+	if ur.Domain.Name == "" {
+		return scope.GlobalDomain
+	}
+	return ur.Domain.Name
+}
+
+// inScope returns the user with only the roles of the domains covered by the scope.
+func inScope(sc scope.Scope, user entity.User) entity.User {
+	if sc.IsGlobal() {
+		return user
+	}
+	var userRoles []entity.UserRole
+	for _, ur := range user.UserRoles {
+		if sc.Allows(userRoleDomain(ur)) {
+			userRoles = append(userRoles, ur)
+		}
+	}
+	user.UserRoles = userRoles
+	return user
 }
 
-// NewService creates a new user service.
-func NewService(repo Repository, domainsRepo domains.Repository, rolesRepo roles.Repository) Service {
-	return service{repo, domainsRepo, rolesRepo}
+// checkManaged returns an error unless the scope covers every domain the user has a role in,
+// domain admins can not change the accounts of users of other domains.
+func checkManaged(sc scope.Scope, user entity.User) error {
+	for _, ur := range user.UserRoles {
+		if err := sc.Check(userRoleDomain(ur)); err != nil {
+			return fmt.Errorf("user `%s` has roles outside of the administered domains", user.Username)
+		}
+	}
+	return nil
 }
 
-// Get returns the user with the specified the user Uuid.
+// Get returns the user with the specified the user Uuid, domain admins only see the roles of
+// the domains they administer.
 func (s service) Get(ctx context.Context, Uuid string) (*auth.User, error) {
 	user, err := s.repo.Get(ctx, Uuid)
 	if err != nil {
 		return nil, err
 	}
-	return user.ToProto(true), nil
+	return inScope(scope.FromContext(ctx), user).ToProto(true), nil
 }
 
 // Create creates a new user.
@@ -111,6 +209,34 @@ func (s service) Create(ctx context.Context, req *auth.CreateUserRequest) (*auth
 	return s.Get(ctx, id)
 }
 
+// Register creates a self registered user and assigns the default role of the domain to it,
+// the user is not created when the role can not be assigned.
+func (s service) Register(ctx context.Context, req *auth.CreateUserRequest, roleUuid, domainUuid string) (*auth.User, error) {
+	var user *auth.User
+	err := s.repo.Transactional(ctx, func(ctx context.Context) error {
+		var err error
+		user, err = s.Create(ctx, req)
+		if err != nil || roleUuid == "" {
+			return err
+		}
+		user, err = s.AddUserRole(ctx, &auth.AddUserRoleRequest{
+			Uuid:       user.Uuid,
+			RoleUuid:   roleUuid,
+			DomainUuid: domainUuid,
+			Enable:     true,
+		})
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	// the role of the user is committed only now, the instances reload it again
+	if roleUuid != "" {
+		pubsub.Notify(pubsub.UserChange, "register user "+user.Uuid)
+	}
+	return user, nil
+}
+
 // Update updates the user with the specified UUID.
 func (s service) Update(ctx context.Context, req *auth.UpdateUserRequest) (*auth.User, error) {
 	if err := ValidateUpdateRequest(req); err != nil {
@@ -121,6 +247,9 @@ func (s service) Update(ctx context.Context, req *auth.UpdateUserRequest) (*auth
 	if err != nil {
 		return nil, err
 	}
+	if err := checkManaged(scope.FromContext(ctx), user); err != nil {
+		return nil, err
+	}
 	now := time.Now()
 	user.Firstname = req.Firstname
 	user.Lastname = req.Lastname
@@ -144,6 +273,9 @@ func (s service) Delete(ctx context.Context, UUID string) (*auth.User, error) {
 	if err != nil {
 		return nil, err
 	}
+	if err := checkManaged(scope.FromContext(ctx), user); err != nil {
+		return nil, err
+	}
 	if err = s.repo.Delete(ctx, user); err != nil {
 		return nil, err
 	}
@@ -155,12 +287,24 @@ func (s service) Count(ctx context.Context) (int64, error) {
 	return s.repo.Count(ctx)
 }
 
-// Query returns the users with the specified offset and limit.
+// Query returns the users with the specified offset and limit, domain admins only see the
+// users having roles in the domains they administer.
 func (s service) Query(ctx context.Context, query string, offset, limit int64) (*auth.ListUsersResponse, error) {
-	items, count, err := s.repo.Query(ctx, query, offset, limit)
+	var items []entity.User
+	var count int
+	var err error
+	sc := scope.FromContext(ctx)
+	if sc.IsGlobal() {
+		items, count, err = s.repo.Query(ctx, query, offset, limit)
+	} else {
+		items, count, err = s.repo.QueryInDomains(ctx, query, sc.DomainList(), offset, limit)
+	}
 	if err != nil {
 		return nil, err
 	}
+	for i := range items {
+		items[i] = inScope(sc, items[i])
+	}
 	return &auth.ListUsersResponse{
 		Users:      entity.UserToProtoList(items),
 		TotalCount: int64(count),
@@ -191,21 +335,31 @@ func (s service) AddUserRole(ctx context.Context, req *auth.AddUserRoleRequest)
 	if err != nil {
 		return nil, err
 	}
+	if err := scope.FromContext(ctx).Check(domain.Name); err != nil {
+		return nil, err
+	}
 
 	role, err := s.rolesRepo.Get(ctx, req.RoleUuid)
 	if err != nil {
 		return nil, err
 	}
+	if err := roles.CheckGrant(ctx, s.rolesRepo, role); err != nil {
+		return nil, err
+	}
 
-	_, err = s.repo.AddUserRole(ctx, entity.UserRole{
-		Role:   role,
-		User:   user,
-		Domain: domain,
-		Enable: req.Enable,
+	id, err := s.repo.AddUserRole(ctx, entity.UserRole{
+		Role:      role,
+		User:      user,
+		Domain:    domain,
+		Enable:    req.Enable,
+		ValidFrom: timeOrNil(req.ValidFrom),
+		ExpiresAt: timeOrNil(req.ExpiresAt),
 	})
 	if err != nil {
 		return nil, err
 	}
+	s.recordRevision(ctx, "add user role "+id)
+	pubsub.Notify(pubsub.UserChange, "add user role "+id)
 
 	// get updated user with its latest roles
 	return s.Get(ctx, user.UUID)
@@ -226,25 +380,39 @@ func (s service) UpdateUserRole(ctx context.Context, req *auth.UpdateUserRoleReq
 	if err != nil {
 		return nil, err
 	}
+	sc := scope.FromContext(ctx)
+	if err := sc.Check(userRoleDomain(userRole)); err != nil {
+		return nil, err
+	}
 
 	domain, err := s.domainsRepo.Get(ctx, req.DomainUuid)
 	if err != nil {
 		return nil, err
 	}
+	if err := sc.Check(domain.Name); err != nil {
+		return nil, err
+	}
 
 	role, err := s.rolesRepo.Get(ctx, req.RoleUuid)
 	if err != nil {
 		return nil, err
 	}
+	if err := roles.CheckGrant(ctx, s.rolesRepo, role); err != nil {
+		return nil, err
+	}
 
 	userRole.Domain = domain
 	userRole.Role = role
 	userRole.Enable = req.Enable
+	userRole.ValidFrom = timeOrNil(req.ValidFrom)
+	userRole.ExpiresAt = timeOrNil(req.ExpiresAt)
 
 	err = s.repo.UpdateUserRole(ctx, userRole)
 	if err != nil {
 		return nil, err
 	}
+	s.recordRevision(ctx, "update user role "+userRole.UUID)
+	pubsub.Notify(pubsub.UserChange, "update user role "+userRole.UUID)
 
 	// get updated user with its latest roles
 	return s.Get(ctx, user.UUID)
@@ -255,9 +423,14 @@ func (s service) DeleteUserRole(ctx context.Context, req *auth.DeleteUserRoleReq
 	if err != nil {
 		return nil, err
 	}
+	if err := scope.FromContext(ctx).Check(userRoleDomain(userRole)); err != nil {
+		return nil, err
+	}
 	if err = s.repo.DeleteUserRole(ctx, userRole); err != nil {
 		return nil, err
 	}
+	s.recordRevision(ctx, "delete user role "+userRole.UUID)
+	pubsub.Notify(pubsub.UserChange, "delete user role "+userRole.UUID)
 	// get updated user with its latest roles
 	return s.Get(ctx, req.Uuid)
 }
@@ -269,3 +442,62 @@ func (s service) ListUserRoles(ctx context.Context) ([]entity.UserRole, error) {
 	}
 	return items, nil
 }
+
+// ListUsernames returns the sorted names of the users of the organization of the context with
+// the given offset and limit.
+func (s service) ListUsernames(ctx context.Context, offset, limit int64) ([]string, error) {
+	return s.repo.Usernames(ctx, offset, limit)
+}
+
+// ListExpiringUserRoles returns the user roles that expire within the given duration, domain
+// admins only see the user roles of the domains they administer.
+func (s service) ListExpiringUserRoles(ctx context.Context, within string, offset, limit int64) (*auth.ListExpiringUserRolesResponse, error) {
+	if within == "" {
+		within = expiringWithin.String()
+	}
+	d, err := time.ParseDuration(within)
+	if err != nil {
+		return nil, fmt.Errorf("invalid within duration `%s`", within)
+	}
+
+	var domains []string
+	if sc := scope.FromContext(ctx); !sc.IsGlobal() {
+		domains = sc.DomainList()
+	}
+	now := time.Now()
+	items, count, err := s.repo.ExpiringUserRoles(ctx, now, now.Add(d), domains, offset, limit)
+	if err != nil {
+		return nil, err
+	}
+	return &auth.ListExpiringUserRolesResponse{
+		UserRoles:  entity.UserRoleToProtoList(items),
+		TotalCount: count,
+		Offset:     offset,
+		Limit:      limit,
+	}, nil
+}
+
+// RevokeExpiredUserRoles deletes the user roles that expired at or before now.
+func (s service) RevokeExpiredUserRoles(ctx context.Context, now time.Time) ([]entity.UserRole, error) {
+	items, err := s.repo.ExpiredUserRoles(ctx, now)
+	if err != nil {
+		return nil, err
+	}
+
+	var revoked []entity.UserRole
+	for _, item := range items {
+		if err := s.repo.DeleteUserRole(ctx, item); err != nil {
+			return revoked, err
+		}
+		revoked = append(revoked, item)
+	}
+	if len(revoked) > 0 {
+		s.recordRevision(ctx, fmt.Sprintf("revoke %d expired user roles", len(revoked)))
+	}
+	return revoked, nil
+}
+
+// ActivatedUserRoles returns the user roles that became valid after from and at or before to.
+func (s service) ActivatedUserRoles(ctx context.Context, from, to time.Time) ([]entity.UserRole, error) {
+	return s.repo.ActivatedUserRoles(ctx, from, to)
+}
@@ -3,6 +3,11 @@ package users
 import (
 	"context"
 	"testing"
+	"time"
+
+	"github.com/golang-tire/auth/internal/entity"
+	"github.com/golang-tire/auth/internal/pkg/scope"
+	"github.com/golang/protobuf/ptypes"
 
 	"github.com/golang-tire/auth/internal/pkg/testutils"
 
@@ -111,7 +116,7 @@ func TestUpdateUserRequest_Validate(t *testing.T) {
 func Test_service_CRUD(t *testing.T) {
 
 	testutils.TestUp()
-	s := NewService(&mockRepository{}, domains.NewMockRepository(), roles.NewMockRepository())
+	s := NewService(&mockRepository{}, domains.NewMockRepository(), roles.NewMockRepository(), nil)
 	ctx := context.Background()
 
 	// initial count
@@ -269,3 +274,202 @@ func Test_service_CRUD(t *testing.T) {
 
 	testutils.TestDown()
 }
+
+type mockAuditLogger struct {
+	logs []*auth.CreateAuditLogRequest
+}
+
+func (m *mockAuditLogger) Create(ctx context.Context, req *auth.CreateAuditLogRequest) (*auth.AuditLog, error) {
+	m.logs = append(m.logs, req)
+	return &auth.AuditLog{Action: req.Action, Object: req.Object}, nil
+}
+
+func TestUserRole_Active(t *testing.T) {
+	now := time.Now()
+	past, future := now.Add(-time.Hour), now.Add(time.Hour)
+
+	assert.True(t, entity.UserRole{Enable: true}.Active(now))
+	assert.False(t, entity.UserRole{Enable: false}.Active(now))
+	assert.False(t, entity.UserRole{Enable: true, ValidFrom: &future}.Active(now))
+	assert.False(t, entity.UserRole{Enable: true, ExpiresAt: &past}.Active(now))
+	assert.True(t, entity.UserRole{Enable: true, ValidFrom: &past, ExpiresAt: &future}.Active(now))
+}
+
+func Test_service_ExpireUserRoles(t *testing.T) {
+	testutils.TestUp()
+	ctx := context.Background()
+	now := time.Now()
+	expired, soon, later := now.Add(-time.Minute), now.Add(time.Hour), now.Add(30*24*time.Hour)
+
+	repo := &mockRepository{}
+	user := entity.User{UUID: "c2a5ed34-3f0b-4a5e-8d8e-2c1d4b7e9f10", Username: "mohsen"}
+	for _, ur := range []entity.UserRole{
+		{User: user, Role: entity.Role{Title: "oncall"}, Enable: true, ExpiresAt: &expired},
+		{User: user, Role: entity.Role{Title: "contractor"}, Enable: true, ExpiresAt: &soon},
+		{User: user, Role: entity.Role{Title: "admin"}, Enable: true, ExpiresAt: &later},
+		{User: user, Role: entity.Role{Title: "reader"}, Enable: true, ValidFrom: &expired},
+	} {
+		_, err := repo.AddUserRole(ctx, ur)
+		assert.Nil(t, err)
+	}
+	s := NewService(repo, domains.NewMockRepository(), roles.NewMockRepository(), nil)
+
+	res, err := s.ListExpiringUserRoles(ctx, "24h", 0, 10)
+	assert.Nil(t, err)
+	assert.Len(t, res.UserRoles, 1)
+	assert.Equal(t, "contractor", res.UserRoles[0].Role)
+	_, err = s.ListExpiringUserRoles(ctx, "soon", 0, 10)
+	assert.NotNil(t, err)
+
+	auditLogs := &mockAuditLogger{}
+	scheduler := &ExpiryScheduler{service: s, auditLogs: auditLogs, interval: time.Minute, last: now.Add(-2 * time.Minute)}
+	assert.Nil(t, scheduler.Tick(ctx, now))
+	assert.Len(t, repo.userRoles, 3)
+	assert.Len(t, auditLogs.logs, 1)
+	assert.Equal(t, expireAuditAction, auditLogs.logs[0].Action)
+	assert.Equal(t, "oncall@", auditLogs.logs[0].OldValue)
+
+	// nothing left to expire
+	revoked, err := s.RevokeExpiredUserRoles(ctx, now)
+	assert.Nil(t, err)
+	assert.Empty(t, revoked)
+}
+
+func TestAddUserRoleRequest_Validate(t *testing.T) {
+	req := &auth.AddUserRoleRequest{
+		Uuid:       "c2a5ed34-3f0b-4a5e-8d8e-2c1d4b7e9f10",
+		RoleUuid:   "c2a5ed34-3f0b-4a5e-8d8e-2c1d4b7e9f11",
+		DomainUuid: "c2a5ed34-3f0b-4a5e-8d8e-2c1d4b7e9f12",
+		ValidFrom:  ptypes.TimestampNow(),
+	}
+	assert.Nil(t, ValidateAddUserRoleRequest(req))
+
+	req.ExpiresAt, _ = ptypes.TimestampProto(time.Now().Add(-time.Hour))
+	assert.NotNil(t, ValidateAddUserRoleRequest(req))
+}
+
+func Test_service_DomainAdmin(t *testing.T) {
+	testutils.TestUp()
+	ctx := context.Background()
+
+	repo := &mockRepository{}
+	domainsRepo := domains.NewMockRepository()
+	rolesRepo := &globalRules{Repository: roles.NewMockRepository(), titles: map[string]bool{"viewer": true}}
+	fooID, _ := domainsRepo.Create(ctx, entity.Domain{Name: "foo.bar", Enable: true})
+	barID, _ := domainsRepo.Create(ctx, entity.Domain{Name: "bar.baz", Enable: true})
+	globalID, _ := domainsRepo.Create(ctx, entity.Domain{Name: "*", Enable: true})
+	adminID, _ := rolesRepo.Create(ctx, entity.Role{Title: scope.AdminRole(), Enable: true})
+	readerID, _ := rolesRepo.Create(ctx, entity.Role{Title: "reader", Enable: true})
+	platformID, _ := rolesRepo.Create(ctx, entity.Role{Title: scope.PlatformAdminRole, Enable: true})
+	viewerID, _ := rolesRepo.Create(ctx, entity.Role{Title: "viewer", Enable: true})
+	aliceID, _ := repo.Create(ctx, entity.User{Username: "alice"})
+	bobID, _ := repo.Create(ctx, entity.User{Username: "bob"})
+	s := NewService(repo, domainsRepo, rolesRepo, nil)
+
+	// the global admin delegates foo.bar to alice and gives bob roles in both domains
+	_, err := s.AddUserRole(ctx, &auth.AddUserRoleRequest{Uuid: aliceID, RoleUuid: adminID, DomainUuid: fooID, Enable: true})
+	assert.Nil(t, err)
+	_, err = s.AddUserRole(ctx, &auth.AddUserRoleRequest{Uuid: bobID, RoleUuid: readerID, DomainUuid: barID, Enable: true})
+	assert.Nil(t, err)
+	alice, err := s.Get(ctx, aliceID)
+	assert.Nil(t, err)
+	assert.False(t, scope.ForUser(alice, time.Now()).IsGlobal())
+
+	adminCtx := scope.NewContext(ctx, scope.ForUser(alice, time.Now()))
+	bob, err := s.AddUserRole(adminCtx, &auth.AddUserRoleRequest{Uuid: bobID, RoleUuid: readerID, DomainUuid: fooID, Enable: true})
+	assert.Nil(t, err)
+	assert.Len(t, bob.Roles, 1)
+	assert.Equal(t, "foo.bar", bob.Roles[0].Domain)
+
+	// no escalation to other domains or to global roles
+	_, err = s.AddUserRole(adminCtx, &auth.AddUserRoleRequest{Uuid: bobID, RoleUuid: readerID, DomainUuid: barID, Enable: true})
+	assert.NotNil(t, err)
+	_, err = s.AddUserRole(adminCtx, &auth.AddUserRoleRequest{Uuid: aliceID, RoleUuid: adminID, DomainUuid: globalID, Enable: true})
+	assert.NotNil(t, err)
+	_, err = s.AddUserRole(adminCtx, &auth.AddUserRoleRequest{Uuid: aliceID, RoleUuid: platformID, DomainUuid: fooID, Enable: true})
+	assert.NotNil(t, err)
+	_, err = s.AddUserRole(adminCtx, &auth.AddUserRoleRequest{Uuid: bobID, RoleUuid: viewerID, DomainUuid: fooID, Enable: true})
+	assert.NotNil(t, err)
+	for _, ur := range bob.Roles {
+		_, err = s.UpdateUserRole(adminCtx, &auth.UpdateUserRoleRequest{Uuid: bobID, UserRoleUuid: ur.Uuid, RoleUuid: platformID, DomainUuid: fooID, Enable: true})
+		assert.NotNil(t, err)
+		_, err = s.UpdateUserRole(adminCtx, &auth.UpdateUserRoleRequest{Uuid: bobID, UserRoleUuid: ur.Uuid, RoleUuid: viewerID, DomainUuid: fooID, Enable: true})
+		assert.NotNil(t, err)
+	}
+
+	// global administrators can grant both
+	_, err = s.AddUserRole(ctx, &auth.AddUserRoleRequest{Uuid: aliceID, RoleUuid: viewerID, DomainUuid: fooID, Enable: true})
+	assert.Nil(t, err)
+
+	// bob also has a role in bar.baz, so alice can not change his account
+	_, err = s.Delete(adminCtx, bobID)
+	assert.NotNil(t, err)
+
+	res, err := s.Query(adminCtx, "", 0, 10)
+	assert.Nil(t, err)
+	assert.Equal(t, int64(2), res.TotalCount)
+	for _, u := range res.Users {
+		for _, ur := range u.Roles {
+			assert.Equal(t, "foo.bar", ur.Domain)
+		}
+	}
+
+	full, err := s.Get(ctx, bobID)
+	assert.Nil(t, err)
+	assert.Len(t, full.Roles, 2)
+	for _, ur := range full.Roles {
+		if ur.Domain == "bar.baz" {
+			_, err = s.DeleteUserRole(adminCtx, &auth.DeleteUserRoleRequest{Uuid: bobID, UserRoleUuid: ur.Uuid})
+			assert.NotNil(t, err)
+		}
+	}
+}
+
+// globalRules marks the roles with the given titles as having global rules.
+type globalRules struct {
+	roles.Repository
+	titles map[string]bool
+}
+
+func (g *globalRules) HasGlobalRules(ctx context.Context, role entity.Role) (bool, error) {
+	return g.titles[role.Title], nil
+}
+
+// rollbackRepository restores the mock records when a transaction fails.
+type rollbackRepository struct {
+	*mockRepository
+}
+
+func (r rollbackRepository) Transactional(ctx context.Context, f func(ctx context.Context) error) error {
+	items := append([]entity.User(nil), r.items...)
+	userRoles := append([]entity.UserRole(nil), r.userRoles...)
+	if err := f(ctx); err != nil {
+		r.items, r.userRoles = items, userRoles
+		return err
+	}
+	return nil
+}
+
+func Test_service_Register(t *testing.T) {
+	testutils.TestUp()
+	ctx := context.Background()
+
+	repo := rollbackRepository{&mockRepository{}}
+	domainsRepo := domains.NewMockRepository()
+	rolesRepo := roles.NewMockRepository()
+	domainID, _ := domainsRepo.Create(ctx, entity.Domain{Name: "foo.bar", Enable: true})
+	memberID, _ := rolesRepo.Create(ctx, entity.Role{Title: "member", Enable: true})
+	s := NewService(repo, domainsRepo, rolesRepo, nil)
+	req := &auth.CreateUserRequest{Username: "newcomer", Password: "password", Email: "newcomer@bar.com", Enable: true}
+
+	// a failed role assignment leaves no user behind
+	_, err := s.Register(ctx, req, "c2ab1a1c-63ef-4b6e-8d8f-4f0b1f3a6f4e", domainID)
+	assert.NotNil(t, err)
+	count, _ := repo.Count(ctx)
+	assert.Equal(t, int64(0), count)
+
+	user, err := s.Register(ctx, req, memberID, domainID)
+	if assert.Nil(t, err) && assert.Len(t, user.Roles, 1) {
+		assert.Equal(t, "member", user.Roles[0].Role)
+	}
+}